@@ -0,0 +1,90 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+
+	"github.com/cristianoliveira/aerospace-ipc/pkg/aerospace"
+)
+
+const defaultIndicatorFormat = "{{.Focused}}"
+
+// indicatorWorkspace is the workspace view exposed to indicator templates.
+type indicatorWorkspace struct {
+	Name        string
+	MonitorID   int
+	MonitorName string
+	Focused     bool
+	Visible     bool
+	Empty       bool
+}
+
+// indicatorData is the data exposed to indicator templates.
+//
+// Example:
+//
+//	--format '{{.Focused}} {{range .Visible}}{{.Name}} {{end}}'
+type indicatorData struct {
+	// Focused is the name of the focused workspace.
+	Focused string
+	// Visible are the workspaces visible on any monitor.
+	Visible []indicatorWorkspace
+	// Workspaces are all workspaces.
+	Workspaces []indicatorWorkspace
+}
+
+// runIndicator prints a single line describing the workspaces state,
+// formatted by a Go template. Meant for tmux and status bars.
+//
+// Usage:
+//
+//	aerospace-ipc indicator --format '{{.Focused}} {{range .Visible}}{{.Name}} {{end}}'
+func runIndicator(client *aerospace.AeroSpaceWM, args []string, out io.Writer) error {
+	flags := flag.NewFlagSet("indicator", flag.ContinueOnError)
+	format := flags.String("format", defaultIndicatorFormat, "Go template used to render the indicator")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	tmpl, err := template.New("indicator").Parse(*format)
+	if err != nil {
+		return fmt.Errorf("invalid indicator format\n%w", err)
+	}
+
+	all, err := client.Workspaces().GetAllWorkspaces()
+	if err != nil {
+		return fmt.Errorf("failed to get workspaces\n%w", err)
+	}
+
+	var data indicatorData
+	for _, workspace := range all {
+		item := indicatorWorkspace{
+			Name:        workspace.Workspace,
+			MonitorID:   workspace.MonitorID,
+			MonitorName: workspace.MonitorName,
+			Focused:     workspace.IsFocused,
+			Visible:     workspace.IsVisible,
+			Empty:       workspace.IsEffectivelyEmpty,
+		}
+		if item.Focused {
+			data.Focused = item.Name
+		}
+		if item.Visible {
+			data.Visible = append(data.Visible, item)
+		}
+		data.Workspaces = append(data.Workspaces, item)
+	}
+
+	var builder strings.Builder
+	if err := tmpl.Execute(&builder, data); err != nil {
+		return fmt.Errorf("failed to render indicator\n%w", err)
+	}
+
+	// Keep the output on a single line, without touching the padding of the template.
+	line := strings.ReplaceAll(strings.TrimRight(builder.String(), "\n"), "\n", " ")
+	_, err = fmt.Fprintln(out, line)
+	return err
+}
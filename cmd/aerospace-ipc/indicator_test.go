@@ -0,0 +1,107 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/cristianoliveira/aerospace-ipc/pkg/aerospace"
+	"github.com/cristianoliveira/aerospace-ipc/pkg/client"
+)
+
+func newTestClient(t *testing.T) *aerospace.AeroSpaceWM {
+	t.Helper()
+	fake := client.NewFakeConnection(client.FakeState{
+		Windows: []client.FakeWindow{
+			{ID: 1, AppName: "Ghostty", Title: "zsh", Workspace: "1"},
+		},
+		Workspaces: []client.FakeWorkspace{
+			{Name: "1"},
+			{Name: "2"},
+			{Name: "3", MonitorID: 2},
+		},
+		Monitors: []client.FakeMonitor{
+			{ID: 1, Name: "Built-in"},
+			{ID: 2, Name: "DELL"},
+		},
+		FocusedWorkspace: "1",
+	})
+	c, err := aerospace.NewClient(aerospace.WithConnector(fake))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return c
+}
+
+func TestRunIndicator(t *testing.T) {
+	t.Run("Happy path", func(tt *testing.T) {
+		testCases := []struct {
+			title    string
+			args     []string
+			expected string
+		}{
+			{
+				title:    "default format",
+				args:     nil,
+				expected: "1\n",
+			},
+			{
+				title:    "keeps the padding of the template",
+				args:     []string{"--format", "{{.Focused}}\t {{range .Visible}}[{{.Name}}]  {{end}}"},
+				expected: "1\t [1]  [3]  \n",
+			},
+			{
+				title:    "joins the lines of the template",
+				args:     []string{"--format", "{{range .Workspaces}}{{.Name}}\n{{end}}"},
+				expected: "1 2 3\n",
+			},
+		}
+		for _, tc := range testCases {
+			tt.Run(tc.title, func(ttt *testing.T) {
+				var out bytes.Buffer
+				if err := runIndicator(newTestClient(ttt), tc.args, &out); err != nil {
+					ttt.Fatalf("unexpected error: %v", err)
+				}
+				if out.String() != tc.expected {
+					ttt.Fatalf("expected %q, got %q", tc.expected, out.String())
+				}
+			})
+		}
+	})
+
+	t.Run("Error cases", func(tt *testing.T) {
+		tt.Run("invalid template", func(ttt *testing.T) {
+			var out bytes.Buffer
+			err := runIndicator(newTestClient(ttt), []string{"--format", "{{.Focused"}, &out)
+			if err == nil || !strings.Contains(err.Error(), "invalid indicator format") {
+				ttt.Fatalf("expected invalid format error, got %v", err)
+			}
+		})
+	})
+}
+
+func TestRunCommand(t *testing.T) {
+	t.Run("runs the indicator", func(tt *testing.T) {
+		var stdout, stderr bytes.Buffer
+		code := runCommand(newTestClient(tt), []string{"indicator", "--format", "[{{.Focused}}]"}, &stdout, &stderr)
+		if code != 0 || stdout.String() != "[1]\n" {
+			tt.Fatalf("expected exit code 0 and %q, got %d and %q (%s)", "[1]\n", code, stdout.String(), stderr.String())
+		}
+	})
+
+	t.Run("prints the windows by default", func(tt *testing.T) {
+		var stdout, stderr bytes.Buffer
+		code := runCommand(newTestClient(tt), nil, &stdout, &stderr)
+		if code != 0 || !strings.Contains(stdout.String(), "Ghostty") {
+			tt.Fatalf("expected exit code 0 and the windows, got %d and %q (%s)", code, stdout.String(), stderr.String())
+		}
+	})
+
+	t.Run("fails on an unknown indicator flag", func(tt *testing.T) {
+		var stdout, stderr bytes.Buffer
+		code := runCommand(newTestClient(tt), []string{"indicator", "--unknown"}, &stdout, &stderr)
+		if code != 1 || !strings.Contains(stderr.String(), "Error rendering indicator") {
+			tt.Fatalf("expected exit code 1 and an error, got %d and %q", code, stderr.String())
+		}
+	})
+}
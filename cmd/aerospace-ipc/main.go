@@ -2,6 +2,7 @@ package main
 
 import (
 	"fmt"
+	"io"
 	"log"
 	"os"
 
@@ -9,9 +10,16 @@ import (
 )
 
 func main() {
+	os.Exit(run(os.Args[1:]))
+}
+
+// run runs the command line and returns its exit code.
+// Exiting only from main lets the deferred cleanups of run happen.
+func run(args []string) int {
 	client, err := aerospace.NewClient()
 	if err != nil {
-		log.Fatalf("failed to create AeroSpace client: %v", err)
+		fmt.Fprintf(os.Stderr, "failed to create AeroSpace client: %v\n", err)
+		return 1
 	}
 	defer func() {
 		if err := client.CloseConnection(); err != nil {
@@ -19,15 +27,29 @@ func main() {
 		}
 	}()
 
+	return runCommand(client, args, os.Stdout, os.Stderr)
+}
+
+// runCommand runs the command line args with client and returns its exit code.
+func runCommand(client *aerospace.AeroSpaceWM, args []string, stdout, stderr io.Writer) int {
+	if len(args) > 0 && args[0] == "indicator" {
+		if err := runIndicator(client, args[1:], stdout); err != nil {
+			fmt.Fprintf(stderr, "Error rendering indicator: %v\n", err)
+			return 1
+		}
+		return 0
+	}
+
 	// Example usage - get all windows
 	windows, err := client.Windows().GetAllWindows()
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error getting windows: %v\n", err)
-		os.Exit(1)
+		fmt.Fprintf(stderr, "Error getting windows: %v\n", err)
+		return 1
 	}
 
 	// Print windows
 	for _, window := range windows {
-		fmt.Println(window)
+		fmt.Fprintln(stdout, window)
 	}
+	return 0
 }
@@ -5,6 +5,7 @@ import (
 	"sync"
 
 	"github.com/cristianoliveira/aerospace-ipc/pkg/aerospace"
+	"github.com/cristianoliveira/aerospace-ipc/pkg/client/commands"
 )
 
 func main() {
@@ -25,7 +26,7 @@ func main() {
 		wg.Add(1)
 		go func(id int) {
 			defer wg.Done()
-			response, err := conn.Connection().SendCommand("list-windows", []string{"--all", "--json"})
+			response, err := conn.Connection().SendCommand(commands.ListWindows, []string{"--all", "--json"})
 			if err != nil {
 				log.Printf("Goroutine %d: Error sending command: %v", id, err)
 				return
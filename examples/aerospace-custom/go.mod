@@ -4,4 +4,4 @@ go 1.24.2
 
 replace github.com/cristianoliveira/aerospace-ipc => ../../
 
-require github.com/cristianoliveira/aerospace-ipc v0.1.2-0.20250608060928-4ad521ef4bd2 // indirect
+require github.com/cristianoliveira/aerospace-ipc v0.1.2-0.20250608060928-4ad521ef4bd2
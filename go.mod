@@ -2,4 +2,4 @@ module github.com/cristianoliveira/aerospace-ipc
 
 go 1.24.2
 
-require go.uber.org/mock v0.5.2 // indirect
+require go.uber.org/mock v0.5.2
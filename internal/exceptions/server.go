@@ -0,0 +1,6 @@
+package exceptions
+
+import "errors"
+
+// ErrServerNotRunning indicates that no AeroSpace server is listening on the socket.
+var ErrServerNotRunning = errors.New("AeroSpace server is not running")
@@ -11,20 +11,25 @@ type ErrVersionMismatch struct {
 	MinorVersion   int
 	MajorVersion   int
 	CurrentVersion string
+	// ReportedVersion is the version string as sent by the server,
+	// before it was parsed into CurrentVersion.
+	ReportedVersion string
 }
 
-func NewErrVersionMismatch(major, minor int, currentVersion string) *ErrVersionMismatch {
+func NewErrVersionMismatch(major, minor int, currentVersion, reportedVersion string) *ErrVersionMismatch {
 	return &ErrVersionMismatch{
-		MajorVersion:   major,
-		MinorVersion:   minor,
-		CurrentVersion: currentVersion,
+		MajorVersion:    major,
+		MinorVersion:    minor,
+		CurrentVersion:  currentVersion,
+		ReportedVersion: reportedVersion,
 	}
 }
 
 func (e *ErrVersionMismatch) Error() string {
 	return fmt.Sprintf(
-		"Server version %s does not match the minimum required version %d.%d.x",
+		"Server version %s (reported as %q) does not match the minimum required version %d.%d.x",
 		e.CurrentVersion,
+		e.ReportedVersion,
 		e.MajorVersion,
 		e.MinorVersion,
 	)
@@ -0,0 +1,76 @@
+// Package fakeserver provides an in-process AeroSpace socket server for
+// benchmarks and tests that need a real socket round-trip.
+//
+// It speaks the same JSON protocol as AeroSpace, answering each request
+// with the Response returned by a Handler.
+package fakeserver
+
+import (
+	"encoding/json"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cristianoliveira/aerospace-ipc/internal/socket"
+)
+
+// Response is the response sent by AeroSpace.
+type Response = socket.Response
+
+// Handler answers a request. args[0] is the command name.
+type Handler func(args []string) Response
+
+// Start serves handler on a unix socket until the test ends and returns the socket path.
+//
+// Usage:
+//
+//	socketPath := fakeserver.Start(b, func(args []string) fakeserver.Response {
+//	    return fakeserver.Response{StdOut: "main"}
+//	})
+func Start(tb testing.TB, handler Handler) string {
+	tb.Helper()
+
+	// Socket paths are limited to ~104 bytes, which t.TempDir() may exceed.
+	dir, err := os.MkdirTemp("", "aerospace")
+	if err != nil {
+		tb.Fatalf("failed to create socket dir: %v", err)
+	}
+	tb.Cleanup(func() { os.RemoveAll(dir) })
+
+	socketPath := filepath.Join(dir, "aerospace.sock")
+	listener, err := net.Listen("unix", socketPath)
+	if err != nil {
+		tb.Fatalf("failed to listen: %v", err)
+	}
+	tb.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go serve(conn, handler)
+		}
+	}()
+
+	return socketPath
+}
+
+// serve answers the requests received on conn until it is closed.
+func serve(conn net.Conn, handler Handler) {
+	defer conn.Close()
+
+	decoder := json.NewDecoder(conn)
+	encoder := json.NewEncoder(conn)
+	for {
+		var request socket.Command
+		if err := decoder.Decode(&request); err != nil {
+			return
+		}
+		if err := encoder.Encode(handler(request.Args)); err != nil {
+			return
+		}
+	}
+}
@@ -0,0 +1,29 @@
+// Package natural compares names the way people expect them sorted,
+// e.g. workspace "2" before workspace "10".
+package natural
+
+import (
+	"cmp"
+	"strconv"
+	"strings"
+)
+
+// Compare compares a and b, numerically when both are integers and
+// case-insensitively otherwise. Numbers sort before other names.
+func Compare(a, b string) int {
+	na, errA := strconv.Atoi(a)
+	nb, errB := strconv.Atoi(b)
+	switch {
+	case errA == nil && errB == nil:
+		return cmp.Compare(na, nb)
+	case errA == nil:
+		return -1
+	case errB == nil:
+		return 1
+	}
+
+	if c := cmp.Compare(strings.ToLower(a), strings.ToLower(b)); c != 0 {
+		return c
+	}
+	return cmp.Compare(a, b)
+}
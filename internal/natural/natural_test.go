@@ -0,0 +1,27 @@
+package natural
+
+import "testing"
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{a: "2", b: "10", expected: -1},
+		{a: "10", b: "2", expected: 1},
+		{a: "3", b: "3", expected: 0},
+		{a: "9", b: "code", expected: -1},
+		{a: "code", b: "9", expected: 1},
+		{a: "Code", b: "mail", expected: -1},
+		{a: "mail", b: "Code", expected: 1},
+		{a: "Code", b: "code", expected: -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+" vs "+tt.b, func(t *testing.T) {
+			if got := Compare(tt.a, tt.b); got != tt.expected {
+				t.Fatalf("expected %d, got %d", tt.expected, got)
+			}
+		})
+	}
+}
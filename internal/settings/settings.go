@@ -0,0 +1,253 @@
+// Package settings loads the client settings file shared by tools built on this library.
+//
+// The file uses a small subset of TOML: `key = value` pairs, `[table]` headers,
+// `#` comments, and string, integer and boolean values.
+//
+// Example:
+//
+//	# ~/.config/aerospace-ipc/config.toml
+//	socket-path = "/tmp/bobko.aerospace-me.sock"
+//	command-timeout = "2s"
+//	dial-timeout = "500ms"
+//	auto-start = true
+//
+//	[retry]
+//	max-attempts = 3
+//	backoff = "50ms"
+//	max-backoff = "1s"
+//
+//	[cache]
+//	ttl = "100ms"
+package settings
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Settings contains the client defaults read from a settings file.
+type Settings struct {
+	// SocketPath is the AeroSpace socket path. Empty means the default socket path.
+	SocketPath string
+
+	// CommandTimeout bounds every command. Zero means no timeout.
+	CommandTimeout time.Duration
+
+	// DialTimeout bounds opening the socket connection. Zero means no timeout.
+	DialTimeout time.Duration
+
+	// AutoStart launches AeroSpace when it is not running.
+	AutoStart bool
+
+	// RetryMaxAttempts is the maximum number of times a command is sent,
+	// including the first one. Values lower than 2 disable retries.
+	RetryMaxAttempts int
+
+	// RetryBackoff is the delay before the first retry.
+	RetryBackoff time.Duration
+
+	// RetryMaxBackoff caps the delay between retries. Zero means no cap.
+	RetryMaxBackoff time.Duration
+
+	// CacheTTL is how long the response of a query is reused by identical
+	// queries. Zero disables the cache.
+	CacheTTL time.Duration
+}
+
+// Load reads the settings file at path. A leading "~/" is expanded to the home directory.
+//
+// Returns an error if the file cannot be read, is malformed,
+// or contains unknown keys.
+func Load(path string) (*Settings, error) {
+	if rest, ok := strings.CutPrefix(path, "~/"); ok {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, fmt.Errorf("failed to expand settings path %s\n%w", path, err)
+		}
+		path = filepath.Join(home, rest)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open settings file %s\n%w", path, err)
+	}
+	defer file.Close()
+
+	settings, err := Parse(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load settings file %s\n%w", path, err)
+	}
+
+	return settings, nil
+}
+
+// Parse reads settings from r.
+func Parse(r io.Reader) (*Settings, error) {
+	values, err := parseValues(r)
+	if err != nil {
+		return nil, err
+	}
+
+	settings := &Settings{}
+	for key, value := range values {
+		switch key {
+		case "socket-path":
+			settings.SocketPath, err = asString(key, value)
+		case "command-timeout":
+			settings.CommandTimeout, err = asDuration(key, value)
+		case "dial-timeout":
+			settings.DialTimeout, err = asDuration(key, value)
+		case "auto-start":
+			settings.AutoStart, err = asBool(key, value)
+		case "retry.max-attempts":
+			settings.RetryMaxAttempts, err = asInt(key, value)
+		case "retry.backoff":
+			settings.RetryBackoff, err = asDuration(key, value)
+		case "retry.max-backoff":
+			settings.RetryMaxBackoff, err = asDuration(key, value)
+		case "cache.ttl":
+			settings.CacheTTL, err = asDuration(key, value)
+		default:
+			err = fmt.Errorf("unknown setting %q", key)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return settings, nil
+}
+
+// parseValues parses the TOML subset into a map of dotted keys to values.
+// Keys under a `[table]` header are prefixed with "table.".
+func parseValues(r io.Reader) (map[string]any, error) {
+	values := map[string]any{}
+	table := ""
+
+	scanner := bufio.NewScanner(r)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(stripComment(scanner.Text()))
+		if line == "" {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") {
+			if !strings.HasSuffix(line, "]") {
+				return nil, fmt.Errorf("line %d: invalid table header %q", lineNumber, line)
+			}
+			table = strings.TrimSpace(line[1 : len(line)-1])
+			if table == "" {
+				return nil, fmt.Errorf("line %d: empty table name", lineNumber)
+			}
+			continue
+		}
+
+		key, rawValue, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected key = value, got %q", lineNumber, line)
+		}
+
+		key = strings.TrimSpace(key)
+		if key == "" {
+			return nil, fmt.Errorf("line %d: missing key", lineNumber)
+		}
+		if table != "" {
+			key = table + "." + key
+		}
+		if _, exists := values[key]; exists {
+			return nil, fmt.Errorf("line %d: duplicated key %q", lineNumber, key)
+		}
+
+		value, err := parseValue(strings.TrimSpace(rawValue))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNumber, err)
+		}
+		values[key] = value
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return values, nil
+}
+
+func parseValue(raw string) (any, error) {
+	switch {
+	case raw == "":
+		return nil, fmt.Errorf("missing value")
+	case strings.HasPrefix(raw, `"`):
+		value, err := strconv.Unquote(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid string %s", raw)
+		}
+		return value, nil
+	case raw == "true" || raw == "false":
+		return raw == "true", nil
+	}
+
+	value, err := strconv.Atoi(strings.ReplaceAll(raw, "_", ""))
+	if err != nil {
+		return nil, fmt.Errorf("unsupported value %s", raw)
+	}
+	return value, nil
+}
+
+// stripComment removes a trailing `#` comment that is not inside a string.
+func stripComment(line string) string {
+	inString := false
+	for i, char := range line {
+		switch {
+		case char == '"' && (i == 0 || line[i-1] != '\\'):
+			inString = !inString
+		case char == '#' && !inString:
+			return line[:i]
+		}
+	}
+	return line
+}
+
+func asString(key string, value any) (string, error) {
+	result, ok := value.(string)
+	if !ok {
+		return "", fmt.Errorf("setting %q must be a string", key)
+	}
+	return result, nil
+}
+
+func asBool(key string, value any) (bool, error) {
+	result, ok := value.(bool)
+	if !ok {
+		return false, fmt.Errorf("setting %q must be a boolean", key)
+	}
+	return result, nil
+}
+
+func asInt(key string, value any) (int, error) {
+	result, ok := value.(int)
+	if !ok || result < 0 {
+		return 0, fmt.Errorf("setting %q must be a non-negative integer", key)
+	}
+	return result, nil
+}
+
+// asDuration parses a duration string, e.g. "500ms" or "2s".
+func asDuration(key string, value any) (time.Duration, error) {
+	raw, ok := value.(string)
+	if !ok {
+		return 0, fmt.Errorf("setting %q must be a duration string, e.g. \"2s\"", key)
+	}
+	result, err := time.ParseDuration(raw)
+	if err != nil || result < 0 {
+		return 0, fmt.Errorf("setting %q must be a non-negative duration, got %q", key, raw)
+	}
+	return result, nil
+}
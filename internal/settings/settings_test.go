@@ -0,0 +1,115 @@
+package settings
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	t.Run("reads known settings", func(tt *testing.T) {
+		settings, err := Parse(strings.NewReader(`
+# shared by every tool on this machine
+socket-path = "/tmp/bobko.aerospace-me.sock" # inline comment
+`))
+		if err != nil {
+			tt.Fatalf("unexpected error: %v", err)
+		}
+		if settings.SocketPath != "/tmp/bobko.aerospace-me.sock" {
+			tt.Fatalf("unexpected socket path %q", settings.SocketPath)
+		}
+	})
+
+	t.Run("reads timeouts, retry and cache settings", func(tt *testing.T) {
+		settings, err := Parse(strings.NewReader(`
+command-timeout = "2s"
+dial-timeout = "500ms"
+auto-start = true
+
+[retry]
+max-attempts = 3
+backoff = "50ms"
+max-backoff = "1s"
+
+[cache]
+ttl = "100ms"
+`))
+		if err != nil {
+			tt.Fatalf("unexpected error: %v", err)
+		}
+		expected := Settings{
+			CommandTimeout:   2 * time.Second,
+			DialTimeout:      500 * time.Millisecond,
+			AutoStart:        true,
+			RetryMaxAttempts: 3,
+			RetryBackoff:     50 * time.Millisecond,
+			RetryMaxBackoff:  time.Second,
+			CacheTTL:         100 * time.Millisecond,
+		}
+		if *settings != expected {
+			tt.Fatalf("expected %+v, got %+v", expected, *settings)
+		}
+	})
+
+	t.Run("empty file uses defaults", func(tt *testing.T) {
+		settings, err := Parse(strings.NewReader(""))
+		if err != nil {
+			tt.Fatalf("unexpected error: %v", err)
+		}
+		if *settings != (Settings{}) {
+			tt.Fatalf("expected zero settings, got %+v", settings)
+		}
+	})
+
+	t.Run("invalid files", func(tt *testing.T) {
+		testCases := []struct {
+			title    string
+			content  string
+			expected string
+		}{
+			{"unknown key", `sockets = "x"`, `unknown setting "sockets"`},
+			{"wrong type", `socket-path = 1`, `setting "socket-path" must be a string`},
+			{"missing equals", `socket-path "x"`, "line 1: expected key = value"},
+			{"missing value", `socket-path =`, "line 1: missing value"},
+			{"duplicated key", "socket-path = \"a\"\nsocket-path = \"b\"", `line 2: duplicated key "socket-path"`},
+			{"unterminated table", "[retry", "line 1: invalid table header"},
+			{"unknown table key", "[retry]\nattempts = 1", `unknown setting "retry.attempts"`},
+			{"invalid duration", `command-timeout = "soon"`, `setting "command-timeout" must be a non-negative duration`},
+			{"duration as integer", `dial-timeout = 500`, `setting "dial-timeout" must be a duration string`},
+			{"negative integer", "[retry]\nmax-attempts = -1", `setting "retry.max-attempts" must be a non-negative integer`},
+			{"boolean as string", `auto-start = "yes"`, `setting "auto-start" must be a boolean`},
+		}
+		for _, tc := range testCases {
+			tt.Run(tc.title, func(ttt *testing.T) {
+				_, err := Parse(strings.NewReader(tc.content))
+				if err == nil {
+					ttt.Fatal("expected error, got nil")
+				}
+				if !strings.Contains(err.Error(), tc.expected) {
+					ttt.Fatalf("expected error containing %q, got %q", tc.expected, err.Error())
+				}
+			})
+		}
+	})
+}
+
+func TestLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	if err := os.WriteFile(path, []byte(`socket-path = "/tmp/custom.sock"`), 0o600); err != nil {
+		t.Fatalf("failed to write settings file: %v", err)
+	}
+
+	settings, err := Load(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if settings.SocketPath != "/tmp/custom.sock" {
+		t.Fatalf("unexpected socket path %q", settings.SocketPath)
+	}
+
+	if _, err := Load(filepath.Join(t.TempDir(), "missing.toml")); err == nil {
+		t.Fatal("expected error for missing file, got nil")
+	}
+}
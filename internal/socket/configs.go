@@ -2,29 +2,126 @@ package socket
 
 import (
 	"fmt"
+	"net"
 	"os"
+	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/cristianoliveira/aerospace-ipc/internal/constants"
+	"github.com/cristianoliveira/aerospace-ipc/internal/exceptions"
 )
 
+// socketDir is where AeroSpace creates its sockets.
+var socketDir = "/tmp"
+
+// probeTimeout bounds how long checking a candidate socket may take.
+const probeTimeout = 100 * time.Millisecond
+
 // GetSocketPath returns the socket path
 //
-// It checks for environment variable AEROSPACESOCK or uses the default socket path.
+// It checks for environment variable AEROSPACESOCK or uses the default socket path
+// of the current user. The sockets of other users are never picked, so the
+// default client cannot drive another user's AeroSpace; see DiscoverSocketPaths
+// to look for them explicitly.
 //
 //	Default: /tmp/bobko.aerospace-<username>.sock
 //	See: https://github.com/nikitabobko/AeroSpace/blob/f12ee6c9d914f7b561ff7d5c64909882c67061cd/Sources/Cli/_main.swift#L47
 //
-// Returns the socket path or an error if the path does not exist
+// Returns an error matching exceptions.ErrServerNotRunning if the socket is not live.
 func GetSocketPath() (string, error) {
-	socketPath := fmt.Sprintf("/tmp/bobko.%s-%s.sock", "aerospace", os.Getenv("USER"))
-	socketPathEnv := os.Getenv(constants.EnvAeroSpaceSock)
-	if socketPathEnv != "" {
-		socketPath = socketPathEnv
+	socketPath := DefaultSocketPath()
+	if err := probe(socketPath); err != nil {
+		return "", fmt.Errorf(
+			"%w: no live socket found\ntried:\n  %s: %s",
+			exceptions.ErrServerNotRunning,
+			socketPath,
+			err,
+		)
+	}
+
+	return socketPath, nil
+}
+
+// DiscoverSocketPaths returns the live AeroSpace socket paths, most relevant first.
+//
+// If the environment variable AEROSPACESOCK is set, it is the only candidate.
+// Otherwise the candidates are the default socket path of the current user
+// followed by any other /tmp/bobko.aerospace-*.sock, which may belong to
+// other users. Only callers opting in to them should use this.
+//
+// A candidate is live if it is a unix socket accepting connections,
+// so stale socket files left by a crashed server are skipped.
+//
+// Returns an error matching exceptions.ErrServerNotRunning, listing every candidate
+// and why it was rejected, if none is live.
+func DiscoverSocketPaths() ([]string, error) {
+	candidates := candidateSocketPaths()
+
+	var live []string
+	var tried []string
+	for _, candidate := range candidates {
+		if err := probe(candidate); err != nil {
+			tried = append(tried, fmt.Sprintf("  %s: %s", candidate, err))
+			continue
+		}
+		live = append(live, candidate)
 	}
 
-	if _, err := os.Stat(socketPath); os.IsNotExist(err) {
-		return "", fmt.Errorf("failed to access socket path %s\r reason: %w", socketPath, err)
+	if len(live) == 0 {
+		return nil, fmt.Errorf(
+			"%w: no live socket found\ntried:\n%s",
+			exceptions.ErrServerNotRunning,
+			strings.Join(tried, "\n"),
+		)
 	}
 
-	return socketPath, nil
+	return live, nil
+}
+
+// DefaultSocketPath returns the socket path AeroSpace uses for the current user,
+// or AEROSPACESOCK if set, regardless of whether it exists.
+func DefaultSocketPath() string {
+	if socketPathEnv := os.Getenv(constants.EnvAeroSpaceSock); socketPathEnv != "" {
+		return socketPathEnv
+	}
+	return filepath.Join(socketDir, fmt.Sprintf("bobko.%s-%s.sock", "aerospace", os.Getenv("USER")))
+}
+
+// candidateSocketPaths returns the socket paths to try, without duplicates.
+func candidateSocketPaths() []string {
+	if socketPathEnv := os.Getenv(constants.EnvAeroSpaceSock); socketPathEnv != "" {
+		return []string{socketPathEnv}
+	}
+
+	candidates := []string{DefaultSocketPath()}
+	// The pattern is valid, so Glob cannot fail.
+	matches, _ := filepath.Glob(filepath.Join(socketDir, "bobko.aerospace-*.sock"))
+	for _, match := range matches {
+		if match != candidates[0] {
+			candidates = append(candidates, match)
+		}
+	}
+
+	return candidates
+}
+
+// probe checks that socketPath is a unix socket accepting connections.
+func probe(socketPath string) error {
+	info, err := os.Stat(socketPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("not found")
+		}
+		return err
+	}
+	if info.Mode()&os.ModeSocket == 0 {
+		return fmt.Errorf("not a socket")
+	}
+
+	conn, err := net.DialTimeout("unix", socketPath, probeTimeout)
+	if err != nil {
+		return fmt.Errorf("not accepting connections (%w)", err)
+	}
+	return conn.Close()
 }
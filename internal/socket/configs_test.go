@@ -0,0 +1,130 @@
+package socket
+
+import (
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/cristianoliveira/aerospace-ipc/internal/constants"
+	"github.com/cristianoliveira/aerospace-ipc/internal/exceptions"
+)
+
+// useSocketDir points the discovery to a temporary directory.
+func useSocketDir(t *testing.T) string {
+	dir, err := os.MkdirTemp("", "aerospace")
+	if err != nil {
+		t.Fatalf("failed to create socket dir: %v", err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	previous := socketDir
+	socketDir = dir
+	t.Cleanup(func() { socketDir = previous })
+
+	t.Setenv(constants.EnvAeroSpaceSock, "")
+	t.Setenv("USER", "me")
+	return dir
+}
+
+// listenAt starts a unix socket listener at path.
+func listenAt(t *testing.T, path string) *net.UnixListener {
+	listener, err := net.ListenUnix("unix", &net.UnixAddr{Name: path, Net: "unix"})
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+	return listener
+}
+
+func TestDiscoverSocketPaths(t *testing.T) {
+	t.Run("Happy path", func(tt *testing.T) {
+		tt.Run("returns live sockets with the user socket first", func(ttt *testing.T) {
+			dir := useSocketDir(ttt)
+			other := filepath.Join(dir, "bobko.aerospace-alice.sock")
+			mine := filepath.Join(dir, "bobko.aerospace-me.sock")
+			listenAt(ttt, other)
+			listenAt(ttt, mine)
+
+			socketPaths, err := DiscoverSocketPaths()
+			if err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+			expected := []string{mine, other}
+			if !reflect.DeepEqual(socketPaths, expected) {
+				ttt.Fatalf("expected %v, got %v", expected, socketPaths)
+			}
+
+			socketPath, err := GetSocketPath()
+			if err != nil || socketPath != mine {
+				ttt.Fatalf("expected %s, got %q (%v)", mine, socketPath, err)
+			}
+		})
+
+		tt.Run("skips stale sockets", func(ttt *testing.T) {
+			dir := useSocketDir(ttt)
+			stale := listenAt(ttt, filepath.Join(dir, "bobko.aerospace-me.sock"))
+			stale.SetUnlinkOnClose(false)
+			stale.Close()
+			live := filepath.Join(dir, "bobko.aerospace-alice.sock")
+			listenAt(ttt, live)
+
+			socketPaths, err := DiscoverSocketPaths()
+			if err != nil || !reflect.DeepEqual(socketPaths, []string{live}) {
+				ttt.Fatalf("expected only %s, got %v (%v)", live, socketPaths, err)
+			}
+		})
+
+		tt.Run("only tries AEROSPACESOCK when set", func(ttt *testing.T) {
+			dir := useSocketDir(ttt)
+			listenAt(ttt, filepath.Join(dir, "bobko.aerospace-me.sock"))
+			custom := filepath.Join(dir, "custom.sock")
+			listenAt(ttt, custom)
+			ttt.Setenv(constants.EnvAeroSpaceSock, custom)
+
+			socketPaths, err := DiscoverSocketPaths()
+			if err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(socketPaths, []string{custom}) {
+				ttt.Fatalf("expected only %s, got %v", custom, socketPaths)
+			}
+		})
+	})
+
+	t.Run("Error cases", func(tt *testing.T) {
+		tt.Run("GetSocketPath does not pick the socket of another user", func(ttt *testing.T) {
+			dir := useSocketDir(ttt)
+			listenAt(ttt, filepath.Join(dir, "bobko.aerospace-alice.sock"))
+
+			socketPath, err := GetSocketPath()
+			if !errors.Is(err, exceptions.ErrServerNotRunning) {
+				ttt.Fatalf("expected ErrServerNotRunning, got %q (%v)", socketPath, err)
+			}
+		})
+
+		tt.Run("lists what was tried", func(ttt *testing.T) {
+			dir := useSocketDir(ttt)
+			regular := filepath.Join(dir, "bobko.aerospace-alice.sock")
+			if err := os.WriteFile(regular, nil, 0o600); err != nil {
+				ttt.Fatalf("failed to write file: %v", err)
+			}
+
+			_, err := DiscoverSocketPaths()
+			if !errors.Is(err, exceptions.ErrServerNotRunning) {
+				ttt.Fatalf("expected ErrServerNotRunning, got %v", err)
+			}
+			for _, expected := range []string{
+				filepath.Join(dir, "bobko.aerospace-me.sock") + ": not found",
+				regular + ": not a socket",
+			} {
+				if !strings.Contains(err.Error(), expected) {
+					ttt.Fatalf("expected error to contain %q, got %q", expected, err.Error())
+				}
+			}
+		})
+	})
+}
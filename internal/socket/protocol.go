@@ -0,0 +1,61 @@
+package socket
+
+import (
+	"log/slog"
+	"strings"
+)
+
+// Command represents the JSON structure for AeroSpace socket commands.
+// This wlll mostly mirror https://github.com/nikitabobko/AeroSpace/blob/main/Sources/Common/model/clientServer.swift#L76
+type Command struct {
+	Command string   `json:"command"`
+	Args    []string `json:"args"`
+	Stdin   string   `json:"stdin"`
+	// For Version: 0.20.0 and above
+	// Pass null if not available
+	WindowID  *uint64 `json:"windowId"`
+	Workspace *string `json:"workspace"`
+}
+
+// Response represents the JSON structure from AeroSpace socket response.
+type Response struct {
+	ServerVersion string `json:"serverVersionAndHash"` // Fornat: "0.0.1-Beta <hash>"
+	StdErr        string `json:"stderr"`
+	StdOut        string `json:"stdout"`
+	ExitCode      int32  `json:"exitCode"`
+}
+
+// Warnings returns the non-empty lines AeroSpace printed to the standard error
+// of a successful command, e.g. deprecation notices and config hints.
+//
+// It returns nil for failed commands, whose standard error is their failure.
+func (r *Response) Warnings() []string {
+	if r == nil || r.ExitCode != 0 {
+		return nil
+	}
+
+	var warnings []string
+	for line := range strings.Lines(r.StdErr) {
+		if line = strings.TrimSpace(line); line != "" {
+			warnings = append(warnings, line)
+		}
+	}
+	return warnings
+}
+
+// LogValue implements slog.LogValuer, logging the exit code, the size of the
+// standard output and the standard error, since the output can be large.
+func (r *Response) LogValue() slog.Value {
+	if r == nil {
+		return slog.AnyValue(nil)
+	}
+
+	attrs := []slog.Attr{
+		slog.Int("exit-code", int(r.ExitCode)),
+		slog.Int("stdout-bytes", len(r.StdOut)),
+	}
+	if stderr := strings.TrimSpace(r.StdErr); stderr != "" {
+		attrs = append(attrs, slog.String("stderr", stderr))
+	}
+	return slog.GroupValue(attrs...)
+}
@@ -0,0 +1,85 @@
+package socket
+
+import (
+	"encoding/json"
+	"log/slog"
+	"slices"
+	"testing"
+)
+
+func TestProtocol(t *testing.T) {
+	t.Run("decodes the AeroSpace response", func(tt *testing.T) {
+		raw := `{"serverVersionAndHash":"0.20.1-Beta abc123","stderr":"","stdout":"main\n","exitCode":0}`
+
+		var response Response
+		if err := json.Unmarshal([]byte(raw), &response); err != nil {
+			tt.Fatalf("unexpected error: %v", err)
+		}
+		if response.ServerVersion != "0.20.1-Beta abc123" || response.StdOut != "main\n" {
+			tt.Fatalf("unexpected response %+v", response)
+		}
+	})
+
+	t.Run("encodes the AeroSpace command", func(tt *testing.T) {
+		command := Command{Command: "list-modes", Args: []string{"list-modes", "--current"}}
+
+		encoded, err := json.Marshal(command)
+		if err != nil {
+			tt.Fatalf("unexpected error: %v", err)
+		}
+		expected := `{"command":"list-modes","args":["list-modes","--current"],"stdin":"","windowId":null,"workspace":null}`
+		if string(encoded) != expected {
+			tt.Fatalf("expected %s, got %s", expected, encoded)
+		}
+	})
+}
+
+func TestResponseWarnings(t *testing.T) {
+	t.Run("returns the stderr lines of successful commands", func(tt *testing.T) {
+		response := &Response{StdErr: "deprecated flag\n\n  config hint\n"}
+
+		warnings := response.Warnings()
+		if !slices.Equal(warnings, []string{"deprecated flag", "config hint"}) {
+			tt.Fatalf("unexpected warnings: %q", warnings)
+		}
+	})
+
+	t.Run("returns nil for failed commands", func(tt *testing.T) {
+		response := &Response{ExitCode: 1, StdErr: "Window not found"}
+
+		if warnings := response.Warnings(); warnings != nil {
+			tt.Fatalf("expected no warnings, got %q", warnings)
+		}
+	})
+
+	t.Run("returns nil without stderr", func(tt *testing.T) {
+		if warnings := (&Response{StdOut: "main"}).Warnings(); warnings != nil {
+			tt.Fatalf("expected no warnings, got %q", warnings)
+		}
+	})
+}
+
+func TestResponseLogValue(t *testing.T) {
+	t.Run("logs the exit code, stdout size and stderr", func(tt *testing.T) {
+		response := &Response{StdOut: "main\n", StdErr: "workspace not found\n", ExitCode: 1}
+
+		value := response.LogValue()
+		expected := []slog.Attr{
+			slog.Int("exit-code", 1),
+			slog.Int("stdout-bytes", 5),
+			slog.String("stderr", "workspace not found"),
+		}
+		if value.Kind() != slog.KindGroup || !slices.EqualFunc(value.Group(), expected, slog.Attr.Equal) {
+			tt.Fatalf("expected %v, got %v", expected, value)
+		}
+	})
+
+	t.Run("logs a nil response", func(tt *testing.T) {
+		var response *Response
+
+		value := response.LogValue()
+		if value.Kind() != slog.KindAny || value.Any() != nil {
+			tt.Fatalf("expected a nil value, got %v", value)
+		}
+	})
+}
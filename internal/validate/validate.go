@@ -0,0 +1,75 @@
+// Package validate holds the option checks shared by the services,
+// so they fail with the same messages.
+package validate
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Directions are the directions accepted by the focus and move commands.
+var Directions = []string{"left", "down", "up", "right"}
+
+// Orders are the relative orders accepted by the monitor commands.
+var Orders = []string{"next", "prev"}
+
+// OneOf returns an error unless value is one of allowed.
+//
+// kind names the value in the error, e.g. "direction".
+func OneOf[T ~string](kind string, value T, allowed ...T) error {
+	for _, candidate := range allowed {
+		if value == candidate {
+			return nil
+		}
+	}
+
+	names := make([]string, len(allowed))
+	for i, candidate := range allowed {
+		names[i] = string(candidate)
+	}
+	return fmt.Errorf("invalid %s %q, must be one of: %s", kind, string(value), strings.Join(names, ", "))
+}
+
+// Choice is an option among mutually exclusive ones, see ExactlyOne.
+type Choice struct {
+	name string
+	set  bool
+}
+
+// Set returns the Choice named name, set when the caller provided it.
+func Set(name string, set bool) Choice {
+	return Choice{name: name, set: set}
+}
+
+// ExactlyOne returns an error unless exactly one of choices is set.
+func ExactlyOne(choices ...Choice) error {
+	count := 0
+	names := make([]string, len(choices))
+	for i, choice := range choices {
+		names[i] = choice.name
+		if choice.set {
+			count++
+		}
+	}
+
+	switch {
+	case count == 0:
+		return fmt.Errorf("must specify exactly one of: %s", joinOr(names))
+	case count > 1:
+		return fmt.Errorf("cannot specify multiple modes; must specify exactly one of: %s", joinOr(names))
+	}
+	return nil
+}
+
+// joinOr joins names as "A, B, or C".
+func joinOr(names []string) string {
+	switch len(names) {
+	case 0:
+		return ""
+	case 1:
+		return names[0]
+	case 2:
+		return names[0] + " or " + names[1]
+	}
+	return strings.Join(names[:len(names)-1], ", ") + ", or " + names[len(names)-1]
+}
@@ -0,0 +1,88 @@
+package validate
+
+import "testing"
+
+type mode string
+
+func TestOneOf(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		allowed  []string
+		expected string
+	}{
+		{name: "allowed value", value: "left", allowed: Directions},
+		{name: "last allowed value", value: "prev", allowed: Orders},
+		{
+			name:     "unknown value",
+			value:    "center",
+			allowed:  Directions,
+			expected: `invalid direction "center", must be one of: left, down, up, right`,
+		},
+		{
+			name:     "empty value",
+			value:    "",
+			allowed:  Orders,
+			expected: `invalid direction "", must be one of: next, prev`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertError(t, OneOf("direction", tt.value, tt.allowed...), tt.expected)
+		})
+	}
+
+	t.Run("typed strings", func(t *testing.T) {
+		assertError(t, OneOf("mode", mode("main"), "main", "service"), "")
+		assertError(t, OneOf("mode", mode("resize"), "main"), `invalid mode "resize", must be one of: main`)
+	})
+}
+
+func TestExactlyOne(t *testing.T) {
+	tests := []struct {
+		name     string
+		choices  []Choice
+		expected string
+	}{
+		{
+			name:    "one set",
+			choices: []Choice{Set("Direction", false), Set("Order", true), Set("Patterns", false)},
+		},
+		{
+			name:     "none set",
+			choices:  []Choice{Set("Direction", false), Set("Order", false), Set("Patterns", false)},
+			expected: "must specify exactly one of: Direction, Order, or Patterns",
+		},
+		{
+			name:     "several set",
+			choices:  []Choice{Set("Direction", true), Set("Order", false), Set("Patterns", true)},
+			expected: "cannot specify multiple modes; must specify exactly one of: Direction, Order, or Patterns",
+		},
+		{
+			name:     "two choices",
+			choices:  []Choice{Set("Stdin", true), Set("NoStdin", true)},
+			expected: "cannot specify multiple modes; must specify exactly one of: Stdin or NoStdin",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertError(t, ExactlyOne(tt.choices...), tt.expected)
+		})
+	}
+}
+
+// assertError fails unless err has the expected message, or is nil when expected is empty.
+func assertError(t *testing.T, err error, expected string) {
+	t.Helper()
+	if expected == "" {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return
+	}
+	if err == nil || err.Error() != expected {
+		t.Fatalf("expected error %q, got %v", expected, err)
+	}
+}
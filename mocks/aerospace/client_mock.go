@@ -10,9 +10,18 @@
 package aerospace_mock
 
 import (
+	context "context"
 	reflect "reflect"
 
+	aerospace "github.com/cristianoliveira/aerospace-ipc/pkg/aerospace"
+	config "github.com/cristianoliveira/aerospace-ipc/pkg/aerospace/config"
 	focus "github.com/cristianoliveira/aerospace-ipc/pkg/aerospace/focus"
+	history "github.com/cristianoliveira/aerospace-ipc/pkg/aerospace/history"
+	layout "github.com/cristianoliveira/aerospace-ipc/pkg/aerospace/layout"
+	marks "github.com/cristianoliveira/aerospace-ipc/pkg/aerospace/marks"
+	modes "github.com/cristianoliveira/aerospace-ipc/pkg/aerospace/modes"
+	monitors "github.com/cristianoliveira/aerospace-ipc/pkg/aerospace/monitors"
+	scratchpad "github.com/cristianoliveira/aerospace-ipc/pkg/aerospace/scratchpad"
 	windows "github.com/cristianoliveira/aerospace-ipc/pkg/aerospace/windows"
 	workspaces "github.com/cristianoliveira/aerospace-ipc/pkg/aerospace/workspaces"
 	client "github.com/cristianoliveira/aerospace-ipc/pkg/client"
@@ -57,6 +66,20 @@ func (mr *MockClientMockRecorder) CloseConnection() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseConnection", reflect.TypeOf((*MockClient)(nil).CloseConnection))
 }
 
+// Config mocks base method.
+func (m *MockClient) Config() *config.Service {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Config")
+	ret0, _ := ret[0].(*config.Service)
+	return ret0
+}
+
+// Config indicates an expected call of Config.
+func (mr *MockClientMockRecorder) Config() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Config", reflect.TypeOf((*MockClient)(nil).Config))
+}
+
 // Connection mocks base method.
 func (m *MockClient) Connection() client.AeroSpaceConnection {
 	m.ctrl.T.Helper()
@@ -85,6 +108,120 @@ func (mr *MockClientMockRecorder) Focus() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Focus", reflect.TypeOf((*MockClient)(nil).Focus))
 }
 
+// GetState mocks base method.
+func (m *MockClient) GetState(ctx context.Context) (*aerospace.State, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetState", ctx)
+	ret0, _ := ret[0].(*aerospace.State)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetState indicates an expected call of GetState.
+func (mr *MockClientMockRecorder) GetState(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetState", reflect.TypeOf((*MockClient)(nil).GetState), ctx)
+}
+
+// GetTree mocks base method.
+func (m *MockClient) GetTree() (*aerospace.Tree, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTree")
+	ret0, _ := ret[0].(*aerospace.Tree)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTree indicates an expected call of GetTree.
+func (mr *MockClientMockRecorder) GetTree() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTree", reflect.TypeOf((*MockClient)(nil).GetTree))
+}
+
+// History mocks base method.
+func (m *MockClient) History() *history.Tracker {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "History")
+	ret0, _ := ret[0].(*history.Tracker)
+	return ret0
+}
+
+// History indicates an expected call of History.
+func (mr *MockClientMockRecorder) History() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "History", reflect.TypeOf((*MockClient)(nil).History))
+}
+
+// Layout mocks base method.
+func (m *MockClient) Layout() *layout.Service {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Layout")
+	ret0, _ := ret[0].(*layout.Service)
+	return ret0
+}
+
+// Layout indicates an expected call of Layout.
+func (mr *MockClientMockRecorder) Layout() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Layout", reflect.TypeOf((*MockClient)(nil).Layout))
+}
+
+// Marks mocks base method.
+func (m *MockClient) Marks() *marks.Service {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Marks")
+	ret0, _ := ret[0].(*marks.Service)
+	return ret0
+}
+
+// Marks indicates an expected call of Marks.
+func (mr *MockClientMockRecorder) Marks() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Marks", reflect.TypeOf((*MockClient)(nil).Marks))
+}
+
+// Modes mocks base method.
+func (m *MockClient) Modes() *modes.Service {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Modes")
+	ret0, _ := ret[0].(*modes.Service)
+	return ret0
+}
+
+// Modes indicates an expected call of Modes.
+func (mr *MockClientMockRecorder) Modes() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Modes", reflect.TypeOf((*MockClient)(nil).Modes))
+}
+
+// Monitors mocks base method.
+func (m *MockClient) Monitors() *monitors.Service {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Monitors")
+	ret0, _ := ret[0].(*monitors.Service)
+	return ret0
+}
+
+// Monitors indicates an expected call of Monitors.
+func (mr *MockClientMockRecorder) Monitors() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Monitors", reflect.TypeOf((*MockClient)(nil).Monitors))
+}
+
+// Scratchpad mocks base method.
+func (m *MockClient) Scratchpad() *scratchpad.Service {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Scratchpad")
+	ret0, _ := ret[0].(*scratchpad.Service)
+	return ret0
+}
+
+// Scratchpad indicates an expected call of Scratchpad.
+func (mr *MockClientMockRecorder) Scratchpad() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Scratchpad", reflect.TypeOf((*MockClient)(nil).Scratchpad))
+}
+
 // Windows mocks base method.
 func (m *MockClient) Windows() *windows.Service {
 	m.ctrl.T.Helper()
@@ -99,6 +236,20 @@ func (mr *MockClientMockRecorder) Windows() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Windows", reflect.TypeOf((*MockClient)(nil).Windows))
 }
 
+// WithContext mocks base method.
+func (m *MockClient) WithContext(ctx context.Context) aerospace.Client {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithContext", ctx)
+	ret0, _ := ret[0].(aerospace.Client)
+	return ret0
+}
+
+// WithContext indicates an expected call of WithContext.
+func (mr *MockClientMockRecorder) WithContext(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithContext", reflect.TypeOf((*MockClient)(nil).WithContext), ctx)
+}
+
 // Workspaces mocks base method.
 func (m *MockClient) Workspaces() *workspaces.Service {
 	m.ctrl.T.Helper()
@@ -0,0 +1,144 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./pkg/aerospace/config/config.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./pkg/aerospace/config/config.go -destination=./mocks/aerospace/config/config_mock.go -package=config_mock
+//
+
+// Package config_mock is a generated GoMock package.
+package config_mock
+
+import (
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockConfigService is a mock of ConfigService interface.
+type MockConfigService struct {
+	ctrl     *gomock.Controller
+	recorder *MockConfigServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockConfigServiceMockRecorder is the mock recorder for MockConfigService.
+type MockConfigServiceMockRecorder struct {
+	mock *MockConfigService
+}
+
+// NewMockConfigService creates a new mock instance.
+func NewMockConfigService(ctrl *gomock.Controller) *MockConfigService {
+	mock := &MockConfigService{ctrl: ctrl}
+	mock.recorder = &MockConfigServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockConfigService) EXPECT() *MockConfigServiceMockRecorder {
+	return m.recorder
+}
+
+// GetAll mocks base method.
+func (m *MockConfigService) GetAll() (map[string]any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll")
+	ret0, _ := ret[0].(map[string]any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockConfigServiceMockRecorder) GetAll() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockConfigService)(nil).GetAll))
+}
+
+// GetAllKeys mocks base method.
+func (m *MockConfigService) GetAllKeys() ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllKeys")
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllKeys indicates an expected call of GetAllKeys.
+func (mr *MockConfigServiceMockRecorder) GetAllKeys() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllKeys", reflect.TypeOf((*MockConfigService)(nil).GetAllKeys))
+}
+
+// GetConfigPath mocks base method.
+func (m *MockConfigService) GetConfigPath() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetConfigPath")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetConfigPath indicates an expected call of GetConfigPath.
+func (mr *MockConfigServiceMockRecorder) GetConfigPath() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConfigPath", reflect.TypeOf((*MockConfigService)(nil).GetConfigPath))
+}
+
+// GetKeys mocks base method.
+func (m *MockConfigService) GetKeys(key string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetKeys", key)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetKeys indicates an expected call of GetKeys.
+func (mr *MockConfigServiceMockRecorder) GetKeys(key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKeys", reflect.TypeOf((*MockConfigService)(nil).GetKeys), key)
+}
+
+// GetMajorKeys mocks base method.
+func (m *MockConfigService) GetMajorKeys() ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMajorKeys")
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMajorKeys indicates an expected call of GetMajorKeys.
+func (mr *MockConfigServiceMockRecorder) GetMajorKeys() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMajorKeys", reflect.TypeOf((*MockConfigService)(nil).GetMajorKeys))
+}
+
+// GetValue mocks base method.
+func (m *MockConfigService) GetValue(key string) (any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetValue", key)
+	ret0, _ := ret[0].(any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetValue indicates an expected call of GetValue.
+func (mr *MockConfigServiceMockRecorder) GetValue(key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetValue", reflect.TypeOf((*MockConfigService)(nil).GetValue), key)
+}
+
+// GetValueInto mocks base method.
+func (m *MockConfigService) GetValueInto(key string, v any) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetValueInto", key, v)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetValueInto indicates an expected call of GetValueInto.
+func (mr *MockConfigServiceMockRecorder) GetValueInto(key, v any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetValueInto", reflect.TypeOf((*MockConfigService)(nil).GetValueInto), key, v)
+}
@@ -11,8 +11,10 @@ package focus_mock
 
 import (
 	reflect "reflect"
+	regexp "regexp"
 
 	focus "github.com/cristianoliveira/aerospace-ipc/pkg/aerospace/focus"
+	client "github.com/cristianoliveira/aerospace-ipc/pkg/client"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -40,6 +42,101 @@ func (m *MockFocusService) EXPECT() *MockFocusServiceMockRecorder {
 	return m.recorder
 }
 
+// FocusBackAndForth mocks base method.
+func (m *MockFocusService) FocusBackAndForth() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FocusBackAndForth")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FocusBackAndForth indicates an expected call of FocusBackAndForth.
+func (mr *MockFocusServiceMockRecorder) FocusBackAndForth() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FocusBackAndForth", reflect.TypeOf((*MockFocusService)(nil).FocusBackAndForth))
+}
+
+// BackAndForthOrWorkspace mocks base method.
+func (m *MockFocusService) BackAndForthOrWorkspace() (focus.BackAndForthResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BackAndForthOrWorkspace")
+	ret0, _ := ret[0].(focus.BackAndForthResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BackAndForthOrWorkspace indicates an expected call of BackAndForthOrWorkspace.
+func (mr *MockFocusServiceMockRecorder) BackAndForthOrWorkspace() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BackAndForthOrWorkspace", reflect.TypeOf((*MockFocusService)(nil).BackAndForthOrWorkspace))
+}
+
+// FocusMonitor mocks base method.
+func (m *MockFocusService) FocusMonitor(target focus.FocusMonitorArgs, opts focus.FocusMonitorOpts) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FocusMonitor", target, opts)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FocusMonitor indicates an expected call of FocusMonitor.
+func (mr *MockFocusServiceMockRecorder) FocusMonitor(target, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FocusMonitor", reflect.TypeOf((*MockFocusService)(nil).FocusMonitor), target, opts)
+}
+
+// MoveMouse mocks base method.
+func (m *MockFocusService) MoveMouse(target string, opts ...focus.MoveMouseOpts) error {
+	m.ctrl.T.Helper()
+	varargs := []any{target}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "MoveMouse", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MoveMouse indicates an expected call of MoveMouse.
+func (mr *MockFocusServiceMockRecorder) MoveMouse(target any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{target}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveMouse", reflect.TypeOf((*MockFocusService)(nil).MoveMouse), varargs...)
+}
+
+// RestoreWorkspaceFocus mocks base method.
+func (m *MockFocusService) RestoreWorkspaceFocus(workspace string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreWorkspaceFocus", workspace)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreWorkspaceFocus indicates an expected call of RestoreWorkspaceFocus.
+func (mr *MockFocusServiceMockRecorder) RestoreWorkspaceFocus(workspace any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreWorkspaceFocus", reflect.TypeOf((*MockFocusService)(nil).RestoreWorkspaceFocus), workspace)
+}
+
+// SetFocusByApp mocks base method.
+func (m *MockFocusService) SetFocusByApp(app string, opts ...focus.SetFocusOpts) error {
+	m.ctrl.T.Helper()
+	varargs := []any{app}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SetFocusByApp", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetFocusByApp indicates an expected call of SetFocusByApp.
+func (mr *MockFocusServiceMockRecorder) SetFocusByApp(app any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{app}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetFocusByApp", reflect.TypeOf((*MockFocusService)(nil).SetFocusByApp), varargs...)
+}
+
 // SetFocusByDFS mocks base method.
 func (m *MockFocusService) SetFocusByDFS(direction string, opts ...focus.SetFocusOpts) error {
 	m.ctrl.T.Helper()
@@ -92,8 +189,27 @@ func (mr *MockFocusServiceMockRecorder) SetFocusByDirection(direction any, opts
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetFocusByDirection", reflect.TypeOf((*MockFocusService)(nil).SetFocusByDirection), varargs...)
 }
 
+// SetFocusByTitle mocks base method.
+func (m *MockFocusService) SetFocusByTitle(pattern *regexp.Regexp, opts ...focus.SetFocusOpts) error {
+	m.ctrl.T.Helper()
+	varargs := []any{pattern}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SetFocusByTitle", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetFocusByTitle indicates an expected call of SetFocusByTitle.
+func (mr *MockFocusServiceMockRecorder) SetFocusByTitle(pattern any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{pattern}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetFocusByTitle", reflect.TypeOf((*MockFocusService)(nil).SetFocusByTitle), varargs...)
+}
+
 // SetFocusByWindowID mocks base method.
-func (m *MockFocusService) SetFocusByWindowID(windowID int, opts ...focus.SetFocusOpts) error {
+func (m *MockFocusService) SetFocusByWindowID(windowID client.WindowID, opts ...focus.SetFocusOpts) error {
 	m.ctrl.T.Helper()
 	varargs := []any{windowID}
 	for _, a := range opts {
@@ -0,0 +1,56 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./pkg/aerospace/focus/restore.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./pkg/aerospace/focus/restore.go -destination=./mocks/aerospace/focus/restore_mock.go -package=focus_mock
+//
+
+// Package focus_mock is a generated GoMock package.
+package focus_mock
+
+import (
+	reflect "reflect"
+
+	client "github.com/cristianoliveira/aerospace-ipc/pkg/client"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockWindowHistory is a mock of WindowHistory interface.
+type MockWindowHistory struct {
+	ctrl     *gomock.Controller
+	recorder *MockWindowHistoryMockRecorder
+	isgomock struct{}
+}
+
+// MockWindowHistoryMockRecorder is the mock recorder for MockWindowHistory.
+type MockWindowHistoryMockRecorder struct {
+	mock *MockWindowHistory
+}
+
+// NewMockWindowHistory creates a new mock instance.
+func NewMockWindowHistory(ctrl *gomock.Controller) *MockWindowHistory {
+	mock := &MockWindowHistory{ctrl: ctrl}
+	mock.recorder = &MockWindowHistoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWindowHistory) EXPECT() *MockWindowHistoryMockRecorder {
+	return m.recorder
+}
+
+// LastFocusedWindow mocks base method.
+func (m *MockWindowHistory) LastFocusedWindow(workspace string) (client.WindowID, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LastFocusedWindow", workspace)
+	ret0, _ := ret[0].(client.WindowID)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// LastFocusedWindow indicates an expected call of LastFocusedWindow.
+func (mr *MockWindowHistoryMockRecorder) LastFocusedWindow(workspace any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LastFocusedWindow", reflect.TypeOf((*MockWindowHistory)(nil).LastFocusedWindow), workspace)
+}
@@ -40,6 +40,44 @@ func (m *MockLayoutService) EXPECT() *MockLayoutServiceMockRecorder {
 	return m.recorder
 }
 
+// Resize mocks base method.
+func (m *MockLayoutService) Resize(dimension layout.Dimension, amount string, opts ...layout.ResizeOpts) error {
+	m.ctrl.T.Helper()
+	varargs := []any{dimension, amount}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Resize", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Resize indicates an expected call of Resize.
+func (mr *MockLayoutServiceMockRecorder) Resize(dimension, amount any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{dimension, amount}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Resize", reflect.TypeOf((*MockLayoutService)(nil).Resize), varargs...)
+}
+
+// ResizeToPercent mocks base method.
+func (m *MockLayoutService) ResizeToPercent(dimension layout.Dimension, percent float64, opts ...layout.ResizeOpts) error {
+	m.ctrl.T.Helper()
+	varargs := []any{dimension, percent}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ResizeToPercent", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResizeToPercent indicates an expected call of ResizeToPercent.
+func (mr *MockLayoutServiceMockRecorder) ResizeToPercent(dimension, percent any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{dimension, percent}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResizeToPercent", reflect.TypeOf((*MockLayoutService)(nil).ResizeToPercent), varargs...)
+}
+
 // SetLayout mocks base method.
 func (m *MockLayoutService) SetLayout(layouts []string, opts ...layout.SetLayoutOpts) error {
 	m.ctrl.T.Helper()
@@ -58,3 +96,53 @@ func (mr *MockLayoutServiceMockRecorder) SetLayout(layouts any, opts ...any) *go
 	varargs := append([]any{layouts}, opts...)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLayout", reflect.TypeOf((*MockLayoutService)(nil).SetLayout), varargs...)
 }
+
+// SetLayoutForWorkspace mocks base method.
+func (m *MockLayoutService) SetLayoutForWorkspace(workspace string, layouts []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetLayoutForWorkspace", workspace, layouts)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetLayoutForWorkspace indicates an expected call of SetLayoutForWorkspace.
+func (mr *MockLayoutServiceMockRecorder) SetLayoutForWorkspace(workspace, layouts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLayoutForWorkspace", reflect.TypeOf((*MockLayoutService)(nil).SetLayoutForWorkspace), workspace, layouts)
+}
+
+// ToggleFloating mocks base method.
+func (m *MockLayoutService) ToggleFloating(opts ...layout.SetLayoutOpts) error {
+	m.ctrl.T.Helper()
+	varargs := []any{}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ToggleFloating", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ToggleFloating indicates an expected call of ToggleFloating.
+func (mr *MockLayoutServiceMockRecorder) ToggleFloating(opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ToggleFloating", reflect.TypeOf((*MockLayoutService)(nil).ToggleFloating), opts...)
+}
+
+// ToggleOrientation mocks base method.
+func (m *MockLayoutService) ToggleOrientation(opts ...layout.SetLayoutOpts) error {
+	m.ctrl.T.Helper()
+	varargs := []any{}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ToggleOrientation", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ToggleOrientation indicates an expected call of ToggleOrientation.
+func (mr *MockLayoutServiceMockRecorder) ToggleOrientation(opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ToggleOrientation", reflect.TypeOf((*MockLayoutService)(nil).ToggleOrientation), opts...)
+}
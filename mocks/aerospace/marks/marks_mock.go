@@ -0,0 +1,114 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./pkg/aerospace/marks/marks.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./pkg/aerospace/marks/marks.go -destination=./mocks/aerospace/marks/marks_mock.go -package=marks_mock
+//
+
+// Package marks_mock is a generated GoMock package.
+package marks_mock
+
+import (
+	reflect "reflect"
+
+	marks "github.com/cristianoliveira/aerospace-ipc/pkg/aerospace/marks"
+	client "github.com/cristianoliveira/aerospace-ipc/pkg/client"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockMarksService is a mock of MarksService interface.
+type MockMarksService struct {
+	ctrl     *gomock.Controller
+	recorder *MockMarksServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockMarksServiceMockRecorder is the mock recorder for MockMarksService.
+type MockMarksServiceMockRecorder struct {
+	mock *MockMarksService
+}
+
+// NewMockMarksService creates a new mock instance.
+func NewMockMarksService(ctrl *gomock.Controller) *MockMarksService {
+	mock := &MockMarksService{ctrl: ctrl}
+	mock.recorder = &MockMarksServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMarksService) EXPECT() *MockMarksServiceMockRecorder {
+	return m.recorder
+}
+
+// Focus mocks base method.
+func (m *MockMarksService) Focus(label string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Focus", label)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Focus indicates an expected call of Focus.
+func (mr *MockMarksServiceMockRecorder) Focus(label any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Focus", reflect.TypeOf((*MockMarksService)(nil).Focus), label)
+}
+
+// GetWindowID mocks base method.
+func (m *MockMarksService) GetWindowID(label string) (client.WindowID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWindowID", label)
+	ret0, _ := ret[0].(client.WindowID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWindowID indicates an expected call of GetWindowID.
+func (mr *MockMarksServiceMockRecorder) GetWindowID(label any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWindowID", reflect.TypeOf((*MockMarksService)(nil).GetWindowID), label)
+}
+
+// List mocks base method.
+func (m *MockMarksService) List() ([]marks.Mark, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List")
+	ret0, _ := ret[0].([]marks.Mark)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockMarksServiceMockRecorder) List() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockMarksService)(nil).List))
+}
+
+// Mark mocks base method.
+func (m *MockMarksService) Mark(windowID client.WindowID, label string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Mark", windowID, label)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Mark indicates an expected call of Mark.
+func (mr *MockMarksServiceMockRecorder) Mark(windowID, label any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Mark", reflect.TypeOf((*MockMarksService)(nil).Mark), windowID, label)
+}
+
+// Unmark mocks base method.
+func (m *MockMarksService) Unmark(label string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unmark", label)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Unmark indicates an expected call of Unmark.
+func (mr *MockMarksServiceMockRecorder) Unmark(label any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unmark", reflect.TypeOf((*MockMarksService)(nil).Unmark), label)
+}
@@ -0,0 +1,84 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./pkg/aerospace/marks/store.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./pkg/aerospace/marks/store.go -destination=./mocks/aerospace/marks/store_mock.go -package=marks_mock
+//
+
+// Package marks_mock is a generated GoMock package.
+package marks_mock
+
+import (
+	reflect "reflect"
+
+	client "github.com/cristianoliveira/aerospace-ipc/pkg/client"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockStore is a mock of Store interface.
+type MockStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockStoreMockRecorder
+	isgomock struct{}
+}
+
+// MockStoreMockRecorder is the mock recorder for MockStore.
+type MockStoreMockRecorder struct {
+	mock *MockStore
+}
+
+// NewMockStore creates a new mock instance.
+func NewMockStore(ctrl *gomock.Controller) *MockStore {
+	mock := &MockStore{ctrl: ctrl}
+	mock.recorder = &MockStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStore) EXPECT() *MockStoreMockRecorder {
+	return m.recorder
+}
+
+// DeleteMark mocks base method.
+func (m *MockStore) DeleteMark(label string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMark", label)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteMark indicates an expected call of DeleteMark.
+func (mr *MockStoreMockRecorder) DeleteMark(label any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMark", reflect.TypeOf((*MockStore)(nil).DeleteMark), label)
+}
+
+// Marks mocks base method.
+func (m *MockStore) Marks() (map[string]client.WindowID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Marks")
+	ret0, _ := ret[0].(map[string]client.WindowID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Marks indicates an expected call of Marks.
+func (mr *MockStoreMockRecorder) Marks() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Marks", reflect.TypeOf((*MockStore)(nil).Marks))
+}
+
+// SetMark mocks base method.
+func (m *MockStore) SetMark(label string, windowID client.WindowID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetMark", label, windowID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetMark indicates an expected call of SetMark.
+func (mr *MockStoreMockRecorder) SetMark(label, windowID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMark", reflect.TypeOf((*MockStore)(nil).SetMark), label, windowID)
+}
@@ -0,0 +1,55 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./pkg/aerospace/modes/modes.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./pkg/aerospace/modes/modes.go -destination=./mocks/aerospace/modes/modes_mock.go -package=modes_mock
+//
+
+// Package modes_mock is a generated GoMock package.
+package modes_mock
+
+import (
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockModesService is a mock of ModesService interface.
+type MockModesService struct {
+	ctrl     *gomock.Controller
+	recorder *MockModesServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockModesServiceMockRecorder is the mock recorder for MockModesService.
+type MockModesServiceMockRecorder struct {
+	mock *MockModesService
+}
+
+// NewMockModesService creates a new mock instance.
+func NewMockModesService(ctrl *gomock.Controller) *MockModesService {
+	mock := &MockModesService{ctrl: ctrl}
+	mock.recorder = &MockModesServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockModesService) EXPECT() *MockModesServiceMockRecorder {
+	return m.recorder
+}
+
+// Current mocks base method.
+func (m *MockModesService) Current() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Current")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Current indicates an expected call of Current.
+func (mr *MockModesServiceMockRecorder) Current() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Current", reflect.TypeOf((*MockModesService)(nil).Current))
+}
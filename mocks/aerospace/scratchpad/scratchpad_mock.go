@@ -0,0 +1,70 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./pkg/aerospace/scratchpad/scratchpad.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./pkg/aerospace/scratchpad/scratchpad.go -destination=./mocks/aerospace/scratchpad/scratchpad_mock.go -package=scratchpad_mock
+//
+
+// Package scratchpad_mock is a generated GoMock package.
+package scratchpad_mock
+
+import (
+	reflect "reflect"
+
+	client "github.com/cristianoliveira/aerospace-ipc/pkg/client"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockScratchpadService is a mock of ScratchpadService interface.
+type MockScratchpadService struct {
+	ctrl     *gomock.Controller
+	recorder *MockScratchpadServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockScratchpadServiceMockRecorder is the mock recorder for MockScratchpadService.
+type MockScratchpadServiceMockRecorder struct {
+	mock *MockScratchpadService
+}
+
+// NewMockScratchpadService creates a new mock instance.
+func NewMockScratchpadService(ctrl *gomock.Controller) *MockScratchpadService {
+	mock := &MockScratchpadService{ctrl: ctrl}
+	mock.recorder = &MockScratchpadServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockScratchpadService) EXPECT() *MockScratchpadServiceMockRecorder {
+	return m.recorder
+}
+
+// Send mocks base method.
+func (m *MockScratchpadService) Send(windowID client.WindowID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", windowID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockScratchpadServiceMockRecorder) Send(windowID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockScratchpadService)(nil).Send), windowID)
+}
+
+// Toggle mocks base method.
+func (m *MockScratchpadService) Toggle(bundleID string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Toggle", bundleID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Toggle indicates an expected call of Toggle.
+func (mr *MockScratchpadServiceMockRecorder) Toggle(bundleID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Toggle", reflect.TypeOf((*MockScratchpadService)(nil).Toggle), bundleID)
+}
@@ -0,0 +1,86 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./pkg/aerospace/session/session.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./pkg/aerospace/session/session.go -destination=./mocks/aerospace/session/session_mock.go -package=session_mock
+//
+
+// Package session_mock is a generated GoMock package.
+package session_mock
+
+import (
+	reflect "reflect"
+
+	session "github.com/cristianoliveira/aerospace-ipc/pkg/aerospace/session"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockSessionService is a mock of SessionService interface.
+type MockSessionService struct {
+	ctrl     *gomock.Controller
+	recorder *MockSessionServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockSessionServiceMockRecorder is the mock recorder for MockSessionService.
+type MockSessionServiceMockRecorder struct {
+	mock *MockSessionService
+}
+
+// NewMockSessionService creates a new mock instance.
+func NewMockSessionService(ctrl *gomock.Controller) *MockSessionService {
+	mock := &MockSessionService{ctrl: ctrl}
+	mock.recorder = &MockSessionServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSessionService) EXPECT() *MockSessionServiceMockRecorder {
+	return m.recorder
+}
+
+// PlanRestore mocks base method.
+func (m *MockSessionService) PlanRestore(arg0 *session.Session) (session.RestoreResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PlanRestore", arg0)
+	ret0, _ := ret[0].(session.RestoreResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PlanRestore indicates an expected call of PlanRestore.
+func (mr *MockSessionServiceMockRecorder) PlanRestore(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PlanRestore", reflect.TypeOf((*MockSessionService)(nil).PlanRestore), arg0)
+}
+
+// Record mocks base method.
+func (m *MockSessionService) Record() (*session.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Record")
+	ret0, _ := ret[0].(*session.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Record indicates an expected call of Record.
+func (mr *MockSessionServiceMockRecorder) Record() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Record", reflect.TypeOf((*MockSessionService)(nil).Record))
+}
+
+// Restore mocks base method.
+func (m *MockSessionService) Restore(arg0 *session.Session) (session.RestoreResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", arg0)
+	ret0, _ := ret[0].(session.RestoreResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockSessionServiceMockRecorder) Restore(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockSessionService)(nil).Restore), arg0)
+}
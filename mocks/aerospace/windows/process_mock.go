@@ -0,0 +1,57 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./pkg/aerospace/windows/process.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./pkg/aerospace/windows/process.go -destination=./mocks/aerospace/windows/process_mock.go -package=windows_mock
+//
+
+// Package windows_mock is a generated GoMock package.
+package windows_mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	windows "github.com/cristianoliveira/aerospace-ipc/pkg/aerospace/windows"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockProcessLookup is a mock of ProcessLookup interface.
+type MockProcessLookup struct {
+	ctrl     *gomock.Controller
+	recorder *MockProcessLookupMockRecorder
+	isgomock struct{}
+}
+
+// MockProcessLookupMockRecorder is the mock recorder for MockProcessLookup.
+type MockProcessLookupMockRecorder struct {
+	mock *MockProcessLookup
+}
+
+// NewMockProcessLookup creates a new mock instance.
+func NewMockProcessLookup(ctrl *gomock.Controller) *MockProcessLookup {
+	mock := &MockProcessLookup{ctrl: ctrl}
+	mock.recorder = &MockProcessLookupMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProcessLookup) EXPECT() *MockProcessLookupMockRecorder {
+	return m.recorder
+}
+
+// LookupProcess mocks base method.
+func (m *MockProcessLookup) LookupProcess(ctx context.Context, pid int) (*windows.ProcessInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LookupProcess", ctx, pid)
+	ret0, _ := ret[0].(*windows.ProcessInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LookupProcess indicates an expected call of LookupProcess.
+func (mr *MockProcessLookupMockRecorder) LookupProcess(ctx, pid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LookupProcess", reflect.TypeOf((*MockProcessLookup)(nil).LookupProcess), ctx, pid)
+}
@@ -10,7 +10,10 @@
 package windows_mock
 
 import (
+	context "context"
+	iter "iter"
 	reflect "reflect"
+	time "time"
 
 	windows "github.com/cristianoliveira/aerospace-ipc/pkg/aerospace/windows"
 	gomock "go.uber.org/mock/gomock"
@@ -40,6 +43,93 @@ func (m *MockWindowsService) EXPECT() *MockWindowsServiceMockRecorder {
 	return m.recorder
 }
 
+// All mocks base method.
+func (m *MockWindowsService) All() iter.Seq2[windows.Window, error] {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "All")
+	ret0, _ := ret[0].(iter.Seq2[windows.Window, error])
+	return ret0
+}
+
+// All indicates an expected call of All.
+func (mr *MockWindowsServiceMockRecorder) All() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "All", reflect.TypeOf((*MockWindowsService)(nil).All))
+}
+
+// CloseWindowsByApp mocks base method.
+func (m *MockWindowsService) CloseWindowsByApp(bundleID string, opts ...windows.CloseWindowsOpts) (windows.CloseSummary, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{bundleID}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CloseWindowsByApp", varargs...)
+	ret0, _ := ret[0].(windows.CloseSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CloseWindowsByApp indicates an expected call of CloseWindowsByApp.
+func (mr *MockWindowsServiceMockRecorder) CloseWindowsByApp(bundleID any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{bundleID}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseWindowsByApp", reflect.TypeOf((*MockWindowsService)(nil).CloseWindowsByApp), varargs...)
+}
+
+// DebugWindows mocks base method.
+func (m *MockWindowsService) DebugWindows(opts ...windows.DebugWindowsOpts) (string, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DebugWindows", varargs...)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DebugWindows indicates an expected call of DebugWindows.
+func (mr *MockWindowsServiceMockRecorder) DebugWindows(opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DebugWindows", reflect.TypeOf((*MockWindowsService)(nil).DebugWindows), opts...)
+}
+
+// Find mocks base method.
+func (m *MockWindowsService) Find(filters ...windows.Filter) ([]windows.Window, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{}
+	for _, a := range filters {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Find", varargs...)
+	ret0, _ := ret[0].([]windows.Window)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Find indicates an expected call of Find.
+func (mr *MockWindowsServiceMockRecorder) Find(filters ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Find", reflect.TypeOf((*MockWindowsService)(nil).Find), filters...)
+}
+
+// GatherWindowsByApp mocks base method.
+func (m *MockWindowsService) GatherWindowsByApp(bundleID, workspace string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GatherWindowsByApp", bundleID, workspace)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GatherWindowsByApp indicates an expected call of GatherWindowsByApp.
+func (mr *MockWindowsServiceMockRecorder) GatherWindowsByApp(bundleID, workspace any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GatherWindowsByApp", reflect.TypeOf((*MockWindowsService)(nil).GatherWindowsByApp), bundleID, workspace)
+}
+
 // GetAllWindows mocks base method.
 func (m *MockWindowsService) GetAllWindows() ([]windows.Window, error) {
 	m.ctrl.T.Helper()
@@ -70,6 +160,20 @@ func (mr *MockWindowsServiceMockRecorder) GetAllWindowsByWorkspace(workspaceName
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllWindowsByWorkspace", reflect.TypeOf((*MockWindowsService)(nil).GetAllWindowsByWorkspace), workspaceName)
 }
 
+// GetAllWindowsFunc mocks base method.
+func (m *MockWindowsService) GetAllWindowsFunc(fn func(windows.Window) bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllWindowsFunc", fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetAllWindowsFunc indicates an expected call of GetAllWindowsFunc.
+func (mr *MockWindowsServiceMockRecorder) GetAllWindowsFunc(fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllWindowsFunc", reflect.TypeOf((*MockWindowsService)(nil).GetAllWindowsFunc), fn)
+}
+
 // GetFocusedWindow mocks base method.
 func (m *MockWindowsService) GetFocusedWindow() (*windows.Window, error) {
 	m.ctrl.T.Helper()
@@ -85,6 +189,85 @@ func (mr *MockWindowsServiceMockRecorder) GetFocusedWindow() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFocusedWindow", reflect.TypeOf((*MockWindowsService)(nil).GetFocusedWindow))
 }
 
+// GetWindowsByAppBundleID mocks base method.
+func (m *MockWindowsService) GetWindowsByAppBundleID(bundleID string) ([]windows.Window, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWindowsByAppBundleID", bundleID)
+	ret0, _ := ret[0].([]windows.Window)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWindowsByAppBundleID indicates an expected call of GetWindowsByAppBundleID.
+func (mr *MockWindowsServiceMockRecorder) GetWindowsByAppBundleID(bundleID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWindowsByAppBundleID", reflect.TypeOf((*MockWindowsService)(nil).GetWindowsByAppBundleID), bundleID)
+}
+
+// GetWindowsByAppName mocks base method.
+func (m *MockWindowsService) GetWindowsByAppName(name string) ([]windows.Window, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWindowsByAppName", name)
+	ret0, _ := ret[0].([]windows.Window)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWindowsByAppName indicates an expected call of GetWindowsByAppName.
+func (mr *MockWindowsServiceMockRecorder) GetWindowsByAppName(name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWindowsByAppName", reflect.TypeOf((*MockWindowsService)(nil).GetWindowsByAppName), name)
+}
+
+// GetWindowsByPID mocks base method.
+func (m *MockWindowsService) GetWindowsByPID(pid int) ([]windows.Window, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWindowsByPID", pid)
+	ret0, _ := ret[0].([]windows.Window)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWindowsByPID indicates an expected call of GetWindowsByPID.
+func (mr *MockWindowsServiceMockRecorder) GetWindowsByPID(pid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWindowsByPID", reflect.TypeOf((*MockWindowsService)(nil).GetWindowsByPID), pid)
+}
+
+// GetWindowsCount mocks base method.
+func (m *MockWindowsService) GetWindowsCount(opts ...windows.ListWindowsOpts) (int, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetWindowsCount", varargs...)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWindowsCount indicates an expected call of GetWindowsCount.
+func (mr *MockWindowsServiceMockRecorder) GetWindowsCount(opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWindowsCount", reflect.TypeOf((*MockWindowsService)(nil).GetWindowsCount), opts...)
+}
+
+// GetWindowsWithOpts mocks base method.
+func (m *MockWindowsService) GetWindowsWithOpts(opts windows.ListWindowsOpts) ([]windows.Window, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWindowsWithOpts", opts)
+	ret0, _ := ret[0].([]windows.Window)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWindowsWithOpts indicates an expected call of GetWindowsWithOpts.
+func (mr *MockWindowsServiceMockRecorder) GetWindowsWithOpts(opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWindowsWithOpts", reflect.TypeOf((*MockWindowsService)(nil).GetWindowsWithOpts), opts)
+}
+
 // SetFocusByDFS mocks base method.
 func (m *MockWindowsService) SetFocusByDFS(args windows.SetFocusByDFSArgs) error {
 	m.ctrl.T.Helper()
@@ -210,3 +393,18 @@ func (mr *MockWindowsServiceMockRecorder) SetLayoutWithOpts(args, opts any) *gom
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLayoutWithOpts", reflect.TypeOf((*MockWindowsService)(nil).SetLayoutWithOpts), args, opts)
 }
+
+// WaitFor mocks base method.
+func (m *MockWindowsService) WaitFor(ctx context.Context, predicate func(windows.Window) bool, pollInterval time.Duration) (*windows.Window, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WaitFor", ctx, predicate, pollInterval)
+	ret0, _ := ret[0].(*windows.Window)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WaitFor indicates an expected call of WaitFor.
+func (mr *MockWindowsServiceMockRecorder) WaitFor(ctx, predicate, pollInterval any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WaitFor", reflect.TypeOf((*MockWindowsService)(nil).WaitFor), ctx, predicate, pollInterval)
+}
@@ -0,0 +1,54 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./pkg/aerospace/workspaces/recent.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./pkg/aerospace/workspaces/recent.go -destination=./mocks/aerospace/workspaces/recent_mock.go -package=workspaces_mock
+//
+
+// Package workspaces_mock is a generated GoMock package.
+package workspaces_mock
+
+import (
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockFocusHistory is a mock of FocusHistory interface.
+type MockFocusHistory struct {
+	ctrl     *gomock.Controller
+	recorder *MockFocusHistoryMockRecorder
+	isgomock struct{}
+}
+
+// MockFocusHistoryMockRecorder is the mock recorder for MockFocusHistory.
+type MockFocusHistoryMockRecorder struct {
+	mock *MockFocusHistory
+}
+
+// NewMockFocusHistory creates a new mock instance.
+func NewMockFocusHistory(ctrl *gomock.Controller) *MockFocusHistory {
+	mock := &MockFocusHistory{ctrl: ctrl}
+	mock.recorder = &MockFocusHistoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFocusHistory) EXPECT() *MockFocusHistoryMockRecorder {
+	return m.recorder
+}
+
+// RecentlyFocusedWorkspaces mocks base method.
+func (m *MockFocusHistory) RecentlyFocusedWorkspaces(n int) []string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecentlyFocusedWorkspaces", n)
+	ret0, _ := ret[0].([]string)
+	return ret0
+}
+
+// RecentlyFocusedWorkspaces indicates an expected call of RecentlyFocusedWorkspaces.
+func (mr *MockFocusHistoryMockRecorder) RecentlyFocusedWorkspaces(n any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecentlyFocusedWorkspaces", reflect.TypeOf((*MockFocusHistory)(nil).RecentlyFocusedWorkspaces), n)
+}
@@ -10,9 +10,11 @@
 package workspaces_mock
 
 import (
+	context "context"
 	reflect "reflect"
 
 	workspaces "github.com/cristianoliveira/aerospace-ipc/pkg/aerospace/workspaces"
+	client "github.com/cristianoliveira/aerospace-ipc/pkg/client"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -40,6 +42,69 @@ func (m *MockWorkspacesService) EXPECT() *MockWorkspacesServiceMockRecorder {
 	return m.recorder
 }
 
+// EnsureWindowOnWorkspace mocks base method.
+func (m *MockWorkspacesService) EnsureWindowOnWorkspace(windowID client.WindowID, workspace string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnsureWindowOnWorkspace", windowID, workspace)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnsureWindowOnWorkspace indicates an expected call of EnsureWindowOnWorkspace.
+func (mr *MockWorkspacesServiceMockRecorder) EnsureWindowOnWorkspace(windowID, workspace any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnsureWindowOnWorkspace", reflect.TypeOf((*MockWorkspacesService)(nil).EnsureWindowOnWorkspace), windowID, workspace)
+}
+
+// FocusNthPrevious mocks base method.
+func (m *MockWorkspacesService) FocusNthPrevious(n int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FocusNthPrevious", n)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FocusNthPrevious indicates an expected call of FocusNthPrevious.
+func (mr *MockWorkspacesServiceMockRecorder) FocusNthPrevious(n any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FocusNthPrevious", reflect.TypeOf((*MockWorkspacesService)(nil).FocusNthPrevious), n)
+}
+
+// GetAllWorkspaces mocks base method.
+func (m *MockWorkspacesService) GetAllWorkspaces(opts ...workspaces.ListWorkspacesOpts) ([]workspaces.Workspace, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetAllWorkspaces", varargs...)
+	ret0, _ := ret[0].([]workspaces.Workspace)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllWorkspaces indicates an expected call of GetAllWorkspaces.
+func (mr *MockWorkspacesServiceMockRecorder) GetAllWorkspaces(opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllWorkspaces", reflect.TypeOf((*MockWorkspacesService)(nil).GetAllWorkspaces), opts...)
+}
+
+// GetEmptyWorkspaces mocks base method.
+func (m *MockWorkspacesService) GetEmptyWorkspaces() ([]workspaces.Workspace, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEmptyWorkspaces")
+	ret0, _ := ret[0].([]workspaces.Workspace)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEmptyWorkspaces indicates an expected call of GetEmptyWorkspaces.
+func (mr *MockWorkspacesServiceMockRecorder) GetEmptyWorkspaces() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEmptyWorkspaces", reflect.TypeOf((*MockWorkspacesService)(nil).GetEmptyWorkspaces))
+}
+
 // GetFocusedWorkspace mocks base method.
 func (m *MockWorkspacesService) GetFocusedWorkspace() (*workspaces.Workspace, error) {
 	m.ctrl.T.Helper()
@@ -55,6 +120,84 @@ func (mr *MockWorkspacesServiceMockRecorder) GetFocusedWorkspace() *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFocusedWorkspace", reflect.TypeOf((*MockWorkspacesService)(nil).GetFocusedWorkspace))
 }
 
+// GetVisibleWorkspaces mocks base method.
+func (m *MockWorkspacesService) GetVisibleWorkspaces() ([]workspaces.Workspace, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVisibleWorkspaces")
+	ret0, _ := ret[0].([]workspaces.Workspace)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVisibleWorkspaces indicates an expected call of GetVisibleWorkspaces.
+func (mr *MockWorkspacesServiceMockRecorder) GetVisibleWorkspaces() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVisibleWorkspaces", reflect.TypeOf((*MockWorkspacesService)(nil).GetVisibleWorkspaces))
+}
+
+// GetWorkspacesByMonitor mocks base method.
+func (m *MockWorkspacesService) GetWorkspacesByMonitor(monitor string) ([]workspaces.Workspace, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWorkspacesByMonitor", monitor)
+	ret0, _ := ret[0].([]workspaces.Workspace)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWorkspacesByMonitor indicates an expected call of GetWorkspacesByMonitor.
+func (mr *MockWorkspacesServiceMockRecorder) GetWorkspacesByMonitor(monitor any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkspacesByMonitor", reflect.TypeOf((*MockWorkspacesService)(nil).GetWorkspacesByMonitor), monitor)
+}
+
+// GetWorkspacesCount mocks base method.
+func (m *MockWorkspacesService) GetWorkspacesCount(opts ...workspaces.ListWorkspacesOpts) (int, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetWorkspacesCount", varargs...)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWorkspacesCount indicates an expected call of GetWorkspacesCount.
+func (mr *MockWorkspacesServiceMockRecorder) GetWorkspacesCount(opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkspacesCount", reflect.TypeOf((*MockWorkspacesService)(nil).GetWorkspacesCount), opts...)
+}
+
+// MoveBackAndForth mocks base method.
+func (m *MockWorkspacesService) MoveBackAndForth() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveBackAndForth")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MoveBackAndForth indicates an expected call of MoveBackAndForth.
+func (mr *MockWorkspacesServiceMockRecorder) MoveBackAndForth() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveBackAndForth", reflect.TypeOf((*MockWorkspacesService)(nil).MoveBackAndForth))
+}
+
+// MoveWindowToNewWorkspace mocks base method.
+func (m *MockWorkspacesService) MoveWindowToNewWorkspace(windowID client.WindowID) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveWindowToNewWorkspace", windowID)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MoveWindowToNewWorkspace indicates an expected call of MoveWindowToNewWorkspace.
+func (mr *MockWorkspacesServiceMockRecorder) MoveWindowToNewWorkspace(windowID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveWindowToNewWorkspace", reflect.TypeOf((*MockWorkspacesService)(nil).MoveWindowToNewWorkspace), windowID)
+}
+
 // MoveWindowToWorkspace mocks base method.
 func (m *MockWorkspacesService) MoveWindowToWorkspace(args workspaces.MoveWindowToWorkspaceArgs) error {
 	m.ctrl.T.Helper()
@@ -70,15 +213,87 @@ func (mr *MockWorkspacesServiceMockRecorder) MoveWindowToWorkspace(args any) *go
 }
 
 // MoveWindowToWorkspaceWithOpts mocks base method.
-func (m *MockWorkspacesService) MoveWindowToWorkspaceWithOpts(args workspaces.MoveWindowToWorkspaceArgs, opts workspaces.MoveWindowToWorkspaceOpts) error {
+func (m *MockWorkspacesService) MoveWindowToWorkspaceWithOpts(target workspaces.MoveWindowToWorkspaceArgs, opts workspaces.MoveWindowToWorkspaceOpts) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "MoveWindowToWorkspaceWithOpts", args, opts)
+	ret := m.ctrl.Call(m, "MoveWindowToWorkspaceWithOpts", target, opts)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // MoveWindowToWorkspaceWithOpts indicates an expected call of MoveWindowToWorkspaceWithOpts.
-func (mr *MockWorkspacesServiceMockRecorder) MoveWindowToWorkspaceWithOpts(args, opts any) *gomock.Call {
+func (mr *MockWorkspacesServiceMockRecorder) MoveWindowToWorkspaceWithOpts(target, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveWindowToWorkspaceWithOpts", reflect.TypeOf((*MockWorkspacesService)(nil).MoveWindowToWorkspaceWithOpts), target, opts)
+}
+
+// MoveWorkspaceToMonitor mocks base method.
+func (m *MockWorkspacesService) MoveWorkspaceToMonitor(target workspaces.MoveWorkspaceToMonitorArgs, opts workspaces.MoveWorkspaceToMonitorOpts) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveWorkspaceToMonitor", target, opts)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MoveWorkspaceToMonitor indicates an expected call of MoveWorkspaceToMonitor.
+func (mr *MockWorkspacesServiceMockRecorder) MoveWorkspaceToMonitor(target, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveWorkspaceToMonitor", reflect.TypeOf((*MockWorkspacesService)(nil).MoveWorkspaceToMonitor), target, opts)
+}
+
+// NextFreeNumeric mocks base method.
+func (m *MockWorkspacesService) NextFreeNumeric() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NextFreeNumeric")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NextFreeNumeric indicates an expected call of NextFreeNumeric.
+func (mr *MockWorkspacesServiceMockRecorder) NextFreeNumeric() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NextFreeNumeric", reflect.TypeOf((*MockWorkspacesService)(nil).NextFreeNumeric))
+}
+
+// RecentlyFocused mocks base method.
+func (m *MockWorkspacesService) RecentlyFocused(n int) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecentlyFocused", n)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecentlyFocused indicates an expected call of RecentlyFocused.
+func (mr *MockWorkspacesServiceMockRecorder) RecentlyFocused(n any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecentlyFocused", reflect.TypeOf((*MockWorkspacesService)(nil).RecentlyFocused), n)
+}
+
+// SummonAndFocus mocks base method.
+func (m *MockWorkspacesService) SummonAndFocus(workspace string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SummonAndFocus", workspace)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SummonAndFocus indicates an expected call of SummonAndFocus.
+func (mr *MockWorkspacesServiceMockRecorder) SummonAndFocus(workspace any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SummonAndFocus", reflect.TypeOf((*MockWorkspacesService)(nil).SummonAndFocus), workspace)
+}
+
+// WaitForFocus mocks base method.
+func (m *MockWorkspacesService) WaitForFocus(ctx context.Context, workspace string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WaitForFocus", ctx, workspace)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WaitForFocus indicates an expected call of WaitForFocus.
+func (mr *MockWorkspacesServiceMockRecorder) WaitForFocus(ctx, workspace any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveWindowToWorkspaceWithOpts", reflect.TypeOf((*MockWorkspacesService)(nil).MoveWindowToWorkspaceWithOpts), args, opts)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WaitForFocus", reflect.TypeOf((*MockWorkspacesService)(nil).WaitForFocus), ctx, workspace)
 }
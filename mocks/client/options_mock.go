@@ -0,0 +1,57 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./pkg/client/options.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./pkg/client/options.go -destination=./mocks/client/options_mock.go -package=client_mock
+//
+
+// Package client_mock is a generated GoMock package.
+package client_mock
+
+import (
+	context "context"
+	net "net"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockDialer is a mock of Dialer interface.
+type MockDialer struct {
+	ctrl     *gomock.Controller
+	recorder *MockDialerMockRecorder
+	isgomock struct{}
+}
+
+// MockDialerMockRecorder is the mock recorder for MockDialer.
+type MockDialerMockRecorder struct {
+	mock *MockDialer
+}
+
+// NewMockDialer creates a new mock instance.
+func NewMockDialer(ctrl *gomock.Controller) *MockDialer {
+	mock := &MockDialer{ctrl: ctrl}
+	mock.recorder = &MockDialerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDialer) EXPECT() *MockDialerMockRecorder {
+	return m.recorder
+}
+
+// DialContext mocks base method.
+func (m *MockDialer) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DialContext", ctx, network, address)
+	ret0, _ := ret[0].(net.Conn)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DialContext indicates an expected call of DialContext.
+func (mr *MockDialerMockRecorder) DialContext(ctx, network, address any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DialContext", reflect.TypeOf((*MockDialer)(nil).DialContext), ctx, network, address)
+}
@@ -10,7 +10,9 @@
 package client_mock
 
 import (
+	context "context"
 	reflect "reflect"
+	time "time"
 
 	client "github.com/cristianoliveira/aerospace-ipc/pkg/client"
 	gomock "go.uber.org/mock/gomock"
@@ -98,6 +100,51 @@ func (mr *MockAeroSpaceConnectionMockRecorder) GetSocketPath() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSocketPath", reflect.TypeOf((*MockAeroSpaceConnection)(nil).GetSocketPath))
 }
 
+// GetVersionInfo mocks base method.
+func (m *MockAeroSpaceConnection) GetVersionInfo() (*client.VersionInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVersionInfo")
+	ret0, _ := ret[0].(*client.VersionInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVersionInfo indicates an expected call of GetVersionInfo.
+func (mr *MockAeroSpaceConnectionMockRecorder) GetVersionInfo() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVersionInfo", reflect.TypeOf((*MockAeroSpaceConnection)(nil).GetVersionInfo))
+}
+
+// Ping mocks base method.
+func (m *MockAeroSpaceConnection) Ping(ctx context.Context) (time.Duration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Ping", ctx)
+	ret0, _ := ret[0].(time.Duration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Ping indicates an expected call of Ping.
+func (mr *MockAeroSpaceConnectionMockRecorder) Ping(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ping", reflect.TypeOf((*MockAeroSpaceConnection)(nil).Ping), ctx)
+}
+
+// SendBatch mocks base method.
+func (m *MockAeroSpaceConnection) SendBatch(ctx context.Context, commands []client.Command) ([]*client.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendBatch", ctx, commands)
+	ret0, _ := ret[0].([]*client.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SendBatch indicates an expected call of SendBatch.
+func (mr *MockAeroSpaceConnectionMockRecorder) SendBatch(ctx, commands any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendBatch", reflect.TypeOf((*MockAeroSpaceConnection)(nil).SendBatch), ctx, commands)
+}
+
 // SendCommand mocks base method.
 func (m *MockAeroSpaceConnection) SendCommand(command string, args []string) (*client.Response, error) {
 	m.ctrl.T.Helper()
@@ -112,3 +159,47 @@ func (mr *MockAeroSpaceConnectionMockRecorder) SendCommand(command, args any) *g
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendCommand", reflect.TypeOf((*MockAeroSpaceConnection)(nil).SendCommand), command, args)
 }
+
+// SendCommandAsync mocks base method.
+func (m *MockAeroSpaceConnection) SendCommandAsync(ctx context.Context, command string, args []string) <-chan client.Result {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendCommandAsync", ctx, command, args)
+	ret0, _ := ret[0].(<-chan client.Result)
+	return ret0
+}
+
+// SendCommandAsync indicates an expected call of SendCommandAsync.
+func (mr *MockAeroSpaceConnectionMockRecorder) SendCommandAsync(ctx, command, args any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendCommandAsync", reflect.TypeOf((*MockAeroSpaceConnection)(nil).SendCommandAsync), ctx, command, args)
+}
+
+// SendCommandContext mocks base method.
+func (m *MockAeroSpaceConnection) SendCommandContext(ctx context.Context, command string, args []string) (*client.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendCommandContext", ctx, command, args)
+	ret0, _ := ret[0].(*client.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SendCommandContext indicates an expected call of SendCommandContext.
+func (mr *MockAeroSpaceConnectionMockRecorder) SendCommandContext(ctx, command, args any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendCommandContext", reflect.TypeOf((*MockAeroSpaceConnection)(nil).SendCommandContext), ctx, command, args)
+}
+
+// SendCommandWithStdin mocks base method.
+func (m *MockAeroSpaceConnection) SendCommandWithStdin(ctx context.Context, command string, args []string, stdin string) (*client.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendCommandWithStdin", ctx, command, args, stdin)
+	ret0, _ := ret[0].(*client.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SendCommandWithStdin indicates an expected call of SendCommandWithStdin.
+func (mr *MockAeroSpaceConnectionMockRecorder) SendCommandWithStdin(ctx, command, args, stdin any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendCommandWithStdin", reflect.TypeOf((*MockAeroSpaceConnection)(nil).SendCommandWithStdin), ctx, command, args, stdin)
+}
@@ -0,0 +1,156 @@
+// Package apply reconciles the windows with a declarative template of placement rules,
+// e.g. Slack on workspace 3 and floating, the terminal on workspace 1.
+//
+// A Template is compared with the current windows to plan the moves and layout
+// changes needed, which Apply then runs.
+package apply
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/cristianoliveira/aerospace-ipc/pkg/aerospace"
+	"github.com/cristianoliveira/aerospace-ipc/pkg/aerospace/plan"
+	"github.com/cristianoliveira/aerospace-ipc/pkg/client/commands"
+)
+
+// Rule is the desired placement of the windows of an application.
+type Rule struct {
+	// AppBundleID matches the windows of the application with this bundle ID.
+	AppBundleID string `json:"app-bundle-id,omitempty"`
+
+	// AppName matches the windows of the application with this name, case-insensitively.
+	// It is ignored when AppBundleID is set.
+	AppName string `json:"app-name,omitempty"`
+
+	// Workspace is the workspace the windows belong on. Empty leaves them where they are.
+	Workspace string `json:"workspace,omitempty"`
+
+	// Floating makes the windows floating when true, or tiling when false.
+	// Nil leaves their layout alone.
+	Floating *bool `json:"floating,omitempty"`
+}
+
+// Matches reports whether the window belongs to the application of the rule.
+// A rule without AppBundleID nor AppName matches the windows of every application.
+func (r Rule) Matches(window aerospace.Window) bool {
+	if r.AppBundleID != "" {
+		return window.AppBundleID == r.AppBundleID
+	}
+	if r.AppName != "" {
+		return strings.EqualFold(window.AppName, r.AppName)
+	}
+	return true
+}
+
+// PlanWindow returns the actions placing the window as the rule wants it,
+// moving it before changing its layout, without running them.
+func (r Rule) PlanWindow(window aerospace.Window) plan.Plan {
+	var p plan.Plan
+	windowID := window.WindowID.String()
+	if r.Workspace != "" && window.Workspace != r.Workspace {
+		p.Add(
+			fmt.Sprintf("%s belongs on workspace %s", window.AppName, r.Workspace),
+			commands.MoveNodeToWorkspace,
+			r.Workspace, "--window-id", windowID,
+		)
+	}
+	if r.Floating != nil && window.IsFloating() != *r.Floating {
+		layout := "tiling"
+		if *r.Floating {
+			layout = "floating"
+		}
+		p.Add(
+			fmt.Sprintf("%s should be %s", window.AppName, layout),
+			commands.Layout,
+			layout, "--window-id", windowID,
+		)
+	}
+	return p
+}
+
+// Template is the desired placement of the windows, as a list of rules.
+//
+// A window is placed by the first rule matching it. Windows matching
+// no rule are left alone.
+//
+// Usage:
+//
+//	floating := true
+//	template := apply.Template{Rules: []apply.Rule{
+//	    {AppBundleID: "com.tinyspeck.slackmacgap", Workspace: "3", Floating: &floating},
+//	    {AppName: "Ghostty", Workspace: "1"},
+//	}}
+type Template struct {
+	Rules []Rule `json:"rules"`
+}
+
+// Validate returns an error if a rule matches no application or changes nothing.
+func (t Template) Validate() error {
+	for i, rule := range t.Rules {
+		if rule.AppBundleID == "" && rule.AppName == "" {
+			return fmt.Errorf("rule %d: either AppBundleID or AppName must be set", i+1)
+		}
+		if rule.Workspace == "" && rule.Floating == nil {
+			return fmt.Errorf("rule %d: either Workspace or Floating must be set", i+1)
+		}
+	}
+	return nil
+}
+
+// Plan returns the actions reconciling the current windows with the template,
+// without running them.
+//
+// It is equivalent to running the command:
+//
+//	aerospace list-windows --all --json --format <format>
+//
+// Usage:
+//
+//	p, err := template.Plan(ctx, client)
+//	fmt.Println(p)
+func (t Template) Plan(ctx context.Context, c aerospace.Client) (plan.Plan, error) {
+	if err := t.Validate(); err != nil {
+		return plan.Plan{}, err
+	}
+
+	all, err := c.WithContext(ctx).Windows().GetAllWindows()
+	if err != nil {
+		return plan.Plan{}, err
+	}
+
+	var p plan.Plan
+	for _, window := range all {
+		for _, rule := range t.Rules {
+			if rule.Matches(window) {
+				p.Actions = append(p.Actions, rule.PlanWindow(window).Actions...)
+				break
+			}
+		}
+	}
+	return p, nil
+}
+
+// Apply reconciles the current windows with the template and returns the actions taken.
+//
+// It stops at the first failing action, returning the actions taken before it.
+//
+// It is equivalent to running the commands:
+//
+//	aerospace list-windows --all --json --format <format>
+//	aerospace move-node-to-workspace <workspace> --window-id <window-id>  # for each window to move
+//	aerospace layout floating|tiling --window-id <window-id>              # for each layout to change
+//
+// Usage:
+//
+//	taken, err := template.Apply(ctx, client)
+//	fmt.Println(taken)
+func (t Template) Apply(ctx context.Context, c aerospace.Client) (plan.Plan, error) {
+	p, err := t.Plan(ctx, c)
+	if err != nil {
+		return plan.Plan{}, err
+	}
+
+	return p.ExecuteTaken(ctx, c.Connection())
+}
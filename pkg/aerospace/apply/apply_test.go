@@ -0,0 +1,112 @@
+package apply
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cristianoliveira/aerospace-ipc/pkg/aerospace"
+	"github.com/cristianoliveira/aerospace-ipc/pkg/client"
+)
+
+func newClient(t *testing.T, conn *client.FakeConnection) aerospace.Client {
+	t.Helper()
+	c, err := aerospace.NewClient(aerospace.WithConnector(conn))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return c
+}
+
+func TestTemplate(t *testing.T) {
+	floating, tiling := true, false
+	template := Template{Rules: []Rule{
+		{AppBundleID: "com.tinyspeck.slackmacgap", Workspace: "3", Floating: &floating},
+		{AppName: "ghostty", Workspace: "1", Floating: &tiling},
+		{AppName: "Ghostty", Workspace: "9"},
+	}}
+
+	t.Run("Happy path", func(tt *testing.T) {
+		tt.Run("Plan", func(ttt *testing.T) {
+			conn := client.NewFakeConnection(client.FakeState{
+				Windows: []client.FakeWindow{
+					{ID: 1, AppName: "Slack", AppBundleID: "com.tinyspeck.slackmacgap", Workspace: "1"},
+					{ID: 2, AppName: "Ghostty", AppBundleID: "com.mitchellh.ghostty", Workspace: "1", Layout: "floating"},
+					{ID: 3, AppName: "Safari", AppBundleID: "com.apple.Safari", Workspace: "2"},
+				},
+			})
+
+			p, err := template.Plan(context.Background(), newClient(ttt, conn))
+			if err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+			expected := "1) aerospace move-node-to-workspace 3 --window-id 1  # Slack belongs on workspace 3\n" +
+				"2) aerospace layout floating --window-id 1  # Slack should be floating\n" +
+				"3) aerospace layout tiling --window-id 2  # Ghostty should be tiling"
+			if p.String() != expected {
+				ttt.Fatalf("expected:\n%s\ngot:\n%s", expected, p)
+			}
+		})
+
+		tt.Run("Apply moves the windows", func(ttt *testing.T) {
+			conn := client.NewFakeConnection(client.FakeState{
+				Windows: []client.FakeWindow{
+					{ID: 1, AppName: "Ghostty", AppBundleID: "com.mitchellh.ghostty", Workspace: "2"},
+				},
+			})
+
+			taken, err := Template{Rules: template.Rules[2:]}.Apply(context.Background(), newClient(ttt, conn))
+			if err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+			if len(taken.Actions) != 1 {
+				ttt.Fatalf("expected 1 action taken, got:\n%s", taken)
+			}
+			if workspace := conn.State().Windows[0].Workspace; workspace != "9" {
+				ttt.Fatalf("expected Ghostty on workspace 9, got %s", workspace)
+			}
+		})
+
+		tt.Run("Apply does nothing once reconciled", func(ttt *testing.T) {
+			conn := client.NewFakeConnection(client.FakeState{
+				Windows: []client.FakeWindow{
+					{ID: 1, AppName: "Ghostty", AppBundleID: "com.mitchellh.ghostty", Workspace: "1"},
+				},
+			})
+
+			taken, err := template.Apply(context.Background(), newClient(ttt, conn))
+			if err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+			if !taken.IsEmpty() {
+				ttt.Fatalf("expected no action, got:\n%s", taken)
+			}
+		})
+	})
+
+	t.Run("Error cases", func(tt *testing.T) {
+		tt.Run("Invalid rules", func(ttt *testing.T) {
+			for _, rule := range []Rule{{Workspace: "1"}, {AppName: "Ghostty"}} {
+				if err := (Template{Rules: []Rule{rule}}).Validate(); err == nil {
+					ttt.Fatalf("expected error for %+v, got nil", rule)
+				}
+			}
+		})
+
+		tt.Run("Apply stops at the first failing action", func(ttt *testing.T) {
+			// The fake connection does not support the layout command.
+			conn := client.NewFakeConnection(client.FakeState{
+				Windows: []client.FakeWindow{
+					{ID: 1, AppName: "Slack", AppBundleID: "com.tinyspeck.slackmacgap", Workspace: "1"},
+				},
+			})
+
+			taken, err := template.Apply(context.Background(), newClient(ttt, conn))
+			if err == nil {
+				ttt.Fatal("expected error, got nil")
+			}
+			if len(taken.Actions) != 1 || taken.Actions[0].Command != "move-node-to-workspace" {
+				ttt.Fatalf("expected only the move to be taken, got:\n%s", taken)
+			}
+		})
+	})
+}
@@ -0,0 +1,214 @@
+package apply
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"slices"
+	"strconv"
+
+	"github.com/cristianoliveira/aerospace-ipc/pkg/aerospace"
+	"github.com/cristianoliveira/aerospace-ipc/pkg/aerospace/plan"
+	"github.com/cristianoliveira/aerospace-ipc/pkg/client/commands"
+)
+
+// ErrProfileNotFound indicates that no profile has the name to switch to.
+var ErrProfileNotFound = errors.New("profile not found")
+
+// Profile is a named arrangement, e.g. "work" or "presentation":
+// the monitor of some workspaces and the placement of the windows.
+type Profile struct {
+	Template
+
+	// Monitors assigns workspaces, by name, to a monitor pattern: a monitor ID,
+	// "main", "secondary", or a case-insensitive regular expression matching
+	// the monitor name. As for the server, "main" is the monitor with the menu bar
+	// and "secondary" is the other monitor when there are exactly two.
+	Monitors map[string]string `json:"monitors,omitempty"`
+}
+
+// Validate returns an error if a rule or a monitor pattern of the profile is invalid.
+func (p Profile) Validate() error {
+	if err := p.Template.Validate(); err != nil {
+		return err
+	}
+	for workspace, pattern := range p.Monitors {
+		if _, err := monitorMatcher(pattern); err != nil {
+			return fmt.Errorf("workspace %s: invalid monitor pattern %q\n%w", workspace, pattern, err)
+		}
+	}
+	return nil
+}
+
+// Plan returns the actions arranging the workspaces and windows as the profile,
+// without running them. Workspaces are moved to their monitor first.
+//
+// Workspaces that do not exist, or are already on a monitor matching their pattern,
+// are not moved.
+//
+// It is equivalent to running the commands:
+//
+//	aerospace list-workspaces --all --json --format <format>
+//	aerospace list-monitors --json --format <format>
+//	aerospace list-windows --all --json --format <format>
+//
+// Usage:
+//
+//	p, err := profile.Plan(ctx, client)
+//	fmt.Println(p)
+func (p Profile) Plan(ctx context.Context, c aerospace.Client) (plan.Plan, error) {
+	if err := p.Validate(); err != nil {
+		return plan.Plan{}, err
+	}
+
+	var actions plan.Plan
+	if len(p.Monitors) > 0 {
+		workspaceList, err := c.WithContext(ctx).Workspaces().GetAllWorkspaces()
+		if err != nil {
+			return plan.Plan{}, err
+		}
+		monitorList, err := c.WithContext(ctx).Monitors().GetAllMonitors()
+		if err != nil {
+			return plan.Plan{}, err
+		}
+
+		for _, workspace := range workspaceList {
+			pattern, ok := p.Monitors[workspace.Workspace]
+			if !ok {
+				continue
+			}
+			monitor := aerospace.Monitor{MonitorID: workspace.MonitorID, MonitorName: workspace.MonitorName}
+			if i := slices.IndexFunc(monitorList, func(m aerospace.Monitor) bool { return m.MonitorID == workspace.MonitorID }); i >= 0 {
+				monitor = monitorList[i]
+			}
+			matches, _ := monitorMatcher(pattern)
+			if matches(monitor, monitorList) {
+				continue
+			}
+			actions.Add(
+				fmt.Sprintf("workspace %s belongs on monitor %s", workspace.Workspace, pattern),
+				commands.MoveWorkspaceToMonitor,
+				"--workspace", workspace.Workspace, pattern,
+			)
+		}
+	}
+
+	windowActions, err := p.Template.Plan(ctx, c)
+	if err != nil {
+		return plan.Plan{}, err
+	}
+	actions.Actions = append(actions.Actions, windowActions.Actions...)
+	return actions, nil
+}
+
+// Apply arranges the workspaces and windows as the profile and returns the actions taken.
+//
+// It stops at the first failing action, returning the actions taken before it.
+//
+// Usage:
+//
+//	taken, err := profile.Apply(ctx, client)
+func (p Profile) Apply(ctx context.Context, c aerospace.Client) (plan.Plan, error) {
+	actions, err := p.Plan(ctx, c)
+	if err != nil {
+		return plan.Plan{}, err
+	}
+	return actions.ExecuteTaken(ctx, c.Connection())
+}
+
+// monitorMatcher returns a function reporting whether a monitor, one of monitorList, matches pattern.
+func monitorMatcher(pattern string) (func(monitor aerospace.Monitor, monitorList []aerospace.Monitor) bool, error) {
+	switch pattern {
+	case "main":
+		return func(monitor aerospace.Monitor, _ []aerospace.Monitor) bool {
+			return isMainMonitor(monitor)
+		}, nil
+	case "secondary":
+		return func(monitor aerospace.Monitor, monitorList []aerospace.Monitor) bool {
+			return len(monitorList) == 2 && !isMainMonitor(monitor)
+		}, nil
+	}
+
+	if id, err := strconv.Atoi(pattern); err == nil {
+		return func(monitor aerospace.Monitor, _ []aerospace.Monitor) bool { return monitor.MonitorID == id }, nil
+	}
+	re, err := regexp.Compile("(?i)" + pattern)
+	if err != nil {
+		return nil, err
+	}
+	return func(monitor aerospace.Monitor, _ []aerospace.Monitor) bool { return re.MatchString(monitor.MonitorName) }, nil
+}
+
+// isMainMonitor reports whether monitor has the menu bar. macOS lists it first in NSScreen.screens.
+func isMainMonitor(monitor aerospace.Monitor) bool {
+	return monitor.AppKitNSScreenScreensID == 1
+}
+
+// Profiles are named profiles, switchable with one call.
+//
+// Example JSON:
+//
+//	{
+//	  "presentation": {
+//	    "rules": [{"app-name": "Keynote", "workspace": "P"}],
+//	    "monitors": {"P": "projector"}
+//	  }
+//	}
+type Profiles map[string]Profile
+
+// ReadProfiles decodes profiles written by Write.
+//
+// Usage:
+//
+//	file, err := os.Open("profiles.json")
+//	profiles, err := apply.ReadProfiles(file)
+func ReadProfiles(r io.Reader) (Profiles, error) {
+	var profiles Profiles
+	if err := json.NewDecoder(r).Decode(&profiles); err != nil {
+		return nil, fmt.Errorf("failed to decode profiles\n%w", err)
+	}
+	for name, profile := range profiles {
+		if err := profile.Validate(); err != nil {
+			return nil, fmt.Errorf("profile %q\n%w", name, err)
+		}
+	}
+	return profiles, nil
+}
+
+// Write encodes the profiles as indented JSON.
+func (p Profiles) Write(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(p); err != nil {
+		return fmt.Errorf("failed to encode profiles\n%w", err)
+	}
+	return nil
+}
+
+// Names returns the names of the profiles, sorted.
+func (p Profiles) Names() []string {
+	names := make([]string, 0, len(p))
+	for name := range p {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Switch applies the profile with the given name and returns the actions taken.
+//
+// Returns an error wrapping ErrProfileNotFound if there is no such profile.
+//
+// Usage:
+//
+//	taken, err := profiles.Switch(ctx, client, "presentation")
+func (p Profiles) Switch(ctx context.Context, c aerospace.Client, name string) (plan.Plan, error) {
+	profile, ok := p[name]
+	if !ok {
+		return plan.Plan{}, fmt.Errorf("%w\nno profile named %q", ErrProfileNotFound, name)
+	}
+	return profile.Apply(ctx, c)
+}
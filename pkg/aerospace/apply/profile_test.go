@@ -0,0 +1,129 @@
+package apply
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/cristianoliveira/aerospace-ipc/pkg/client"
+)
+
+func TestProfiles(t *testing.T) {
+	profiles := Profiles{
+		"work": {
+			Template: Template{Rules: []Rule{{AppName: "Slack", Workspace: "3"}}},
+		},
+		"presentation": {
+			Template: Template{Rules: []Rule{{AppName: "Keynote", Workspace: "P"}}},
+			Monitors: map[string]string{"P": "dell", "1": "1"},
+		},
+	}
+	state := client.FakeState{
+		Monitors: []client.FakeMonitor{
+			{ID: 1, Name: "Built-in Retina Display"},
+			{ID: 2, Name: "DELL U2720Q"},
+		},
+		Workspaces: []client.FakeWorkspace{
+			{Name: "1", MonitorID: 1},
+			{Name: "P", MonitorID: 1},
+		},
+		Windows: []client.FakeWindow{
+			{ID: 1, AppName: "Slack", Workspace: "1"},
+			{ID: 2, AppName: "Keynote", Workspace: "1"},
+		},
+	}
+
+	t.Run("Happy path", func(tt *testing.T) {
+		tt.Run("Plan moves the workspaces, then the windows", func(ttt *testing.T) {
+			conn := client.NewFakeConnection(state)
+
+			p, err := profiles["presentation"].Plan(context.Background(), newClient(ttt, conn))
+			if err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+			expected := "1) aerospace move-workspace-to-monitor --workspace P dell  # workspace P belongs on monitor dell\n" +
+				"2) aerospace move-node-to-workspace P --window-id 2  # Keynote belongs on workspace P"
+			if p.String() != expected {
+				ttt.Fatalf("expected:\n%s\ngot:\n%s", expected, p)
+			}
+		})
+
+		tt.Run("Plan resolves the main and secondary monitors", func(ttt *testing.T) {
+			profile := Profile{Monitors: map[string]string{"1": "main", "P": "secondary"}}
+
+			p, err := profile.Plan(context.Background(), newClient(ttt, client.NewFakeConnection(state)))
+			if err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+			expected := "1) aerospace move-workspace-to-monitor --workspace P secondary  # workspace P belongs on monitor secondary"
+			if p.String() != expected {
+				ttt.Fatalf("expected:\n%s\ngot:\n%s", expected, p)
+			}
+
+			arranged := state
+			arranged.Workspaces = []client.FakeWorkspace{
+				{Name: "1", MonitorID: 1},
+				{Name: "P", MonitorID: 2},
+			}
+			p, err = profile.Plan(context.Background(), newClient(ttt, client.NewFakeConnection(arranged)))
+			if err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+			if len(p.Actions) != 0 {
+				ttt.Fatalf("expected nothing to do once arranged, got:\n%s", p)
+			}
+		})
+
+		tt.Run("Switch", func(ttt *testing.T) {
+			conn := client.NewFakeConnection(state)
+
+			taken, err := profiles.Switch(context.Background(), newClient(ttt, conn), "work")
+			if err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+			if len(taken.Actions) != 1 {
+				ttt.Fatalf("expected 1 action taken, got:\n%s", taken)
+			}
+			if workspace := conn.State().Windows[0].Workspace; workspace != "3" {
+				ttt.Fatalf("expected Slack on workspace 3, got %s", workspace)
+			}
+		})
+
+		tt.Run("Write and Read round trip", func(ttt *testing.T) {
+			var buf bytes.Buffer
+			if err := profiles.Write(&buf); err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+			decoded, err := ReadProfiles(&buf)
+			if err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(decoded, profiles) {
+				ttt.Fatalf("expected %+v, got %+v", profiles, decoded)
+			}
+			if names := decoded.Names(); !reflect.DeepEqual(names, []string{"presentation", "work"}) {
+				ttt.Fatalf("unexpected names %v", names)
+			}
+		})
+	})
+
+	t.Run("Error cases", func(tt *testing.T) {
+		tt.Run("Switch to an unknown profile", func(ttt *testing.T) {
+			conn := client.NewFakeConnection(state)
+			_, err := profiles.Switch(context.Background(), newClient(ttt, conn), "gaming")
+			if !errors.Is(err, ErrProfileNotFound) {
+				ttt.Fatalf("expected ErrProfileNotFound, got %v", err)
+			}
+		})
+
+		tt.Run("Read an invalid monitor pattern", func(ttt *testing.T) {
+			_, err := ReadProfiles(strings.NewReader(`{"work": {"rules": [], "monitors": {"1": "("}}}`))
+			if err == nil {
+				ttt.Fatal("expected error, got nil")
+			}
+		})
+	})
+}
@@ -0,0 +1,223 @@
+// Package apps tracks the lifecycle of the running applications.
+//
+// AeroSpace does not notify clients when applications launch or quit. A Tracker
+// polls the applications and emits an Event whenever one appears or disappears,
+// so automations can trigger on "Slack opened" without binding to NSWorkspace.
+package apps
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/cristianoliveira/aerospace-ipc/pkg/aerospace/windows"
+	"github.com/cristianoliveira/aerospace-ipc/pkg/client"
+	"github.com/cristianoliveira/aerospace-ipc/pkg/client/commands"
+)
+
+// DefaultPollInterval is how often a Tracker lists the applications by default.
+const DefaultPollInterval = 500 * time.Millisecond
+
+// App is a running application.
+type App struct {
+	PID      int    `json:"app-pid"`
+	BundleID string `json:"app-bundle-id"`
+	Name     string `json:"app-name"`
+}
+
+const appFormat = "%{app-pid} %{app-bundle-id} %{app-name}"
+
+// EventKind is the kind of an application lifecycle Event.
+type EventKind int
+
+const (
+	// AppLaunched is emitted when an application appears.
+	AppLaunched EventKind = iota
+	// AppQuit is emitted when an application disappears.
+	AppQuit
+)
+
+func (k EventKind) String() string {
+	switch k {
+	case AppLaunched:
+		return "launched"
+	case AppQuit:
+		return "quit"
+	}
+	return fmt.Sprintf("EventKind(%d)", int(k))
+}
+
+// Event describes an application launching or quitting.
+type Event struct {
+	Kind EventKind
+	App  App
+}
+
+// TrackerOpts contains optional parameters for NewTracker.
+type TrackerOpts struct {
+	// PollInterval is how often Run lists the applications. Defaults to DefaultPollInterval.
+	PollInterval time.Duration
+
+	// FromWindows derives the applications from list-windows instead of list-apps,
+	// so only applications with windows are tracked: an application is launched
+	// when its first window opens and quits when its last window closes.
+	FromWindows bool
+
+	// OnEvent is called, from the Run goroutine, for every application launching or quitting.
+	OnEvent func(Event)
+
+	// OnError is called when listing the applications fails. Run keeps polling.
+	OnError func(err error)
+}
+
+// Tracker emits application lifecycle events from the differences between
+// two listings of the applications. It is safe for concurrent use.
+type Tracker struct {
+	client client.AeroSpaceConnection
+	opts   TrackerOpts
+
+	mu sync.Mutex
+	// running is nil until the first listing, which only sets the baseline.
+	running []App
+}
+
+// NewTracker creates a tracker listing the applications on the given connection.
+//
+// The applications running when Run starts are the baseline: no AppLaunched
+// event is emitted for them.
+//
+// Usage:
+//
+//	tracker := apps.NewTracker(conn, apps.TrackerOpts{
+//	    OnEvent: func(event apps.Event) {
+//	        if event.Kind == apps.AppLaunched && event.App.BundleID == "com.tinyspeck.slackmacgap" {
+//	            // Slack opened
+//	        }
+//	    },
+//	})
+//	go tracker.Run(ctx)
+func NewTracker(client client.AeroSpaceConnection, opts ...TrackerOpts) *Tracker {
+	var opt TrackerOpts
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	if opt.PollInterval <= 0 {
+		opt.PollInterval = DefaultPollInterval
+	}
+	return &Tracker{client: client, opts: opt}
+}
+
+// Run polls the applications and emits their lifecycle events until ctx is done.
+// It then returns the cause of ctx being done.
+//
+// It is equivalent to running, every poll interval, the command:
+//
+//	aerospace list-apps --json --format <format>
+//
+// or, with FromWindows, the command:
+//
+//	aerospace list-windows --all --json --format <format>
+//
+// Usage:
+//
+//	ctx, cancel := context.WithCancel(context.Background())
+//	defer cancel()
+//	go tracker.Run(ctx)
+func (t *Tracker) Run(ctx context.Context) error {
+	ticker := time.NewTicker(t.opts.PollInterval)
+	defer ticker.Stop()
+	for {
+		t.poll(ctx)
+
+		select {
+		case <-ctx.Done():
+			return context.Cause(ctx)
+		case <-ticker.C:
+		}
+	}
+}
+
+// poll lists the applications once and emits the events since the previous listing.
+func (t *Tracker) poll(ctx context.Context) {
+	current, err := t.list(ctx)
+	if err != nil {
+		if ctx.Err() == nil && t.opts.OnError != nil {
+			t.opts.OnError(fmt.Errorf("failed to list the applications\n%w", err))
+		}
+		return
+	}
+
+	t.mu.Lock()
+	previous := t.running
+	t.running = current
+	t.mu.Unlock()
+
+	if previous == nil || t.opts.OnEvent == nil {
+		return
+	}
+	for _, event := range diff(previous, current) {
+		t.opts.OnEvent(event)
+	}
+}
+
+// list returns the distinct running applications, in listing order.
+func (t *Tracker) list(ctx context.Context) ([]App, error) {
+	var listed []App
+	if t.opts.FromWindows {
+		all, err := windows.NewService(t.client).WithContext(ctx).GetAllWindows()
+		if err != nil {
+			return nil, err
+		}
+		for _, window := range all {
+			listed = append(listed, App{PID: window.AppPID, BundleID: window.AppBundleID, Name: window.AppName})
+		}
+	} else {
+		var err error
+		listed, err = client.Query[[]App](ctx, t.client, commands.ListApps, "--json", "--format", appFormat)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	apps := []App{}
+	seen := map[App]bool{}
+	for _, app := range listed {
+		if !seen[app] {
+			seen[app] = true
+			apps = append(apps, app)
+		}
+	}
+	return apps, nil
+}
+
+// diff returns the AppQuit events of the applications missing from current,
+// followed by the AppLaunched events of the applications missing from previous.
+func diff(previous, current []App) []Event {
+	var events []Event
+	inCurrent := map[App]bool{}
+	for _, app := range current {
+		inCurrent[app] = true
+	}
+	inPrevious := map[App]bool{}
+	for _, app := range previous {
+		inPrevious[app] = true
+		if !inCurrent[app] {
+			events = append(events, Event{Kind: AppQuit, App: app})
+		}
+	}
+	for _, app := range current {
+		if !inPrevious[app] {
+			events = append(events, Event{Kind: AppLaunched, App: app})
+		}
+	}
+	return events
+}
+
+// Running returns the applications of the last listing, or nil before the first one.
+func (t *Tracker) Running() []App {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return slices.Clone(t.running)
+}
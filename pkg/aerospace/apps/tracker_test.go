@@ -0,0 +1,133 @@
+package apps
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	mock_client "github.com/cristianoliveira/aerospace-ipc/internal/mocks"
+	"github.com/cristianoliveira/aerospace-ipc/pkg/client"
+	"go.uber.org/mock/gomock"
+)
+
+func TestTracker(t *testing.T) {
+	listArgs := []string{"--json", "--format", appFormat}
+	slack := App{PID: 10, BundleID: "com.tinyspeck.slackmacgap", Name: "Slack"}
+	safari := App{PID: 20, BundleID: "com.apple.Safari", Name: "Safari"}
+	ghostty := App{PID: 30, BundleID: "com.mitchellh.ghostty", Name: "Ghostty"}
+
+	t.Run("Happy path", func(tt *testing.T) {
+		tt.Run("emits launched and quit apps from list-apps", func(ttt *testing.T) {
+			ctrl := gomock.NewController(ttt)
+			defer ctrl.Finish()
+
+			ctx := context.Background()
+			mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+			gomock.InOrder(
+				mockConn.EXPECT().
+					SendCommandContext(ctx, "list-apps", listArgs).
+					Return(&client.Response{StdOut: `[
+						{"app-pid": 20, "app-bundle-id": "com.apple.Safari", "app-name": "Safari"},
+						{"app-pid": 30, "app-bundle-id": "com.mitchellh.ghostty", "app-name": "Ghostty"}
+					]`}, nil),
+				mockConn.EXPECT().
+					SendCommandContext(ctx, "list-apps", listArgs).
+					Return(&client.Response{StdOut: `[
+						{"app-pid": 30, "app-bundle-id": "com.mitchellh.ghostty", "app-name": "Ghostty"},
+						{"app-pid": 10, "app-bundle-id": "com.tinyspeck.slackmacgap", "app-name": "Slack"}
+					]`}, nil),
+			)
+
+			var events []Event
+			tracker := NewTracker(mockConn, TrackerOpts{OnEvent: func(event Event) {
+				events = append(events, event)
+			}})
+			tracker.poll(ctx)
+			if len(events) != 0 {
+				ttt.Fatalf("expected no event for the baseline, got %v", events)
+			}
+			tracker.poll(ctx)
+
+			expected := []Event{{Kind: AppQuit, App: safari}, {Kind: AppLaunched, App: slack}}
+			if !reflect.DeepEqual(events, expected) {
+				ttt.Fatalf("expected %v, got %v", expected, events)
+			}
+			if running := tracker.Running(); !reflect.DeepEqual(running, []App{ghostty, slack}) {
+				ttt.Fatalf("expected Ghostty and Slack to be running, got %v", running)
+			}
+		})
+
+		tt.Run("derives the apps from the windows", func(ttt *testing.T) {
+			conn := client.NewFakeConnection(client.FakeState{
+				Windows: []client.FakeWindow{
+					{ID: 1, AppName: "Ghostty", AppBundleID: "com.mitchellh.ghostty", AppPID: 30, Workspace: "1"},
+					{ID: 2, AppName: "Ghostty", AppBundleID: "com.mitchellh.ghostty", AppPID: 30, Workspace: "2"},
+					{ID: 3, AppName: "Slack", AppBundleID: "com.tinyspeck.slackmacgap", AppPID: 10, Workspace: "2"},
+				},
+			})
+			var events []Event
+			tracker := NewTracker(conn, TrackerOpts{FromWindows: true, OnEvent: func(event Event) {
+				events = append(events, event)
+			}})
+			ctx := context.Background()
+
+			tracker.poll(ctx)
+			if running := tracker.Running(); !reflect.DeepEqual(running, []App{ghostty, slack}) {
+				ttt.Fatalf("expected Ghostty and Slack to be running, got %v", running)
+			}
+
+			for _, windowID := range []string{"1", "3"} {
+				if _, err := conn.SendCommand("close", []string{"--window-id", windowID}); err != nil {
+					ttt.Fatal(err)
+				}
+			}
+			tracker.poll(ctx)
+
+			expected := []Event{{Kind: AppQuit, App: slack}}
+			if !reflect.DeepEqual(events, expected) {
+				ttt.Fatalf("expected %v, got %v", expected, events)
+			}
+		})
+
+		tt.Run("Run stops once the context is done", func(ttt *testing.T) {
+			conn := client.NewFakeConnection(client.FakeState{
+				Windows: []client.FakeWindow{{ID: 1, AppName: "Ghostty", Workspace: "1"}},
+			})
+			tracker := NewTracker(conn, TrackerOpts{FromWindows: true, PollInterval: time.Millisecond})
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+			defer cancel()
+			if err := tracker.Run(ctx); !errors.Is(err, context.DeadlineExceeded) {
+				ttt.Fatalf("expected context.DeadlineExceeded, got %v", err)
+			}
+			if len(tracker.Running()) != 1 {
+				ttt.Fatalf("expected Ghostty to be running, got %v", tracker.Running())
+			}
+		})
+	})
+
+	t.Run("Error cases", func(tt *testing.T) {
+		tt.Run("reports failing polls", func(ttt *testing.T) {
+			ctrl := gomock.NewController(ttt)
+			defer ctrl.Finish()
+
+			ctx := context.Background()
+			mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+			mockConn.EXPECT().
+				SendCommandContext(ctx, "list-apps", listArgs).
+				Return(&client.Response{ExitCode: 1, StdErr: "boom"}, nil)
+
+			var reported error
+			tracker := NewTracker(mockConn, TrackerOpts{OnError: func(err error) { reported = err }})
+			tracker.poll(ctx)
+			if reported == nil {
+				ttt.Fatal("expected the error to be reported")
+			}
+			if tracker.Running() != nil {
+				ttt.Fatal("expected no listing")
+			}
+		})
+	})
+}
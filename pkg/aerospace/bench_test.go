@@ -0,0 +1,69 @@
+package aerospace
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/cristianoliveira/aerospace-ipc/internal/fakeserver"
+	"github.com/cristianoliveira/aerospace-ipc/pkg/client"
+)
+
+// benchClient returns a client connected to a fake server with windowCount windows.
+func benchClient(b *testing.B, windowCount int) *AeroSpaceWM {
+	windows := make([]Window, windowCount)
+	for i := range windows {
+		windows[i] = Window{
+			WindowID:    WindowID(i),
+			WindowTitle: fmt.Sprintf("Window %d", i),
+			AppName:     "Terminal",
+			Workspace:   "1",
+		}
+	}
+	windowsJSON, _ := json.Marshal(windows)
+	workspacesJSON, _ := json.Marshal([]Workspace{{Workspace: "1"}})
+
+	socketPath := fakeserver.Start(b, func(args []string) fakeserver.Response {
+		switch args[0] {
+		case "list-windows":
+			return fakeserver.Response{StdOut: string(windowsJSON)}
+		case "list-workspaces":
+			return fakeserver.Response{StdOut: string(workspacesJSON)}
+		}
+		return fakeserver.Response{ExitCode: 1, StdErr: "unknown command " + args[0]}
+	})
+
+	conn, err := client.NewAeroSpaceSocketConnection(socketPath)
+	if err != nil {
+		b.Fatalf("failed to connect: %v", err)
+	}
+	b.Cleanup(func() { conn.CloseConnection() })
+
+	return &AeroSpaceWM{conn: conn}
+}
+
+func BenchmarkServices(b *testing.B) {
+	for _, windowCount := range []int{10, 1000} {
+		b.Run(fmt.Sprintf("GetAllWindows %d windows", windowCount), func(b *testing.B) {
+			wm := benchClient(b, windowCount)
+
+			b.ReportAllocs()
+			for b.Loop() {
+				if _, err := wm.Windows().GetAllWindows(); err != nil {
+					b.Fatalf("unexpected error: %v", err)
+				}
+			}
+		})
+	}
+
+	b.Run("GetFocusedWorkspace", func(b *testing.B) {
+		wm := benchClient(b, 0)
+
+		b.ReportAllocs()
+		for b.Loop() {
+			if _, err := wm.Workspaces().GetFocusedWorkspace(); err != nil {
+				b.Fatalf("unexpected error: %v", err)
+			}
+		}
+	})
+}
@@ -1,11 +1,21 @@
 package aerospace
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/cristianoliveira/aerospace-ipc/internal/exceptions"
+	"github.com/cristianoliveira/aerospace-ipc/internal/settings"
+	"github.com/cristianoliveira/aerospace-ipc/pkg/aerospace/config"
 	"github.com/cristianoliveira/aerospace-ipc/pkg/aerospace/focus"
+	"github.com/cristianoliveira/aerospace-ipc/pkg/aerospace/history"
 	"github.com/cristianoliveira/aerospace-ipc/pkg/aerospace/layout"
+	"github.com/cristianoliveira/aerospace-ipc/pkg/aerospace/marks"
+	"github.com/cristianoliveira/aerospace-ipc/pkg/aerospace/modes"
+	"github.com/cristianoliveira/aerospace-ipc/pkg/aerospace/monitors"
+	"github.com/cristianoliveira/aerospace-ipc/pkg/aerospace/scratchpad"
 	"github.com/cristianoliveira/aerospace-ipc/pkg/aerospace/windows"
 	"github.com/cristianoliveira/aerospace-ipc/pkg/aerospace/workspaces"
 	"github.com/cristianoliveira/aerospace-ipc/pkg/client"
@@ -14,6 +24,45 @@ import (
 // ErrVersionMismatch indicates that the server version does not match the minimum required version.
 var ErrVersionMismatch = exceptions.ErrVersion
 
+// ErrServerNotRunning indicates that no AeroSpace server is listening on the socket.
+//
+// Usage:
+//
+//	client, err := aerospace.NewClient()
+//	if errors.Is(err, aerospace.ErrServerNotRunning) {
+//	    log.Fatal("Start AeroSpace first")
+//	}
+var ErrServerNotRunning = exceptions.ErrServerNotRunning
+
+// ErrNotInitialized is returned by the connection of an AeroSpaceWM client
+// that was not created with one of the constructors, e.g. a zero value.
+var ErrNotInitialized = errors.New("AeroSpaceWM client is not initialized")
+
+// ErrTimeout indicates that a command hit a deadline while waiting for AeroSpace.
+//
+// Usage:
+//
+//	windows, err := client.WithContext(ctx).Windows().GetAllWindows()
+//	if errors.Is(err, aerospace.ErrTimeout) {
+//	    // retry later
+//	}
+var ErrTimeout = client.ErrTimeout
+
+// ErrWindowNotFound indicates that no window matches the one to focus,
+// e.g. with Focus().SetFocusByApp.
+var ErrWindowNotFound = focus.ErrWindowNotFound
+
+// CommandError is returned when AeroSpace reports that a command failed.
+//
+// Usage:
+//
+//	err := client.Focus().SetFocusByWindowID(42)
+//	var cmdErr *aerospace.CommandError
+//	if errors.As(err, &cmdErr) {
+//	    log.Printf("exit code %d: %s", cmdErr.ExitCode, cmdErr.StdErr)
+//	}
+type CommandError = client.CommandError
+
 // Client defines the interface for interacting with AeroSpaceWM.
 type Client interface {
 	// Windows returns the windows service for interacting with windows.
@@ -28,6 +77,33 @@ type Client interface {
 	// Layout returns the layout service for interacting with layout operations.
 	Layout() *layout.Service
 
+	// Config returns the config service for introspecting the AeroSpace configuration.
+	Config() *config.Service
+
+	// Monitors returns the monitors service for interacting with monitors.
+	Monitors() *monitors.Service
+
+	// Modes returns the modes service for querying binding modes.
+	Modes() *modes.Service
+
+	// Scratchpad returns the scratchpad service for hiding and showing windows.
+	Scratchpad() *scratchpad.Service
+
+	// Marks returns the marks service for labelling windows and focusing them by label.
+	Marks() *marks.Service
+
+	// History returns the opt-in tracker of the focus history.
+	History() *history.Tracker
+
+	// GetTree returns the monitors, workspaces and windows as one nested structure.
+	GetTree() (*Tree, error)
+
+	// GetState returns a snapshot of the monitors, workspaces, windows, focus and binding mode.
+	GetState(ctx context.Context) (*State, error)
+
+	// WithContext returns a client whose services send every command with ctx.
+	WithContext(ctx context.Context) Client
+
 	// Connection returns the AeroSpaceWM client.
 	//
 	// Returns the AeroSpaceConnection interface for further operations.
@@ -42,18 +118,30 @@ type Client interface {
 // AeroSpaceWM implements the Client interface.
 type AeroSpaceWM struct {
 	conn client.AeroSpaceConnection
+	ctx  context.Context
+	// marksStore and history are shared with the clients returned by WithContext.
+	marksStore marks.Store
+	history    *history.Tracker
 
 	// Services
 	windowsService    *windows.Service
 	workspacesService *workspaces.Service
 	focusService      *focus.Service
 	layoutService     *layout.Service
+	configService     *config.Service
+	monitorsService   *monitors.Service
+	modesService      *modes.Service
+	scratchpadService *scratchpad.Service
+	marksService      *marks.Service
 }
 
 // Windows returns the windows service for interacting with windows.
 func (a *AeroSpaceWM) Windows() *windows.Service {
 	if a.windowsService == nil {
-		a.windowsService = windows.NewService(a.conn)
+		a.windowsService = windows.NewService(a.Connection())
+		if a.ctx != nil {
+			a.windowsService = a.windowsService.WithContext(a.ctx)
+		}
 	}
 	return a.windowsService
 }
@@ -61,7 +149,10 @@ func (a *AeroSpaceWM) Windows() *windows.Service {
 // Workspaces returns the workspace service for interacting with workspaces.
 func (a *AeroSpaceWM) Workspaces() *workspaces.Service {
 	if a.workspacesService == nil {
-		a.workspacesService = workspaces.NewService(a.conn)
+		a.workspacesService = workspaces.NewService(a.Connection()).WithFocusHistory(a.History())
+		if a.ctx != nil {
+			a.workspacesService = a.workspacesService.WithContext(a.ctx)
+		}
 	}
 	return a.workspacesService
 }
@@ -69,7 +160,10 @@ func (a *AeroSpaceWM) Workspaces() *workspaces.Service {
 // Focus returns the focus service for interacting with focus operations.
 func (a *AeroSpaceWM) Focus() *focus.Service {
 	if a.focusService == nil {
-		a.focusService = focus.NewService(a.conn)
+		a.focusService = focus.NewService(a.Connection()).WithFocusHistory(a.History())
+		if a.ctx != nil {
+			a.focusService = a.focusService.WithContext(a.ctx)
+		}
 	}
 	return a.focusService
 }
@@ -77,36 +171,148 @@ func (a *AeroSpaceWM) Focus() *focus.Service {
 // Layout returns the layout service for interacting with layout operations.
 func (a *AeroSpaceWM) Layout() *layout.Service {
 	if a.layoutService == nil {
-		a.layoutService = layout.NewService(a.conn)
+		a.layoutService = layout.NewService(a.Connection())
+		if a.ctx != nil {
+			a.layoutService = a.layoutService.WithContext(a.ctx)
+		}
 	}
 	return a.layoutService
 }
 
+// Config returns the config service for introspecting the AeroSpace configuration.
+func (a *AeroSpaceWM) Config() *config.Service {
+	if a.configService == nil {
+		a.configService = config.NewService(a.Connection())
+		if a.ctx != nil {
+			a.configService = a.configService.WithContext(a.ctx)
+		}
+	}
+	return a.configService
+}
+
+// Monitors returns the monitors service for interacting with monitors.
+func (a *AeroSpaceWM) Monitors() *monitors.Service {
+	if a.monitorsService == nil {
+		a.monitorsService = monitors.NewService(a.Connection())
+		if a.ctx != nil {
+			a.monitorsService = a.monitorsService.WithContext(a.ctx)
+		}
+	}
+	return a.monitorsService
+}
+
+// Modes returns the modes service for querying binding modes.
+func (a *AeroSpaceWM) Modes() *modes.Service {
+	if a.modesService == nil {
+		a.modesService = modes.NewService(a.Connection())
+		if a.ctx != nil {
+			a.modesService = a.modesService.WithContext(a.ctx)
+		}
+	}
+	return a.modesService
+}
+
+// Scratchpad returns the scratchpad service for hiding and showing windows,
+// using scratchpad.DefaultWorkspace. Use WithWorkspace on the service to change it.
+func (a *AeroSpaceWM) Scratchpad() *scratchpad.Service {
+	if a.scratchpadService == nil {
+		a.scratchpadService = scratchpad.NewService(a.Connection())
+		if a.ctx != nil {
+			a.scratchpadService = a.scratchpadService.WithContext(a.ctx)
+		}
+	}
+	return a.scratchpadService
+}
+
+// Marks returns the marks service for labelling windows and focusing them by label.
+//
+// The marks are kept in memory, unless the client was created with WithMarksStore.
+func (a *AeroSpaceWM) Marks() *marks.Service {
+	if a.marksService == nil {
+		a.marksService = marks.NewService(a.Connection(), a.getMarksStore())
+		if a.ctx != nil {
+			a.marksService = a.marksService.WithContext(a.ctx)
+		}
+	}
+	return a.marksService
+}
+
+// History returns the tracker of the focus history, shared by the clients returned by WithContext.
+//
+// The tracker is opt-in: it records nothing until it is run.
+//
+// Usage:
+//
+//	go client.History().Run(ctx)
+//	// ...
+//	previous := client.History().PreviousWindows(3)
+func (a *AeroSpaceWM) History() *history.Tracker {
+	if a.history == nil {
+		a.history = history.NewTracker(a.Connection())
+	}
+	return a.history
+}
+
+// getMarksStore returns the marks store, creating a MemoryStore on first use.
+func (a *AeroSpaceWM) getMarksStore() marks.Store {
+	if a.marksStore == nil {
+		a.marksStore = marks.NewMemoryStore()
+	}
+	return a.marksStore
+}
+
+// WithContext returns a client sharing the connection whose services
+// send every command with ctx, so requests can be cancelled or bounded by a deadline.
+//
+// Usage:
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+//	defer cancel()
+//	windows, err := client.WithContext(ctx).Windows().GetAllWindows()
+func (a *AeroSpaceWM) WithContext(ctx context.Context) Client {
+	if ctx == nil {
+		panic("ASSERTION: context cannot be nil")
+	}
+	return &AeroSpaceWM{conn: a.conn, ctx: ctx, marksStore: a.getMarksStore(), history: a.History()}
+}
+
 // Connection returns the AeroSpaceConnection
 // which allows low-level interaction with the AeroSpace socket.
+//
+// If the client was not created with NewClient, or one of the other constructors,
+// it returns a connection failing every call with ErrNotInitialized.
 func (a *AeroSpaceWM) Connection() client.AeroSpaceConnection {
 	if a.conn == nil {
-		panic("ASSERTION: AeroSpaceWM client is not initialized")
+		return uninitializedConnection{}
 	}
 
 	return a.conn
 }
 
-func (a *AeroSpaceWM) CloseConnection() error {
+// MustConnection is like Connection but panics if the client is not initialized.
+func (a *AeroSpaceWM) MustConnection() client.AeroSpaceConnection {
 	if a.conn == nil {
 		panic("ASSERTION: AeroSpaceWM client is not initialized")
 	}
 
-	return a.conn.CloseConnection()
+	return a.conn
 }
 
-// NewClient creates a new Client with the default socket path.
+// CloseConnection closes the AeroSpaceWM connection and releases resources.
 //
-// It checks for environment variable AEROSPACESOCK or uses the default socket path.
+// Returns ErrNotInitialized if the client is not initialized.
+func (a *AeroSpaceWM) CloseConnection() error {
+	return a.Connection().CloseConnection()
+}
+
+// NewClient creates a new Client configured by opts.
+//
+// Without options, it checks for environment variable AEROSPACESOCK or uses the default socket path.
 //
 //	Default: /tmp/bobko.aerospace-<username>.sock
 //
-// Returns an AeroSpaceWM client or an error if the connection fails.
+// Returns an AeroSpaceWM client or an error if the connection fails,
+// or if the server version is rejected by the version policy.
 //
 // Usage:
 //
@@ -116,16 +322,33 @@ func (a *AeroSpaceWM) CloseConnection() error {
 //	}
 //	defer client.CloseConnection()
 //
+//	client, err := aerospace.NewClient(
+//	    aerospace.WithSocketPath("/tmp/bobko.aerospace-me.sock"),
+//	    aerospace.WithTimeout(2*time.Second),
+//	    aerospace.WithRetry(client.RetryPolicy{MaxAttempts: 3, Backoff: 50 * time.Millisecond}),
+//	    aerospace.WithVersionPolicy(aerospace.VersionPolicyStrict),
+//	)
+//
 // More:
 // https://github.com/cristianoliveira/aerospace-ipc/tree/main/examples
-func NewClient() (*AeroSpaceWM, error) {
-	conn, err := client.GetDefaultConnector().Connect()
+func NewClient(opts ...Option) (*AeroSpaceWM, error) {
+	var o clientOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	conn, err := o.connect()
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to socket\n %w", err)
 	}
+	if err := o.checkVersion(conn); err != nil {
+		_ = conn.CloseConnection()
+		return nil, fmt.Errorf("failed to connect to socket\n %w", err)
+	}
 
 	client := &AeroSpaceWM{
-		conn: conn,
+		conn:       conn,
+		marksStore: o.marksStore,
 	}
 
 	return client, nil
@@ -133,16 +356,39 @@ func NewClient() (*AeroSpaceWM, error) {
 
 type CustomConnectionOpts struct {
 	// SocketPath is the custom socket path for the AeroSpace connection.
+	// With Network set, it is the network address, e.g. "127.0.0.1:9999".
 	SocketPath string
+	// Network is the network to connect on, e.g. "tcp" for a socket forwarded
+	// through an SSH tunnel. Defaults to "unix". See client.WithNetwork.
+	Network string
 	// ValidateVersion is deprecated and has no effect.
 	// The connector always validates the server version.
 	// Deprecated: This field is ignored. Version validation always occurs.
 	ValidateVersion bool
+	// DialTimeout bounds how long opening the socket connection may take.
+	// Zero means no timeout. See client.WithDialTimeout.
+	DialTimeout time.Duration
+	// CommandTimeout bounds every command sent on the connection.
+	// Zero means no timeout. See client.WithCommandTimeout.
+	CommandTimeout time.Duration
+	// ReconnectAttempts is how many times a command is resent on a new connection
+	// when the server closed the previous one. See client.WithReconnect.
+	ReconnectAttempts int
+	// Retry retries commands failing with transient errors. See client.WithRetry.
+	Retry client.RetryPolicy
+	// AutoStart launches AeroSpace if it is not running. See client.WithAutoStart.
+	AutoStart bool
+	// StderrPolicy decides whether commands printing warnings fail.
+	// Defaults to reporting them to OnWarning. See client.WithStderrPolicy.
+	StderrPolicy client.StderrPolicy
+	// OnWarning receives the warnings of successful commands. See client.WithWarningHandler.
+	OnWarning client.WarningHandler
 }
 
 // NewCustomClient creates a new Client with a custom socket path.
 //
 // It allows specifying a custom socket path. The server version is always validated.
+// It is equivalent to NewClient with WithSocketPath and VersionPolicyStrict.
 // Returns an AeroSpaceWM client or an error if the connection fails.
 // Usage:
 //
@@ -161,18 +407,116 @@ func NewCustomClient(opts CustomConnectionOpts) (*AeroSpaceWM, error) {
 		return nil, fmt.Errorf("socket path cannot be empty")
 	}
 
-	connector := &client.AeroSpaceCustomConnector{
-		SocketPath: opts.SocketPath,
+	var connOptions []client.Option
+	if opts.Network != "" {
+		connOptions = append(connOptions, client.WithNetwork(opts.Network, opts.SocketPath))
+	}
+	if opts.DialTimeout > 0 {
+		connOptions = append(connOptions, client.WithDialTimeout(opts.DialTimeout))
+	}
+	if opts.CommandTimeout > 0 {
+		connOptions = append(connOptions, client.WithCommandTimeout(opts.CommandTimeout))
+	}
+	if opts.ReconnectAttempts > 0 {
+		connOptions = append(connOptions, client.WithReconnect(opts.ReconnectAttempts))
+	}
+	if opts.AutoStart {
+		connOptions = append(connOptions, client.WithAutoStart(true))
+	}
+	if opts.Retry.MaxAttempts > 1 {
+		connOptions = append(connOptions, client.WithRetry(opts.Retry))
+	}
+	if opts.StderrPolicy != client.StderrWarn {
+		connOptions = append(connOptions, client.WithStderrPolicy(opts.StderrPolicy))
+	}
+	if opts.OnWarning != nil {
+		connOptions = append(connOptions, client.WithWarningHandler(opts.OnWarning))
 	}
 
-	conn, err := connector.Connect()
+	return NewClient(
+		WithSocketPath(opts.SocketPath),
+		WithConnectionOptions(connOptions...),
+		WithVersionPolicy(VersionPolicyStrict),
+	)
+}
+
+// NewClientFromConfig creates a new Client using the settings file at path.
+//
+// The settings file lets multiple tools on the same machine share identical
+// connection behavior. It uses a subset of TOML with the following keys, all optional:
+//
+//	# Socket path. If omitted, the default socket path is used.
+//	socket-path = "/tmp/bobko.aerospace-me.sock"
+//	# Bounds every command. See client.WithCommandTimeout.
+//	command-timeout = "2s"
+//	# Bounds opening the socket connection. See client.WithDialTimeout.
+//	dial-timeout = "500ms"
+//	# Launches AeroSpace when it is not running. See client.WithAutoStart.
+//	auto-start = true
+//
+//	# Retries commands failing with transient errors. See client.WithRetry.
+//	[retry]
+//	max-attempts = 3
+//	backoff = "50ms"
+//	max-backoff = "1s"
+//
+//	# Reuses the response of a query (list-*, config...) for the identical
+//	# queries sent within ttl. See client.RateLimit.Debounce.
+//	[cache]
+//	ttl = "100ms"
+//
+// When socket-path is set, the server version is validated as in NewCustomClient.
+// Returns an error if the file cannot be read, contains unknown keys, or the connection fails.
+//
+// Usage:
+//
+//	client, err := aerospace.NewClientFromConfig("~/.config/aerospace-ipc/config.toml")
+//	if err != nil {
+//	    log.Fatalf("failed to create AeroSpace client: %v", err)
+//	}
+//	defer client.CloseConnection()
+func NewClientFromConfig(path string) (*AeroSpaceWM, error) {
+	cfg, err := settings.Load(path)
 	if err != nil {
-		return nil, fmt.Errorf("failed to connect to socket\n %w", err)
+		return nil, err
 	}
 
-	client := &AeroSpaceWM{
-		conn: conn,
+	wm, err := NewClient(configOptions(cfg)...)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.CacheTTL > 0 {
+		wm.conn = client.NewRateLimitedConnection(wm.conn, client.RateLimit{Debounce: cfg.CacheTTL})
 	}
+	return wm, nil
+}
 
-	return client, nil
+// configOptions returns the NewClient options configured by the settings file.
+func configOptions(cfg *settings.Settings) []Option {
+	var opts []Option
+	if cfg.SocketPath != "" {
+		opts = append(opts, WithSocketPath(cfg.SocketPath), WithVersionPolicy(VersionPolicyStrict))
+	}
+
+	var connOptions []client.Option
+	if cfg.CommandTimeout > 0 {
+		connOptions = append(connOptions, client.WithCommandTimeout(cfg.CommandTimeout))
+	}
+	if cfg.DialTimeout > 0 {
+		connOptions = append(connOptions, client.WithDialTimeout(cfg.DialTimeout))
+	}
+	if cfg.AutoStart {
+		connOptions = append(connOptions, client.WithAutoStart(true))
+	}
+	if cfg.RetryMaxAttempts > 1 {
+		connOptions = append(connOptions, client.WithRetry(client.RetryPolicy{
+			MaxAttempts: cfg.RetryMaxAttempts,
+			Backoff:     cfg.RetryBackoff,
+			MaxBackoff:  cfg.RetryMaxBackoff,
+		}))
+	}
+	if len(connOptions) > 0 {
+		opts = append(opts, WithConnectionOptions(connOptions...))
+	}
+	return opts
 }
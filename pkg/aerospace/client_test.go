@@ -1,6 +1,33 @@
 package aerospace
 
-import "testing"
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	mock_client "github.com/cristianoliveira/aerospace-ipc/internal/mocks"
+	"github.com/cristianoliveira/aerospace-ipc/internal/settings"
+	"github.com/cristianoliveira/aerospace-ipc/pkg/aerospace/marks"
+	"github.com/cristianoliveira/aerospace-ipc/pkg/client"
+	"go.uber.org/mock/gomock"
+)
+
+// recordingDialer records the address it is asked to dial and fails.
+type recordingDialer struct {
+	address string
+}
+
+func (d *recordingDialer) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	d.address = address
+	return nil, errors.New("dial refused")
+}
 
 func TestAeroSpaceWM(t *testing.T) {
 	t.Run("Implements the Client interface", func(t *testing.T) {
@@ -13,4 +40,308 @@ func TestAeroSpaceWM(t *testing.T) {
 		}
 		t.Log("AeroSpaceWM implements Client interface")
 	})
+
+	t.Run("WithContext binds the context to every service", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx := context.Background()
+		mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+		wm := &AeroSpaceWM{conn: mockConn}
+
+		mockConn.EXPECT().
+			SendCommandContext(ctx, "list-modes", []string{"--current"}).
+			Return(&client.Response{StdOut: "main"}, nil)
+
+		mode, err := wm.WithContext(ctx).Modes().Current()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if mode != "main" {
+			t.Fatalf("expected mode 'main', got '%s'", mode)
+		}
+	})
+
+	t.Run("WithContext shares the marks", func(t *testing.T) {
+		wm := &AeroSpaceWM{conn: client.NewFakeConnection(client.FakeState{})}
+
+		if err := wm.Marks().Mark(42, "editor"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		windowID, err := wm.WithContext(context.Background()).Marks().GetWindowID("editor")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if windowID != 42 {
+			t.Fatalf("expected window 42, got %d", windowID)
+		}
+	})
+
+	t.Run("Workspaces use the shared focus history", func(t *testing.T) {
+		wm := &AeroSpaceWM{conn: client.NewFakeConnection(client.FakeState{})}
+		wm.History().RecordWorkspace("1")
+		wm.History().RecordWorkspace("2")
+
+		recent, err := wm.WithContext(context.Background()).Workspaces().RecentlyFocused(2)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(recent) != 2 || recent[0] != "2" || recent[1] != "1" {
+			t.Fatalf("expected [2 1], got %v", recent)
+		}
+	})
+
+	t.Run("fails with ErrNotInitialized without a connection", func(t *testing.T) {
+		wm := &AeroSpaceWM{}
+
+		if _, err := wm.Connection().SendCommand("list-modes", nil); !errors.Is(err, ErrNotInitialized) {
+			t.Fatalf("expected ErrNotInitialized, got %v", err)
+		}
+		if _, err := wm.Windows().GetAllWindows(); !errors.Is(err, ErrNotInitialized) {
+			t.Fatalf("expected ErrNotInitialized, got %v", err)
+		}
+		if err := wm.CloseConnection(); !errors.Is(err, ErrNotInitialized) {
+			t.Fatalf("expected ErrNotInitialized, got %v", err)
+		}
+	})
+
+	t.Run("MustConnection panics without a connection", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Fatal("expected a panic")
+			}
+		}()
+		(&AeroSpaceWM{}).MustConnection()
+	})
+}
+
+func TestNewClient(t *testing.T) {
+	t.Run("connects with the given connector", func(t *testing.T) {
+		conn := client.NewFakeConnection(client.FakeState{})
+
+		wm, err := NewClient(WithConnector(conn))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if wm.Connection() != conn {
+			t.Fatal("expected the connection of the connector")
+		}
+	})
+
+	t.Run("fails with ErrServerNotRunning when the socket is missing", func(t *testing.T) {
+		_, err := NewClient(
+			WithSocketPath(filepath.Join(t.TempDir(), "missing.sock")),
+			WithTimeout(time.Second),
+			WithRetry(client.RetryPolicy{MaxAttempts: 1}),
+		)
+		if !errors.Is(err, ErrServerNotRunning) {
+			t.Fatalf("expected ErrServerNotRunning, got %v", err)
+		}
+	})
+
+	t.Run("rejects socket options with a connector", func(t *testing.T) {
+		_, err := NewClient(
+			WithConnector(client.NewFakeConnection(client.FakeState{})),
+			WithTimeout(time.Second),
+		)
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+	})
+
+	t.Run("rejects a logger with a connector", func(t *testing.T) {
+		_, err := NewClient(
+			WithConnector(client.NewFakeConnection(client.FakeState{})),
+			WithLogger(slog.Default()),
+		)
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+	})
+
+	t.Run("applies the socket options to the default connector", func(t *testing.T) {
+		defer client.SetDefaultConnector(client.GetDefaultConnector())
+		socketPath := filepath.Join(t.TempDir(), "custom.sock")
+		client.SetDefaultConnector(&client.AeroSpaceCustomConnector{SocketPath: socketPath})
+
+		dialer := &recordingDialer{}
+		_, err := NewClient(WithConnectionOptions(client.WithDialer(dialer)))
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+		if dialer.address != socketPath {
+			t.Fatalf("expected the default connector to dial %q with the dialer, got %q", socketPath, dialer.address)
+		}
+	})
+
+	t.Run("rejects socket options with a default connector that cannot take them", func(t *testing.T) {
+		defer client.SetDefaultConnector(client.GetDefaultConnector())
+		client.SetDefaultConnector(client.NewFakeConnection(client.FakeState{}))
+
+		_, err := NewClient(WithTimeout(time.Second))
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+	})
+
+	t.Run("applies the version policy", func(t *testing.T) {
+		unsupported := client.FakeState{ServerVersion: "0.1.0-Beta abc123"}
+
+		if _, err := NewClient(WithConnector(client.NewFakeConnection(unsupported))); err != nil {
+			t.Fatalf("expected the version check to be skipped, got %v", err)
+		}
+
+		var logs bytes.Buffer
+		defer slog.SetDefault(slog.Default())
+		slog.SetDefault(slog.New(slog.NewTextHandler(&logs, nil)))
+		_, err := NewClient(
+			WithConnector(client.NewFakeConnection(unsupported)),
+			WithVersionPolicy(VersionPolicyWarn),
+		)
+		if err != nil {
+			t.Fatalf("expected a warning only, got %v", err)
+		}
+		if !strings.Contains(logs.String(), "unsupported AeroSpace server version") {
+			t.Fatalf("expected a warning to be logged, got %q", logs.String())
+		}
+
+		conn := client.NewFakeConnection(unsupported)
+		_, err = NewClient(WithConnector(conn), WithVersionPolicy(VersionPolicyStrict))
+		if !errors.Is(err, ErrVersionMismatch) {
+			t.Fatalf("expected ErrVersionMismatch, got %v", err)
+		}
+		if _, err := conn.SendCommand("list-modes", nil); !errors.Is(err, client.ErrClosed) {
+			t.Fatalf("expected the rejected connection to be closed, got %v", err)
+		}
+	})
+
+	t.Run("keeps the marks in the given store", func(t *testing.T) {
+		store := marks.NewMemoryStore()
+		wm, err := NewClient(
+			WithConnector(client.NewFakeConnection(client.FakeState{})),
+			WithMarksStore(store),
+		)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if wm.Marks().Store() != store {
+			t.Fatal("expected the marks service to use the given store")
+		}
+	})
+}
+
+func TestNewCustomClient(t *testing.T) {
+	t.Run("fails with ErrServerNotRunning when the socket is missing", func(t *testing.T) {
+		_, err := NewCustomClient(CustomConnectionOpts{
+			SocketPath: filepath.Join(t.TempDir(), "missing.sock"),
+		})
+		if !errors.Is(err, ErrServerNotRunning) {
+			t.Fatalf("expected ErrServerNotRunning, got %v", err)
+		}
+	})
+}
+
+func TestNewClientFromConfig(t *testing.T) {
+	t.Run("fails for a missing settings file", func(t *testing.T) {
+		_, err := NewClientFromConfig(filepath.Join(t.TempDir(), "missing.toml"))
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+	})
+
+	t.Run("fails for unknown settings", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "config.toml")
+		if err := os.WriteFile(path, []byte(`socket = "/tmp/aerospace.sock"`), 0o600); err != nil {
+			t.Fatalf("failed to write settings file: %v", err)
+		}
+
+		_, err := NewClientFromConfig(path)
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+	})
+}
+
+func TestConfigOptions(t *testing.T) {
+	t.Run("maps the settings to client options", func(t *testing.T) {
+		var o clientOptions
+		for _, opt := range configOptions(&settings.Settings{
+			SocketPath:       "/tmp/custom.sock",
+			CommandTimeout:   2 * time.Second,
+			DialTimeout:      500 * time.Millisecond,
+			AutoStart:        true,
+			RetryMaxAttempts: 3,
+		}) {
+			opt(&o)
+		}
+
+		if o.socketPath != "/tmp/custom.sock" {
+			t.Fatalf("unexpected socket path %q", o.socketPath)
+		}
+		if o.versionPolicy != VersionPolicyStrict {
+			t.Fatalf("expected the version to be validated with a socket path, got %s", o.versionPolicy)
+		}
+		if len(o.connOptions) != 4 {
+			t.Fatalf("expected 4 connection options, got %d", len(o.connOptions))
+		}
+	})
+
+	t.Run("defaults without settings", func(t *testing.T) {
+		if opts := configOptions(&settings.Settings{}); len(opts) != 0 {
+			t.Fatalf("expected no options, got %d", len(opts))
+		}
+	})
+}
+
+func TestFakeConnection(t *testing.T) {
+	t.Run("Serves the services without AeroSpace", func(tt *testing.T) {
+		conn := client.NewFakeConnection(client.FakeState{
+			Windows: []client.FakeWindow{
+				{ID: 1, AppName: "Ghostty", Title: "zsh", Workspace: "1"},
+				{ID: 2, AppName: "Safari", Title: "GitHub", Workspace: "2"},
+			},
+			FocusedWindowID: 1,
+		})
+		wm := &AeroSpaceWM{conn: conn}
+
+		all, err := wm.Windows().GetAllWindows()
+		if err != nil {
+			tt.Fatalf("unexpected error: %v", err)
+		}
+		if len(all) != 2 || all[1].AppName != "Safari" || all[1].Workspace != "2" {
+			tt.Fatalf("unexpected windows %+v", all)
+		}
+
+		if err := wm.Focus().SetFocusByWindowID(2); err != nil {
+			tt.Fatalf("unexpected error: %v", err)
+		}
+		focused, err := wm.Windows().GetFocusedWindow()
+		if err != nil {
+			tt.Fatalf("unexpected error: %v", err)
+		}
+		if focused.WindowID != 2 {
+			tt.Fatalf("expected window 2 focused, got %+v", focused)
+		}
+
+		workspace, err := wm.Workspaces().GetFocusedWorkspace()
+		if err != nil {
+			tt.Fatalf("unexpected error: %v", err)
+		}
+		if workspace.Workspace != "2" {
+			tt.Fatalf("expected workspace 2 focused, got %+v", workspace)
+		}
+
+		monitor, err := wm.Monitors().GetFocusedMonitor()
+		if err != nil {
+			tt.Fatalf("unexpected error: %v", err)
+		}
+		if monitor.VisibleWorkspace.Workspace != "2" {
+			tt.Fatalf("expected workspace 2 visible, got %+v", monitor)
+		}
+
+		mode, err := wm.Modes().Current()
+		if err != nil || mode != "main" {
+			tt.Fatalf("expected mode main, got %q (error: %v)", mode, err)
+		}
+	})
 }
@@ -0,0 +1,232 @@
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/cristianoliveira/aerospace-ipc/pkg/client"
+	"github.com/cristianoliveira/aerospace-ipc/pkg/client/commands"
+)
+
+// Service provides methods to introspect the AeroSpaceWM configuration.
+type Service struct {
+	client client.AeroSpaceConnection
+	ctx    context.Context
+}
+
+// ConfigService defines the interface for config operations in AeroSpaceWM.
+type ConfigService interface {
+	// GetConfigPath returns the absolute path of the loaded config file.
+	GetConfigPath() (string, error)
+
+	// GetValue returns the decoded value of a config key.
+	GetValue(key string) (any, error)
+
+	// GetValueInto decodes the value of a config key into v.
+	GetValueInto(key string, v any) error
+
+	// GetKeys returns the keys of a config key that holds a table.
+	GetKeys(key string) ([]string, error)
+
+	// GetMajorKeys returns the top-level config keys.
+	GetMajorKeys() ([]string, error)
+
+	// GetAllKeys returns every config key, including nested ones.
+	GetAllKeys() ([]string, error)
+
+	// GetAll returns the whole config as a map of top-level keys to their decoded values.
+	GetAll() (map[string]any, error)
+}
+
+// NewService creates a new config service with the given AeroSpace client connection.
+func NewService(client client.AeroSpaceConnection) *Service {
+	return &Service{client: client}
+}
+
+// WithContext returns a copy of the service that sends every command with ctx,
+// so requests can be cancelled or bounded by a deadline.
+//
+// Usage:
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+//	defer cancel()
+//	path, err := configService.WithContext(ctx).GetConfigPath()
+func (s *Service) WithContext(ctx context.Context) *Service {
+	if ctx == nil {
+		panic("ASSERTION: context cannot be nil")
+	}
+	return &Service{client: s.client, ctx: ctx}
+}
+
+// sendCommand sends a command using the service context, if any.
+func (s *Service) sendCommand(command string, args []string) (*client.Response, error) {
+	if s.ctx == nil {
+		return s.client.SendCommand(command, args)
+	}
+	return s.client.SendCommandContext(s.ctx, command, args)
+}
+
+// GetConfigPath returns the absolute path of the loaded config file.
+//
+// It is equivalent to running the command:
+//
+//	aerospace config --config-path
+//
+// Usage:
+//
+//	path, err := configService.GetConfigPath()
+//	fmt.Println("Config:", path)
+func (s *Service) GetConfigPath() (string, error) {
+	response, err := s.sendCommand(commands.Config, []string{"--config-path"})
+	if err != nil {
+		return "", err
+	}
+
+	if response.ExitCode != 0 {
+		return "", fmt.Errorf("failed to get config path\n%s", response.StdErr)
+	}
+
+	return strings.TrimSpace(response.StdOut), nil
+}
+
+// GetValue returns the decoded value of a config key.
+//
+// Keys are dot separated, e.g. "gaps.inner.horizontal" or "mode.main.binding".
+// Tables are returned as map[string]any, arrays as []any, numbers as float64.
+//
+// It is equivalent to running the command:
+//
+//	aerospace config --get <key> --json
+//
+// Usage:
+//
+//	value, err := configService.GetValue("default-root-container-layout")
+//	fmt.Println("Layout:", value)
+func (s *Service) GetValue(key string) (any, error) {
+	var value any
+	if err := s.GetValueInto(key, &value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// GetValueInto decodes the value of a config key into v.
+//
+// It is equivalent to running the command:
+//
+//	aerospace config --get <key> --json
+//
+// Usage:
+//
+//	var bindings map[string]string
+//	err := configService.GetValueInto("mode.main.binding", &bindings)
+func (s *Service) GetValueInto(key string, v any) error {
+	if key == "" {
+		return fmt.Errorf("config key cannot be empty")
+	}
+
+	response, err := s.sendCommand(commands.Config, []string{"--get", key, "--json"})
+	if err != nil {
+		return err
+	}
+
+	if response.ExitCode != 0 {
+		return fmt.Errorf("failed to get config key %s\n%s", key, response.StdErr)
+	}
+
+	err = json.Unmarshal([]byte(response.StdOut), v)
+	if err != nil {
+		return fmt.Errorf(
+			"failed to unmarshal config key %s: %w\nOut:%s\nErr:%s",
+			key,
+			err,
+			response.StdOut,
+			response.StdErr,
+		)
+	}
+	return nil
+}
+
+// GetKeys returns the keys of a config key that holds a table.
+//
+// It is equivalent to running the command:
+//
+//	aerospace config --get <key> --keys
+//
+// Usage:
+//
+//	modes, err := configService.GetKeys("mode")
+func (s *Service) GetKeys(key string) ([]string, error) {
+	if key == "" {
+		return nil, fmt.Errorf("config key cannot be empty")
+	}
+
+	return s.listKeys([]string{"--get", key, "--keys"})
+}
+
+// GetMajorKeys returns the top-level config keys.
+//
+// It is equivalent to running the command:
+//
+//	aerospace config --major-keys
+func (s *Service) GetMajorKeys() ([]string, error) {
+	return s.listKeys([]string{"--major-keys"})
+}
+
+// GetAllKeys returns every config key, including nested ones.
+//
+// It is equivalent to running the command:
+//
+//	aerospace config --all-keys
+func (s *Service) GetAllKeys() ([]string, error) {
+	return s.listKeys([]string{"--all-keys"})
+}
+
+// GetAll returns the whole config as a map of top-level keys to their decoded values.
+//
+// It runs `aerospace config --major-keys` followed by one
+// `aerospace config --get <key> --json` per key.
+//
+// Usage:
+//
+//	cfg, err := configService.GetAll()
+//	fmt.Println("Gaps:", cfg["gaps"])
+func (s *Service) GetAll() (map[string]any, error) {
+	keys, err := s.GetMajorKeys()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]any, len(keys))
+	for _, key := range keys {
+		value, err := s.GetValue(key)
+		if err != nil {
+			return nil, err
+		}
+		result[key] = value
+	}
+
+	return result, nil
+}
+
+func (s *Service) listKeys(args []string) ([]string, error) {
+	response, err := s.sendCommand(commands.Config, args)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.ExitCode != 0 {
+		return nil, fmt.Errorf("failed to list config keys\n%s", response.StdErr)
+	}
+
+	var keys []string
+	for _, line := range strings.Split(response.StdOut, "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" {
+			keys = append(keys, line)
+		}
+	}
+	return keys, nil
+}
@@ -0,0 +1,222 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	mock_client "github.com/cristianoliveira/aerospace-ipc/internal/mocks"
+	"github.com/cristianoliveira/aerospace-ipc/pkg/client"
+	"go.uber.org/mock/gomock"
+)
+
+// TestConfigServiceInterface ensures that Service implements ConfigService interface.
+// This is a compile-time check - if Service doesn't implement all methods, this will fail to compile.
+func TestConfigServiceInterface(t *testing.T) {
+	var _ ConfigService = (*Service)(nil)
+}
+
+func TestConfigService(t *testing.T) {
+	t.Run("Happy path", func(tt *testing.T) {
+		t.Run("GetConfigPath", func(ttt *testing.T) {
+			ctrl := gomock.NewController(ttt)
+			defer ctrl.Finish()
+
+			mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+			service := NewService(mockConn)
+
+			mockConn.EXPECT().
+				SendCommand("config", []string{"--config-path"}).
+				Return(&client.Response{StdOut: "/Users/me/.aerospace.toml\n"}, nil)
+
+			path, err := service.GetConfigPath()
+			if err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+			if path != "/Users/me/.aerospace.toml" {
+				ttt.Fatalf("unexpected path %q", path)
+			}
+		})
+
+		t.Run("GetValue", func(ttt *testing.T) {
+			ctrl := gomock.NewController(ttt)
+			defer ctrl.Finish()
+
+			mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+			service := NewService(mockConn)
+
+			mockConn.EXPECT().
+				SendCommand("config", []string{"--get", "gaps.inner", "--json"}).
+				Return(&client.Response{StdOut: `{"horizontal": 10, "vertical": 8}`}, nil)
+
+			value, err := service.GetValue("gaps.inner")
+			if err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+			expected := map[string]any{"horizontal": float64(10), "vertical": float64(8)}
+			if !reflect.DeepEqual(value, expected) {
+				ttt.Fatalf("expected %v, got %v", expected, value)
+			}
+		})
+
+		t.Run("GetValueInto", func(ttt *testing.T) {
+			ctrl := gomock.NewController(ttt)
+			defer ctrl.Finish()
+
+			mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+			service := NewService(mockConn)
+
+			mockConn.EXPECT().
+				SendCommand("config", []string{"--get", "mode.main.binding", "--json"}).
+				Return(&client.Response{StdOut: `{"alt-h": "focus left"}`}, nil)
+
+			var bindings map[string]string
+			err := service.GetValueInto("mode.main.binding", &bindings)
+			if err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+			if bindings["alt-h"] != "focus left" {
+				ttt.Fatalf("unexpected bindings %v", bindings)
+			}
+		})
+
+		t.Run("GetKeys", func(ttt *testing.T) {
+			ctrl := gomock.NewController(ttt)
+			defer ctrl.Finish()
+
+			mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+			service := NewService(mockConn)
+
+			mockConn.EXPECT().
+				SendCommand("config", []string{"--get", "mode", "--keys"}).
+				Return(&client.Response{StdOut: "main\nservice\n"}, nil)
+
+			keys, err := service.GetKeys("mode")
+			if err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(keys, []string{"main", "service"}) {
+				ttt.Fatalf("unexpected keys %v", keys)
+			}
+		})
+
+		t.Run("GetAllKeys", func(ttt *testing.T) {
+			ctrl := gomock.NewController(ttt)
+			defer ctrl.Finish()
+
+			mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+			service := NewService(mockConn)
+
+			mockConn.EXPECT().
+				SendCommand("config", []string{"--all-keys"}).
+				Return(&client.Response{StdOut: "gaps\ngaps.inner\n"}, nil)
+
+			keys, err := service.GetAllKeys()
+			if err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(keys, []string{"gaps", "gaps.inner"}) {
+				ttt.Fatalf("unexpected keys %v", keys)
+			}
+		})
+
+		t.Run("GetAll", func(ttt *testing.T) {
+			ctrl := gomock.NewController(ttt)
+			defer ctrl.Finish()
+
+			mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+			service := NewService(mockConn)
+
+			gomock.InOrder(
+				mockConn.EXPECT().
+					SendCommand("config", []string{"--major-keys"}).
+					Return(&client.Response{StdOut: "default-root-container-layout\ngaps\n"}, nil),
+				mockConn.EXPECT().
+					SendCommand("config", []string{"--get", "default-root-container-layout", "--json"}).
+					Return(&client.Response{StdOut: `"tiles"`}, nil),
+				mockConn.EXPECT().
+					SendCommand("config", []string{"--get", "gaps", "--json"}).
+					Return(&client.Response{StdOut: `{"inner": {"horizontal": 0}}`}, nil),
+			)
+
+			cfg, err := service.GetAll()
+			if err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+			if cfg["default-root-container-layout"] != "tiles" {
+				ttt.Fatalf("unexpected config %v", cfg)
+			}
+			if _, ok := cfg["gaps"].(map[string]any); !ok {
+				ttt.Fatalf("expected gaps to be a table, got %T", cfg["gaps"])
+			}
+		})
+	})
+
+	t.Run("Error cases", func(tt *testing.T) {
+		t.Run("GetValue with empty key", func(ttt *testing.T) {
+			ctrl := gomock.NewController(ttt)
+			defer ctrl.Finish()
+
+			service := NewService(mock_client.NewMockAeroSpaceConnection(ctrl))
+
+			if _, err := service.GetValue(""); err == nil {
+				ttt.Fatal("expected error, got nil")
+			}
+		})
+
+		t.Run("GetValue unknown key", func(ttt *testing.T) {
+			ctrl := gomock.NewController(ttt)
+			defer ctrl.Finish()
+
+			mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+			service := NewService(mockConn)
+
+			mockConn.EXPECT().
+				SendCommand("config", []string{"--get", "unknown", "--json"}).
+				Return(&client.Response{StdErr: "No value at unknown", ExitCode: 1}, nil)
+
+			_, err := service.GetValue("unknown")
+			if err == nil {
+				ttt.Fatal("expected error, got nil")
+			}
+			if err.Error() != "failed to get config key unknown\nNo value at unknown" {
+				ttt.Errorf("unexpected error message: %v", err)
+			}
+		})
+
+		t.Run("GetAll connection error", func(ttt *testing.T) {
+			ctrl := gomock.NewController(ttt)
+			defer ctrl.Finish()
+
+			mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+			service := NewService(mockConn)
+
+			mockConn.EXPECT().
+				SendCommand("config", []string{"--major-keys"}).
+				Return(nil, fmt.Errorf("connection error"))
+
+			if _, err := service.GetAll(); err == nil {
+				ttt.Fatal("expected error, got nil")
+			}
+		})
+	})
+}
+
+func TestServiceWithContext(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+	service := NewService(mockConn).WithContext(ctx)
+
+	mockConn.EXPECT().
+		SendCommandContext(ctx, "config", []string{"--config-path"}).
+		Return(&client.Response{StdOut: "/Users/me/.aerospace.toml"}, nil)
+
+	_, err := service.GetConfigPath()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,71 @@
+package focus
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/cristianoliveira/aerospace-ipc/pkg/client"
+	"github.com/cristianoliveira/aerospace-ipc/pkg/client/commands"
+)
+
+// BackAndForthResult describes what BackAndForthOrWorkspace switched to.
+type BackAndForthResult int
+
+const (
+	// BackAndForthNone means nothing was switched, the call failed.
+	BackAndForthNone BackAndForthResult = iota
+	// BackAndForthWindow means the previously focused window was focused.
+	BackAndForthWindow
+	// BackAndForthWorkspace means the previous window was gone, so the
+	// previously focused workspace was focused instead.
+	BackAndForthWorkspace
+)
+
+func (r BackAndForthResult) String() string {
+	switch r {
+	case BackAndForthNone:
+		return "none"
+	case BackAndForthWindow:
+		return "window"
+	case BackAndForthWorkspace:
+		return "workspace"
+	}
+	return fmt.Sprintf("BackAndForthResult(%d)", int(r))
+}
+
+// BackAndForthOrWorkspace switches to the previously focused window or,
+// when it was closed, to the previously focused workspace.
+//
+// It is equivalent to running the command:
+//
+//	aerospace focus-back-and-forth || aerospace workspace-back-and-forth
+//
+// Returns which of the two switched, or an error if both failed.
+//
+// Usage:
+//
+//	result, err := focusService.BackAndForthOrWorkspace()
+//	if err == nil && result == focus.BackAndForthWorkspace {
+//	    log.Println("previous window was closed, switched workspace instead")
+//	}
+func (s *Service) BackAndForthOrWorkspace() (BackAndForthResult, error) {
+	response, err := s.sendCommand(commands.FocusBackAndForth, []string{})
+	var cmdErr *client.CommandError
+	if err != nil && !errors.As(err, &cmdErr) {
+		// Only a failed command falls back, not a broken connection.
+		return BackAndForthNone, err
+	}
+	if err == nil && response.ExitCode == 0 {
+		return BackAndForthWindow, nil
+	}
+
+	response, err = s.sendCommand(commands.WorkspaceBackAndForth, []string{})
+	if err != nil {
+		return BackAndForthNone, err
+	}
+	if response.ExitCode != 0 {
+		return BackAndForthNone, fmt.Errorf("failed to switch workspace back and forth\n%s", response.StdErr)
+	}
+
+	return BackAndForthWorkspace, nil
+}
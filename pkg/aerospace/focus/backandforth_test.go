@@ -0,0 +1,120 @@
+package focus
+
+import (
+	"errors"
+	"testing"
+
+	mock_client "github.com/cristianoliveira/aerospace-ipc/internal/mocks"
+	"github.com/cristianoliveira/aerospace-ipc/pkg/client"
+	"go.uber.org/mock/gomock"
+)
+
+func TestBackAndForthOrWorkspace(t *testing.T) {
+	t.Run("Happy path", func(tt *testing.T) {
+		tt.Run("previous window", func(ttt *testing.T) {
+			ctrl := gomock.NewController(ttt)
+			defer ctrl.Finish()
+
+			mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+			mockConn.EXPECT().
+				SendCommand("focus-back-and-forth", []string{}).
+				Return(&client.Response{}, nil)
+
+			result, err := NewService(mockConn).BackAndForthOrWorkspace()
+			if err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+			if result != BackAndForthWindow {
+				ttt.Fatalf("expected %s, got %s", BackAndForthWindow, result)
+			}
+		})
+
+		tt.Run("falls back to the previous workspace", func(ttt *testing.T) {
+			ctrl := gomock.NewController(ttt)
+			defer ctrl.Finish()
+
+			mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+			gomock.InOrder(
+				mockConn.EXPECT().
+					SendCommand("focus-back-and-forth", []string{}).
+					Return(nil, &client.CommandError{
+						Command:  "focus-back-and-forth",
+						ExitCode: 1,
+						StdErr:   "No previous window",
+					}),
+				mockConn.EXPECT().
+					SendCommand("workspace-back-and-forth", []string{}).
+					Return(&client.Response{}, nil),
+			)
+
+			result, err := NewService(mockConn).BackAndForthOrWorkspace()
+			if err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+			if result != BackAndForthWorkspace {
+				ttt.Fatalf("expected %s, got %s", BackAndForthWorkspace, result)
+			}
+		})
+	})
+
+	t.Run("Error cases", func(tt *testing.T) {
+		tt.Run("does not fall back on connection errors", func(ttt *testing.T) {
+			ctrl := gomock.NewController(ttt)
+			defer ctrl.Finish()
+
+			mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+			mockConn.EXPECT().
+				SendCommand("focus-back-and-forth", []string{}).
+				Return(nil, errors.New("connection refused"))
+
+			result, err := NewService(mockConn).BackAndForthOrWorkspace()
+			if err == nil || result != BackAndForthNone {
+				ttt.Fatalf("expected error and %s, got %v and %s", BackAndForthNone, err, result)
+			}
+		})
+
+		tt.Run("workspace fallback fails", func(ttt *testing.T) {
+			ctrl := gomock.NewController(ttt)
+			defer ctrl.Finish()
+
+			mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+			gomock.InOrder(
+				mockConn.EXPECT().
+					SendCommand("focus-back-and-forth", []string{}).
+					Return(nil, &client.CommandError{Command: "focus-back-and-forth", ExitCode: 1}),
+				mockConn.EXPECT().
+					SendCommand("workspace-back-and-forth", []string{}).
+					Return(nil, &client.CommandError{
+						Command:  "workspace-back-and-forth",
+						ExitCode: 1,
+						StdErr:   "No previous workspace",
+					}),
+			)
+
+			result, err := NewService(mockConn).BackAndForthOrWorkspace()
+			if err == nil || result != BackAndForthNone {
+				ttt.Fatalf("expected error and %s, got %v and %s", BackAndForthNone, err, result)
+			}
+		})
+
+		tt.Run("workspace fallback exits with a non-zero code", func(ttt *testing.T) {
+			ctrl := gomock.NewController(ttt)
+			defer ctrl.Finish()
+
+			mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+			gomock.InOrder(
+				mockConn.EXPECT().
+					SendCommand("focus-back-and-forth", []string{}).
+					Return(&client.Response{ExitCode: 1}, nil),
+				mockConn.EXPECT().
+					SendCommand("workspace-back-and-forth", []string{}).
+					Return(&client.Response{ExitCode: 1, StdErr: "No previous workspace"}, nil),
+			)
+
+			_, err := NewService(mockConn).BackAndForthOrWorkspace()
+			if err == nil || err.Error() != "failed to switch workspace back and forth\nNo previous workspace" {
+				ttt.Fatalf("expected the workspace error, got %v", err)
+			}
+		})
+	})
+}
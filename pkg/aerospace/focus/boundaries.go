@@ -0,0 +1,74 @@
+package focus
+
+import "github.com/cristianoliveira/aerospace-ipc/internal/validate"
+
+// Boundaries defines the focus boundaries of SetFocusByDirection and SetFocusByDFS.
+type Boundaries string
+
+const (
+	// BoundariesWorkspace keeps the focus within the focused workspace. It is the default.
+	BoundariesWorkspace Boundaries = "workspace"
+
+	// BoundariesAllMonitorsOuterFrame lets the focus cross to the other monitors.
+	BoundariesAllMonitorsOuterFrame Boundaries = "all-monitors-outer-frame"
+)
+
+// Ptr returns the boundaries as a string pointer, as expected by SetFocusOpts.Boundaries.
+//
+// Usage:
+//
+//	err := focusService.SetFocusByDirection("left", focus.SetFocusOpts{
+//	    Boundaries: focus.BoundariesAllMonitorsOuterFrame.Ptr(),
+//	})
+func (b Boundaries) Ptr() *string {
+	value := string(b)
+	return &value
+}
+
+// Validate returns an error if b is not one of the Boundaries constants.
+func (b Boundaries) Validate() error {
+	return validate.OneOf("boundaries", b, BoundariesWorkspace, BoundariesAllMonitorsOuterFrame)
+}
+
+// BoundariesAction defines the behavior when the focus is requested to cross the boundaries.
+type BoundariesAction string
+
+const (
+	// ActionStop does nothing. It is the default.
+	ActionStop BoundariesAction = "stop"
+
+	// ActionFail fails the command.
+	ActionFail BoundariesAction = "fail"
+
+	// ActionWrapWorkspace wraps the focus around the workspace.
+	ActionWrapWorkspace BoundariesAction = "wrap-around-the-workspace"
+
+	// ActionWrapAllMonitors wraps the focus around all monitors.
+	ActionWrapAllMonitors BoundariesAction = "wrap-around-all-monitors"
+)
+
+// Ptr returns the action as a string pointer, as expected by SetFocusOpts.BoundariesAction.
+func (a BoundariesAction) Ptr() *string {
+	value := string(a)
+	return &value
+}
+
+// Validate returns an error if a is not one of the BoundariesAction constants.
+func (a BoundariesAction) Validate() error {
+	return validate.OneOf("boundaries action", a, ActionStop, ActionFail, ActionWrapWorkspace, ActionWrapAllMonitors)
+}
+
+// validate returns an error if the boundaries options hold unknown values.
+func (opt SetFocusOpts) validate() error {
+	if opt.Boundaries != nil {
+		if err := Boundaries(*opt.Boundaries).Validate(); err != nil {
+			return err
+		}
+	}
+	if opt.BoundariesAction != nil {
+		if err := BoundariesAction(*opt.BoundariesAction).Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package focus
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/cristianoliveira/aerospace-ipc/pkg/client"
+	"github.com/cristianoliveira/aerospace-ipc/pkg/client/args"
+	"github.com/cristianoliveira/aerospace-ipc/pkg/client/commands"
+)
+
+// ErrWindowNotFound is returned when no window matches the one to focus,
+// e.g. by SetFocusByApp and SetFocusByTitle.
+//
+// Usage:
+//
+//	err := focusService.SetFocusByApp("Slack")
+//	if errors.Is(err, focus.ErrWindowNotFound) {
+//	    // launch the app instead
+//	}
+var ErrWindowNotFound = errors.New("window not found")
+
+// candidateWindow holds the list-windows fields needed to pick the window to focus.
+type candidateWindow struct {
+	WindowID           client.WindowID `json:"window-id"`
+	WindowTitle        string          `json:"window-title"`
+	AppName            string          `json:"app-name"`
+	AppBundleID        string          `json:"app-bundle-id"`
+	WorkspaceIsFocused bool            `json:"workspace-is-focused"`
+}
+
+const candidateWindowFormat = "%{window-id} %{window-title} %{app-name} %{app-bundle-id} %{workspace-is-focused}"
+
+// SetFocusByApp focuses the first window of an application, given its name or
+// bundle ID, both compared case-insensitively.
+//
+// AeroSpace does not expose the focus history, so the windows on the focused
+// workspace are preferred, then the windows are taken in list-windows order.
+//
+// It is equivalent to running the commands:
+//
+//	aerospace list-windows --all --json --format <format>
+//	aerospace focus --window-id <window-id> [--ignore-floating]
+//
+// Returns an error wrapping ErrWindowNotFound if the application has no window.
+//
+// Usage:
+//
+//	err := focusService.SetFocusByApp("com.mitchellh.ghostty")
+//
+//	// Focus by app name with options
+//	err := focusService.SetFocusByApp("Ghostty", focus.SetFocusOpts{
+//	    IgnoreFloating: true,
+//	})
+func (s *Service) SetFocusByApp(app string, opts ...SetFocusOpts) error {
+	if app == "" {
+		return fmt.Errorf("app cannot be empty")
+	}
+
+	return s.focusFirst(fmt.Sprintf("no window of app %q", app), func(window candidateWindow) bool {
+		return strings.EqualFold(window.AppName, app) || strings.EqualFold(window.AppBundleID, app)
+	}, opts)
+}
+
+// SetFocusByTitle focuses the first window whose title matches pattern,
+// e.g. to jump to the editor or terminal showing a branch name.
+//
+// As with SetFocusByApp, the windows on the focused workspace are preferred.
+//
+// It is equivalent to running the commands:
+//
+//	aerospace list-windows --all --json --format <format>
+//	aerospace focus --window-id <window-id> [--ignore-floating]
+//
+// Returns an error wrapping ErrWindowNotFound if no title matches.
+//
+// Usage:
+//
+//	err := focusService.SetFocusByTitle(regexp.MustCompile(`feature/focus-by-title`))
+func (s *Service) SetFocusByTitle(pattern *regexp.Regexp, opts ...SetFocusOpts) error {
+	if pattern == nil {
+		panic("ASSERTION: pattern cannot be nil")
+	}
+
+	return s.focusFirst(fmt.Sprintf("no window title matches %q", pattern), func(window candidateWindow) bool {
+		return pattern.MatchString(window.WindowTitle)
+	}, opts)
+}
+
+// focusFirst lists all windows and focuses the first one matching match,
+// preferring the windows on the focused workspace. notFound describes
+// the error returned when no window matches.
+func (s *Service) focusFirst(notFound string, match func(candidateWindow) bool, opts []SetFocusOpts) error {
+	cmdArgs := args.New().
+		Flag("--all", "--json").
+		KV("--format", candidateWindowFormat).
+		Build()
+
+	response, err := s.sendCommand(commands.ListWindows, cmdArgs)
+	if err != nil {
+		return err
+	}
+	if response.ExitCode != 0 {
+		return fmt.Errorf("failed to list windows\n%s", response.StdErr)
+	}
+
+	var windows []candidateWindow
+	if err := json.Unmarshal([]byte(response.StdOut), &windows); err != nil {
+		return fmt.Errorf("failed to unmarshal windows: %w\nOut:%s\nErr:%s", err, response.StdOut, response.StdErr)
+	}
+
+	var found *candidateWindow
+	for i, window := range windows {
+		if !match(window) {
+			continue
+		}
+		if found == nil || (window.WorkspaceIsFocused && !found.WorkspaceIsFocused) {
+			found = &windows[i]
+		}
+	}
+	if found == nil {
+		return fmt.Errorf("%w\n%s", ErrWindowNotFound, notFound)
+	}
+
+	return s.SetFocusByWindowID(found.WindowID, opts...)
+}
@@ -0,0 +1,179 @@
+package focus
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+
+	mock_client "github.com/cristianoliveira/aerospace-ipc/internal/mocks"
+	"github.com/cristianoliveira/aerospace-ipc/pkg/client"
+	"go.uber.org/mock/gomock"
+)
+
+const candidateWindowsJSON = `[
+  {"window-id": 1, "window-title": "nvim ~/src (feature/focus)", "app-name": "Ghostty",
+   "app-bundle-id": "com.mitchellh.ghostty", "workspace-is-focused": false},
+  {"window-id": 2, "window-title": "GitHub - feature/focus", "app-name": "Safari",
+   "app-bundle-id": "com.apple.Safari", "workspace-is-focused": false},
+  {"window-id": 3, "window-title": "Docs", "app-name": "Safari",
+   "app-bundle-id": "com.apple.Safari", "workspace-is-focused": true}
+]`
+
+func TestSetFocusByApp(t *testing.T) {
+	listArgs := []string{"--all", "--json", "--format", candidateWindowFormat}
+
+	t.Run("Happy path", func(tt *testing.T) {
+		testCases := []struct {
+			title    string
+			app      string
+			opts     []SetFocusOpts
+			expected []string
+		}{
+			{
+				title:    "by app name",
+				app:      "ghostty",
+				expected: []string{"--window-id", "1"},
+			},
+			{
+				title:    "by bundle ID",
+				app:      "com.mitchellh.ghostty",
+				opts:     []SetFocusOpts{{IgnoreFloating: true}},
+				expected: []string{"--window-id", "1", "--ignore-floating"},
+			},
+			{
+				title:    "prefers the focused workspace",
+				app:      "Safari",
+				expected: []string{"--window-id", "3"},
+			},
+		}
+		for _, tc := range testCases {
+			tt.Run(tc.title, func(ttt *testing.T) {
+				ctrl := gomock.NewController(ttt)
+				defer ctrl.Finish()
+
+				mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+				gomock.InOrder(
+					mockConn.EXPECT().
+						SendCommand("list-windows", listArgs).
+						Return(&client.Response{StdOut: candidateWindowsJSON}, nil),
+					mockConn.EXPECT().
+						SendCommand("focus", tc.expected).
+						Return(&client.Response{}, nil),
+				)
+
+				if err := NewService(mockConn).SetFocusByApp(tc.app, tc.opts...); err != nil {
+					ttt.Fatalf("unexpected error: %v", err)
+				}
+			})
+		}
+	})
+
+	t.Run("Error cases", func(tt *testing.T) {
+		tt.Run("no window of the app", func(ttt *testing.T) {
+			ctrl := gomock.NewController(ttt)
+			defer ctrl.Finish()
+
+			mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+			mockConn.EXPECT().
+				SendCommand("list-windows", listArgs).
+				Return(&client.Response{StdOut: candidateWindowsJSON}, nil)
+
+			err := NewService(mockConn).SetFocusByApp("Slack")
+			if !errors.Is(err, ErrWindowNotFound) {
+				ttt.Fatalf("expected ErrWindowNotFound, got %v", err)
+			}
+		})
+
+		tt.Run("list-windows fails", func(ttt *testing.T) {
+			ctrl := gomock.NewController(ttt)
+			defer ctrl.Finish()
+
+			mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+			mockConn.EXPECT().
+				SendCommand("list-windows", listArgs).
+				Return(&client.Response{ExitCode: 1, StdErr: "boom"}, nil)
+
+			err := NewService(mockConn).SetFocusByApp("Slack")
+			if err == nil || errors.Is(err, ErrWindowNotFound) {
+				ttt.Fatalf("expected listing error, got %v", err)
+			}
+		})
+
+		tt.Run("empty app", func(ttt *testing.T) {
+			ctrl := gomock.NewController(ttt)
+			defer ctrl.Finish()
+
+			mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+			if err := NewService(mockConn).SetFocusByApp(""); err == nil {
+				ttt.Fatal("expected error, got nil")
+			}
+		})
+	})
+}
+
+func TestSetFocusByTitle(t *testing.T) {
+	listArgs := []string{"--all", "--json", "--format", candidateWindowFormat}
+
+	t.Run("Happy path", func(tt *testing.T) {
+		testCases := []struct {
+			title    string
+			pattern  *regexp.Regexp
+			opts     []SetFocusOpts
+			expected []string
+		}{
+			{
+				title:    "first matching title",
+				pattern:  regexp.MustCompile(`feature/focus`),
+				expected: []string{"--window-id", "1"},
+			},
+			{
+				title:    "with options",
+				pattern:  regexp.MustCompile(`^GitHub`),
+				opts:     []SetFocusOpts{{IgnoreFloating: true}},
+				expected: []string{"--window-id", "2", "--ignore-floating"},
+			},
+			{
+				title:    "prefers the focused workspace",
+				pattern:  regexp.MustCompile(`(?i)docs|github`),
+				expected: []string{"--window-id", "3"},
+			},
+		}
+		for _, tc := range testCases {
+			tt.Run(tc.title, func(ttt *testing.T) {
+				ctrl := gomock.NewController(ttt)
+				defer ctrl.Finish()
+
+				mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+				gomock.InOrder(
+					mockConn.EXPECT().
+						SendCommand("list-windows", listArgs).
+						Return(&client.Response{StdOut: candidateWindowsJSON}, nil),
+					mockConn.EXPECT().
+						SendCommand("focus", tc.expected).
+						Return(&client.Response{}, nil),
+				)
+
+				if err := NewService(mockConn).SetFocusByTitle(tc.pattern, tc.opts...); err != nil {
+					ttt.Fatalf("unexpected error: %v", err)
+				}
+			})
+		}
+	})
+
+	t.Run("Error cases", func(tt *testing.T) {
+		tt.Run("no matching title", func(ttt *testing.T) {
+			ctrl := gomock.NewController(ttt)
+			defer ctrl.Finish()
+
+			mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+			mockConn.EXPECT().
+				SendCommand("list-windows", listArgs).
+				Return(&client.Response{StdOut: candidateWindowsJSON}, nil)
+
+			err := NewService(mockConn).SetFocusByTitle(regexp.MustCompile(`main`))
+			if !errors.Is(err, ErrWindowNotFound) {
+				ttt.Fatalf("expected ErrWindowNotFound, got %v", err)
+			}
+		})
+	})
+}
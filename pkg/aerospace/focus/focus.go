@@ -1,9 +1,14 @@
 package focus
 
 import (
+	"context"
 	"fmt"
+	"regexp"
 
+	"github.com/cristianoliveira/aerospace-ipc/internal/validate"
 	"github.com/cristianoliveira/aerospace-ipc/pkg/client"
+	"github.com/cristianoliveira/aerospace-ipc/pkg/client/args"
+	"github.com/cristianoliveira/aerospace-ipc/pkg/client/commands"
 )
 
 // SetFocusOpts contains optional parameters for focus operations.
@@ -14,24 +19,80 @@ type SetFocusOpts struct {
 
 	// Boundaries defines focus boundaries.
 	// Used with SetFocusByDirection and SetFocusByDFS.
-	// Possible values: "workspace" (default), "all-monitors-outer-frame"
+	// Possible values: the Boundaries constants, e.g. BoundariesWorkspace.Ptr() (default).
 	Boundaries *string
 
 	// BoundariesAction defines the behavior when requested to cross the boundary.
 	// Used with SetFocusByDirection and SetFocusByDFS.
-	// Possible values: "stop" (default), "fail", "wrap-around-the-workspace", "wrap-around-all-monitors"
+	// Possible values: the BoundariesAction constants, e.g. ActionStop.Ptr() (default).
 	BoundariesAction *string
 }
 
+// FocusMonitorArgs contains arguments for FocusMonitor.
+// Exactly one of Direction, Order, or Patterns must be specified.
+type FocusMonitorArgs struct {
+	// Direction specifies the direction to focus the monitor (left|down|up|right).
+	// Focus monitor in direction relative to the focused monitor.
+	Direction string
+
+	// Order specifies the order-based focus (next|prev).
+	// Focus the next or prev monitor relative to the focused monitor.
+	Order string
+
+	// Patterns specifies one or more monitor patterns to match.
+	// Focuses the first matching monitor.
+	// Multiple monitor patterns are useful for different monitor configurations.
+	Patterns []string
+}
+
+// FocusMonitorOpts contains optional parameters for FocusMonitor.
+type FocusMonitorOpts struct {
+	// WrapAround allows focusing between first and last monitors.
+	// Only valid with Direction or Order.
+	WrapAround bool
+}
+
+// Mouse positions accepted by MoveMouse.
+const (
+	// MouseMonitorLazyCenter moves the mouse to the center of the focused monitor,
+	// unless it is already within the monitor boundaries.
+	MouseMonitorLazyCenter = "monitor-lazy-center"
+
+	// MouseMonitorForceCenter moves the mouse to the center of the focused monitor.
+	MouseMonitorForceCenter = "monitor-force-center"
+
+	// MouseWindowLazyCenter moves the mouse to the center of the focused window,
+	// unless it is already within the window boundaries.
+	MouseWindowLazyCenter = "window-lazy-center"
+
+	// MouseWindowForceCenter moves the mouse to the center of the focused window.
+	MouseWindowForceCenter = "window-force-center"
+)
+
+// MoveMouseOpts contains optional parameters for MoveMouse.
+type MoveMouseOpts struct {
+	// FailIfNoop exits with non-zero code if the mouse is already at the requested position.
+	// Only meaningful for the lazy positions.
+	FailIfNoop bool
+}
+
 // Service provides methods to interact with focus in AeroSpaceWM.
 type Service struct {
-	client client.AeroSpaceConnection
+	client  client.AeroSpaceConnection
+	ctx     context.Context
+	history WindowHistory
 }
 
 // FocusService defines the interface for focus operations in AeroSpaceWM.
 type FocusService interface {
 	// SetFocusByWindowID sets focus to a window specified by its ID.
-	SetFocusByWindowID(windowID int, opts ...SetFocusOpts) error
+	SetFocusByWindowID(windowID client.WindowID, opts ...SetFocusOpts) error
+
+	// SetFocusByApp focuses the first window of an application, given its name or bundle ID.
+	SetFocusByApp(app string, opts ...SetFocusOpts) error
+
+	// SetFocusByTitle focuses the first window whose title matches pattern.
+	SetFocusByTitle(pattern *regexp.Regexp, opts ...SetFocusOpts) error
 
 	// SetFocusByDirection sets focus to the nearest window in the given direction.
 	SetFocusByDirection(direction string, opts ...SetFocusOpts) error
@@ -44,6 +105,20 @@ type FocusService interface {
 
 	// FocusBackAndForth switches between the current and previously focused window.
 	FocusBackAndForth() error
+
+	// BackAndForthOrWorkspace switches to the previously focused window,
+	// or to the previously focused workspace when the window was closed.
+	BackAndForthOrWorkspace() (BackAndForthResult, error)
+
+	// FocusMonitor focuses a monitor.
+	// Supports three modes: direction-based (left|down|up|right), order-based (next|prev), or pattern-based.
+	FocusMonitor(target FocusMonitorArgs, opts FocusMonitorOpts) error
+
+	// MoveMouse moves the mouse to the requested position.
+	MoveMouse(target string, opts ...MoveMouseOpts) error
+
+	// RestoreWorkspaceFocus focuses a workspace and the window last focused on it.
+	RestoreWorkspaceFocus(workspace string) error
 }
 
 // NewService creates a new focus service with the given AeroSpace client connection.
@@ -51,6 +126,29 @@ func NewService(client client.AeroSpaceConnection) *Service {
 	return &Service{client: client}
 }
 
+// WithContext returns a copy of the service that sends every command with ctx,
+// so requests can be cancelled or bounded by a deadline.
+//
+// Usage:
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+//	defer cancel()
+//	err := focusService.WithContext(ctx).SetFocusByWindowID(12345)
+func (s *Service) WithContext(ctx context.Context) *Service {
+	if ctx == nil {
+		panic("ASSERTION: context cannot be nil")
+	}
+	return &Service{client: s.client, ctx: ctx, history: s.history}
+}
+
+// sendCommand sends a command using the service context, if any.
+func (s *Service) sendCommand(command string, args []string) (*client.Response, error) {
+	if s.ctx == nil {
+		return s.client.SendCommand(command, args)
+	}
+	return s.client.SendCommandContext(s.ctx, command, args)
+}
+
 // SetFocusByWindowID sets focus to a window specified by its ID.
 //
 // It is equivalent to running the command:
@@ -68,21 +166,18 @@ func NewService(client client.AeroSpaceConnection) *Service {
 //	err := focusService.SetFocusByWindowID(12345, focus.SetFocusOpts{
 //	    IgnoreFloating: true,
 //	})
-func (s *Service) SetFocusByWindowID(windowID int, opts ...SetFocusOpts) error {
-	cmdArgs := []string{
-		"--window-id", fmt.Sprintf("%d", windowID),
-	}
-
+func (s *Service) SetFocusByWindowID(windowID client.WindowID, opts ...SetFocusOpts) error {
 	var opt SetFocusOpts
 	if len(opts) > 0 {
 		opt = opts[0]
 	}
 
-	if opt.IgnoreFloating {
-		cmdArgs = append(cmdArgs, "--ignore-floating")
-	}
+	cmdArgs := args.New().
+		KV("--window-id", windowID).
+		FlagIf(opt.IgnoreFloating, "--ignore-floating").
+		Build()
 
-	response, err := s.client.SendCommand("focus", cmdArgs)
+	response, err := s.sendCommand(commands.Focus, cmdArgs)
 	if err != nil {
 		return err
 	}
@@ -110,38 +205,32 @@ func (s *Service) SetFocusByWindowID(windowID int, opts ...SetFocusOpts) error {
 //	err := focusService.SetFocusByDirection("left")
 //
 //	// Focus by direction with all options
-//	boundaries := "workspace"
-//	action := "wrap-around-the-workspace"
 //	err := focusService.SetFocusByDirection("left", focus.SetFocusOpts{
-//	    IgnoreFloating:  true,
-//	    Boundaries:      &boundaries,
-//	    BoundariesAction: &action,
+//	    IgnoreFloating:   true,
+//	    Boundaries:       focus.BoundariesWorkspace.Ptr(),
+//	    BoundariesAction: focus.ActionWrapWorkspace.Ptr(),
 //	})
 func (s *Service) SetFocusByDirection(direction string, opts ...SetFocusOpts) error {
-	// Validate direction value
-	validDirections := map[string]bool{"left": true, "down": true, "up": true, "right": true}
-	if !validDirections[direction] {
-		return fmt.Errorf("invalid direction %q, must be one of: left, down, up, right", direction)
+	if err := validate.OneOf("direction", direction, validate.Directions...); err != nil {
+		return err
 	}
 
-	cmdArgs := []string{direction}
-
 	var opt SetFocusOpts
 	if len(opts) > 0 {
 		opt = opts[0]
 	}
-
-	if opt.IgnoreFloating {
-		cmdArgs = append(cmdArgs, "--ignore-floating")
-	}
-	if opt.Boundaries != nil {
-		cmdArgs = append(cmdArgs, "--boundaries", *opt.Boundaries)
-	}
-	if opt.BoundariesAction != nil {
-		cmdArgs = append(cmdArgs, "--boundaries-action", *opt.BoundariesAction)
+	if err := opt.validate(); err != nil {
+		return err
 	}
 
-	response, err := s.client.SendCommand("focus", cmdArgs)
+	cmdArgs := args.New().
+		Values(direction).
+		FlagIf(opt.IgnoreFloating, "--ignore-floating").
+		KV("--boundaries", opt.Boundaries).
+		KV("--boundaries-action", opt.BoundariesAction).
+		Build()
+
+	response, err := s.sendCommand(commands.Focus, cmdArgs)
 	if err != nil {
 		return err
 	}
@@ -169,35 +258,31 @@ func (s *Service) SetFocusByDirection(direction string, opts ...SetFocusOpts) er
 //	err := focusService.SetFocusByDFS("dfs-next")
 //
 //	// Focus by DFS with options
-//	action := "wrap-around-the-workspace"
 //	err := focusService.SetFocusByDFS("dfs-prev", focus.SetFocusOpts{
-//	    IgnoreFloating:  true,
-//	    BoundariesAction: &action,
+//	    IgnoreFloating:   true,
+//	    BoundariesAction: focus.ActionWrapWorkspace.Ptr(),
 //	})
 func (s *Service) SetFocusByDFS(direction string, opts ...SetFocusOpts) error {
-	// Validate DFS direction value
-	if direction != "dfs-next" && direction != "dfs-prev" {
-		return fmt.Errorf("invalid DFS direction %q, must be one of: dfs-next, dfs-prev", direction)
+	if err := validate.OneOf("DFS direction", direction, "dfs-next", "dfs-prev"); err != nil {
+		return err
 	}
 
-	cmdArgs := []string{direction}
-
 	var opt SetFocusOpts
 	if len(opts) > 0 {
 		opt = opts[0]
 	}
-
-	if opt.IgnoreFloating {
-		cmdArgs = append(cmdArgs, "--ignore-floating")
-	}
-	if opt.Boundaries != nil {
-		cmdArgs = append(cmdArgs, "--boundaries", *opt.Boundaries)
-	}
-	if opt.BoundariesAction != nil {
-		cmdArgs = append(cmdArgs, "--boundaries-action", *opt.BoundariesAction)
+	if err := opt.validate(); err != nil {
+		return err
 	}
 
-	response, err := s.client.SendCommand("focus", cmdArgs)
+	cmdArgs := args.New().
+		Values(direction).
+		FlagIf(opt.IgnoreFloating, "--ignore-floating").
+		KV("--boundaries", opt.Boundaries).
+		KV("--boundaries-action", opt.BoundariesAction).
+		Build()
+
+	response, err := s.sendCommand(commands.Focus, cmdArgs)
 	if err != nil {
 		return err
 	}
@@ -222,11 +307,9 @@ func (s *Service) SetFocusByDFS(direction string, opts ...SetFocusOpts) error {
 //	// Focus by DFS index
 //	err := focusService.SetFocusByDFSIndex(0)
 func (s *Service) SetFocusByDFSIndex(dfsIndex int) error {
-	cmdArgs := []string{
-		"--dfs-index", fmt.Sprintf("%d", dfsIndex),
-	}
+	cmdArgs := args.New().KV("--dfs-index", dfsIndex).Build()
 
-	response, err := s.client.SendCommand("focus", cmdArgs)
+	response, err := s.sendCommand(commands.Focus, cmdArgs)
 	if err != nil {
 		return err
 	}
@@ -258,13 +341,15 @@ func (s *Service) SetFocusByDFSIndex(dfsIndex int) error {
 //	    workspaceService.MoveBackAndForth()
 //	}
 //
+// BackAndForthOrWorkspace implements this pattern as a single call.
+//
 // Returns an error if the operation fails (e.g., if the previous window was closed).
 //
 // Usage:
 //
 //	err := focusService.FocusBackAndForth()
 func (s *Service) FocusBackAndForth() error {
-	response, err := s.client.SendCommand("focus-back-and-forth", []string{})
+	response, err := s.sendCommand(commands.FocusBackAndForth, []string{})
 	if err != nil {
 		return err
 	}
@@ -276,6 +361,142 @@ func (s *Service) FocusBackAndForth() error {
 	return nil
 }
 
+// FocusMonitor focuses a monitor.
+//
+// Supports three modes:
+//  1. Direction-based: Focus monitor in direction relative to the focused monitor (left|down|up|right)
+//  2. Order-based: Focus next or previous monitor (next|prev)
+//  3. Pattern-based: Focus the first monitor matching pattern(s)
+//
+// Exactly one of target.Direction, target.Order, or target.Patterns must be specified.
+//
+// It is equivalent to running the command:
+//
+//	aerospace focus-monitor [--wrap-around] (left|down|up|right)
+//	aerospace focus-monitor [--wrap-around] (next|prev)
+//	aerospace focus-monitor <monitor-pattern>...
+//
+// Returns an error if the operation fails.
+//
+// Usage:
+//
+//	// Focus monitor on the left
+//	err := focusService.FocusMonitor(focus.FocusMonitorArgs{
+//	    Direction: "left",
+//	}, focus.FocusMonitorOpts{})
+//
+//	// Focus next monitor with wrap around
+//	err := focusService.FocusMonitor(focus.FocusMonitorArgs{
+//	    Order: "next",
+//	}, focus.FocusMonitorOpts{
+//	    WrapAround: true,
+//	})
+//
+//	// Focus monitor matching pattern
+//	err := focusService.FocusMonitor(focus.FocusMonitorArgs{
+//	    Patterns: []string{"HDMI-1", "DP-1"},
+//	}, focus.FocusMonitorOpts{})
+func (s *Service) FocusMonitor(target FocusMonitorArgs, opts FocusMonitorOpts) error {
+	// Validate that exactly one mode is specified
+	if err := validate.ExactlyOne(
+		validate.Set("Direction", target.Direction != ""),
+		validate.Set("Order", target.Order != ""),
+		validate.Set("Patterns", len(target.Patterns) > 0),
+	); err != nil {
+		return err
+	}
+	if target.Direction != "" {
+		if err := validate.OneOf("direction", target.Direction, validate.Directions...); err != nil {
+			return err
+		}
+	}
+	if target.Order != "" {
+		if err := validate.OneOf("order", target.Order, validate.Orders...); err != nil {
+			return err
+		}
+	}
+
+	if opts.WrapAround && len(target.Patterns) > 0 {
+		return fmt.Errorf("wrap around cannot be used with monitor patterns")
+	}
+
+	// Build command arguments
+	builder := args.New().FlagIf(opts.WrapAround, "--wrap-around")
+
+	// Add the mode-specific argument(s)
+	if target.Direction != "" {
+		builder.Values(target.Direction)
+	} else if target.Order != "" {
+		builder.Values(target.Order)
+	} else if len(target.Patterns) > 0 {
+		builder.Values(target.Patterns...)
+	}
+	cmdArgs := builder.Build()
+
+	response, err := s.sendCommand(commands.FocusMonitor, cmdArgs)
+	if err != nil {
+		return err
+	}
+
+	if response.ExitCode != 0 {
+		return fmt.Errorf("failed to focus monitor: %s", response.StdErr)
+	}
+
+	return nil
+}
+
+// MoveMouse moves the mouse to the requested position.
+//
+// Target must be one of: "monitor-lazy-center", "monitor-force-center",
+// "window-lazy-center", "window-force-center" (see the Mouse* constants).
+//
+// It is equivalent to running the command:
+//
+//	aerospace move-mouse [--fail-if-noop] <mouse-position>
+//
+// Useful to implement focus-follows-mouse style integrations,
+// e.g. centering the mouse after changing focus.
+//
+// Returns an error if the operation fails.
+//
+// Usage:
+//
+//	err := focusService.MoveMouse(focus.MouseWindowLazyCenter)
+//
+//	// Fail if the mouse is already inside the window
+//	err := focusService.MoveMouse(focus.MouseWindowLazyCenter, focus.MoveMouseOpts{
+//	    FailIfNoop: true,
+//	})
+func (s *Service) MoveMouse(target string, opts ...MoveMouseOpts) error {
+	if err := validate.OneOf(
+		"mouse position", target,
+		MouseMonitorLazyCenter, MouseMonitorForceCenter, MouseWindowLazyCenter, MouseWindowForceCenter,
+	); err != nil {
+		return err
+	}
+
+	var opt MoveMouseOpts
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+
+	cmdArgs := args.New().
+		FlagIf(opt.FailIfNoop, "--fail-if-noop").
+		Values(target).
+		Build()
+
+	response, err := s.sendCommand(commands.MoveMouse, cmdArgs)
+	if err != nil {
+		return err
+	}
+
+	if response.ExitCode != 0 {
+		return fmt.Errorf("failed to move mouse to %s\n%s", target, response.StdErr)
+	}
+
+	return nil
+}
+
 // Helper functions for creating pointers (useful for API usage)
 
 // IntPtr returns a pointer to the given int value.
@@ -1,6 +1,7 @@
 package focus
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
@@ -119,6 +120,26 @@ func TestFocusService(t *testing.T) {
 			}
 		})
 
+		t.Run("SetFocusByDirection with typed boundaries", func(ttt *testing.T) {
+			ctrl := gomock.NewController(ttt)
+			defer ctrl.Finish()
+
+			mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+			service := NewService(mockConn)
+
+			mockConn.EXPECT().
+				SendCommand("focus", []string{"right", "--boundaries", "all-monitors-outer-frame", "--boundaries-action", "wrap-around-all-monitors"}).
+				Return(&client.Response{ExitCode: 0}, nil)
+
+			err := service.SetFocusByDirection("right", SetFocusOpts{
+				Boundaries:       BoundariesAllMonitorsOuterFrame.Ptr(),
+				BoundariesAction: ActionWrapAllMonitors.Ptr(),
+			})
+			if err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+		})
+
 		t.Run("SetFocusByDFS", func(ttt *testing.T) {
 			ctrl := gomock.NewController(ttt)
 			defer ctrl.Finish()
@@ -223,6 +244,94 @@ func TestFocusService(t *testing.T) {
 			}
 		})
 
+		t.Run("FocusMonitor", func(ttt *testing.T) {
+			testCases := []struct {
+				title    string
+				args     FocusMonitorArgs
+				opts     FocusMonitorOpts
+				expected []string
+			}{
+				{
+					title:    "direction mode",
+					args:     FocusMonitorArgs{Direction: "left"},
+					expected: []string{"left"},
+				},
+				{
+					title:    "order mode with wrap-around",
+					args:     FocusMonitorArgs{Order: "next"},
+					opts:     FocusMonitorOpts{WrapAround: true},
+					expected: []string{"--wrap-around", "next"},
+				},
+				{
+					title:    "pattern mode",
+					args:     FocusMonitorArgs{Patterns: []string{"HDMI-1", "DP-1"}},
+					expected: []string{"HDMI-1", "DP-1"},
+				},
+			}
+			for _, tc := range testCases {
+				ttt.Run(tc.title, func(tttt *testing.T) {
+					ctrl := gomock.NewController(tttt)
+					defer ctrl.Finish()
+
+					mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+					service := NewService(mockConn)
+
+					mockConn.EXPECT().
+						SendCommand("focus-monitor", tc.expected).
+						Return(&client.Response{ExitCode: 0}, nil)
+
+					err := service.FocusMonitor(tc.args, tc.opts)
+					if err != nil {
+						tttt.Fatalf("unexpected error: %v", err)
+					}
+				})
+			}
+		})
+
+		t.Run("MoveMouse", func(ttt *testing.T) {
+			targets := []string{
+				MouseMonitorLazyCenter,
+				MouseMonitorForceCenter,
+				MouseWindowLazyCenter,
+				MouseWindowForceCenter,
+			}
+			for _, target := range targets {
+				ttt.Run(target, func(tttt *testing.T) {
+					ctrl := gomock.NewController(tttt)
+					defer ctrl.Finish()
+
+					mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+					service := NewService(mockConn)
+
+					mockConn.EXPECT().
+						SendCommand("move-mouse", []string{target}).
+						Return(&client.Response{ExitCode: 0}, nil)
+
+					err := service.MoveMouse(target)
+					if err != nil {
+						tttt.Fatalf("unexpected error: %v", err)
+					}
+				})
+			}
+		})
+
+		t.Run("MoveMouse with FailIfNoop", func(ttt *testing.T) {
+			ctrl := gomock.NewController(ttt)
+			defer ctrl.Finish()
+
+			mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+			service := NewService(mockConn)
+
+			mockConn.EXPECT().
+				SendCommand("move-mouse", []string{"--fail-if-noop", "window-lazy-center"}).
+				Return(&client.Response{ExitCode: 0}, nil)
+
+			err := service.MoveMouse(MouseWindowLazyCenter, MoveMouseOpts{FailIfNoop: true})
+			if err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+		})
+
 		t.Run("SetFocusByDirection - all directions", func(ttt *testing.T) {
 			ctrl := gomock.NewController(ttt)
 			defer ctrl.Finish()
@@ -317,6 +426,40 @@ func TestFocusService(t *testing.T) {
 			}
 		})
 
+		t.Run("SetFocusByDirection with invalid boundaries", func(ttt *testing.T) {
+			ctrl := gomock.NewController(ttt)
+			defer ctrl.Finish()
+
+			mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+			service := NewService(mockConn)
+
+			boundaries := "monitor"
+			err := service.SetFocusByDirection("left", SetFocusOpts{Boundaries: &boundaries})
+			if err == nil {
+				ttt.Fatal("expected error, got nil")
+			}
+			if err.Error() != `invalid boundaries "monitor", must be one of: workspace, all-monitors-outer-frame` {
+				ttt.Errorf("unexpected error message: %v", err)
+			}
+		})
+
+		t.Run("SetFocusByDFS with invalid boundaries action", func(ttt *testing.T) {
+			ctrl := gomock.NewController(ttt)
+			defer ctrl.Finish()
+
+			mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+			service := NewService(mockConn)
+
+			action := "wrap"
+			err := service.SetFocusByDFS("dfs-next", SetFocusOpts{BoundariesAction: &action})
+			if err == nil {
+				ttt.Fatal("expected error, got nil")
+			}
+			if err.Error() != `invalid boundaries action "wrap", must be one of: stop, fail, wrap-around-the-workspace, wrap-around-all-monitors` {
+				ttt.Errorf("unexpected error message: %v", err)
+			}
+		})
+
 		t.Run("Command execution error", func(ttt *testing.T) {
 			ctrl := gomock.NewController(ttt)
 			defer ctrl.Finish()
@@ -407,6 +550,100 @@ func TestFocusService(t *testing.T) {
 				ttt.Fatal("expected error, got nil")
 			}
 		})
+		t.Run("FocusMonitor validation errors", func(ttt *testing.T) {
+			testCases := []struct {
+				title    string
+				args     FocusMonitorArgs
+				opts     FocusMonitorOpts
+				expected string
+			}{
+				{
+					title:    "no mode specified",
+					expected: "must specify exactly one of: Direction, Order, or Patterns",
+				},
+				{
+					title:    "multiple modes specified",
+					args:     FocusMonitorArgs{Direction: "left", Order: "next"},
+					expected: "cannot specify multiple modes; must specify exactly one of: Direction, Order, or Patterns",
+				},
+				{
+					title:    "invalid direction",
+					args:     FocusMonitorArgs{Direction: "invalid"},
+					expected: `invalid direction "invalid", must be one of: left, down, up, right`,
+				},
+				{
+					title:    "invalid order",
+					args:     FocusMonitorArgs{Order: "invalid"},
+					expected: `invalid order "invalid", must be one of: next, prev`,
+				},
+				{
+					title:    "wrap-around with patterns",
+					args:     FocusMonitorArgs{Patterns: []string{"HDMI-1"}},
+					opts:     FocusMonitorOpts{WrapAround: true},
+					expected: "wrap around cannot be used with monitor patterns",
+				},
+			}
+			for _, tc := range testCases {
+				ttt.Run(tc.title, func(tttt *testing.T) {
+					ctrl := gomock.NewController(tttt)
+					defer ctrl.Finish()
+
+					mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+					service := NewService(mockConn)
+
+					err := service.FocusMonitor(tc.args, tc.opts)
+					if err == nil {
+						tttt.Fatal("expected error, got nil")
+					}
+					if err.Error() != tc.expected {
+						tttt.Errorf("unexpected error message: %v", err)
+					}
+				})
+			}
+		})
+
+		t.Run("MoveMouse with invalid target", func(ttt *testing.T) {
+			ctrl := gomock.NewController(ttt)
+			defer ctrl.Finish()
+
+			mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+			service := NewService(mockConn)
+
+			err := service.MoveMouse("top-left")
+			if err == nil {
+				ttt.Fatal("expected error, got nil")
+			}
+			expected := `invalid mouse position "top-left", must be one of: monitor-lazy-center, monitor-force-center, window-lazy-center, window-force-center`
+			if err.Error() != expected {
+				ttt.Errorf("unexpected error message: %v", err)
+			}
+		})
+
+		t.Run("FocusMonitor non-zero exit code", func(ttt *testing.T) {
+			ctrl := gomock.NewController(ttt)
+			defer ctrl.Finish()
+
+			mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+			service := NewService(mockConn)
+
+			mockConn.EXPECT().
+				SendCommand("focus-monitor", []string{"right"}).
+				Return(
+					&client.Response{
+						StdErr:   "no monitor found",
+						ExitCode: 1,
+					},
+					nil,
+				)
+
+			err := service.FocusMonitor(FocusMonitorArgs{Direction: "right"}, FocusMonitorOpts{})
+			if err == nil {
+				ttt.Fatal("expected error for non-zero exit code, got nil")
+			}
+			if err.Error() != "failed to focus monitor: no monitor found" {
+				ttt.Errorf("unexpected error message: %v", err)
+			}
+		})
 	})
 }
 
@@ -439,3 +676,21 @@ func TestHelperFunctions(t *testing.T) {
 		}
 	})
 }
+
+func TestServiceWithContext(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+	service := NewService(mockConn).WithContext(ctx)
+
+	mockConn.EXPECT().
+		SendCommandContext(ctx, "focus-back-and-forth", []string{}).
+		Return(&client.Response{StdOut: ""}, nil)
+
+	err := service.FocusBackAndForth()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
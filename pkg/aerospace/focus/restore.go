@@ -0,0 +1,108 @@
+package focus
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/cristianoliveira/aerospace-ipc/pkg/client"
+	"github.com/cristianoliveira/aerospace-ipc/pkg/client/args"
+	"github.com/cristianoliveira/aerospace-ipc/pkg/client/commands"
+)
+
+// ErrNoFocusHistory indicates that the service has no WindowHistory, see WithFocusHistory.
+var ErrNoFocusHistory = errors.New("no focus history")
+
+// WindowHistory provides the window last focused on every workspace,
+// tracked on the client side since AeroSpace does not expose it.
+//
+// It is implemented by history.Tracker.
+type WindowHistory interface {
+	// LastFocusedWindow returns the window last focused on the workspace,
+	// and false if no window was recorded there.
+	LastFocusedWindow(workspace string) (client.WindowID, bool)
+}
+
+// WithFocusHistory returns a copy of the service using history for
+// RestoreWorkspaceFocus. The services of aerospace.Client use Client.History().
+//
+// Usage:
+//
+//	tracker := history.NewTracker(conn)
+//	go tracker.Run(ctx)
+//	focusService := focus.NewService(conn).WithFocusHistory(tracker)
+func (s *Service) WithFocusHistory(history WindowHistory) *Service {
+	return &Service{client: s.client, ctx: s.ctx, history: history}
+}
+
+// RestoreWorkspaceFocus focuses the workspace and then the window last focused on it,
+// so switching workspaces lands on the window the user was last using there.
+//
+// When no window was recorded on the workspace, or the window has since been
+// closed or moved away, the workspace is focused as AeroSpace would.
+//
+// It is equivalent to running the commands:
+//
+//	aerospace workspace <workspace>
+//	aerospace list-windows --workspace <workspace> --json --format %{window-id}
+//	aerospace focus --window-id <window-id>
+//
+// Returns ErrNoFocusHistory if the service has no WindowHistory.
+//
+// Usage:
+//
+//	err := client.Focus().RestoreWorkspaceFocus("2")
+func (s *Service) RestoreWorkspaceFocus(workspace string) error {
+	if s.history == nil {
+		return ErrNoFocusHistory
+	}
+
+	response, err := s.sendCommand(commands.Workspace, args.New().Values(workspace).Build())
+	if err != nil {
+		return err
+	}
+	if response.ExitCode != 0 {
+		return fmt.Errorf("failed to focus workspace %s\n%s", workspace, response.StdErr)
+	}
+
+	windowID, ok := s.history.LastFocusedWindow(workspace)
+	if !ok {
+		return nil
+	}
+
+	onWorkspace, err := s.isOnWorkspace(windowID, workspace)
+	if err != nil || !onWorkspace {
+		return err
+	}
+	return s.SetFocusByWindowID(windowID)
+}
+
+// isOnWorkspace reports whether the window is still on the workspace.
+func (s *Service) isOnWorkspace(windowID client.WindowID, workspace string) (bool, error) {
+	cmdArgs := args.New().
+		KV("--workspace", workspace).
+		Flag("--json").
+		KV("--format", "%{window-id}").
+		Build()
+
+	response, err := s.sendCommand(commands.ListWindows, cmdArgs)
+	if err != nil {
+		return false, err
+	}
+	if response.ExitCode != 0 {
+		return false, fmt.Errorf("failed to list windows of workspace %s\n%s", workspace, response.StdErr)
+	}
+
+	var windows []struct {
+		WindowID client.WindowID `json:"window-id"`
+	}
+	if err := json.Unmarshal([]byte(response.StdOut), &windows); err != nil {
+		return false, fmt.Errorf("failed to unmarshal windows: %w\nOut:%s\nErr:%s", err, response.StdOut, response.StdErr)
+	}
+	for _, window := range windows {
+		if window.WindowID == windowID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,106 @@
+package focus
+
+import (
+	"errors"
+	"testing"
+
+	mock_client "github.com/cristianoliveira/aerospace-ipc/internal/mocks"
+	"github.com/cristianoliveira/aerospace-ipc/pkg/client"
+	"go.uber.org/mock/gomock"
+)
+
+// staticHistory is a WindowHistory of fixed windows by workspace.
+type staticHistory map[string]client.WindowID
+
+func (h staticHistory) LastFocusedWindow(workspace string) (client.WindowID, bool) {
+	windowID, ok := h[workspace]
+	return windowID, ok
+}
+
+func TestRestoreWorkspaceFocus(t *testing.T) {
+	history := staticHistory{"2": 42}
+	listArgs := []string{"--workspace", "2", "--json", "--format", "%{window-id}"}
+
+	t.Run("Happy path", func(tt *testing.T) {
+		tt.Run("focuses the last focused window", func(ttt *testing.T) {
+			ctrl := gomock.NewController(ttt)
+			defer ctrl.Finish()
+
+			mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+			gomock.InOrder(
+				mockConn.EXPECT().
+					SendCommand("workspace", []string{"2"}).
+					Return(&client.Response{}, nil),
+				mockConn.EXPECT().
+					SendCommand("list-windows", listArgs).
+					Return(&client.Response{StdOut: `[{"window-id": 7}, {"window-id": 42}]`}, nil),
+				mockConn.EXPECT().
+					SendCommand("focus", []string{"--window-id", "42"}).
+					Return(&client.Response{}, nil),
+			)
+
+			if err := NewService(mockConn).WithFocusHistory(history).RestoreWorkspaceFocus("2"); err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+		})
+
+		tt.Run("only focuses the workspace without a recorded window", func(ttt *testing.T) {
+			ctrl := gomock.NewController(ttt)
+			defer ctrl.Finish()
+
+			mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+			mockConn.EXPECT().
+				SendCommand("workspace", []string{"3"}).
+				Return(&client.Response{}, nil)
+
+			if err := NewService(mockConn).WithFocusHistory(history).RestoreWorkspaceFocus("3"); err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+		})
+
+		tt.Run("only focuses the workspace once the window left it", func(ttt *testing.T) {
+			ctrl := gomock.NewController(ttt)
+			defer ctrl.Finish()
+
+			mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+			gomock.InOrder(
+				mockConn.EXPECT().
+					SendCommand("workspace", []string{"2"}).
+					Return(&client.Response{}, nil),
+				mockConn.EXPECT().
+					SendCommand("list-windows", listArgs).
+					Return(&client.Response{StdOut: `[{"window-id": 7}]`}, nil),
+			)
+
+			if err := NewService(mockConn).WithFocusHistory(history).RestoreWorkspaceFocus("2"); err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+		})
+	})
+
+	t.Run("Error cases", func(tt *testing.T) {
+		tt.Run("without focus history", func(ttt *testing.T) {
+			ctrl := gomock.NewController(ttt)
+			defer ctrl.Finish()
+
+			mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+			if err := NewService(mockConn).RestoreWorkspaceFocus("2"); !errors.Is(err, ErrNoFocusHistory) {
+				ttt.Fatalf("expected ErrNoFocusHistory, got %v", err)
+			}
+		})
+
+		tt.Run("when the workspace cannot be focused", func(ttt *testing.T) {
+			ctrl := gomock.NewController(ttt)
+			defer ctrl.Finish()
+
+			mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+			mockConn.EXPECT().
+				SendCommand("workspace", []string{"2"}).
+				Return(&client.Response{ExitCode: 1, StdErr: "boom"}, nil)
+
+			if err := NewService(mockConn).WithFocusHistory(history).RestoreWorkspaceFocus("2"); err == nil {
+				ttt.Fatal("expected an error")
+			}
+		})
+	})
+}
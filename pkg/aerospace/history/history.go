@@ -0,0 +1,235 @@
+// Package history tracks the focus on the client side.
+//
+// AeroSpace only remembers the previously focused window and workspace, for
+// focus-back-and-forth and workspace-back-and-forth. A Tracker polls the focus,
+// or is fed by an event source, and keeps a bounded history of the most recently
+// focused windows and workspaces.
+package history
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/cristianoliveira/aerospace-ipc/pkg/aerospace/windows"
+	"github.com/cristianoliveira/aerospace-ipc/pkg/aerospace/workspaces"
+	"github.com/cristianoliveira/aerospace-ipc/pkg/client"
+)
+
+// Defaults of TrackerOpts.
+const (
+	DefaultSize         = 32
+	DefaultPollInterval = 250 * time.Millisecond
+)
+
+// TrackerOpts contains optional parameters for NewTracker.
+type TrackerOpts struct {
+	// Size is the maximum number of windows, and of workspaces, remembered. Defaults to DefaultSize.
+	Size int
+
+	// PollInterval is how often Run queries the focus. Defaults to DefaultPollInterval.
+	PollInterval time.Duration
+
+	// OnError is called when querying the focus fails. Run keeps polling.
+	OnError func(err error)
+}
+
+// Tracker records the history of the focused windows and workspaces.
+//
+// The history holds distinct windows, and workspaces, most recently focused first:
+// focusing a window again moves it to the front. It also remembers the last
+// focused window of every workspace. It is safe for concurrent use.
+type Tracker struct {
+	client client.AeroSpaceConnection
+	opts   TrackerOpts
+
+	mu         sync.Mutex
+	windows    []windows.Window
+	workspaces []string
+	// lastByWorkspace is the last focused window of every workspace.
+	lastByWorkspace map[string]client.WindowID
+}
+
+// NewTracker creates a tracker querying the focus on the given connection.
+//
+// The tracker is opt-in: nothing is recorded until Run is started or Record is called.
+//
+// Usage:
+//
+//	tracker := history.NewTracker(conn)
+//	go tracker.Run(ctx)
+func NewTracker(client client.AeroSpaceConnection, opts ...TrackerOpts) *Tracker {
+	var opt TrackerOpts
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	if opt.Size <= 0 {
+		opt.Size = DefaultSize
+	}
+	if opt.PollInterval <= 0 {
+		opt.PollInterval = DefaultPollInterval
+	}
+	return &Tracker{client: client, opts: opt, lastByWorkspace: map[string]windows.WindowID{}}
+}
+
+// Run polls the focus and records it until ctx is done.
+// It then returns the cause of ctx being done.
+//
+// It is equivalent to running, every poll interval, the commands:
+//
+//	aerospace list-windows --focused --json --format <format>
+//	aerospace list-workspaces --focused --json --format <format>
+//
+// Usage:
+//
+//	ctx, cancel := context.WithCancel(context.Background())
+//	defer cancel()
+//	go tracker.Run(ctx)
+func (t *Tracker) Run(ctx context.Context) error {
+	ticker := time.NewTicker(t.opts.PollInterval)
+	defer ticker.Stop()
+	for {
+		t.poll(ctx)
+
+		select {
+		case <-ctx.Done():
+			return context.Cause(ctx)
+		case <-ticker.C:
+		}
+	}
+}
+
+// poll queries the focus once and records it.
+func (t *Tracker) poll(ctx context.Context) {
+	focused, err := windows.NewService(t.client).WithContext(ctx).GetWindowsWithOpts(windows.ListWindowsOpts{Focused: true})
+	if err != nil {
+		if ctx.Err() == nil && t.opts.OnError != nil {
+			t.opts.OnError(fmt.Errorf("failed to query the focused window\n%w", err))
+		}
+		return
+	}
+	if len(focused) > 0 {
+		t.Record(focused[0])
+	}
+
+	workspace, err := workspaces.NewService(t.client).WithContext(ctx).GetFocusedWorkspace()
+	if err != nil {
+		if ctx.Err() == nil && t.opts.OnError != nil {
+			t.opts.OnError(fmt.Errorf("failed to query the focused workspace\n%w", err))
+		}
+		return
+	}
+	t.RecordWorkspace(workspace.Workspace)
+}
+
+// Record records the window as focused, e.g. when fed by an event source instead of Run.
+func (t *Tracker) Record(window windows.Window) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if window.Workspace != "" {
+		t.lastByWorkspace[window.Workspace] = window.WindowID
+	}
+	if len(t.windows) > 0 && t.windows[0].WindowID == window.WindowID {
+		// Refresh the details of the window, e.g. its title.
+		t.windows[0] = window
+		return
+	}
+	t.windows = slices.DeleteFunc(t.windows, func(w windows.Window) bool {
+		return w.WindowID == window.WindowID
+	})
+	t.windows = slices.Insert(t.windows, 0, window)
+	if len(t.windows) > t.opts.Size {
+		t.windows = t.windows[:t.opts.Size]
+	}
+}
+
+// RecordWorkspace records the workspace as focused, e.g. when fed by an event source instead of Run.
+func (t *Tracker) RecordWorkspace(workspace string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if len(t.workspaces) > 0 && t.workspaces[0] == workspace {
+		return
+	}
+	t.workspaces = slices.DeleteFunc(t.workspaces, func(w string) bool {
+		return w == workspace
+	})
+	t.workspaces = slices.Insert(t.workspaces, 0, workspace)
+	if len(t.workspaces) > t.opts.Size {
+		t.workspaces = t.workspaces[:t.opts.Size]
+	}
+}
+
+// Forget removes the window from the history, e.g. once it is closed.
+func (t *Tracker) Forget(windowID client.WindowID) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.windows = slices.DeleteFunc(t.windows, func(w windows.Window) bool {
+		return w.WindowID == windowID
+	})
+	maps.DeleteFunc(t.lastByWorkspace, func(_ string, id client.WindowID) bool {
+		return id == windowID
+	})
+}
+
+// Current returns the most recently focused window, or nil if none was recorded.
+func (t *Tracker) Current() *windows.Window {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if len(t.windows) == 0 {
+		return nil
+	}
+	current := t.windows[0]
+	return &current
+}
+
+// PreviousWindows returns up to n windows focused before the current one,
+// most recently focused first.
+//
+// Usage:
+//
+//	previous := client.History().PreviousWindows(3)
+//	for _, window := range previous {
+//	    fmt.Println(window)
+//	}
+func (t *Tracker) PreviousWindows(n int) []windows.Window {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if n <= 0 || len(t.windows) < 2 {
+		return nil
+	}
+	previous := t.windows[1:]
+	return slices.Clone(previous[:min(n, len(previous))])
+}
+
+// RecentlyFocusedWorkspaces returns up to n workspaces, most recently focused first,
+// starting with the focused one.
+//
+// Usage:
+//
+//	recent := client.History().RecentlyFocusedWorkspaces(3)
+func (t *Tracker) RecentlyFocusedWorkspaces(n int) []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if n <= 0 || len(t.workspaces) == 0 {
+		return nil
+	}
+	return slices.Clone(t.workspaces[:min(n, len(t.workspaces))])
+}
+
+// LastFocusedWindow returns the window last focused on the workspace,
+// and false if no window was recorded there.
+//
+// Usage:
+//
+//	windowID, ok := client.History().LastFocusedWindow("2")
+func (t *Tracker) LastFocusedWindow(workspace string) (client.WindowID, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	windowID, ok := t.lastByWorkspace[workspace]
+	return windowID, ok
+}
@@ -0,0 +1,146 @@
+package history
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cristianoliveira/aerospace-ipc/pkg/aerospace/windows"
+	"github.com/cristianoliveira/aerospace-ipc/pkg/client"
+)
+
+func windowIDs(list []windows.Window) []windows.WindowID {
+	ids := []windows.WindowID{}
+	for _, window := range list {
+		ids = append(ids, window.WindowID)
+	}
+	return ids
+}
+
+func TestTracker(t *testing.T) {
+	t.Run("Happy path", func(tt *testing.T) {
+		tt.Run("keeps distinct windows, most recent first", func(ttt *testing.T) {
+			tracker := NewTracker(client.NewFakeConnection(client.FakeState{}), TrackerOpts{Size: 3})
+			for _, id := range []windows.WindowID{1, 2, 2, 3, 1, 4} {
+				tracker.Record(windows.Window{WindowID: id})
+			}
+
+			if current := tracker.Current(); current == nil || current.WindowID != 4 {
+				ttt.Fatalf("expected window 4 to be current, got %+v", current)
+			}
+			if ids := windowIDs(tracker.PreviousWindows(5)); !reflect.DeepEqual(ids, []windows.WindowID{1, 3}) {
+				ttt.Fatalf("expected windows [1 3], got %v", ids)
+			}
+			if ids := windowIDs(tracker.PreviousWindows(1)); !reflect.DeepEqual(ids, []windows.WindowID{1}) {
+				ttt.Fatalf("expected windows [1], got %v", ids)
+			}
+
+			tracker.Forget(1)
+			if ids := windowIDs(tracker.PreviousWindows(5)); !reflect.DeepEqual(ids, []windows.WindowID{3}) {
+				ttt.Fatalf("expected windows [3], got %v", ids)
+			}
+		})
+
+		tt.Run("keeps distinct workspaces, most recent first", func(ttt *testing.T) {
+			tracker := NewTracker(client.NewFakeConnection(client.FakeState{}), TrackerOpts{Size: 3})
+			for _, workspace := range []string{"1", "2", "2", "3", "1", "4"} {
+				tracker.RecordWorkspace(workspace)
+			}
+
+			if recent := tracker.RecentlyFocusedWorkspaces(5); !reflect.DeepEqual(recent, []string{"4", "1", "3"}) {
+				ttt.Fatalf("expected workspaces [4 1 3], got %v", recent)
+			}
+			if recent := tracker.RecentlyFocusedWorkspaces(2); !reflect.DeepEqual(recent, []string{"4", "1"}) {
+				ttt.Fatalf("expected workspaces [4 1], got %v", recent)
+			}
+		})
+
+		tt.Run("remembers the last focused window of every workspace", func(ttt *testing.T) {
+			tracker := NewTracker(client.NewFakeConnection(client.FakeState{}))
+			tracker.Record(windows.Window{WindowID: 1, Workspace: "1"})
+			tracker.Record(windows.Window{WindowID: 2, Workspace: "2"})
+			tracker.Record(windows.Window{WindowID: 3, Workspace: "1"})
+
+			if id, ok := tracker.LastFocusedWindow("1"); !ok || id != 3 {
+				ttt.Fatalf("expected window 3 on workspace 1, got %d %v", id, ok)
+			}
+			if id, ok := tracker.LastFocusedWindow("2"); !ok || id != 2 {
+				ttt.Fatalf("expected window 2 on workspace 2, got %d %v", id, ok)
+			}
+			if _, ok := tracker.LastFocusedWindow("3"); ok {
+				ttt.Fatal("expected no window on workspace 3")
+			}
+
+			tracker.Forget(2)
+			if _, ok := tracker.LastFocusedWindow("2"); ok {
+				ttt.Fatal("expected the forgotten window to be removed")
+			}
+		})
+
+		tt.Run("polls the focused window", func(ttt *testing.T) {
+			conn := client.NewFakeConnection(client.FakeState{
+				Windows: []client.FakeWindow{
+					{ID: 1, AppName: "Ghostty", Workspace: "1"},
+					{ID: 2, AppName: "Safari", Workspace: "2"},
+				},
+				FocusedWindowID: 1,
+			})
+			tracker := NewTracker(conn)
+			ctx := context.Background()
+
+			tracker.poll(ctx)
+			if _, err := conn.SendCommand("focus", []string{"--window-id", "2"}); err != nil {
+				ttt.Fatal(err)
+			}
+			tracker.poll(ctx)
+
+			if current := tracker.Current(); current == nil || current.AppName != "Safari" {
+				ttt.Fatalf("expected Safari to be current, got %+v", current)
+			}
+			if ids := windowIDs(tracker.PreviousWindows(1)); !reflect.DeepEqual(ids, []windows.WindowID{1}) {
+				ttt.Fatalf("expected windows [1], got %v", ids)
+			}
+			if recent := tracker.RecentlyFocusedWorkspaces(2); !reflect.DeepEqual(recent, []string{"2", "1"}) {
+				ttt.Fatalf("expected workspaces [2 1], got %v", recent)
+			}
+		})
+
+		tt.Run("Run stops once the context is done", func(ttt *testing.T) {
+			conn := client.NewFakeConnection(client.FakeState{
+				Windows:         []client.FakeWindow{{ID: 1, Workspace: "1"}},
+				FocusedWindowID: 1,
+			})
+			tracker := NewTracker(conn, TrackerOpts{PollInterval: time.Millisecond})
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+			defer cancel()
+			if err := tracker.Run(ctx); !errors.Is(err, context.DeadlineExceeded) {
+				ttt.Fatalf("expected context.DeadlineExceeded, got %v", err)
+			}
+			if tracker.Current() == nil {
+				ttt.Fatal("expected the focused window to be recorded")
+			}
+		})
+	})
+
+	t.Run("Error cases", func(tt *testing.T) {
+		tt.Run("reports failing polls", func(ttt *testing.T) {
+			conn := client.NewFakeConnection(client.FakeState{})
+			if err := conn.CloseConnection(); err != nil {
+				ttt.Fatal(err)
+			}
+
+			var reported error
+			tracker := NewTracker(conn, TrackerOpts{OnError: func(err error) { reported = err }})
+			tracker.poll(context.Background())
+			if !errors.Is(reported, client.ErrClosed) {
+				ttt.Fatalf("expected client.ErrClosed, got %v", reported)
+			}
+			if tracker.Current() != nil || tracker.PreviousWindows(1) != nil {
+				ttt.Fatal("expected an empty history")
+			}
+		})
+	})
+}
@@ -1,26 +1,45 @@
 package layout
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/cristianoliveira/aerospace-ipc/pkg/client"
+	"github.com/cristianoliveira/aerospace-ipc/pkg/client/args"
+	"github.com/cristianoliveira/aerospace-ipc/pkg/client/commands"
 )
 
 // SetLayoutOpts contains optional parameters for SetLayout.
 type SetLayoutOpts struct {
 	// WindowID specifies the window ID to set layout for. If not set, the focused window is used.
-	WindowID *int
+	WindowID *client.WindowID
 }
 
 // Service provides methods to interact with layout in AeroSpaceWM.
 type Service struct {
 	client client.AeroSpaceConnection
+	ctx    context.Context
 }
 
 // LayoutService defines the interface for layout operations in AeroSpaceWM.
 type LayoutService interface {
 	// SetLayout sets the layout for the focused window or a specific window.
 	SetLayout(layouts []string, opts ...SetLayoutOpts) error
+
+	// SetLayoutForWorkspace sets the layout of every window in the workspace.
+	SetLayoutForWorkspace(workspace string, layouts []string) error
+
+	// ToggleFloating toggles the focused window, or a specific window, between floating and tiling.
+	ToggleFloating(opts ...SetLayoutOpts) error
+
+	// ToggleOrientation toggles the parent container between horizontal and vertical.
+	ToggleOrientation(opts ...SetLayoutOpts) error
+
+	// Resize changes the size of the focused window or a specific window, in pixels.
+	Resize(dimension Dimension, amount string, opts ...ResizeOpts) error
+
+	// ResizeToPercent sets the width or height of a window to a percentage of its monitor.
+	ResizeToPercent(dimension Dimension, percent float64, opts ...ResizeOpts) error
 }
 
 // NewService creates a new layout service with the given AeroSpace client connection.
@@ -28,6 +47,29 @@ func NewService(client client.AeroSpaceConnection) *Service {
 	return &Service{client: client}
 }
 
+// WithContext returns a copy of the service that sends every command with ctx,
+// so requests can be cancelled or bounded by a deadline.
+//
+// Usage:
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+//	defer cancel()
+//	err := layoutService.WithContext(ctx).SetLayout([]string{"floating"})
+func (s *Service) WithContext(ctx context.Context) *Service {
+	if ctx == nil {
+		panic("ASSERTION: context cannot be nil")
+	}
+	return &Service{client: s.client, ctx: ctx}
+}
+
+// sendCommand sends a command using the service context, if any.
+func (s *Service) sendCommand(command string, args []string) (*client.Response, error) {
+	if s.ctx == nil {
+		return s.client.SendCommand(command, args)
+	}
+	return s.client.SendCommandContext(s.ctx, command, args)
+}
+
 // SetLayout sets the layout for the focused window or a specific window.
 //
 // Layouts can be one or more of: accordion|tiles|horizontal|vertical|h_accordion|v_accordion|h_tiles|v_tiles|tiling|floating
@@ -45,37 +87,35 @@ func NewService(client client.AeroSpaceConnection) *Service {
 //	// Set a single layout for focused window
 //	err := layoutService.SetLayout([]string{"floating"})
 //
-//	// Toggle between layouts (order doesn't matter)
+//	// Toggle between layouts (order doesn't matter), see also ToggleFloating and ToggleOrientation
 //	err := layoutService.SetLayout([]string{"floating", "tiling"})
 //	err := layoutService.SetLayout([]string{"horizontal", "vertical"})
 //
 //	// Set layout for specific window
 //	err := layoutService.SetLayout([]string{"floating"}, layout.SetLayoutOpts{
-//	    WindowID: layout.IntPtr(12345),
+//	    WindowID: windows.WindowID(12345).Ptr(),
 //	})
 //
 //	// Toggle layout for specific window
 //	err := layoutService.SetLayout([]string{"floating", "tiling"}, layout.SetLayoutOpts{
-//	    WindowID: layout.IntPtr(12345),
+//	    WindowID: windows.WindowID(12345).Ptr(),
 //	})
 func (s *Service) SetLayout(layouts []string, opts ...SetLayoutOpts) error {
 	if len(layouts) == 0 {
 		return fmt.Errorf("at least one layout must be provided")
 	}
 
-	cmdArgs := make([]string, 0, len(layouts)+2)
-	cmdArgs = append(cmdArgs, layouts...)
-
 	var opt SetLayoutOpts
 	if len(opts) > 0 {
 		opt = opts[0]
 	}
 
-	if opt.WindowID != nil {
-		cmdArgs = append(cmdArgs, "--window-id", fmt.Sprintf("%d", *opt.WindowID))
-	}
+	cmdArgs := args.New().
+		Values(layouts...).
+		KV("--window-id", opt.WindowID).
+		Build()
 
-	response, err := s.client.SendCommand("layout", cmdArgs)
+	response, err := s.sendCommand(commands.Layout, cmdArgs)
 	if err != nil {
 		return fmt.Errorf("failed to set layout(s) %v: %w", layouts, err)
 	}
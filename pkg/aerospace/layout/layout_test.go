@@ -1,6 +1,7 @@
 package layout
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
@@ -93,7 +94,7 @@ func TestLayoutService(t *testing.T) {
 			mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
 			service := NewService(mockConn)
 
-			windowID := 123456
+			windowID := client.WindowID(123456)
 			mockConn.EXPECT().
 				SendCommand("layout", []string{"floating", "--window-id", "123456"}).
 				Return(
@@ -121,7 +122,7 @@ func TestLayoutService(t *testing.T) {
 			mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
 			service := NewService(mockConn)
 
-			windowID := 123456
+			windowID := client.WindowID(123456)
 			mockConn.EXPECT().
 				SendCommand("layout", []string{"floating", "tiling", "--window-id", "123456"}).
 				Return(
@@ -219,3 +220,21 @@ func TestHelperFunctions(t *testing.T) {
 		}
 	})
 }
+
+func TestServiceWithContext(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+	service := NewService(mockConn).WithContext(ctx)
+
+	mockConn.EXPECT().
+		SendCommandContext(ctx, "layout", []string{"floating"}).
+		Return(&client.Response{StdOut: ""}, nil)
+
+	err := service.SetLayout([]string{"floating"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
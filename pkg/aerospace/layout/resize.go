@@ -0,0 +1,116 @@
+package layout
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/cristianoliveira/aerospace-ipc/internal/validate"
+	"github.com/cristianoliveira/aerospace-ipc/pkg/client"
+	"github.com/cristianoliveira/aerospace-ipc/pkg/client/args"
+	"github.com/cristianoliveira/aerospace-ipc/pkg/client/commands"
+)
+
+// Dimension is the dimension changed by Resize.
+type Dimension string
+
+// Possible Dimension values.
+const (
+	// DimensionSmart resizes along the orientation of the parent container.
+	DimensionSmart Dimension = "smart"
+	// DimensionSmartOpposite resizes against the orientation of the parent container.
+	DimensionSmartOpposite Dimension = "smart-opposite"
+	DimensionWidth         Dimension = "width"
+	DimensionHeight        Dimension = "height"
+)
+
+// ResizeOpts contains optional parameters for Resize and ResizeToPercent.
+type ResizeOpts struct {
+	// WindowID specifies the window ID to resize. If not set, the focused window is resized.
+	WindowID *client.WindowID
+
+	// MonitorSize is the size in pixels of the monitor along the resized dimension.
+	// Required by ResizeToPercent, since AeroSpace does not report monitor dimensions.
+	MonitorSize int
+}
+
+// Resize changes the size of the focused window, or of the given window.
+//
+// The amount is a number of pixels: "+100" and "-100" grow and shrink the
+// window, "600" sets its size.
+//
+// It is equivalent to running the command:
+//
+//	aerospace resize [--window-id <window-id>] (smart|smart-opposite|width|height) [+|-]<number>
+//
+// Usage:
+//
+//	err := layoutService.Resize(layout.DimensionSmart, "+50")
+func (s *Service) Resize(dimension Dimension, amount string, opts ...ResizeOpts) error {
+	err := validate.OneOf("dimension", dimension,
+		DimensionSmart, DimensionSmartOpposite, DimensionWidth, DimensionHeight,
+	)
+	if err != nil {
+		return err
+	}
+	if _, err := strconv.Atoi(amount); err != nil {
+		return fmt.Errorf("invalid resize amount %q, expected [+|-]<number>", amount)
+	}
+
+	var opt ResizeOpts
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+
+	cmdArgs := args.New().
+		KV("--window-id", opt.WindowID).
+		Values(string(dimension), amount).
+		Build()
+
+	response, err := s.sendCommand(commands.Resize, cmdArgs)
+	if err != nil {
+		return err
+	}
+
+	if response.ExitCode != 0 {
+		return fmt.Errorf("failed to resize window %s to %s\n%s", dimension, amount, response.StdErr)
+	}
+
+	return nil
+}
+
+// ResizeToPercent sets the width or height of the focused window, or of the
+// given window, to a percentage of its monitor.
+//
+// AeroSpace only accepts pixels and does not report monitor dimensions,
+// so opts.MonitorSize must be set to the monitor size along dimension.
+//
+// It is equivalent to running the command:
+//
+//	aerospace resize [--window-id <window-id>] (width|height) <percent * monitor size / 100>
+//
+// Usage:
+//
+//	// Half of a 2560px wide monitor
+//	err := layoutService.ResizeToPercent(layout.DimensionWidth, 50, layout.ResizeOpts{
+//	    MonitorSize: 2560,
+//	})
+func (s *Service) ResizeToPercent(dimension Dimension, percent float64, opts ...ResizeOpts) error {
+	if err := validate.OneOf("dimension", dimension, DimensionWidth, DimensionHeight); err != nil {
+		return err
+	}
+	if percent <= 0 || percent > 100 {
+		return fmt.Errorf("invalid percent %v, must be greater than 0 and at most 100", percent)
+	}
+
+	var opt ResizeOpts
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	if opt.MonitorSize <= 0 {
+		return fmt.Errorf("monitor size is required, AeroSpace does not report monitor dimensions")
+	}
+
+	pixels := int(math.Round(float64(opt.MonitorSize) * percent / 100))
+	return s.Resize(dimension, strconv.Itoa(pixels), opt)
+}
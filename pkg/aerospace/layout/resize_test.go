@@ -0,0 +1,106 @@
+package layout
+
+import (
+	"testing"
+
+	mock_client "github.com/cristianoliveira/aerospace-ipc/internal/mocks"
+	"github.com/cristianoliveira/aerospace-ipc/pkg/client"
+	"go.uber.org/mock/gomock"
+)
+
+func TestResize(t *testing.T) {
+	windowID := client.WindowID(42)
+
+	t.Run("Happy path", func(tt *testing.T) {
+		testCases := []struct {
+			title    string
+			resize   func(s *Service) error
+			expected []string
+		}{
+			{
+				title:    "Resize smart",
+				resize:   func(s *Service) error { return s.Resize(DimensionSmart, "+50") },
+				expected: []string{"smart", "+50"},
+			},
+			{
+				title: "Resize a window",
+				resize: func(s *Service) error {
+					return s.Resize(DimensionWidth, "600", ResizeOpts{WindowID: &windowID})
+				},
+				expected: []string{"--window-id", "42", "width", "600"},
+			},
+			{
+				title: "ResizeToPercent",
+				resize: func(s *Service) error {
+					return s.ResizeToPercent(DimensionWidth, 50, ResizeOpts{MonitorSize: 2560})
+				},
+				expected: []string{"width", "1280"},
+			},
+			{
+				title: "ResizeToPercent rounds to pixels",
+				resize: func(s *Service) error {
+					return s.ResizeToPercent(DimensionHeight, 33.3, ResizeOpts{WindowID: &windowID, MonitorSize: 1440})
+				},
+				expected: []string{"--window-id", "42", "height", "480"},
+			},
+		}
+		for _, tc := range testCases {
+			tt.Run(tc.title, func(ttt *testing.T) {
+				ctrl := gomock.NewController(ttt)
+				defer ctrl.Finish()
+
+				mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+				mockConn.EXPECT().
+					SendCommand("resize", tc.expected).
+					Return(&client.Response{}, nil)
+
+				if err := tc.resize(NewService(mockConn)); err != nil {
+					ttt.Fatalf("unexpected error: %v", err)
+				}
+			})
+		}
+	})
+
+	t.Run("Error cases", func(tt *testing.T) {
+		testCases := []struct {
+			title  string
+			resize func(s *Service) error
+		}{
+			{
+				title:  "unknown dimension",
+				resize: func(s *Service) error { return s.Resize("depth", "+50") },
+			},
+			{
+				title:  "invalid amount",
+				resize: func(s *Service) error { return s.Resize(DimensionWidth, "50%") },
+			},
+			{
+				title: "smart percent",
+				resize: func(s *Service) error {
+					return s.ResizeToPercent(DimensionSmart, 50, ResizeOpts{MonitorSize: 2560})
+				},
+			},
+			{
+				title: "percent out of range",
+				resize: func(s *Service) error {
+					return s.ResizeToPercent(DimensionWidth, 150, ResizeOpts{MonitorSize: 2560})
+				},
+			},
+			{
+				title:  "missing monitor size",
+				resize: func(s *Service) error { return s.ResizeToPercent(DimensionWidth, 50) },
+			},
+		}
+		for _, tc := range testCases {
+			tt.Run(tc.title, func(ttt *testing.T) {
+				ctrl := gomock.NewController(ttt)
+				defer ctrl.Finish()
+
+				mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+				if err := tc.resize(NewService(mockConn)); err == nil {
+					ttt.Fatal("expected error, got nil")
+				}
+			})
+		}
+	})
+}
@@ -0,0 +1,31 @@
+package layout
+
+// ToggleFloating makes the focused window, or the given window, floating
+// if it is tiled and tiled if it is floating.
+//
+// It is equivalent to running the command:
+//
+//	aerospace layout floating tiling [--window-id <window-id>]
+//
+// Usage:
+//
+//	err := layoutService.ToggleFloating()
+func (s *Service) ToggleFloating(opts ...SetLayoutOpts) error {
+	return s.SetLayout([]string{"floating", "tiling"}, opts...)
+}
+
+// ToggleOrientation switches the parent container of the focused window,
+// or of the given window, between horizontal and vertical.
+//
+// It is equivalent to running the command:
+//
+//	aerospace layout horizontal vertical [--window-id <window-id>]
+//
+// Usage:
+//
+//	err := layoutService.ToggleOrientation(layout.SetLayoutOpts{
+//	    WindowID: windows.WindowID(12345).Ptr(),
+//	})
+func (s *Service) ToggleOrientation(opts ...SetLayoutOpts) error {
+	return s.SetLayout([]string{"horizontal", "vertical"}, opts...)
+}
@@ -0,0 +1,52 @@
+package layout
+
+import (
+	"testing"
+
+	mock_client "github.com/cristianoliveira/aerospace-ipc/internal/mocks"
+	"github.com/cristianoliveira/aerospace-ipc/pkg/client"
+	"go.uber.org/mock/gomock"
+)
+
+func TestToggles(t *testing.T) {
+	windowID := client.WindowID(42)
+
+	testCases := []struct {
+		title    string
+		toggle   func(s *Service) error
+		expected []string
+	}{
+		{
+			title:    "ToggleFloating",
+			toggle:   func(s *Service) error { return s.ToggleFloating() },
+			expected: []string{"floating", "tiling"},
+		},
+		{
+			title: "ToggleFloating for a window",
+			toggle: func(s *Service) error {
+				return s.ToggleFloating(SetLayoutOpts{WindowID: &windowID})
+			},
+			expected: []string{"floating", "tiling", "--window-id", "42"},
+		},
+		{
+			title:    "ToggleOrientation",
+			toggle:   func(s *Service) error { return s.ToggleOrientation() },
+			expected: []string{"horizontal", "vertical"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.title, func(tt *testing.T) {
+			ctrl := gomock.NewController(tt)
+			defer ctrl.Finish()
+
+			mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+			mockConn.EXPECT().
+				SendCommand("layout", tc.expected).
+				Return(&client.Response{}, nil)
+
+			if err := tc.toggle(NewService(mockConn)); err != nil {
+				tt.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
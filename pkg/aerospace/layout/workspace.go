@@ -0,0 +1,81 @@
+package layout
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/cristianoliveira/aerospace-ipc/pkg/client"
+	"github.com/cristianoliveira/aerospace-ipc/pkg/client/args"
+	"github.com/cristianoliveira/aerospace-ipc/pkg/client/commands"
+)
+
+// maxConcurrentLayouts bounds the layout commands SetLayoutForWorkspace sends at once.
+const maxConcurrentLayouts = 4
+
+// SetLayoutForWorkspace sets the layout of every window in the workspace.
+//
+// The layouts are applied to several windows concurrently. A window failing
+// does not stop the others; the returned error joins the errors of all
+// failed windows.
+//
+// Since every window is set on its own, passing several layouts toggles
+// each window independently, see SetLayout.
+//
+// It is equivalent to running the commands:
+//
+//	aerospace list-windows --workspace <workspace> --json --format %{window-id}
+//	aerospace layout <layout>... --window-id <window-id>  # for each window
+//
+// Usage:
+//
+//	err := layoutService.SetLayoutForWorkspace("terminal", []string{"tiling"})
+func (s *Service) SetLayoutForWorkspace(workspace string, layouts []string) error {
+	if workspace == "" {
+		return fmt.Errorf("workspace cannot be empty")
+	}
+	if len(layouts) == 0 {
+		return fmt.Errorf("at least one layout must be provided")
+	}
+
+	cmdArgs := args.New().
+		KV("--workspace", workspace).
+		Flag("--json").
+		KV("--format", "%{window-id}").
+		Build()
+
+	response, err := s.sendCommand(commands.ListWindows, cmdArgs)
+	if err != nil {
+		return err
+	}
+	if response.ExitCode != 0 {
+		return fmt.Errorf("failed to list windows of workspace %s\n%s", workspace, response.StdErr)
+	}
+
+	var windows []struct {
+		WindowID client.WindowID `json:"window-id"`
+	}
+	if err := json.Unmarshal([]byte(response.StdOut), &windows); err != nil {
+		return fmt.Errorf("failed to unmarshal windows: %w\nOut:%s\nErr:%s", err, response.StdOut, response.StdErr)
+	}
+
+	// One slot per window, so the joined errors follow the window order.
+	errs := make([]error, len(windows))
+	limit := make(chan struct{}, maxConcurrentLayouts)
+	var wg sync.WaitGroup
+	for i, window := range windows {
+		wg.Add(1)
+		limit <- struct{}{}
+		go func() {
+			defer func() {
+				<-limit
+				wg.Done()
+			}()
+			errs[i] = s.SetLayout(layouts, SetLayoutOpts{WindowID: &window.WindowID})
+		}()
+	}
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,79 @@
+package layout
+
+import (
+	"strings"
+	"testing"
+
+	mock_client "github.com/cristianoliveira/aerospace-ipc/internal/mocks"
+	"github.com/cristianoliveira/aerospace-ipc/pkg/client"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSetLayoutForWorkspace(t *testing.T) {
+	listArgs := []string{"--workspace", "terminal", "--json", "--format", "%{window-id}"}
+	windowsJSON := `[{"window-id": 1}, {"window-id": 2}, {"window-id": 3}]`
+
+	t.Run("Happy path", func(tt *testing.T) {
+		tt.Run("sets the layout of every window", func(ttt *testing.T) {
+			ctrl := gomock.NewController(ttt)
+			defer ctrl.Finish()
+
+			mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+			mockConn.EXPECT().
+				SendCommand("list-windows", listArgs).
+				Return(&client.Response{StdOut: windowsJSON}, nil)
+			for _, id := range []string{"1", "2", "3"} {
+				mockConn.EXPECT().
+					SendCommand("layout", []string{"floating", "tiling", "--window-id", id}).
+					Return(&client.Response{}, nil)
+			}
+
+			err := NewService(mockConn).SetLayoutForWorkspace("terminal", []string{"floating", "tiling"})
+			if err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+		})
+	})
+
+	t.Run("Error cases", func(tt *testing.T) {
+		tt.Run("joins the errors of failed windows", func(ttt *testing.T) {
+			ctrl := gomock.NewController(ttt)
+			defer ctrl.Finish()
+
+			mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+			mockConn.EXPECT().
+				SendCommand("list-windows", listArgs).
+				Return(&client.Response{StdOut: windowsJSON}, nil)
+			mockConn.EXPECT().
+				SendCommand("layout", []string{"tiling", "--window-id", "1"}).
+				Return(&client.Response{ExitCode: 1, StdErr: "window 1 failed"}, nil)
+			mockConn.EXPECT().
+				SendCommand("layout", []string{"tiling", "--window-id", "2"}).
+				Return(&client.Response{}, nil)
+			mockConn.EXPECT().
+				SendCommand("layout", []string{"tiling", "--window-id", "3"}).
+				Return(&client.Response{ExitCode: 1, StdErr: "window 3 failed"}, nil)
+
+			err := NewService(mockConn).SetLayoutForWorkspace("terminal", []string{"tiling"})
+			if err == nil {
+				ttt.Fatal("expected error, got nil")
+			}
+			if !strings.Contains(err.Error(), "window 1 failed") || !strings.Contains(err.Error(), "window 3 failed") {
+				ttt.Fatalf("expected both failures, got %v", err)
+			}
+		})
+
+		tt.Run("requires a workspace and a layout", func(ttt *testing.T) {
+			ctrl := gomock.NewController(ttt)
+			defer ctrl.Finish()
+
+			service := NewService(mock_client.NewMockAeroSpaceConnection(ctrl))
+			if err := service.SetLayoutForWorkspace("", []string{"tiling"}); err == nil {
+				ttt.Fatal("expected error for empty workspace, got nil")
+			}
+			if err := service.SetLayoutForWorkspace("terminal", nil); err == nil {
+				ttt.Fatal("expected error for empty layouts, got nil")
+			}
+		})
+	})
+}
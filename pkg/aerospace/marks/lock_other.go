@@ -0,0 +1,10 @@
+//go:build !unix
+
+package marks
+
+import "os"
+
+// lockFile does nothing: file locks are only taken on Unix systems.
+func lockFile(file *os.File) error {
+	return nil
+}
@@ -0,0 +1,191 @@
+// Package marks labels windows so they can be found and focused later,
+// as the marks of i3 and sway.
+//
+// AeroSpace has no marks of its own: the labels are kept in a Store,
+// in memory with MemoryStore or in a file shared between processes with FileStore.
+package marks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/cristianoliveira/aerospace-ipc/pkg/aerospace/focus"
+	"github.com/cristianoliveira/aerospace-ipc/pkg/client"
+)
+
+// ErrMarkNotFound indicates that no window is marked with the label.
+var ErrMarkNotFound = errors.New("mark not found")
+
+// Mark is a label attached to a window.
+type Mark struct {
+	Label    string
+	WindowID client.WindowID
+}
+
+// Service provides methods to mark windows and focus them by mark.
+type Service struct {
+	client client.AeroSpaceConnection
+	ctx    context.Context
+	store  Store
+}
+
+// MarksService defines the interface for marks operations in AeroSpaceWM.
+type MarksService interface {
+	// Mark marks the window with label.
+	Mark(windowID client.WindowID, label string) error
+
+	// Unmark removes the mark with label.
+	Unmark(label string) error
+
+	// List returns all the marks, sorted by label.
+	List() ([]Mark, error)
+
+	// GetWindowID returns the ID of the window marked with label.
+	GetWindowID(label string) (client.WindowID, error)
+
+	// Focus focuses the window marked with label.
+	Focus(label string) error
+}
+
+// NewService creates a new marks service with the given AeroSpace client connection,
+// keeping the marks in store.
+func NewService(client client.AeroSpaceConnection, store Store) *Service {
+	if store == nil {
+		panic("ASSERTION: store cannot be nil")
+	}
+	return &Service{client: client, store: store}
+}
+
+// WithContext returns a copy of the service that sends every command with ctx,
+// so requests can be cancelled or bounded by a deadline.
+//
+// Usage:
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+//	defer cancel()
+//	err := marksService.WithContext(ctx).Focus("editor")
+func (s *Service) WithContext(ctx context.Context) *Service {
+	if ctx == nil {
+		panic("ASSERTION: context cannot be nil")
+	}
+	return &Service{client: s.client, ctx: ctx, store: s.store}
+}
+
+// Store returns the store keeping the marks.
+func (s *Service) Store() Store {
+	return s.store
+}
+
+// Mark marks the window with label. A label marks a single window, so marking
+// another window with it moves the mark. A window can have several marks.
+//
+// Labels are trimmed of surrounding spaces, here and by the other methods,
+// so " editor " and "editor" are the same mark.
+//
+// Usage:
+//
+//	err := marksService.Mark(windowID, "editor")
+func (s *Service) Mark(windowID client.WindowID, label string) error {
+	label = strings.TrimSpace(label)
+	if label == "" {
+		return fmt.Errorf("label cannot be empty")
+	}
+	if err := s.store.SetMark(label, windowID); err != nil {
+		return fmt.Errorf("failed to mark window %d\n%w", windowID, err)
+	}
+	return nil
+}
+
+// Unmark removes the mark with label.
+//
+// Returns an error wrapping ErrMarkNotFound if no window is marked with label.
+//
+// Usage:
+//
+//	err := marksService.Unmark("editor")
+func (s *Service) Unmark(label string) error {
+	label = strings.TrimSpace(label)
+	if _, err := s.GetWindowID(label); err != nil {
+		return err
+	}
+	if err := s.store.DeleteMark(label); err != nil {
+		return fmt.Errorf("failed to unmark %q\n%w", label, err)
+	}
+	return nil
+}
+
+// List returns all the marks, sorted by label.
+//
+// Usage:
+//
+//	marks, err := marksService.List()
+//	for _, mark := range marks {
+//	    fmt.Printf("%s: %d\n", mark.Label, mark.WindowID)
+//	}
+func (s *Service) List() ([]Mark, error) {
+	stored, err := s.store.Marks()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list marks\n%w", err)
+	}
+
+	marks := make([]Mark, 0, len(stored))
+	for label, windowID := range stored {
+		marks = append(marks, Mark{Label: label, WindowID: windowID})
+	}
+	slices.SortFunc(marks, func(a, b Mark) int {
+		return strings.Compare(a.Label, b.Label)
+	})
+	return marks, nil
+}
+
+// GetWindowID returns the ID of the window marked with label.
+//
+// Returns an error wrapping ErrMarkNotFound if no window is marked with label.
+//
+// Usage:
+//
+//	windowID, err := marksService.GetWindowID("editor")
+func (s *Service) GetWindowID(label string) (client.WindowID, error) {
+	label = strings.TrimSpace(label)
+	stored, err := s.store.Marks()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get mark %q\n%w", label, err)
+	}
+	windowID, ok := stored[label]
+	if !ok {
+		return 0, fmt.Errorf("%w\nno window marked %q", ErrMarkNotFound, label)
+	}
+	return windowID, nil
+}
+
+// Focus focuses the window marked with label.
+//
+// It is equivalent to running the command:
+//
+//	aerospace focus --window-id <marked-window-id>
+//
+// Returns an error wrapping ErrMarkNotFound if no window is marked with label.
+// The mark is kept if the window no longer exists; remove it with Unmark.
+//
+// Usage:
+//
+//	err := marksService.Focus("editor")
+func (s *Service) Focus(label string) error {
+	windowID, err := s.GetWindowID(strings.TrimSpace(label))
+	if err != nil {
+		return err
+	}
+	return s.newFocusService().SetFocusByWindowID(windowID)
+}
+
+// newFocusService returns a focus service sharing the connection and context.
+func (s *Service) newFocusService() *focus.Service {
+	service := focus.NewService(s.client)
+	if s.ctx != nil {
+		service = service.WithContext(s.ctx)
+	}
+	return service
+}
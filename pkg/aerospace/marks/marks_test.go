@@ -0,0 +1,158 @@
+package marks
+
+import (
+	"errors"
+	"testing"
+
+	mock_client "github.com/cristianoliveira/aerospace-ipc/internal/mocks"
+	"github.com/cristianoliveira/aerospace-ipc/pkg/client"
+	"go.uber.org/mock/gomock"
+)
+
+// TestMarksServiceInterface ensures that Service implements MarksService interface.
+// This is a compile-time check - if Service doesn't implement all methods, this will fail to compile.
+func TestMarksServiceInterface(t *testing.T) {
+	var _ MarksService = (*Service)(nil)
+}
+
+func TestMarks(t *testing.T) {
+	t.Run("Happy path", func(tt *testing.T) {
+		tt.Run("Mark and list", func(ttt *testing.T) {
+			ctrl := gomock.NewController(ttt)
+			defer ctrl.Finish()
+
+			service := NewService(mock_client.NewMockAeroSpaceConnection(ctrl), NewMemoryStore())
+			for label, windowID := range map[string]client.WindowID{"web": 2, "editor": 1, " term ": 3} {
+				if err := service.Mark(windowID, label); err != nil {
+					ttt.Fatalf("unexpected error: %v", err)
+				}
+			}
+			// Marking another window moves the mark.
+			if err := service.Mark(4, "web"); err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+
+			marks, err := service.List()
+			if err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+			expected := []Mark{{"editor", 1}, {"term", 3}, {"web", 4}}
+			if len(marks) != len(expected) {
+				ttt.Fatalf("expected %v, got %v", expected, marks)
+			}
+			for i := range expected {
+				if marks[i] != expected[i] {
+					ttt.Fatalf("expected %v, got %v", expected, marks)
+				}
+			}
+		})
+
+		tt.Run("Focus", func(ttt *testing.T) {
+			ctrl := gomock.NewController(ttt)
+			defer ctrl.Finish()
+
+			mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+			mockConn.EXPECT().
+				SendCommand("focus", []string{"--window-id", "42"}).
+				Return(&client.Response{}, nil)
+
+			service := NewService(mockConn, NewMemoryStore())
+			if err := service.Mark(42, "editor"); err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+			if err := service.Focus("editor"); err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+		})
+
+		tt.Run("Unmark", func(ttt *testing.T) {
+			ctrl := gomock.NewController(ttt)
+			defer ctrl.Finish()
+
+			service := NewService(mock_client.NewMockAeroSpaceConnection(ctrl), NewMemoryStore())
+			if err := service.Mark(42, "editor"); err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+			if err := service.Unmark("editor"); err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+			if _, err := service.GetWindowID("editor"); !errors.Is(err, ErrMarkNotFound) {
+				ttt.Fatalf("expected ErrMarkNotFound, got %v", err)
+			}
+		})
+
+		tt.Run("Labels are trimmed", func(ttt *testing.T) {
+			ctrl := gomock.NewController(ttt)
+			defer ctrl.Finish()
+
+			mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+			mockConn.EXPECT().
+				SendCommand("focus", []string{"--window-id", "42"}).
+				Return(&client.Response{}, nil)
+
+			service := NewService(mockConn, NewMemoryStore())
+			if err := service.Mark(42, " editor "); err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+			if windowID, err := service.GetWindowID("editor\n"); err != nil || windowID != 42 {
+				ttt.Fatalf("expected window 42, got %d and %v", windowID, err)
+			}
+			if err := service.Focus(" editor"); err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+			if err := service.Unmark("editor "); err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+		})
+	})
+
+	t.Run("Error cases", func(tt *testing.T) {
+		tt.Run("Focus an unknown mark", func(ttt *testing.T) {
+			ctrl := gomock.NewController(ttt)
+			defer ctrl.Finish()
+
+			service := NewService(mock_client.NewMockAeroSpaceConnection(ctrl), NewMemoryStore())
+			if err := service.Focus("editor"); !errors.Is(err, ErrMarkNotFound) {
+				ttt.Fatalf("expected ErrMarkNotFound, got %v", err)
+			}
+		})
+
+		tt.Run("Unmark an unknown mark", func(ttt *testing.T) {
+			ctrl := gomock.NewController(ttt)
+			defer ctrl.Finish()
+
+			service := NewService(mock_client.NewMockAeroSpaceConnection(ctrl), NewMemoryStore())
+			if err := service.Unmark("editor"); !errors.Is(err, ErrMarkNotFound) {
+				ttt.Fatalf("expected ErrMarkNotFound, got %v", err)
+			}
+		})
+
+		tt.Run("Mark with an empty label", func(ttt *testing.T) {
+			ctrl := gomock.NewController(ttt)
+			defer ctrl.Finish()
+
+			service := NewService(mock_client.NewMockAeroSpaceConnection(ctrl), NewMemoryStore())
+			if err := service.Mark(42, "  "); err == nil {
+				ttt.Fatal("expected error, got nil")
+			}
+		})
+
+		tt.Run("Focus fails", func(ttt *testing.T) {
+			ctrl := gomock.NewController(ttt)
+			defer ctrl.Finish()
+
+			mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+			mockConn.EXPECT().
+				SendCommand("focus", []string{"--window-id", "42"}).
+				Return(&client.Response{ExitCode: 1, StdErr: "Invalid <window-id> 42"}, nil)
+
+			service := NewService(mockConn, NewMemoryStore())
+			if err := service.Mark(42, "editor"); err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+			if err := service.Focus("editor"); err == nil {
+				ttt.Fatal("expected error, got nil")
+			}
+		})
+	})
+}
@@ -0,0 +1,215 @@
+package marks
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/cristianoliveira/aerospace-ipc/pkg/client"
+)
+
+// Store persists the marks, mapping each label to a window ID.
+//
+// Implementations must be safe for concurrent use.
+type Store interface {
+	// Marks returns all the marks, keyed by label.
+	Marks() (map[string]client.WindowID, error)
+
+	// SetMark marks the window with label, replacing the window previously marked with it.
+	SetMark(label string, windowID client.WindowID) error
+
+	// DeleteMark removes the mark with label. Removing a missing mark is not an error.
+	DeleteMark(label string) error
+}
+
+// MemoryStore keeps the marks in memory, for the lifetime of the process.
+type MemoryStore struct {
+	mu    sync.Mutex
+	marks map[string]client.WindowID
+}
+
+var _ Store = (*MemoryStore)(nil)
+
+// NewMemoryStore returns an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{marks: map[string]client.WindowID{}}
+}
+
+// Marks returns a copy of the marks.
+func (m *MemoryStore) Marks() (map[string]client.WindowID, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return maps.Clone(m.marks), nil
+}
+
+// SetMark marks the window with label.
+func (m *MemoryStore) SetMark(label string, windowID client.WindowID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.marks[label] = windowID
+	return nil
+}
+
+// DeleteMark removes the mark with label.
+func (m *MemoryStore) DeleteMark(label string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.marks, label)
+	return nil
+}
+
+// FileStore keeps the marks in a JSON file, so they are shared between processes
+// and survive restarts.
+//
+// The file is read on every call and rewritten atomically on every change.
+// A missing file holds no marks. Changes hold an exclusive lock on the file
+// at path + ".lock", so the changes of several processes do not overwrite
+// each other. The lock is only taken on Unix systems, elsewhere only the
+// changes made through the same FileStore are serialized.
+type FileStore struct {
+	path string
+	mu   sync.Mutex
+}
+
+var _ Store = (*FileStore)(nil)
+
+// NewFileStore returns a FileStore keeping the marks in the file at path.
+//
+// Usage:
+//
+//	store := marks.NewFileStore(filepath.Join(os.Getenv("HOME"), ".local/state/aerospace-marks.json"))
+//	marksService := marks.NewService(conn, store)
+func NewFileStore(path string) *FileStore {
+	if path == "" {
+		panic("ASSERTION: path cannot be empty")
+	}
+	return &FileStore{path: path}
+}
+
+// Path returns the path of the file keeping the marks.
+func (f *FileStore) Path() string {
+	return f.path
+}
+
+// Marks reads the marks from the file.
+func (f *FileStore) Marks() (map[string]client.WindowID, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.read()
+}
+
+// SetMark marks the window with label and writes the file.
+func (f *FileStore) SetMark(label string, windowID client.WindowID) error {
+	unlock, err := f.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	marks, err := f.read()
+	if err != nil {
+		return err
+	}
+	marks[label] = windowID
+	return f.write(marks)
+}
+
+// DeleteMark removes the mark with label and writes the file.
+func (f *FileStore) DeleteMark(label string) error {
+	unlock, err := f.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	marks, err := f.read()
+	if err != nil {
+		return err
+	}
+	if _, ok := marks[label]; !ok {
+		return nil
+	}
+	delete(marks, label)
+	return f.write(marks)
+}
+
+// lock takes f.mu and the lock file shared with the other processes,
+// and returns the function releasing both.
+func (f *FileStore) lock() (func(), error) {
+	f.mu.Lock()
+	if err := os.MkdirAll(filepath.Dir(f.path), 0o755); err != nil {
+		f.mu.Unlock()
+		return nil, fmt.Errorf("failed to create marks directory\n%w", err)
+	}
+	file, err := os.OpenFile(f.path+".lock", os.O_CREATE|os.O_RDWR, 0o644)
+	if err != nil {
+		f.mu.Unlock()
+		return nil, fmt.Errorf("failed to open marks lock file\n%w", err)
+	}
+	if err := lockFile(file); err != nil {
+		_ = file.Close()
+		f.mu.Unlock()
+		return nil, fmt.Errorf("failed to lock marks file\n%w", err)
+	}
+
+	return func() {
+		// Closing the file releases its lock.
+		_ = file.Close()
+		f.mu.Unlock()
+	}, nil
+}
+
+// read decodes the marks from the file. The caller must hold f.mu.
+func (f *FileStore) read() (map[string]client.WindowID, error) {
+	marks := map[string]client.WindowID{}
+	data, err := os.ReadFile(f.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return marks, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read marks file\n%w", err)
+	}
+	if len(data) == 0 {
+		return marks, nil
+	}
+	if err := json.Unmarshal(data, &marks); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal marks: %w\nFile: %s", err, f.path)
+	}
+	return marks, nil
+}
+
+// write encodes the marks to a temporary file and renames it over the file,
+// so readers never see a partial file. The caller must hold f.mu.
+func (f *FileStore) write(marks map[string]client.WindowID) error {
+	data, err := json.MarshalIndent(marks, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal marks\n%w", err)
+	}
+
+	dir := filepath.Dir(f.path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create marks directory\n%w", err)
+	}
+	tmp, err := os.CreateTemp(dir, filepath.Base(f.path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to write marks file\n%w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write marks file\n%w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write marks file\n%w", err)
+	}
+	if err := os.Rename(tmp.Name(), f.path); err != nil {
+		return fmt.Errorf("failed to write marks file\n%w", err)
+	}
+	return nil
+}
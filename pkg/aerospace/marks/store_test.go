@@ -0,0 +1,88 @@
+package marks
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/cristianoliveira/aerospace-ipc/pkg/client"
+)
+
+func TestFileStore(t *testing.T) {
+	t.Run("Happy path", func(tt *testing.T) {
+		tt.Run("Missing file holds no marks", func(ttt *testing.T) {
+			store := NewFileStore(filepath.Join(ttt.TempDir(), "marks.json"))
+			marks, err := store.Marks()
+			if err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+			if len(marks) != 0 {
+				ttt.Fatalf("expected no marks, got %v", marks)
+			}
+		})
+
+		tt.Run("Marks are shared through the file", func(ttt *testing.T) {
+			path := filepath.Join(ttt.TempDir(), "state", "marks.json")
+			if err := NewFileStore(path).SetMark("editor", 42); err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+			if err := NewFileStore(path).SetMark("web", 7); err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+			if err := NewFileStore(path).DeleteMark("web"); err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+
+			marks, err := NewFileStore(path).Marks()
+			if err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+			if len(marks) != 1 || marks["editor"] != client.WindowID(42) {
+				ttt.Fatalf("expected editor marking 42, got %v", marks)
+			}
+		})
+
+		tt.Run("Concurrent changes are not lost", func(ttt *testing.T) {
+			path := filepath.Join(ttt.TempDir(), "marks.json")
+
+			// A FileStore per writer, as if each ran in its own process.
+			var wg sync.WaitGroup
+			for i := range 20 {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					if err := NewFileStore(path).SetMark(fmt.Sprintf("mark-%d", i), client.WindowID(i)); err != nil {
+						ttt.Errorf("unexpected error: %v", err)
+					}
+				}()
+			}
+			wg.Wait()
+
+			marks, err := NewFileStore(path).Marks()
+			if err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+			if len(marks) != 20 {
+				ttt.Fatalf("expected 20 marks, got %d", len(marks))
+			}
+		})
+	})
+
+	t.Run("Error cases", func(tt *testing.T) {
+		tt.Run("Corrupted file", func(ttt *testing.T) {
+			path := filepath.Join(ttt.TempDir(), "marks.json")
+			if err := os.WriteFile(path, []byte("not json"), 0o644); err != nil {
+				ttt.Fatal(err)
+			}
+			store := NewFileStore(path)
+			if _, err := store.Marks(); err == nil {
+				ttt.Fatal("expected error, got nil")
+			}
+			if err := store.SetMark("editor", 42); err == nil {
+				ttt.Fatal("expected error, got nil")
+			}
+		})
+	})
+}
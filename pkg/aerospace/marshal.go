@@ -0,0 +1,79 @@
+package aerospace
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// StateSchemaVersion is the version of the JSON schema of State.
+//
+// It is bumped whenever a change of the schema would break decoding older snapshots.
+const StateSchemaVersion = 1
+
+// stateJSON is the JSON schema of State.
+//
+// FocusedWorkspace is not encoded, it is resolved from the workspace marked as focused.
+type stateJSON struct {
+	Version       int         `json:"version"`
+	Monitors      []Monitor   `json:"monitors"`
+	Workspaces    []Workspace `json:"workspaces"`
+	Windows       []Window    `json:"windows"`
+	FocusedWindow *Window     `json:"focused-window,omitempty"`
+	Mode          string      `json:"mode"`
+}
+
+// MarshalJSON encodes the state with the kebab-case keys used by the AeroSpace server
+// and a "version" field set to StateSchemaVersion, so snapshots can be stored and
+// decoded back into a State in a later session.
+//
+// Usage:
+//
+//	state, err := client.GetState(ctx)
+//	data, err := json.Marshal(state)
+//	err = os.WriteFile("state.json", data, 0o644)
+func (s State) MarshalJSON() ([]byte, error) {
+	return json.Marshal(stateJSON{
+		Version:       StateSchemaVersion,
+		Monitors:      nonNil(s.Monitors),
+		Workspaces:    nonNil(s.Workspaces),
+		Windows:       nonNil(s.Windows),
+		FocusedWindow: s.FocusedWindow,
+		Mode:          s.Mode,
+	})
+}
+
+// UnmarshalJSON decodes a state encoded by MarshalJSON.
+//
+// Returns an error if the snapshot has no version or was encoded with a newer schema.
+//
+// Usage:
+//
+//	var state aerospace.State
+//	err := json.Unmarshal(data, &state)
+func (s *State) UnmarshalJSON(data []byte) error {
+	var decoded stateJSON
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	if decoded.Version < 1 || decoded.Version > StateSchemaVersion {
+		return fmt.Errorf("unsupported state schema version %d, expected 1 to %d", decoded.Version, StateSchemaVersion)
+	}
+
+	*s = State{
+		Monitors:      decoded.Monitors,
+		Workspaces:    decoded.Workspaces,
+		Windows:       decoded.Windows,
+		FocusedWindow: decoded.FocusedWindow,
+		Mode:          decoded.Mode,
+	}
+	s.resolveFocusedWorkspace()
+	return nil
+}
+
+// nonNil returns an empty slice for nil, so it is encoded as [] rather than null.
+func nonNil[T any](items []T) []T {
+	if items == nil {
+		return []T{}
+	}
+	return items
+}
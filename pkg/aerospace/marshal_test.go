@@ -0,0 +1,66 @@
+package aerospace
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestStateMarshal(t *testing.T) {
+	state := State{
+		Monitors: []Monitor{{MonitorID: 1, MonitorName: "Built-in Retina Display", AppKitNSScreenScreensID: 1}},
+		Workspaces: []Workspace{
+			{Workspace: "1", MonitorID: 1, MonitorName: "Built-in Retina Display"},
+			{Workspace: "2", MonitorID: 1, MonitorName: "Built-in Retina Display", IsVisible: true, IsFocused: true},
+		},
+		Windows:       []Window{{WindowID: 7, AppName: "Ghostty", Workspace: "2"}},
+		FocusedWindow: &Window{WindowID: 7, AppName: "Ghostty", Workspace: "2"},
+		Mode:          "main",
+	}
+	state.resolveFocusedWorkspace()
+
+	t.Run("Happy path", func(tt *testing.T) {
+		tt.Run("MarshalJSON sets the version", func(ttt *testing.T) {
+			data, err := json.Marshal(State{})
+			if err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+			expected := `{"version":1,"monitors":[],"workspaces":[],"windows":[],"mode":""}`
+			if string(data) != expected {
+				ttt.Fatalf("expected %s, got %s", expected, data)
+			}
+		})
+
+		tt.Run("round trip", func(ttt *testing.T) {
+			data, err := json.Marshal(state)
+			if err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+			var decoded State
+			if err := json.Unmarshal(data, &decoded); err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(decoded, state) {
+				ttt.Fatalf("expected %+v, got %+v", state, decoded)
+			}
+			if decoded.FocusedWorkspace != &decoded.Workspaces[1] {
+				ttt.Fatal("expected the focused workspace to point into Workspaces")
+			}
+		})
+	})
+
+	t.Run("Error cases", func(tt *testing.T) {
+		for name, data := range map[string]string{
+			"missing version": `{"mode":"main"}`,
+			"newer version":   `{"version":2}`,
+			"invalid JSON":    `{`,
+		} {
+			tt.Run(name, func(ttt *testing.T) {
+				var decoded State
+				if err := json.Unmarshal([]byte(data), &decoded); err == nil {
+					ttt.Fatal("expected error, got nil")
+				}
+			})
+		}
+	})
+}
@@ -0,0 +1,80 @@
+package modes
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/cristianoliveira/aerospace-ipc/pkg/client"
+	"github.com/cristianoliveira/aerospace-ipc/pkg/client/commands"
+)
+
+// Service provides methods to interact with binding modes in AeroSpaceWM.
+type Service struct {
+	client client.AeroSpaceConnection
+	ctx    context.Context
+}
+
+// ModesService defines the interface for binding mode operations in AeroSpaceWM.
+type ModesService interface {
+	// Current returns the name of the active binding mode.
+	Current() (string, error)
+}
+
+// NewService creates a new modes service with the given AeroSpace client connection.
+func NewService(client client.AeroSpaceConnection) *Service {
+	return &Service{client: client}
+}
+
+// WithContext returns a copy of the service that sends every command with ctx,
+// so requests can be cancelled or bounded by a deadline.
+//
+// Usage:
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+//	defer cancel()
+//	mode, err := modesService.WithContext(ctx).Current()
+func (s *Service) WithContext(ctx context.Context) *Service {
+	if ctx == nil {
+		panic("ASSERTION: context cannot be nil")
+	}
+	return &Service{client: s.client, ctx: ctx}
+}
+
+// sendCommand sends a command using the service context, if any.
+func (s *Service) sendCommand(command string, args []string) (*client.Response, error) {
+	if s.ctx == nil {
+		return s.client.SendCommand(command, args)
+	}
+	return s.client.SendCommandContext(s.ctx, command, args)
+}
+
+// Current returns the name of the active binding mode, e.g. "main", "resize" or "service".
+//
+// It is equivalent to running the command:
+//
+//	aerospace list-modes --current
+//
+// Usage:
+//
+//	mode, err := modesService.Current()
+//	if mode != "main" {
+//	    fmt.Println("Mode:", mode)
+//	}
+func (s *Service) Current() (string, error) {
+	response, err := s.sendCommand(commands.ListModes, []string{"--current"})
+	if err != nil {
+		return "", err
+	}
+
+	if response.ExitCode != 0 {
+		return "", fmt.Errorf("failed to get current mode\n%s", response.StdErr)
+	}
+
+	mode := strings.TrimSpace(response.StdOut)
+	if mode == "" {
+		return "", fmt.Errorf("no current mode found")
+	}
+
+	return mode, nil
+}
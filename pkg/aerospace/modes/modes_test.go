@@ -0,0 +1,93 @@
+package modes
+
+import (
+	"context"
+	"testing"
+
+	mock_client "github.com/cristianoliveira/aerospace-ipc/internal/mocks"
+	"github.com/cristianoliveira/aerospace-ipc/pkg/client"
+	"go.uber.org/mock/gomock"
+)
+
+// TestModesServiceInterface ensures that Service implements ModesService interface.
+// This is a compile-time check - if Service doesn't implement all methods, this will fail to compile.
+func TestModesServiceInterface(t *testing.T) {
+	var _ ModesService = (*Service)(nil)
+}
+
+func TestModesService(t *testing.T) {
+	t.Run("Happy path", func(tt *testing.T) {
+		tt.Run("Current", func(ttt *testing.T) {
+			ctrl := gomock.NewController(ttt)
+			defer ctrl.Finish()
+
+			mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+			service := NewService(mockConn)
+
+			mockConn.EXPECT().
+				SendCommand("list-modes", []string{"--current"}).
+				Return(&client.Response{StdOut: "resize\n"}, nil)
+
+			mode, err := service.Current()
+			if err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+			if mode != "resize" {
+				ttt.Fatalf("expected mode 'resize', got '%s'", mode)
+			}
+		})
+	})
+
+	t.Run("Error cases", func(tt *testing.T) {
+		tests := []struct {
+			name     string
+			response *client.Response
+		}{
+			{
+				name:     "non-zero exit code",
+				response: &client.Response{ExitCode: 1, StdErr: "Unknown command"},
+			},
+			{
+				name:     "empty output",
+				response: &client.Response{StdOut: "\n"},
+			},
+		}
+
+		for _, tc := range tests {
+			tt.Run(tc.name, func(ttt *testing.T) {
+				ctrl := gomock.NewController(ttt)
+				defer ctrl.Finish()
+
+				mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+				service := NewService(mockConn)
+
+				mockConn.EXPECT().
+					SendCommand("list-modes", []string{"--current"}).
+					Return(tc.response, nil)
+
+				_, err := service.Current()
+				if err == nil {
+					ttt.Fatal("expected error, got nil")
+				}
+			})
+		}
+	})
+}
+
+func TestServiceWithContext(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+	service := NewService(mockConn).WithContext(ctx)
+
+	mockConn.EXPECT().
+		SendCommandContext(ctx, "list-modes", []string{"--current"}).
+		Return(&client.Response{StdOut: "main"}, nil)
+
+	_, err := service.Current()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
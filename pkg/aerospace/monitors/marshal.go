@@ -0,0 +1,71 @@
+package monitors
+
+import (
+	"encoding/json"
+
+	"github.com/cristianoliveira/aerospace-ipc/pkg/aerospace/workspaces"
+)
+
+// monitorJSON has the fields of Monitor but none of its methods,
+// so encoding it does not recurse into MarshalJSON.
+type monitorJSON Monitor
+
+// MarshalJSON encodes the monitor with the same kebab-case keys used by
+// the AeroSpace server, so snapshots can be decoded back into a Monitor.
+func (m Monitor) MarshalJSON() ([]byte, error) {
+	return json.Marshal(monitorJSON(m))
+}
+
+// UnmarshalJSON decodes a monitor encoded by MarshalJSON or by the AeroSpace server.
+func (m *Monitor) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, (*monitorJSON)(m))
+}
+
+// MarshalText encodes the monitor as its JSON object.
+//
+// Structs embedding a Monitor, like FocusedMonitor, must define their own MarshalJSON
+// and MarshalText, otherwise the promoted ones drop every field but the monitor's.
+func (m Monitor) MarshalText() ([]byte, error) {
+	return m.MarshalJSON()
+}
+
+// UnmarshalText decodes a monitor encoded by MarshalText.
+func (m *Monitor) UnmarshalText(text []byte) error {
+	return m.UnmarshalJSON(text)
+}
+
+// focusedMonitorJSON embeds the monitor fields without the Monitor methods,
+// which would otherwise be promoted and drop VisibleWorkspace.
+type focusedMonitorJSON struct {
+	monitorJSON
+	VisibleWorkspace workspaces.Workspace
+}
+
+// MarshalJSON encodes the monitor keys together with its VisibleWorkspace.
+func (m FocusedMonitor) MarshalJSON() ([]byte, error) {
+	return json.Marshal(focusedMonitorJSON{
+		monitorJSON:      monitorJSON(m.Monitor),
+		VisibleWorkspace: m.VisibleWorkspace,
+	})
+}
+
+// UnmarshalJSON decodes a focused monitor encoded by MarshalJSON.
+func (m *FocusedMonitor) UnmarshalJSON(data []byte) error {
+	var decoded focusedMonitorJSON
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	m.Monitor = Monitor(decoded.monitorJSON)
+	m.VisibleWorkspace = decoded.VisibleWorkspace
+	return nil
+}
+
+// MarshalText encodes the focused monitor as its JSON object.
+func (m FocusedMonitor) MarshalText() ([]byte, error) {
+	return m.MarshalJSON()
+}
+
+// UnmarshalText decodes a focused monitor encoded by MarshalText.
+func (m *FocusedMonitor) UnmarshalText(text []byte) error {
+	return m.UnmarshalJSON(text)
+}
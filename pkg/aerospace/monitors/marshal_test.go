@@ -0,0 +1,57 @@
+package monitors
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/cristianoliveira/aerospace-ipc/pkg/aerospace/workspaces"
+)
+
+func TestMonitorMarshal(t *testing.T) {
+	monitor := Monitor{MonitorID: 2, MonitorName: "DELL U2720Q", AppKitNSScreenScreensID: 2}
+
+	t.Run("Happy path", func(tt *testing.T) {
+		tt.Run("MarshalJSON uses the server keys", func(ttt *testing.T) {
+			data, err := json.Marshal(monitor)
+			if err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+			expected := `{"monitor-id":2,"monitor-name":"DELL U2720Q","monitor-appkit-nsscreen-screens-id":2}`
+			if string(data) != expected {
+				ttt.Fatalf("expected %s, got %s", expected, data)
+			}
+		})
+
+		tt.Run("text round trip", func(ttt *testing.T) {
+			text, err := monitor.MarshalText()
+			if err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+			var decoded Monitor
+			if err := decoded.UnmarshalText(text); err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+			if decoded != monitor {
+				ttt.Fatalf("expected %+v, got %+v", monitor, decoded)
+			}
+		})
+
+		tt.Run("focused monitor keeps its visible workspace", func(ttt *testing.T) {
+			focused := FocusedMonitor{
+				Monitor:          monitor,
+				VisibleWorkspace: workspaces.Workspace{Workspace: "terminal", MonitorID: 2},
+			}
+			data, err := json.Marshal(focused)
+			if err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+			var decoded FocusedMonitor
+			if err := json.Unmarshal(data, &decoded); err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+			if decoded != focused {
+				ttt.Fatalf("expected %+v, got %+v from %s", focused, decoded, data)
+			}
+		})
+	})
+}
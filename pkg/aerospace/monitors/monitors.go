@@ -0,0 +1,216 @@
+package monitors
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/cristianoliveira/aerospace-ipc/pkg/aerospace/workspaces"
+	"github.com/cristianoliveira/aerospace-ipc/pkg/client"
+	"github.com/cristianoliveira/aerospace-ipc/pkg/client/args"
+	"github.com/cristianoliveira/aerospace-ipc/pkg/client/commands"
+)
+
+// Monitor represents a monitor in AeroSpaceWM.
+//
+// See: aerospace list-monitors --json --format <format>
+//
+// Example JSON response:
+//
+//	[
+//	  {
+//	    "monitor-id": 1,
+//	    "monitor-name": "Built-in Retina Display",
+//	    "monitor-appkit-nsscreen-screens-id": 1
+//	  },
+//	  {
+//	    "monitor-id": 2,
+//	    "monitor-name": "DELL U2720Q",
+//	    "monitor-appkit-nsscreen-screens-id": 2
+//	  }
+//	]
+type Monitor struct {
+	// MonitorID is the 1-based sequential number of the monitor,
+	// as accepted by the --monitor filters of other commands.
+	MonitorID int `json:"monitor-id"`
+
+	MonitorName string `json:"monitor-name"`
+
+	// AppKitNSScreenScreensID is the 1-based index of the monitor in macOS NSScreen.screens.
+	AppKitNSScreenScreensID int `json:"monitor-appkit-nsscreen-screens-id"`
+}
+
+// Filter returns the monitor ID in the form accepted by --monitor filters,
+// e.g. workspaces.ListWorkspacesOpts.Monitors.
+func (m Monitor) Filter() string {
+	return strconv.Itoa(m.MonitorID)
+}
+
+// FocusedMonitor is the focused monitor together with the workspace visible on it.
+type FocusedMonitor struct {
+	Monitor
+
+	// VisibleWorkspace is the workspace currently shown on the monitor.
+	VisibleWorkspace workspaces.Workspace
+}
+
+const formatArguments = "%{monitor-id} %{monitor-name} %{monitor-appkit-nsscreen-screens-id}"
+
+// ListMonitorsOpts contains optional parameters for GetAllMonitors.
+type ListMonitorsOpts struct {
+	// Focused filters the monitors by focus.
+	// true lists only the focused monitor, false lists only the unfocused ones.
+	Focused *bool
+
+	// Mouse filters the monitors by the mouse position.
+	// true lists only the monitor with the mouse, false lists only the others.
+	Mouse *bool
+}
+
+// Service provides methods to interact with monitors in AeroSpaceWM.
+type Service struct {
+	client client.AeroSpaceConnection
+	ctx    context.Context
+}
+
+// MonitorsService defines the interface for monitor operations in AeroSpaceWM.
+type MonitorsService interface {
+	// GetAllMonitors returns the monitors matching the given options.
+	GetAllMonitors(opts ...ListMonitorsOpts) ([]Monitor, error)
+
+	// GetFocusedMonitor returns the focused monitor together with its visible workspace.
+	GetFocusedMonitor() (*FocusedMonitor, error)
+}
+
+// NewService creates a new monitors service with the given AeroSpace client connection.
+func NewService(client client.AeroSpaceConnection) *Service {
+	return &Service{client: client}
+}
+
+// WithContext returns a copy of the service that sends every command with ctx,
+// so requests can be cancelled or bounded by a deadline.
+//
+// Usage:
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+//	defer cancel()
+//	monitors, err := monitorsService.WithContext(ctx).GetAllMonitors()
+func (s *Service) WithContext(ctx context.Context) *Service {
+	if ctx == nil {
+		panic("ASSERTION: context cannot be nil")
+	}
+	return &Service{client: s.client, ctx: ctx}
+}
+
+// sendCommand sends a command using the service context, if any.
+func (s *Service) sendCommand(command string, args []string) (*client.Response, error) {
+	if s.ctx == nil {
+		return s.client.SendCommand(command, args)
+	}
+	return s.client.SendCommandContext(s.ctx, command, args)
+}
+
+// GetAllMonitors returns the monitors matching the given options.
+//
+// Without options all monitors are returned.
+//
+// It is equivalent to running the command:
+//
+//	aerospace list-monitors [--focused [no]] [--mouse [no]] --json --format <format>
+//
+// Usage:
+//
+//	// All monitors
+//	monitors, err := monitorsService.GetAllMonitors()
+//
+//	// Monitor with the mouse
+//	monitors, err := monitorsService.GetAllMonitors(monitors.ListMonitorsOpts{
+//	    Mouse: monitors.BoolPtr(true),
+//	})
+func (s *Service) GetAllMonitors(opts ...ListMonitorsOpts) ([]Monitor, error) {
+	var opt ListMonitorsOpts
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+
+	cmdArgs := args.New().
+		Toggle("--focused", opt.Focused).
+		Toggle("--mouse", opt.Mouse).
+		Flag("--json").
+		KV("--format", formatArguments).
+		Build()
+
+	response, err := s.sendCommand(commands.ListMonitors, cmdArgs)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.ExitCode != 0 {
+		return nil, fmt.Errorf("failed to list monitors\n%s", response.StdErr)
+	}
+
+	var monitors []Monitor
+	err = json.Unmarshal([]byte(response.StdOut), &monitors)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to unmarshal monitors: %w\nOut:%s\nErr:%s",
+			err,
+			response.StdOut,
+			response.StdErr,
+		)
+	}
+	return monitors, nil
+}
+
+// GetFocusedMonitor returns the focused monitor together with its visible workspace.
+//
+// It is equivalent to running the commands:
+//
+//	aerospace list-monitors --focused --json --format <format>
+//	aerospace list-workspaces --monitor <monitor-id> --visible --json --format <format>
+//
+// Returns an error if there is no focused monitor or no visible workspace on it.
+//
+// Usage:
+//
+//	focused, err := monitorsService.GetFocusedMonitor()
+//	fmt.Println(focused.MonitorName, focused.VisibleWorkspace.Workspace)
+func (s *Service) GetFocusedMonitor() (*FocusedMonitor, error) {
+	monitors, err := s.GetAllMonitors(ListMonitorsOpts{
+		Focused: BoolPtr(true),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(monitors) == 0 {
+		return nil, fmt.Errorf("no focused monitor found")
+	}
+
+	workspacesService := workspaces.NewService(s.client)
+	if s.ctx != nil {
+		workspacesService = workspacesService.WithContext(s.ctx)
+	}
+	visible, err := workspacesService.GetAllWorkspaces(workspaces.ListWorkspacesOpts{
+		// The monitor found above, even if the focus moved since.
+		Monitors: []string{monitors[0].Filter()},
+		Visible:  workspaces.BoolPtr(true),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(visible) == 0 {
+		return nil, fmt.Errorf("no visible workspace found on monitor %d", monitors[0].MonitorID)
+	}
+
+	return &FocusedMonitor{
+		Monitor:          monitors[0],
+		VisibleWorkspace: visible[0],
+	}, nil
+}
+
+// BoolPtr returns a pointer to the given bool value.
+// Helper function for creating optional bool parameters.
+func BoolPtr(b bool) *bool {
+	return &b
+}
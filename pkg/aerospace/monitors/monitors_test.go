@@ -0,0 +1,277 @@
+package monitors
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	mock_client "github.com/cristianoliveira/aerospace-ipc/internal/mocks"
+	"github.com/cristianoliveira/aerospace-ipc/pkg/client"
+	"go.uber.org/mock/gomock"
+)
+
+// TestMonitorsServiceInterface ensures that Service implements MonitorsService interface.
+// This is a compile-time check - if Service doesn't implement all methods, this will fail to compile.
+func TestMonitorsServiceInterface(t *testing.T) {
+	var _ MonitorsService = (*Service)(nil)
+}
+
+func TestMonitorsService(t *testing.T) {
+	t.Run("Happy path", func(tt *testing.T) {
+		tt.Run("GetAllMonitors", func(ttt *testing.T) {
+			ttt.Run("all monitors", func(tttt *testing.T) {
+				ctrl := gomock.NewController(tttt)
+				defer ctrl.Finish()
+
+				mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+				service := NewService(mockConn)
+
+				monitors := []Monitor{
+					{MonitorID: 1, MonitorName: "Built-in Retina Display", AppKitNSScreenScreensID: 1},
+					{MonitorID: 2, MonitorName: "DELL U2720Q", AppKitNSScreenScreensID: 2},
+				}
+				dataJSON, err := json.Marshal(monitors)
+				if err != nil {
+					tttt.Fatalf("failed to marshal monitors response: %v", err)
+				}
+
+				mockConn.EXPECT().
+					SendCommand(
+						"list-monitors",
+						[]string{
+							"--json",
+							"--format", formatArguments,
+						},
+					).
+					Return(
+						&client.Response{
+							StdOut: string(dataJSON),
+						},
+						nil,
+					)
+
+				result, err := service.GetAllMonitors()
+				if err != nil {
+					tttt.Fatalf("unexpected error: %v", err)
+				}
+
+				if len(result) != 2 {
+					tttt.Fatalf("expected 2 monitors, got %d", len(result))
+				}
+				if result[1] != monitors[1] {
+					tttt.Fatalf("expected %+v, got %+v", monitors[1], result[1])
+				}
+				if result[1].Filter() != "2" {
+					tttt.Fatalf("expected filter '2', got '%s'", result[1].Filter())
+				}
+			})
+
+			ttt.Run("with all options", func(tttt *testing.T) {
+				ctrl := gomock.NewController(tttt)
+				defer ctrl.Finish()
+
+				mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+				service := NewService(mockConn)
+
+				mockConn.EXPECT().
+					SendCommand(
+						"list-monitors",
+						[]string{
+							"--focused", "no",
+							"--mouse",
+							"--json",
+							"--format", formatArguments,
+						},
+					).
+					Return(
+						&client.Response{
+							StdOut: "[]",
+						},
+						nil,
+					)
+
+				result, err := service.GetAllMonitors(ListMonitorsOpts{
+					Focused: BoolPtr(false),
+					Mouse:   BoolPtr(true),
+				})
+				if err != nil {
+					tttt.Fatalf("unexpected error: %v", err)
+				}
+				if len(result) != 0 {
+					tttt.Fatalf("expected no monitors, got %d", len(result))
+				}
+			})
+		})
+
+		tt.Run("GetFocusedMonitor", func(ttt *testing.T) {
+			ctrl := gomock.NewController(ttt)
+			defer ctrl.Finish()
+
+			mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+			service := NewService(mockConn)
+
+			gomock.InOrder(
+				mockConn.EXPECT().
+					SendCommand(
+						"list-monitors",
+						[]string{
+							"--focused",
+							"--json",
+							"--format", formatArguments,
+						},
+					).
+					Return(
+						&client.Response{
+							StdOut: `[{"monitor-id": 2, "monitor-name": "DELL U2720Q", "monitor-appkit-nsscreen-screens-id": 2}]`,
+						},
+						nil,
+					),
+				mockConn.EXPECT().
+					SendCommand("list-workspaces", hasPrefix("--monitor", "2", "--visible", "--json")).
+					Return(
+						&client.Response{
+							StdOut: `[{"workspace": "terminal", "monitor-id": 2, "monitor-name": "DELL U2720Q"}]`,
+						},
+						nil,
+					),
+			)
+
+			focused, err := service.GetFocusedMonitor()
+			if err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+
+			if focused.MonitorID != 2 || focused.MonitorName != "DELL U2720Q" {
+				ttt.Fatalf("unexpected monitor %+v", focused.Monitor)
+			}
+			if focused.VisibleWorkspace.Workspace != "terminal" {
+				ttt.Fatalf("expected visible workspace 'terminal', got '%s'", focused.VisibleWorkspace.Workspace)
+			}
+		})
+	})
+
+	t.Run("Error cases", func(tt *testing.T) {
+		tt.Run("GetAllMonitors", func(ttt *testing.T) {
+			ttt.Run("fails on non-zero exit code", func(tttt *testing.T) {
+				ctrl := gomock.NewController(tttt)
+				defer ctrl.Finish()
+
+				mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+				service := NewService(mockConn)
+
+				mockConn.EXPECT().
+					SendCommand("list-monitors", gomock.Any()).
+					Return(
+						&client.Response{
+							ExitCode: 1,
+							StdErr:   "Unknown flag",
+						},
+						nil,
+					)
+
+				_, err := service.GetAllMonitors()
+				if err == nil {
+					tttt.Fatal("expected error, got nil")
+				}
+			})
+
+			ttt.Run("fails on invalid JSON", func(tttt *testing.T) {
+				ctrl := gomock.NewController(tttt)
+				defer ctrl.Finish()
+
+				mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+				service := NewService(mockConn)
+
+				mockConn.EXPECT().
+					SendCommand("list-monitors", gomock.Any()).
+					Return(
+						&client.Response{
+							StdOut: "not json",
+						},
+						nil,
+					)
+
+				_, err := service.GetAllMonitors()
+				if err == nil {
+					tttt.Fatal("expected error, got nil")
+				}
+			})
+		})
+
+		tt.Run("GetFocusedMonitor", func(ttt *testing.T) {
+			ttt.Run("fails without focused monitor", func(tttt *testing.T) {
+				ctrl := gomock.NewController(tttt)
+				defer ctrl.Finish()
+
+				mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+				service := NewService(mockConn)
+
+				mockConn.EXPECT().
+					SendCommand("list-monitors", gomock.Any()).
+					Return(&client.Response{StdOut: "[]"}, nil)
+
+				_, err := service.GetFocusedMonitor()
+				if err == nil || err.Error() != "no focused monitor found" {
+					tttt.Fatalf("expected 'no focused monitor found', got %v", err)
+				}
+			})
+
+			ttt.Run("fails without visible workspace", func(tttt *testing.T) {
+				ctrl := gomock.NewController(tttt)
+				defer ctrl.Finish()
+
+				mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+				service := NewService(mockConn)
+
+				mockConn.EXPECT().
+					SendCommand("list-monitors", gomock.Any()).
+					Return(&client.Response{StdOut: `[{"monitor-id": 1}]`}, nil)
+				mockConn.EXPECT().
+					SendCommand("list-workspaces", gomock.Any()).
+					Return(&client.Response{StdOut: "[]"}, nil)
+
+				_, err := service.GetFocusedMonitor()
+				if err == nil || err.Error() != "no visible workspace found on monitor 1" {
+					tttt.Fatalf("expected 'no visible workspace found on monitor 1', got %v", err)
+				}
+			})
+		})
+	})
+}
+
+// hasPrefix matches command arguments starting with prefix.
+func hasPrefix(prefix ...string) gomock.Matcher {
+	return gomock.Cond(func(args []string) bool {
+		if len(args) < len(prefix) {
+			return false
+		}
+		for i := range prefix {
+			if args[i] != prefix[i] {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+func TestServiceWithContext(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+	service := NewService(mockConn).WithContext(ctx)
+
+	// The workspaces query of GetFocusedMonitor must inherit the context.
+	mockConn.EXPECT().
+		SendCommandContext(ctx, "list-monitors", gomock.Any()).
+		Return(&client.Response{StdOut: `[{"monitor-id": 1}]`}, nil)
+	mockConn.EXPECT().
+		SendCommandContext(ctx, "list-workspaces", gomock.Any()).
+		Return(&client.Response{StdOut: `[{"workspace": "1"}]`}, nil)
+
+	_, err := service.GetFocusedMonitor()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,167 @@
+package aerospace
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// MultiClient manages clients connected to several AeroSpace sockets,
+// e.g. one per user or per test instance, keyed by socket path.
+//
+// It gives access to each instance services through Client and runs
+// queries on every instance at once through FanOut.
+type MultiClient struct {
+	mu          sync.RWMutex
+	clients     map[string]Client
+	socketPaths []string
+}
+
+// NewMultiClient connects to every given socket path, as NewCustomClient does.
+//
+// Returns an error if any connection fails, after closing the ones already opened.
+//
+// Usage:
+//
+//	multi, err := aerospace.NewMultiClient(
+//	    "/tmp/bobko.aerospace-alice.sock",
+//	    "/tmp/bobko.aerospace-bob.sock",
+//	)
+//	if err != nil {
+//	    log.Fatalf("failed to connect: %v", err)
+//	}
+//	defer multi.Close()
+func NewMultiClient(socketPaths ...string) (*MultiClient, error) {
+	multi := &MultiClient{}
+	for _, socketPath := range socketPaths {
+		c, err := NewCustomClient(CustomConnectionOpts{
+			SocketPath: socketPath,
+		})
+		if err != nil {
+			_ = multi.Close()
+			return nil, fmt.Errorf("failed to connect to %s\n%w", socketPath, err)
+		}
+		multi.Add(socketPath, c)
+	}
+
+	return multi, nil
+}
+
+// Add registers a client for socketPath, replacing any previous one.
+//
+// It allows managing clients created with custom options.
+func (m *MultiClient) Add(socketPath string, c Client) {
+	if c == nil {
+		panic("ASSERTION: client cannot be nil")
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.clients == nil {
+		m.clients = map[string]Client{}
+	}
+	if _, exists := m.clients[socketPath]; !exists {
+		m.socketPaths = append(m.socketPaths, socketPath)
+	}
+	m.clients[socketPath] = c
+}
+
+// Client returns the client connected to socketPath.
+//
+// Usage:
+//
+//	c, ok := multi.Client("/tmp/bobko.aerospace-alice.sock")
+//	if ok {
+//	    windows, err := c.Windows().GetAllWindows()
+//	}
+func (m *MultiClient) Client(socketPath string) (Client, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	c, ok := m.clients[socketPath]
+	return c, ok
+}
+
+// SocketPaths returns the socket paths of the managed clients, in the order they were added.
+func (m *MultiClient) SocketPaths() []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return append([]string(nil), m.socketPaths...)
+}
+
+// Close closes every managed connection.
+//
+// Returns the errors of the connections that failed to close.
+func (m *MultiClient) Close() error {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var errs []error
+	for _, socketPath := range m.socketPaths {
+		if err := m.clients[socketPath].CloseConnection(); err != nil {
+			errs = append(errs, fmt.Errorf("failed to close %s\n%w", socketPath, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// FanOut runs query concurrently on every client of m and returns the results keyed by socket path.
+//
+// Results of the instances where query succeeded are returned even if others failed.
+// The returned error joins the failures, each prefixed by its socket path.
+//
+// Usage:
+//
+//	modes, err := aerospace.FanOut(multi, func(c aerospace.Client) (string, error) {
+//	    return c.Modes().Current()
+//	})
+func FanOut[T any](m *MultiClient, query func(c Client) (T, error)) (map[string]T, error) {
+	m.mu.RLock()
+	clients := make(map[string]Client, len(m.clients))
+	for socketPath, c := range m.clients {
+		clients[socketPath] = c
+	}
+	m.mu.RUnlock()
+
+	var (
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		results = make(map[string]T, len(clients))
+		errs    []error
+	)
+	for socketPath, c := range clients {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result, err := query(c)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s\n%w", socketPath, err))
+				return
+			}
+			results[socketPath] = result
+		}()
+	}
+	wg.Wait()
+
+	return results, errors.Join(errs...)
+}
+
+// GetAllWindows returns the windows of every instance, keyed by socket path.
+//
+// See FanOut for how failures are reported.
+func (m *MultiClient) GetAllWindows() (map[string][]Window, error) {
+	return FanOut(m, func(c Client) ([]Window, error) {
+		return c.Windows().GetAllWindows()
+	})
+}
+
+// GetAllWorkspaces returns the workspaces of every instance, keyed by socket path.
+//
+// See FanOut for how failures are reported.
+func (m *MultiClient) GetAllWorkspaces() (map[string][]Workspace, error) {
+	return FanOut(m, func(c Client) ([]Workspace, error) {
+		return c.Workspaces().GetAllWorkspaces()
+	})
+}
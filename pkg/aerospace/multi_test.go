@@ -0,0 +1,117 @@
+package aerospace
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	mock_client "github.com/cristianoliveira/aerospace-ipc/internal/mocks"
+	"github.com/cristianoliveira/aerospace-ipc/pkg/client"
+	"go.uber.org/mock/gomock"
+)
+
+func TestMultiClient(t *testing.T) {
+	t.Run("Happy path", func(tt *testing.T) {
+		tt.Run("gives access to each instance", func(ttt *testing.T) {
+			ctrl := gomock.NewController(ttt)
+			defer ctrl.Finish()
+
+			alice := &AeroSpaceWM{conn: mock_client.NewMockAeroSpaceConnection(ctrl)}
+			multi := &MultiClient{}
+			multi.Add("/tmp/alice.sock", alice)
+			multi.Add("/tmp/bob.sock", &AeroSpaceWM{conn: mock_client.NewMockAeroSpaceConnection(ctrl)})
+
+			c, ok := multi.Client("/tmp/alice.sock")
+			if !ok || c != alice {
+				ttt.Fatalf("expected the alice client, got %v", c)
+			}
+			if _, ok := multi.Client("/tmp/unknown.sock"); ok {
+				ttt.Fatalf("expected no client for an unknown socket path")
+			}
+
+			paths := multi.SocketPaths()
+			if len(paths) != 2 || paths[0] != "/tmp/alice.sock" || paths[1] != "/tmp/bob.sock" {
+				ttt.Fatalf("unexpected socket paths %v", paths)
+			}
+		})
+
+		tt.Run("fans out queries to every instance", func(ttt *testing.T) {
+			ctrl := gomock.NewController(ttt)
+			defer ctrl.Finish()
+
+			aliceConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+			bobConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+			aliceConn.EXPECT().
+				SendCommand("list-workspaces", gomock.Any()).
+				Return(&client.Response{StdOut: `[{"workspace": "1"}]`}, nil)
+			bobConn.EXPECT().
+				SendCommand("list-workspaces", gomock.Any()).
+				Return(&client.Response{StdOut: `[{"workspace": "2"}, {"workspace": "3"}]`}, nil)
+
+			multi := &MultiClient{}
+			multi.Add("/tmp/alice.sock", &AeroSpaceWM{conn: aliceConn})
+			multi.Add("/tmp/bob.sock", &AeroSpaceWM{conn: bobConn})
+
+			results, err := multi.GetAllWorkspaces()
+			if err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+			if len(results["/tmp/alice.sock"]) != 1 || len(results["/tmp/bob.sock"]) != 2 {
+				ttt.Fatalf("unexpected results %+v", results)
+			}
+		})
+
+		tt.Run("closes every connection", func(ttt *testing.T) {
+			ctrl := gomock.NewController(ttt)
+			defer ctrl.Finish()
+
+			aliceConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+			bobConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+			aliceConn.EXPECT().CloseConnection().Return(nil)
+			bobConn.EXPECT().CloseConnection().Return(nil)
+
+			multi := &MultiClient{}
+			multi.Add("/tmp/alice.sock", &AeroSpaceWM{conn: aliceConn})
+			multi.Add("/tmp/bob.sock", &AeroSpaceWM{conn: bobConn})
+
+			if err := multi.Close(); err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+		})
+	})
+
+	t.Run("Error cases", func(tt *testing.T) {
+		tt.Run("returns partial results with the failing instances", func(ttt *testing.T) {
+			ctrl := gomock.NewController(ttt)
+			defer ctrl.Finish()
+
+			aliceConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+			bobConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+			aliceConn.EXPECT().
+				SendCommand("list-windows", gomock.Any()).
+				Return(&client.Response{StdOut: `[{"window-id": 1}]`}, nil)
+			bobConn.EXPECT().
+				SendCommand("list-windows", gomock.Any()).
+				Return(nil, errors.New("broken pipe"))
+
+			multi := &MultiClient{}
+			multi.Add("/tmp/alice.sock", &AeroSpaceWM{conn: aliceConn})
+			multi.Add("/tmp/bob.sock", &AeroSpaceWM{conn: bobConn})
+
+			results, err := multi.GetAllWindows()
+			if err == nil || !strings.Contains(err.Error(), "/tmp/bob.sock") {
+				ttt.Fatalf("expected error for /tmp/bob.sock, got %v", err)
+			}
+			if len(results) != 1 || len(results["/tmp/alice.sock"]) != 1 {
+				ttt.Fatalf("expected alice results only, got %+v", results)
+			}
+		})
+
+		tt.Run("fails to connect to a missing socket", func(ttt *testing.T) {
+			_, err := NewMultiClient("/tmp/aerospace-ipc-missing.sock")
+			if err == nil {
+				ttt.Fatal("expected error, got nil")
+			}
+		})
+	})
+}
@@ -0,0 +1,164 @@
+package aerospace
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/cristianoliveira/aerospace-ipc/pkg/aerospace/marks"
+	"github.com/cristianoliveira/aerospace-ipc/pkg/client"
+)
+
+// Option configures a Client created with NewClient.
+//
+// Usage:
+//
+//	client, err := aerospace.NewClient(
+//	    aerospace.WithSocketPath("/tmp/bobko.aerospace-me.sock"),
+//	    aerospace.WithTimeout(2*time.Second),
+//	)
+type Option func(*clientOptions)
+
+type clientOptions struct {
+	socketPath    string
+	connector     client.AeroSpaceConnector
+	logger        *slog.Logger
+	versionPolicy VersionPolicy
+	connOptions   []client.Option
+	marksStore    marks.Store
+}
+
+// VersionPolicy decides what NewClient does when the AeroSpace server
+// version is not supported by the client.
+type VersionPolicy int
+
+const (
+	// VersionPolicySkip does not check the server version. It is the default.
+	VersionPolicySkip VersionPolicy = iota
+	// VersionPolicyWarn logs unsupported server versions as a warning on the
+	// logger set by WithLogger, or slog.Default(), and creates the client anyway.
+	VersionPolicyWarn
+	// VersionPolicyStrict fails with an error matching ErrVersionMismatch
+	// when the server version is not supported.
+	VersionPolicyStrict
+)
+
+func (p VersionPolicy) String() string {
+	switch p {
+	case VersionPolicySkip:
+		return "skip"
+	case VersionPolicyWarn:
+		return "warn"
+	case VersionPolicyStrict:
+		return "strict"
+	}
+	return fmt.Sprintf("VersionPolicy(%d)", int(p))
+}
+
+// WithSocketPath connects to the socket at path instead of the default socket.
+func WithSocketPath(path string) Option {
+	return func(o *clientOptions) {
+		o.socketPath = path
+	}
+}
+
+// WithTimeout bounds every command sent on the connection. See client.WithCommandTimeout.
+func WithTimeout(timeout time.Duration) Option {
+	return WithConnectionOptions(client.WithCommandTimeout(timeout))
+}
+
+// WithRetry retries commands failing with transient errors. See client.WithRetry.
+func WithRetry(policy client.RetryPolicy) Option {
+	return WithConnectionOptions(client.WithRetry(policy))
+}
+
+// WithLogger sets the logger of the connection and of the version check.
+// Defaults to slog.Default().
+//
+// It cannot be combined with WithConnector, which creates its own connections.
+func WithLogger(logger *slog.Logger) Option {
+	return func(o *clientOptions) {
+		o.logger = logger
+	}
+}
+
+// WithVersionPolicy sets how the server version is checked once connected.
+// Defaults to VersionPolicySkip.
+func WithVersionPolicy(policy VersionPolicy) Option {
+	return func(o *clientOptions) {
+		o.versionPolicy = policy
+	}
+}
+
+// WithConnector connects with connector instead of dialing the socket,
+// e.g. a client.FakeConnection in tests.
+//
+// It cannot be combined with the options configuring the socket connection.
+func WithConnector(connector client.AeroSpaceConnector) Option {
+	return func(o *clientOptions) {
+		o.connector = connector
+	}
+}
+
+// WithConnectionOptions passes low-level options to the socket connection,
+// e.g. client.WithReconnect or client.WithDialTimeout.
+func WithConnectionOptions(opts ...client.Option) Option {
+	return func(o *clientOptions) {
+		o.connOptions = append(o.connOptions, opts...)
+	}
+}
+
+// WithMarksStore keeps the marks of Client.Marks in store instead of in memory,
+// e.g. a marks.FileStore to share them between processes.
+func WithMarksStore(store marks.Store) Option {
+	return func(o *clientOptions) {
+		o.marksStore = store
+	}
+}
+
+// connect opens the connection configured by the options.
+func (o *clientOptions) connect() (client.AeroSpaceConnection, error) {
+	if o.connector != nil {
+		if o.socketPath != "" || len(o.connOptions) > 0 || o.logger != nil {
+			return nil, fmt.Errorf("socket options cannot be used with a custom connector")
+		}
+		return o.connector.Connect()
+	}
+
+	connOptions := o.connOptions
+	if o.logger != nil {
+		connOptions = append([]client.Option{client.WithLogger(o.logger)}, connOptions...)
+	}
+
+	if o.socketPath != "" {
+		conn, err := client.NewAeroSpaceSocketConnection(o.socketPath, connOptions...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create a socket connection\n%w", err)
+		}
+		return conn, nil
+	}
+
+	connector, err := client.ConfigureConnector(client.GetDefaultConnector(), connOptions...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure the default connector\n%w", err)
+	}
+	return connector.Connect()
+}
+
+// checkVersion checks the server version of conn following the version policy.
+func (o *clientOptions) checkVersion(conn client.AeroSpaceConnection) error {
+	if o.versionPolicy == VersionPolicySkip {
+		return nil
+	}
+
+	err := conn.CheckServerVersion()
+	if err != nil && o.versionPolicy == VersionPolicyWarn {
+		logger := o.logger
+		if logger == nil {
+			logger = slog.Default()
+		}
+		logger.Warn("unsupported AeroSpace server version", "error", err)
+		return nil
+	}
+	return err
+}
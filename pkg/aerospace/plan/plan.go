@@ -0,0 +1,137 @@
+// Package plan describes a list of AeroSpace commands to be executed later.
+//
+// A Plan is a dry-run artifact: it can be reviewed, rendered for humans,
+// serialized to JSON and only then executed against a connection.
+package plan
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/cristianoliveira/aerospace-ipc/pkg/client"
+)
+
+// Action is a single command intended to be run, with the reason it is needed.
+//
+// Example JSON:
+//
+//	{
+//	  "command": "move-node-to-workspace",
+//	  "args": ["--window-id", "6231", "3"],
+//	  "reason": "Slack belongs on workspace 3"
+//	}
+type Action struct {
+	Command string   `json:"command"`
+	Args    []string `json:"args"`
+	Reason  string   `json:"reason,omitempty"`
+}
+
+// String returns the action as the equivalent CLI invocation.
+//
+// Example:
+//
+//	aerospace move-node-to-workspace --window-id 6231 3  # Slack belongs on workspace 3
+func (a Action) String() string {
+	builder := strings.Join(append([]string{"aerospace", a.Command}, a.Args...), " ")
+	if a.Reason != "" {
+		builder += fmt.Sprintf("  # %s", a.Reason)
+	}
+	return builder
+}
+
+// Plan is an ordered list of actions.
+//
+// The zero value is an empty plan ready to use.
+type Plan struct {
+	Actions []Action `json:"actions"`
+}
+
+// Add appends an action to the plan.
+//
+// Usage:
+//
+//	var p plan.Plan
+//	p.Add("Slack belongs on workspace 3", "move-node-to-workspace", "--window-id", "6231", "3")
+func (p *Plan) Add(reason string, command string, args ...string) {
+	p.Actions = append(p.Actions, Action{
+		Command: command,
+		Args:    args,
+		Reason:  reason,
+	})
+}
+
+// IsEmpty reports whether the plan has no actions.
+func (p Plan) IsEmpty() bool {
+	return len(p.Actions) == 0
+}
+
+// String renders the plan for humans, one numbered action per line.
+//
+// Example:
+//
+//	fmt.Println(p)
+//
+//	// Output:
+//	// 1) aerospace move-node-to-workspace --window-id 6231 3  # Slack belongs on workspace 3
+//	// 2) aerospace layout floating --window-id 6231  # Slack should be floating
+func (p Plan) String() string {
+	if p.IsEmpty() {
+		return "Nothing to do"
+	}
+
+	lines := make([]string, 0, len(p.Actions))
+	for i, action := range p.Actions {
+		lines = append(lines, fmt.Sprintf("%d) %s", i+1, action))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Execute runs the actions in order on the given connection.
+//
+// It stops at the first failing action and returns an error describing it.
+func (p Plan) Execute(conn client.AeroSpaceConnection) error {
+	_, err := p.run(conn.SendCommand)
+	return err
+}
+
+// ExecuteContext is like Execute but sends every action with ctx,
+// so the remaining actions are aborted once ctx is done.
+func (p Plan) ExecuteContext(ctx context.Context, conn client.AeroSpaceConnection) error {
+	_, err := p.ExecuteTaken(ctx, conn)
+	return err
+}
+
+// ExecuteTaken is like ExecuteContext but also returns the actions executed,
+// which are the ones before the failing action if any.
+//
+// Usage:
+//
+//	taken, err := p.ExecuteTaken(ctx, conn)
+//	if err != nil {
+//	    fmt.Printf("stopped after:\n%s\n", taken)
+//	}
+func (p Plan) ExecuteTaken(ctx context.Context, conn client.AeroSpaceConnection) (Plan, error) {
+	n, err := p.run(func(command string, args []string) (*client.Response, error) {
+		return conn.SendCommandContext(ctx, command, args)
+	})
+	return Plan{Actions: slices.Clone(p.Actions[:n])}, err
+}
+
+// run sends the actions in order and returns how many succeeded,
+// stopping at the first failing action.
+func (p Plan) run(send func(command string, args []string) (*client.Response, error)) (int, error) {
+	for i, action := range p.Actions {
+		response, err := send(action.Command, action.Args)
+		if err != nil {
+			return i, fmt.Errorf("failed to execute action %d (%s)\n%w", i+1, action, err)
+		}
+
+		if response.ExitCode != 0 {
+			return i, fmt.Errorf("failed to execute action %d (%s)\n%s", i+1, action, response.StdErr)
+		}
+	}
+
+	return len(p.Actions), nil
+}
@@ -0,0 +1,122 @@
+package plan
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	mock_client "github.com/cristianoliveira/aerospace-ipc/internal/mocks"
+	"github.com/cristianoliveira/aerospace-ipc/pkg/client"
+	"go.uber.org/mock/gomock"
+)
+
+func TestPlan(t *testing.T) {
+	var p Plan
+	p.Add("Slack belongs on workspace 3", "move-node-to-workspace", "--window-id", "6231", "3")
+	p.Add("", "layout", "floating", "--window-id", "6231")
+
+	t.Run("renders for humans", func(tt *testing.T) {
+		expected := "1) aerospace move-node-to-workspace --window-id 6231 3  # Slack belongs on workspace 3\n" +
+			"2) aerospace layout floating --window-id 6231"
+		if p.String() != expected {
+			tt.Fatalf("expected %q, got %q", expected, p.String())
+		}
+
+		if (Plan{}).String() != "Nothing to do" {
+			tt.Fatalf("unexpected rendering for empty plan: %q", Plan{}.String())
+		}
+	})
+
+	t.Run("round trips through JSON", func(tt *testing.T) {
+		data, err := json.Marshal(p)
+		if err != nil {
+			tt.Fatalf("failed to marshal plan: %v", err)
+		}
+
+		var decoded Plan
+		if err := json.Unmarshal(data, &decoded); err != nil {
+			tt.Fatalf("failed to unmarshal plan: %v", err)
+		}
+		if decoded.String() != p.String() {
+			tt.Fatalf("expected %q, got %q", p.String(), decoded.String())
+		}
+	})
+
+	t.Run("executes actions in order", func(tt *testing.T) {
+		ctrl := gomock.NewController(tt)
+		defer ctrl.Finish()
+
+		mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+		gomock.InOrder(
+			mockConn.EXPECT().
+				SendCommand("move-node-to-workspace", []string{"--window-id", "6231", "3"}).
+				Return(&client.Response{}, nil),
+			mockConn.EXPECT().
+				SendCommand("layout", []string{"floating", "--window-id", "6231"}).
+				Return(&client.Response{}, nil),
+		)
+
+		if err := p.Execute(mockConn); err != nil {
+			tt.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("executes actions with a context", func(tt *testing.T) {
+		ctrl := gomock.NewController(tt)
+		defer ctrl.Finish()
+
+		ctx := context.Background()
+		mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+		gomock.InOrder(
+			mockConn.EXPECT().
+				SendCommandContext(ctx, "move-node-to-workspace", []string{"--window-id", "6231", "3"}).
+				Return(&client.Response{}, nil),
+			mockConn.EXPECT().
+				SendCommandContext(ctx, "layout", []string{"floating", "--window-id", "6231"}).
+				Return(&client.Response{}, nil),
+		)
+
+		if err := p.ExecuteContext(ctx, mockConn); err != nil {
+			tt.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("stops at the first failing action", func(tt *testing.T) {
+		ctrl := gomock.NewController(tt)
+		defer ctrl.Finish()
+
+		mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+		mockConn.EXPECT().
+			SendCommand("move-node-to-workspace", []string{"--window-id", "6231", "3"}).
+			Return(nil, fmt.Errorf("connection error"))
+
+		if err := p.Execute(mockConn); err == nil {
+			tt.Fatal("expected error, got nil")
+		}
+	})
+
+	t.Run("returns the actions taken before the failing one", func(tt *testing.T) {
+		ctrl := gomock.NewController(tt)
+		defer ctrl.Finish()
+
+		ctx := context.Background()
+		mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+		gomock.InOrder(
+			mockConn.EXPECT().
+				SendCommandContext(ctx, "move-node-to-workspace", []string{"--window-id", "6231", "3"}).
+				Return(&client.Response{}, nil),
+			mockConn.EXPECT().
+				SendCommandContext(ctx, "layout", []string{"floating", "--window-id", "6231"}).
+				Return(&client.Response{ExitCode: 1, StdErr: "Window not found"}, nil),
+		)
+
+		taken, err := p.ExecuteTaken(ctx, mockConn)
+		if err == nil {
+			tt.Fatal("expected error, got nil")
+		}
+		if len(taken.Actions) != 1 || taken.Actions[0].Command != "move-node-to-workspace" {
+			tt.Fatalf("expected only the move to be taken, got:\n%s", taken)
+		}
+	})
+}
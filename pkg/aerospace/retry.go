@@ -0,0 +1,37 @@
+package aerospace
+
+import (
+	"context"
+
+	"github.com/cristianoliveira/aerospace-ipc/pkg/aerospace/retry"
+)
+
+// ErrConditionNotMet indicates that RetryUntil gave up before its condition was met.
+var ErrConditionNotMet = retry.ErrConditionNotMet
+
+// Defaults of RetryUntilOpts, see the retry package.
+const (
+	DefaultRetryUntilAttempts   = retry.DefaultAttempts
+	DefaultRetryUntilBackoff    = retry.DefaultBackoff
+	DefaultRetryUntilMaxBackoff = retry.DefaultMaxBackoff
+)
+
+// RetryUntilOpts contains optional parameters for RetryUntil.
+type RetryUntilOpts = retry.UntilOpts
+
+// RetryUntil checks the condition fn until it is met, backing off between attempts.
+// See retry.Until, which the services can use without importing this package.
+//
+// Usage:
+//
+//	// Wait for the window of a launched app to be tiled before moving it
+//	err := aerospace.RetryUntil(ctx, func(ctx context.Context) (bool, error) {
+//	    window, err := client.WithContext(ctx).Windows().GetFocusedWindow()
+//	    if err != nil {
+//	        return false, err
+//	    }
+//	    return window.AppName == "Ghostty" && !window.IsFloating(), nil
+//	})
+func RetryUntil(ctx context.Context, fn func(ctx context.Context) (bool, error), opts ...RetryUntilOpts) error {
+	return retry.Until(ctx, fn, opts...)
+}
@@ -0,0 +1,113 @@
+// Package retry waits for a condition on AeroSpace to be met, e.g. a window
+// being tiled after its launch. It has no dependency on the services,
+// so they can use it too.
+package retry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrConditionNotMet indicates that Until gave up before its condition was met.
+var ErrConditionNotMet = errors.New("condition not met")
+
+// Defaults of UntilOpts, tuned for the races of AeroSpace, e.g. a window
+// reported as tiled a moment after its launch, or the focus lagging behind a move.
+// They add up to about 1.5 seconds of waiting.
+const (
+	DefaultAttempts   = 10
+	DefaultBackoff    = 25 * time.Millisecond
+	DefaultMaxBackoff = 250 * time.Millisecond
+)
+
+// UntilOpts contains optional parameters for Until.
+type UntilOpts struct {
+	// MaxAttempts is the maximum number of times the condition is checked,
+	// including the first one. Defaults to DefaultAttempts.
+	MaxAttempts int
+
+	// Backoff is the delay before the first retry. It doubles on each subsequent retry.
+	// Defaults to DefaultBackoff.
+	Backoff time.Duration
+
+	// MaxBackoff caps the delay between retries. Defaults to DefaultMaxBackoff.
+	MaxBackoff time.Duration
+
+	// Retryable reports whether an error returned by the condition is worth retrying.
+	// Defaults to retrying every error.
+	Retryable func(err error) bool
+}
+
+// Until checks the condition fn until it is met, backing off between attempts.
+//
+// fn reports whether the condition is met. When it fails with an error that is not
+// retryable, Until returns the error right away. Once the attempts are exhausted,
+// it returns an error wrapping ErrConditionNotMet and the last error of fn, if any.
+// It stops early, with the cause of ctx, when ctx is done.
+//
+// Usage:
+//
+//	// Wait for the window of a launched app to be tiled before moving it
+//	err := retry.Until(ctx, func(ctx context.Context) (bool, error) {
+//	    window, err := client.WithContext(ctx).Windows().GetFocusedWindow()
+//	    if err != nil {
+//	        return false, err
+//	    }
+//	    return window.AppName == "Ghostty" && !window.IsFloating(), nil
+//	})
+func Until(ctx context.Context, fn func(ctx context.Context) (bool, error), opts ...UntilOpts) error {
+	if fn == nil {
+		panic("ASSERTION: fn cannot be nil")
+	}
+
+	var opt UntilOpts
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	if opt.MaxAttempts <= 0 {
+		opt.MaxAttempts = DefaultAttempts
+	}
+	if opt.Backoff <= 0 {
+		opt.Backoff = DefaultBackoff
+	}
+	if opt.MaxBackoff <= 0 {
+		opt.MaxBackoff = DefaultMaxBackoff
+	}
+
+	backoff := opt.Backoff
+	var lastErr error
+	for attempt := 1; ; attempt++ {
+		if err := ctx.Err(); err != nil {
+			return fmt.Errorf("failed to retry\n%w", context.Cause(ctx))
+		}
+
+		met, err := fn(ctx)
+		if err == nil && met {
+			return nil
+		}
+		if err != nil && opt.Retryable != nil && !opt.Retryable(err) {
+			return err
+		}
+		lastErr = err
+
+		if attempt == opt.MaxAttempts {
+			break
+		}
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("failed to retry\n%w", context.Cause(ctx))
+		case <-timer.C:
+		}
+		backoff = min(backoff*2, opt.MaxBackoff)
+	}
+
+	if lastErr != nil {
+		return fmt.Errorf("%w after %d attempts\n%w", ErrConditionNotMet, opt.MaxAttempts, lastErr)
+	}
+	return fmt.Errorf("%w after %d attempts", ErrConditionNotMet, opt.MaxAttempts)
+}
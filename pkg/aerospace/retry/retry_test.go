@@ -0,0 +1,78 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestUntil(t *testing.T) {
+	fast := UntilOpts{Backoff: time.Millisecond, MaxBackoff: time.Millisecond}
+
+	t.Run("Happy path", func(tt *testing.T) {
+		tt.Run("retries until the condition is met", func(ttt *testing.T) {
+			attempts := 0
+			err := Until(context.Background(), func(context.Context) (bool, error) {
+				attempts++
+				if attempts == 2 {
+					return false, errors.New("window not found")
+				}
+				return attempts == 3, nil
+			}, fast)
+			if err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+			if attempts != 3 {
+				ttt.Fatalf("expected 3 attempts, got %d", attempts)
+			}
+		})
+	})
+
+	t.Run("Error cases", func(tt *testing.T) {
+		tt.Run("gives up after the attempts", func(ttt *testing.T) {
+			windowErr := errors.New("window not found")
+			attempts := 0
+			opts := fast
+			opts.MaxAttempts = 4
+			err := Until(context.Background(), func(context.Context) (bool, error) {
+				attempts++
+				return false, windowErr
+			}, opts)
+			if !errors.Is(err, ErrConditionNotMet) || !errors.Is(err, windowErr) {
+				ttt.Fatalf("expected ErrConditionNotMet wrapping the last error, got %v", err)
+			}
+			if attempts != 4 {
+				ttt.Fatalf("expected 4 attempts, got %d", attempts)
+			}
+		})
+
+		tt.Run("stops at an error that is not retryable", func(ttt *testing.T) {
+			attempts := 0
+			closedErr := errors.New("connection closed")
+			opts := fast
+			opts.Retryable = func(err error) bool { return !errors.Is(err, closedErr) }
+			err := Until(context.Background(), func(context.Context) (bool, error) {
+				attempts++
+				return false, closedErr
+			}, opts)
+			if !errors.Is(err, closedErr) || errors.Is(err, ErrConditionNotMet) {
+				ttt.Fatalf("expected the connection error, got %v", err)
+			}
+			if attempts != 1 {
+				ttt.Fatalf("expected 1 attempt, got %d", attempts)
+			}
+		})
+
+		tt.Run("stops once the context is done", func(ttt *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			err := Until(ctx, func(context.Context) (bool, error) {
+				cancel()
+				return false, nil
+			})
+			if !errors.Is(err, context.Canceled) {
+				ttt.Fatalf("expected context.Canceled, got %v", err)
+			}
+		})
+	})
+}
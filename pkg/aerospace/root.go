@@ -1,9 +1,12 @@
 package aerospace
 
 import (
+	"github.com/cristianoliveira/aerospace-ipc/pkg/aerospace/monitors"
 	"github.com/cristianoliveira/aerospace-ipc/pkg/aerospace/windows"
 	"github.com/cristianoliveira/aerospace-ipc/pkg/aerospace/workspaces"
 )
 
 type Window = windows.Window
 type Workspace = workspaces.Workspace
+type WindowID = windows.WindowID
+type Monitor = monitors.Monitor
@@ -0,0 +1,224 @@
+// Package rules places new windows automatically, as the on-window-detected
+// callbacks of AeroSpace but on the client side: Watch polls for windows
+// that appeared and applies the first rule matching each of them.
+package rules
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/cristianoliveira/aerospace-ipc/internal/validate"
+	"github.com/cristianoliveira/aerospace-ipc/pkg/aerospace"
+	"github.com/cristianoliveira/aerospace-ipc/pkg/aerospace/apply"
+	"github.com/cristianoliveira/aerospace-ipc/pkg/aerospace/layout"
+	"github.com/cristianoliveira/aerospace-ipc/pkg/aerospace/plan"
+	"github.com/cristianoliveira/aerospace-ipc/pkg/client/commands"
+)
+
+// DefaultPollInterval is how often Watch lists the windows by default.
+const DefaultPollInterval = time.Second
+
+// Resize is the size to give to the matched windows, see layout.Service.Resize.
+type Resize struct {
+	Dimension layout.Dimension
+	// Amount is a number of pixels: "+100" and "-100" grow and shrink the window, "600" sets its size.
+	Amount string
+}
+
+// Rule places the new windows matching it.
+//
+// A window matches when it belongs to the application of the rule, as for
+// apply.Rule, and its title matches Title when set. At least one criterion
+// and one action must be set.
+type Rule struct {
+	// Name identifies the rule in the hooks and errors.
+	Name string
+
+	// AppBundleID matches the windows of the application with this bundle ID.
+	AppBundleID string
+	// AppName matches the windows of the application with this name, case-insensitively.
+	// It is ignored when AppBundleID is set.
+	AppName string
+	// Title matches the windows whose title matches the regular expression.
+	Title *regexp.Regexp
+
+	// Workspace moves the windows to this workspace.
+	Workspace string
+	// Floating makes the windows floating when true, or tiling when false.
+	Floating *bool
+	// Resize resizes the windows.
+	Resize *Resize
+}
+
+// Matches reports whether the window matches the criteria of the rule.
+func (r Rule) Matches(window aerospace.Window) bool {
+	if r.Title != nil && !r.Title.MatchString(window.WindowTitle) {
+		return false
+	}
+	return r.placement().Matches(window)
+}
+
+// Validate returns an error if the rule matches every window or does nothing.
+func (r Rule) Validate() error {
+	if r.AppBundleID == "" && r.AppName == "" && r.Title == nil {
+		return fmt.Errorf("rule %q: either AppBundleID, AppName or Title must be set", r.Name)
+	}
+	if r.Workspace == "" && r.Floating == nil && r.Resize == nil {
+		return fmt.Errorf("rule %q: either Workspace, Floating or Resize must be set", r.Name)
+	}
+	if r.Resize != nil {
+		err := validate.OneOf("dimension", r.Resize.Dimension,
+			layout.DimensionSmart, layout.DimensionSmartOpposite, layout.DimensionWidth, layout.DimensionHeight,
+		)
+		if err != nil {
+			return fmt.Errorf("rule %q: %w", r.Name, err)
+		}
+		if _, err := strconv.Atoi(r.Resize.Amount); err != nil {
+			return fmt.Errorf("rule %q: invalid resize amount %q, expected [+|-]<number>", r.Name, r.Resize.Amount)
+		}
+	}
+	return nil
+}
+
+// placement returns the application and placement of the rule, which are shared with apply.
+func (r Rule) placement() apply.Rule {
+	return apply.Rule{
+		AppBundleID: r.AppBundleID,
+		AppName:     r.AppName,
+		Workspace:   r.Workspace,
+		Floating:    r.Floating,
+	}
+}
+
+// plan returns the actions of the rule for the window, moving it before changing its layout and size.
+func (r Rule) plan(window aerospace.Window) plan.Plan {
+	p := r.placement().PlanWindow(window)
+	if r.Resize != nil {
+		p.Add(
+			fmt.Sprintf("%s should be resized to %s %s", window.AppName, r.Resize.Dimension, r.Resize.Amount),
+			commands.Resize,
+			"--window-id", window.WindowID.String(), string(r.Resize.Dimension), r.Resize.Amount,
+		)
+	}
+	return p
+}
+
+// WatchOpts contains optional parameters for Watch.
+type WatchOpts struct {
+	// PollInterval is how often the windows are listed. Defaults to DefaultPollInterval.
+	PollInterval time.Duration
+
+	// ApplyToExisting applies the rules to the windows already open when Watch starts.
+	// By default only the windows appearing afterwards are placed.
+	ApplyToExisting bool
+
+	// OnApplied is called after a rule was applied to a new window.
+	OnApplied func(window aerospace.Window, rule Rule)
+
+	// OnError is called when listing the windows, or applying a rule, fails.
+	// Watch keeps polling. A window whose rule failed is not retried.
+	OnError func(err error)
+}
+
+// Watch polls the windows and applies the first rule matching each new window,
+// until ctx is done. It then returns the cause of ctx being done.
+//
+// It is equivalent to running, every poll interval, the command:
+//
+//	aerospace list-windows --all --json --format <format>
+//
+// followed, for each new window matching a rule, by the commands:
+//
+//	aerospace move-node-to-workspace <workspace> --window-id <window-id>
+//	aerospace layout floating|tiling --window-id <window-id>
+//	aerospace resize <dimension> <amount> --window-id <window-id>
+//
+// Usage:
+//
+//	floating := true
+//	err := rules.Watch(ctx, client, []rules.Rule{
+//	    {Name: "slack", AppBundleID: "com.tinyspeck.slackmacgap", Workspace: "3"},
+//	    {Name: "pip", Title: regexp.MustCompile(`Picture-in-Picture`), Floating: &floating},
+//	}, rules.WatchOpts{
+//	    PollInterval: 500 * time.Millisecond,
+//	    OnError:      func(err error) { log.Println(err) },
+//	})
+func Watch(ctx context.Context, c aerospace.Client, rules []Rule, opts ...WatchOpts) error {
+	var opt WatchOpts
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	if opt.PollInterval <= 0 {
+		opt.PollInterval = DefaultPollInterval
+	}
+	for _, rule := range rules {
+		if err := rule.Validate(); err != nil {
+			return err
+		}
+	}
+
+	w := &watcher{ctx: ctx, client: c.WithContext(ctx), rules: rules, opts: opt}
+	w.poll()
+
+	ticker := time.NewTicker(opt.PollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return context.Cause(ctx)
+		case <-ticker.C:
+			w.poll()
+		}
+	}
+}
+
+// watcher keeps track of the windows already seen by Watch.
+type watcher struct {
+	ctx    context.Context
+	client aerospace.Client
+	rules  []Rule
+	opts   WatchOpts
+	// seen has the IDs of the windows open at the last successful poll, nil before it.
+	seen map[aerospace.WindowID]bool
+}
+
+// poll lists the windows and applies the rules to the new ones.
+// Unless ApplyToExisting is set, the windows listed by the first successful poll are not new.
+func (w *watcher) poll() {
+	all, err := w.client.Windows().GetAllWindows()
+	if err != nil {
+		w.onError(fmt.Errorf("failed to list windows\n%w", err))
+		return
+	}
+
+	skip := w.seen == nil && !w.opts.ApplyToExisting
+
+	seen := make(map[aerospace.WindowID]bool, len(all))
+	for _, window := range all {
+		seen[window.WindowID] = true
+		if skip || w.seen[window.WindowID] {
+			continue
+		}
+		for _, rule := range w.rules {
+			if !rule.Matches(window) {
+				continue
+			}
+			if err := rule.plan(window).ExecuteContext(w.ctx, w.client.Connection()); err != nil {
+				w.onError(fmt.Errorf("failed to apply rule %q to window %d\n%w", rule.Name, window.WindowID, err))
+			} else if w.opts.OnApplied != nil {
+				w.opts.OnApplied(window, rule)
+			}
+			break
+		}
+	}
+	w.seen = seen
+}
+
+func (w *watcher) onError(err error) {
+	if w.opts.OnError != nil {
+		w.opts.OnError(err)
+	}
+}
@@ -0,0 +1,193 @@
+package rules
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	mock_client "github.com/cristianoliveira/aerospace-ipc/internal/mocks"
+	"github.com/cristianoliveira/aerospace-ipc/pkg/aerospace"
+	"github.com/cristianoliveira/aerospace-ipc/pkg/aerospace/layout"
+	"github.com/cristianoliveira/aerospace-ipc/pkg/client"
+	"go.uber.org/mock/gomock"
+)
+
+// connector connects to an existing connection, e.g. a mock.
+type connector struct {
+	conn client.AeroSpaceConnection
+}
+
+func (c connector) Connect() (client.AeroSpaceConnection, error) {
+	return c.conn, nil
+}
+
+func newClient(t *testing.T, conn client.AeroSpaceConnection) aerospace.Client {
+	t.Helper()
+	c, err := aerospace.NewClient(aerospace.WithConnector(connector{conn}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return c
+}
+
+func TestRule(t *testing.T) {
+	window := aerospace.Window{AppName: "Safari", AppBundleID: "com.apple.Safari", WindowTitle: "Picture-in-Picture"}
+
+	for name, tc := range map[string]struct {
+		rule    Rule
+		matches bool
+	}{
+		"bundle ID":         {Rule{AppBundleID: "com.apple.Safari"}, true},
+		"app name":          {Rule{AppName: "safari"}, true},
+		"title":             {Rule{Title: regexp.MustCompile(`^Picture`)}, true},
+		"every criterion":   {Rule{AppName: "Safari", Title: regexp.MustCompile(`GitHub`)}, false},
+		"another bundle ID": {Rule{AppBundleID: "com.google.Chrome"}, false},
+		"bundle ID first":   {Rule{AppBundleID: "com.apple.Safari", AppName: "Safari Technology Preview"}, true},
+	} {
+		t.Run(name, func(tt *testing.T) {
+			if matches := tc.rule.Matches(window); matches != tc.matches {
+				tt.Fatalf("expected %v, got %v", tc.matches, matches)
+			}
+		})
+	}
+}
+
+func TestWatch(t *testing.T) {
+	t.Run("Happy path", func(tt *testing.T) {
+		tt.Run("Applies the rules to new windows", func(ttt *testing.T) {
+			ctrl := gomock.NewController(ttt)
+			defer ctrl.Finish()
+
+			var polls atomic.Int32
+			mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+			mockConn.EXPECT().
+				SendCommandContext(gomock.Any(), "list-windows", gomock.Any()).
+				DoAndReturn(func(context.Context, string, []string) (*client.Response, error) {
+					out := `[{"window-id": 1, "app-name": "Slack", "workspace": "1"}]`
+					if polls.Add(1) > 1 {
+						out = `[
+						  {"window-id": 1, "app-name": "Slack", "workspace": "1"},
+						  {"window-id": 2, "app-name": "Slack", "workspace": "1"},
+						  {"window-id": 3, "app-name": "Safari", "workspace": "1"}
+						]`
+					}
+					return &client.Response{StdOut: out}, nil
+				}).
+				AnyTimes()
+			mockConn.EXPECT().
+				SendCommandContext(gomock.Any(), "move-node-to-workspace", []string{"3", "--window-id", "2"}).
+				Return(&client.Response{}, nil)
+			mockConn.EXPECT().
+				SendCommandContext(gomock.Any(), "layout", []string{"floating", "--window-id", "2"}).
+				Return(&client.Response{}, nil)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			floating := true
+			var applied []aerospace.WindowID
+			err := Watch(ctx, newClient(ttt, mockConn), []Rule{
+				{Name: "slack", AppName: "Slack", Workspace: "3", Floating: &floating},
+			}, WatchOpts{
+				PollInterval: time.Millisecond,
+				OnApplied: func(window aerospace.Window, rule Rule) {
+					applied = append(applied, window.WindowID)
+					cancel()
+				},
+				OnError: func(err error) {
+					ttt.Errorf("unexpected error: %v", err)
+				},
+			})
+			if !errors.Is(err, context.Canceled) {
+				ttt.Fatalf("expected context.Canceled, got %v", err)
+			}
+			if len(applied) != 1 || applied[0] != 2 {
+				ttt.Fatalf("expected the rule applied to window 2, got %v", applied)
+			}
+		})
+
+		tt.Run("Applies the rules to existing windows", func(ttt *testing.T) {
+			ctrl := gomock.NewController(ttt)
+			defer ctrl.Finish()
+
+			mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+			mockConn.EXPECT().
+				SendCommandContext(gomock.Any(), "list-windows", gomock.Any()).
+				Return(&client.Response{StdOut: `[{"window-id": 1, "app-name": "Slack", "workspace": "1"}]`}, nil).
+				AnyTimes()
+			mockConn.EXPECT().
+				SendCommandContext(gomock.Any(), "resize", []string{"--window-id", "1", "width", "800"}).
+				Return(&client.Response{}, nil)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			err := Watch(ctx, newClient(ttt, mockConn), []Rule{
+				{Name: "slack", AppName: "Slack", Resize: &Resize{Dimension: layout.DimensionWidth, Amount: "800"}},
+			}, WatchOpts{
+				PollInterval:    time.Millisecond,
+				ApplyToExisting: true,
+				OnApplied:       func(aerospace.Window, Rule) { cancel() },
+			})
+			if !errors.Is(err, context.Canceled) {
+				ttt.Fatalf("expected context.Canceled, got %v", err)
+			}
+		})
+	})
+
+	t.Run("Error cases", func(tt *testing.T) {
+		tt.Run("Invalid rule", func(ttt *testing.T) {
+			ctrl := gomock.NewController(ttt)
+			defer ctrl.Finish()
+
+			mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+			err := Watch(context.Background(), newClient(ttt, mockConn), []Rule{{Name: "everything", Workspace: "1"}})
+			if err == nil {
+				ttt.Fatal("expected error, got nil")
+			}
+		})
+
+		tt.Run("Invalid resize", func(ttt *testing.T) {
+			ctrl := gomock.NewController(ttt)
+			defer ctrl.Finish()
+
+			mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+			err := Watch(context.Background(), newClient(ttt, mockConn), []Rule{
+				{Name: "slack", AppName: "Slack", Resize: &Resize{Dimension: layout.DimensionWidth, Amount: "wide"}},
+			})
+			if err == nil {
+				ttt.Fatal("expected error, got nil")
+			}
+		})
+
+		tt.Run("Reports failures and keeps polling", func(ttt *testing.T) {
+			ctrl := gomock.NewController(ttt)
+			defer ctrl.Finish()
+
+			mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+			mockConn.EXPECT().
+				SendCommandContext(gomock.Any(), "list-windows", gomock.Any()).
+				Return(&client.Response{StdOut: "not json"}, nil).
+				AnyTimes()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			var failures atomic.Int32
+			err := Watch(ctx, newClient(ttt, mockConn), []Rule{{Name: "slack", AppName: "Slack", Workspace: "3"}}, WatchOpts{
+				PollInterval: time.Millisecond,
+				OnError: func(error) {
+					if failures.Add(1) == 2 {
+						cancel()
+					}
+				},
+			})
+			if !errors.Is(err, context.Canceled) {
+				ttt.Fatalf("expected context.Canceled, got %v", err)
+			}
+		})
+	})
+}
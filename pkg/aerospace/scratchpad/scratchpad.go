@@ -0,0 +1,186 @@
+// Package scratchpad keeps windows out of sight on a dedicated workspace
+// and brings them back on demand, as the scratchpad of i3 and sway.
+//
+// AeroSpace has no scratchpad of its own: the windows are moved to a
+// workspace that is never shown, ".scratchpad" by default.
+package scratchpad
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cristianoliveira/aerospace-ipc/pkg/aerospace/focus"
+	"github.com/cristianoliveira/aerospace-ipc/pkg/aerospace/windows"
+	"github.com/cristianoliveira/aerospace-ipc/pkg/aerospace/workspaces"
+	"github.com/cristianoliveira/aerospace-ipc/pkg/client"
+)
+
+// DefaultWorkspace is the workspace holding the scratchpad windows by default.
+const DefaultWorkspace = ".scratchpad"
+
+// Service provides methods to hide windows in, and show them from, the scratchpad.
+type Service struct {
+	client    client.AeroSpaceConnection
+	ctx       context.Context
+	workspace string
+}
+
+// ScratchpadService defines the interface for scratchpad operations in AeroSpaceWM.
+type ScratchpadService interface {
+	// Send moves the window to the scratchpad.
+	Send(windowID client.WindowID) error
+
+	// Toggle hides the windows of an application in the scratchpad,
+	// or shows them on the focused workspace if they are all hidden.
+	Toggle(bundleID string) (bool, error)
+}
+
+// NewService creates a new scratchpad service with the given AeroSpace client connection,
+// using DefaultWorkspace as the scratchpad.
+func NewService(client client.AeroSpaceConnection) *Service {
+	return &Service{client: client, workspace: DefaultWorkspace}
+}
+
+// WithContext returns a copy of the service that sends every command with ctx,
+// so requests can be cancelled or bounded by a deadline.
+//
+// Usage:
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+//	defer cancel()
+//	err := scratchpadService.WithContext(ctx).Send(windowID)
+func (s *Service) WithContext(ctx context.Context) *Service {
+	if ctx == nil {
+		panic("ASSERTION: context cannot be nil")
+	}
+	return &Service{client: s.client, ctx: ctx, workspace: s.workspace}
+}
+
+// WithWorkspace returns a copy of the service using workspace as the scratchpad.
+//
+// Usage:
+//
+//	scratchpadService := scratchpad.NewService(conn).WithWorkspace("S")
+func (s *Service) WithWorkspace(workspace string) *Service {
+	if workspace == "" {
+		panic("ASSERTION: workspace cannot be empty")
+	}
+	return &Service{client: s.client, ctx: s.ctx, workspace: workspace}
+}
+
+// Workspace returns the name of the scratchpad workspace.
+func (s *Service) Workspace() string {
+	return s.workspace
+}
+
+// Send moves the window to the scratchpad.
+//
+// It is equivalent to running the command:
+//
+//	aerospace move-node-to-workspace <scratchpad> --window-id <window-id>
+//
+// Usage:
+//
+//	err := scratchpadService.Send(windowID)
+func (s *Service) Send(windowID client.WindowID) error {
+	return s.newWorkspacesService().MoveWindowToWorkspaceWithOpts(
+		workspaces.MoveWindowToWorkspaceArgs{WorkspaceName: s.workspace},
+		workspaces.MoveWindowToWorkspaceOpts{WindowID: &windowID},
+	)
+}
+
+// Toggle hides or shows the windows of the application with the given bundle ID.
+//
+// If any of its windows is outside of the scratchpad, they are all sent to it.
+// Otherwise they are moved to the focused workspace and the first one is focused.
+// It reports whether the windows were shown.
+//
+// It is equivalent to running the commands:
+//
+//	aerospace list-windows --monitor all --app-bundle-id <bundle-id> --json --format <format>
+//	aerospace move-node-to-workspace <scratchpad> --window-id <window-id>  # to hide, for each window
+//
+//	aerospace list-workspaces --focused --json --format <format>            # to show
+//	aerospace move-node-to-workspace <focused> --window-id <window-id>     # for each window
+//	aerospace focus --window-id <window-id>
+//
+// Returns an error wrapping focus.ErrWindowNotFound if the application has no window.
+//
+// Usage:
+//
+//	shown, err := scratchpadService.Toggle("com.mitchellh.ghostty")
+func (s *Service) Toggle(bundleID string) (bool, error) {
+	if bundleID == "" {
+		return false, fmt.Errorf("bundle ID cannot be empty")
+	}
+
+	appWindows, err := s.newWindowsService().GetWindowsByAppBundleID(bundleID)
+	if err != nil {
+		return false, err
+	}
+	if len(appWindows) == 0 {
+		return false, fmt.Errorf("%w\nno window of app %q", focus.ErrWindowNotFound, bundleID)
+	}
+
+	var shown []windows.Window
+	for _, window := range appWindows {
+		if window.Workspace != s.workspace {
+			shown = append(shown, window)
+		}
+	}
+
+	if len(shown) > 0 {
+		for _, window := range shown {
+			if err := s.Send(window.WindowID); err != nil {
+				return false, err
+			}
+		}
+		return false, nil
+	}
+
+	workspaceService := s.newWorkspacesService()
+	focused, err := workspaceService.GetFocusedWorkspace()
+	if err != nil {
+		return false, err
+	}
+	for _, window := range appWindows {
+		err := workspaceService.MoveWindowToWorkspaceWithOpts(
+			workspaces.MoveWindowToWorkspaceArgs{WorkspaceName: focused.Workspace},
+			workspaces.MoveWindowToWorkspaceOpts{WindowID: &window.WindowID},
+		)
+		if err != nil {
+			return false, err
+		}
+	}
+	if err := s.newFocusService().SetFocusByWindowID(appWindows[0].WindowID); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// newWindowsService returns a windows service sharing the connection and context.
+func (s *Service) newWindowsService() *windows.Service {
+	service := windows.NewService(s.client)
+	if s.ctx != nil {
+		service = service.WithContext(s.ctx)
+	}
+	return service
+}
+
+// newWorkspacesService returns a workspaces service sharing the connection and context.
+func (s *Service) newWorkspacesService() *workspaces.Service {
+	service := workspaces.NewService(s.client)
+	if s.ctx != nil {
+		service = service.WithContext(s.ctx)
+	}
+	return service
+}
+
+// newFocusService returns a focus service sharing the connection and context.
+func (s *Service) newFocusService() *focus.Service {
+	service := focus.NewService(s.client)
+	if s.ctx != nil {
+		service = service.WithContext(s.ctx)
+	}
+	return service
+}
@@ -0,0 +1,140 @@
+package scratchpad
+
+import (
+	"errors"
+	"testing"
+
+	mock_client "github.com/cristianoliveira/aerospace-ipc/internal/mocks"
+	"github.com/cristianoliveira/aerospace-ipc/pkg/aerospace/focus"
+	"github.com/cristianoliveira/aerospace-ipc/pkg/client"
+	"go.uber.org/mock/gomock"
+)
+
+// TestScratchpadServiceInterface ensures that Service implements ScratchpadService interface.
+// This is a compile-time check - if Service doesn't implement all methods, this will fail to compile.
+func TestScratchpadServiceInterface(t *testing.T) {
+	var _ ScratchpadService = (*Service)(nil)
+}
+
+// expectAppWindows expects the listing of the Ghostty windows, returning out.
+func expectAppWindows(mockConn *mock_client.MockAeroSpaceConnection, out string) *gomock.Call {
+	return mockConn.EXPECT().
+		SendCommand("list-windows", gomock.Cond(func(args []string) bool {
+			return len(args) > 3 && args[2] == "--app-bundle-id" && args[3] == "com.mitchellh.ghostty"
+		})).
+		Return(&client.Response{StdOut: out}, nil)
+}
+
+func TestScratchpad(t *testing.T) {
+	t.Run("Happy path", func(tt *testing.T) {
+		tt.Run("Send", func(ttt *testing.T) {
+			ctrl := gomock.NewController(ttt)
+			defer ctrl.Finish()
+
+			mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+			mockConn.EXPECT().
+				SendCommand("move-node-to-workspace", []string{".scratchpad", "--window-id", "42"}).
+				Return(&client.Response{}, nil)
+
+			if err := NewService(mockConn).Send(42); err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+		})
+
+		tt.Run("Send with a custom workspace", func(ttt *testing.T) {
+			ctrl := gomock.NewController(ttt)
+			defer ctrl.Finish()
+
+			mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+			mockConn.EXPECT().
+				SendCommand("move-node-to-workspace", []string{"S", "--window-id", "42"}).
+				Return(&client.Response{}, nil)
+
+			if err := NewService(mockConn).WithWorkspace("S").Send(42); err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+		})
+
+		tt.Run("Toggle hides visible windows", func(ttt *testing.T) {
+			ctrl := gomock.NewController(ttt)
+			defer ctrl.Finish()
+
+			mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+			gomock.InOrder(
+				expectAppWindows(mockConn, `[
+				  {"window-id": 1, "workspace": "1"},
+				  {"window-id": 2, "workspace": ".scratchpad"}
+				]`),
+				mockConn.EXPECT().
+					SendCommand("move-node-to-workspace", []string{".scratchpad", "--window-id", "1"}).
+					Return(&client.Response{}, nil),
+			)
+
+			shown, err := NewService(mockConn).Toggle("com.mitchellh.ghostty")
+			if err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+			if shown {
+				ttt.Fatal("expected the windows to be hidden")
+			}
+		})
+
+		tt.Run("Toggle shows hidden windows", func(ttt *testing.T) {
+			ctrl := gomock.NewController(ttt)
+			defer ctrl.Finish()
+
+			mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+			gomock.InOrder(
+				expectAppWindows(mockConn, `[
+				  {"window-id": 1, "workspace": ".scratchpad"},
+				  {"window-id": 2, "workspace": ".scratchpad"}
+				]`),
+				mockConn.EXPECT().
+					SendCommand("list-workspaces", gomock.Any()).
+					Return(&client.Response{StdOut: `[{"workspace": "3"}]`}, nil),
+				mockConn.EXPECT().
+					SendCommand("move-node-to-workspace", []string{"3", "--window-id", "1"}).
+					Return(&client.Response{}, nil),
+				mockConn.EXPECT().
+					SendCommand("move-node-to-workspace", []string{"3", "--window-id", "2"}).
+					Return(&client.Response{}, nil),
+				mockConn.EXPECT().
+					SendCommand("focus", []string{"--window-id", "1"}).
+					Return(&client.Response{}, nil),
+			)
+
+			shown, err := NewService(mockConn).Toggle("com.mitchellh.ghostty")
+			if err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+			if !shown {
+				ttt.Fatal("expected the windows to be shown")
+			}
+		})
+	})
+
+	t.Run("Error cases", func(tt *testing.T) {
+		tt.Run("Toggle without windows", func(ttt *testing.T) {
+			ctrl := gomock.NewController(ttt)
+			defer ctrl.Finish()
+
+			mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+			expectAppWindows(mockConn, `[]`)
+
+			_, err := NewService(mockConn).Toggle("com.mitchellh.ghostty")
+			if !errors.Is(err, focus.ErrWindowNotFound) {
+				ttt.Fatalf("expected ErrWindowNotFound, got %v", err)
+			}
+		})
+
+		tt.Run("Toggle without bundle ID", func(ttt *testing.T) {
+			ctrl := gomock.NewController(ttt)
+			defer ctrl.Finish()
+
+			mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+			if _, err := NewService(mockConn).Toggle(""); err == nil {
+				ttt.Fatal("expected error, got nil")
+			}
+		})
+	})
+}
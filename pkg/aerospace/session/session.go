@@ -0,0 +1,313 @@
+// Package session records on which workspace the windows of every application live,
+// and moves them back there later, e.g. to restore a layout after a reboot.
+//
+// Window IDs do not survive restarts, so windows are matched by application
+// and, when possible, by title.
+package session
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/cristianoliveira/aerospace-ipc/pkg/aerospace/plan"
+	"github.com/cristianoliveira/aerospace-ipc/pkg/aerospace/windows"
+	"github.com/cristianoliveira/aerospace-ipc/pkg/client"
+	"github.com/cristianoliveira/aerospace-ipc/pkg/client/commands"
+)
+
+// SchemaVersion is the version of the JSON schema of Session.
+const SchemaVersion = 1
+
+// Placement records the workspace of a window.
+type Placement struct {
+	AppBundleID string `json:"app-bundle-id,omitempty"`
+	AppName     string `json:"app-name"`
+	WindowTitle string `json:"window-title"`
+	Workspace   string `json:"workspace"`
+}
+
+// appKey identifies the application of the placement, by bundle ID or else by name.
+func (p Placement) appKey() string {
+	if p.AppBundleID != "" {
+		return p.AppBundleID
+	}
+	return p.AppName
+}
+
+// Session is a record of the workspace of every window.
+//
+// Example JSON:
+//
+//	{
+//	  "version": 1,
+//	  "placements": [
+//	    {"app-bundle-id": "com.tinyspeck.slackmacgap", "app-name": "Slack", "window-title": "general", "workspace": "3"}
+//	  ]
+//	}
+type Session struct {
+	Version    int         `json:"version"`
+	Placements []Placement `json:"placements"`
+}
+
+// Read decodes a session written by Write.
+//
+// Returns an error if the session was written with a newer schema.
+func Read(r io.Reader) (*Session, error) {
+	var session Session
+	if err := json.NewDecoder(r).Decode(&session); err != nil {
+		return nil, fmt.Errorf("failed to decode session\n%w", err)
+	}
+	if session.Version < 1 || session.Version > SchemaVersion {
+		return nil, fmt.Errorf("unsupported session schema version %d, expected 1 to %d", session.Version, SchemaVersion)
+	}
+	return &session, nil
+}
+
+// Write encodes the session as indented JSON.
+//
+// Usage:
+//
+//	file, err := os.Create("session.json")
+//	defer file.Close()
+//	err = session.Write(file)
+func (s *Session) Write(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(s); err != nil {
+		return fmt.Errorf("failed to encode session\n%w", err)
+	}
+	return nil
+}
+
+// RestoreResult reports what restoring a session did.
+type RestoreResult struct {
+	// Plan has an action for every window moved back to its workspace.
+	Plan plan.Plan
+
+	// Moved are the actions of Plan run by Restore, up to the first failing one.
+	Moved plan.Plan
+
+	// Unmatched are the placements without a window to restore,
+	// e.g. because the application is not running.
+	Unmatched []Placement
+}
+
+// Service records and restores sessions.
+type Service struct {
+	client client.AeroSpaceConnection
+	ctx    context.Context
+}
+
+// SessionService defines the interface for session operations in AeroSpaceWM.
+type SessionService interface {
+	// Record returns the placement of every window.
+	Record() (*Session, error)
+
+	// PlanRestore returns the moves restoring the session, without running them.
+	PlanRestore(session *Session) (RestoreResult, error)
+
+	// Restore moves the windows back to the workspaces recorded in the session.
+	Restore(session *Session) (RestoreResult, error)
+}
+
+// NewService creates a new session service with the given AeroSpace client connection.
+func NewService(client client.AeroSpaceConnection) *Service {
+	return &Service{client: client}
+}
+
+// WithContext returns a copy of the service that sends every command with ctx,
+// so requests can be cancelled or bounded by a deadline.
+//
+// Usage:
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+//	defer cancel()
+//	session, err := sessionService.WithContext(ctx).Record()
+func (s *Service) WithContext(ctx context.Context) *Service {
+	if ctx == nil {
+		panic("ASSERTION: context cannot be nil")
+	}
+	return &Service{client: s.client, ctx: ctx}
+}
+
+// Record returns the placement of every window, in the order listed by AeroSpace.
+//
+// It is equivalent to running the command:
+//
+//	aerospace list-windows --all --json --format <format>
+//
+// Usage:
+//
+//	session, err := sessionService.Record()
+//	err = session.Write(file)
+func (s *Service) Record() (*Session, error) {
+	all, err := s.newWindowsService().GetAllWindows()
+	if err != nil {
+		return nil, err
+	}
+
+	session := &Session{Version: SchemaVersion, Placements: make([]Placement, 0, len(all))}
+	for _, window := range all {
+		session.Placements = append(session.Placements, Placement{
+			AppBundleID: window.AppBundleID,
+			AppName:     window.AppName,
+			WindowTitle: window.WindowTitle,
+			Workspace:   window.Workspace,
+		})
+	}
+	return session, nil
+}
+
+// PlanRestore returns the moves restoring the session, without running them.
+//
+// The windows of an application are matched to its placements by title first,
+// and the remaining ones in order. Windows already on their recorded workspace
+// are not moved, and windows without a placement are left alone.
+//
+// It is equivalent to running the command:
+//
+//	aerospace list-windows --all --json --format <format>
+//
+// Usage:
+//
+//	result, err := sessionService.PlanRestore(session)
+//	fmt.Println(result.Plan)
+func (s *Service) PlanRestore(session *Session) (RestoreResult, error) {
+	if session == nil {
+		return RestoreResult{}, fmt.Errorf("session cannot be nil")
+	}
+
+	all, err := s.newWindowsService().GetAllWindows()
+	if err != nil {
+		return RestoreResult{}, err
+	}
+
+	windowsByApp := map[string][]windows.Window{}
+	for _, window := range all {
+		key := Placement{AppBundleID: window.AppBundleID, AppName: window.AppName}.appKey()
+		windowsByApp[key] = append(windowsByApp[key], window)
+	}
+
+	placementsByApp := map[string][]Placement{}
+	var apps []string
+	for _, placement := range session.Placements {
+		key := placement.appKey()
+		if _, ok := placementsByApp[key]; !ok {
+			apps = append(apps, key)
+		}
+		placementsByApp[key] = append(placementsByApp[key], placement)
+	}
+
+	var result RestoreResult
+	for _, app := range apps {
+		matches, unmatched := match(placementsByApp[app], windowsByApp[app])
+		result.Unmatched = append(result.Unmatched, unmatched...)
+		for _, m := range matches {
+			if m.window.Workspace == m.placement.Workspace {
+				continue
+			}
+			result.Plan.Add(
+				fmt.Sprintf("%s (%s) was on workspace %s", m.window.AppName, m.window.WindowTitle, m.placement.Workspace),
+				commands.MoveNodeToWorkspace,
+				m.placement.Workspace, "--window-id", strconv.Itoa(int(m.window.WindowID)),
+			)
+		}
+	}
+	return result, nil
+}
+
+// Restore moves the windows back to the workspaces recorded in the session.
+//
+// See PlanRestore for how windows are matched. It stops at the first move failing,
+// and the returned result still describes the whole plan, with the moves done in Moved.
+//
+// It is equivalent to running the commands:
+//
+//	aerospace list-windows --all --json --format <format>
+//	aerospace move-node-to-workspace <workspace> --window-id <window-id>  # for each window to move
+//
+// Usage:
+//
+//	file, err := os.Open("session.json")
+//	session, err := session.Read(file)
+//	result, err := sessionService.Restore(session)
+//	for _, placement := range result.Unmatched {
+//	    fmt.Printf("%s (%s) is not running\n", placement.AppName, placement.WindowTitle)
+//	}
+func (s *Service) Restore(session *Session) (RestoreResult, error) {
+	result, err := s.PlanRestore(session)
+	if err != nil {
+		return result, err
+	}
+
+	ctx := s.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	result.Moved, err = result.Plan.ExecuteTaken(ctx, s.client)
+	if err != nil {
+		return result, fmt.Errorf("failed to restore session\n%w", err)
+	}
+	return result, nil
+}
+
+// matched is a placement with the window restored to it.
+type matched struct {
+	placement Placement
+	window    windows.Window
+}
+
+// match pairs the placements of an application with its windows, by title first
+// and the remaining ones in order. It returns the pairs and the placements left.
+func match(placements []Placement, appWindows []windows.Window) ([]matched, []Placement) {
+	used := make([]bool, len(appWindows))
+	pairs := make([]*windows.Window, len(placements))
+
+	for i, placement := range placements {
+		for j := range appWindows {
+			if !used[j] && appWindows[j].WindowTitle == placement.WindowTitle {
+				used[j] = true
+				pairs[i] = &appWindows[j]
+				break
+			}
+		}
+	}
+
+	next := 0
+	for i := range placements {
+		if pairs[i] != nil {
+			continue
+		}
+		for next < len(appWindows) && used[next] {
+			next++
+		}
+		if next == len(appWindows) {
+			break
+		}
+		used[next] = true
+		pairs[i] = &appWindows[next]
+	}
+
+	var matches []matched
+	var unmatched []Placement
+	for i, placement := range placements {
+		if pairs[i] == nil {
+			unmatched = append(unmatched, placement)
+			continue
+		}
+		matches = append(matches, matched{placement: placement, window: *pairs[i]})
+	}
+	return matches, unmatched
+}
+
+// newWindowsService returns a windows service sharing the connection and context.
+func (s *Service) newWindowsService() *windows.Service {
+	service := windows.NewService(s.client)
+	if s.ctx != nil {
+		service = service.WithContext(s.ctx)
+	}
+	return service
+}
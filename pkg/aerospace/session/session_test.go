@@ -0,0 +1,125 @@
+package session
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/cristianoliveira/aerospace-ipc/pkg/client"
+)
+
+// TestSessionServiceInterface ensures that Service implements SessionService interface.
+// This is a compile-time check - if Service doesn't implement all methods, this will fail to compile.
+func TestSessionServiceInterface(t *testing.T) {
+	var _ SessionService = (*Service)(nil)
+}
+
+func TestSession(t *testing.T) {
+	recorded := &Session{
+		Version: SchemaVersion,
+		Placements: []Placement{
+			{AppBundleID: "com.mitchellh.ghostty", AppName: "Ghostty", WindowTitle: "zsh", Workspace: "1"},
+			{AppBundleID: "com.mitchellh.ghostty", AppName: "Ghostty", WindowTitle: "vim", Workspace: "2"},
+			{AppBundleID: "com.tinyspeck.slackmacgap", AppName: "Slack", WindowTitle: "general", Workspace: "3"},
+			{AppBundleID: "com.apple.Safari", AppName: "Safari", WindowTitle: "GitHub", Workspace: "4"},
+		},
+	}
+
+	t.Run("Happy path", func(tt *testing.T) {
+		tt.Run("Record", func(ttt *testing.T) {
+			conn := client.NewFakeConnection(client.FakeState{
+				Windows: []client.FakeWindow{
+					{ID: 1, AppName: "Ghostty", AppBundleID: "com.mitchellh.ghostty", Title: "zsh", Workspace: "1"},
+				},
+			})
+
+			session, err := NewService(conn).Record()
+			if err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+			expected := &Session{Version: SchemaVersion, Placements: recorded.Placements[:1]}
+			if !reflect.DeepEqual(session, expected) {
+				ttt.Fatalf("expected %+v, got %+v", expected, session)
+			}
+		})
+
+		tt.Run("Restore matches by title, then in order", func(ttt *testing.T) {
+			conn := client.NewFakeConnection(client.FakeState{
+				Windows: []client.FakeWindow{
+					{ID: 10, AppName: "Ghostty", AppBundleID: "com.mitchellh.ghostty", Title: "vim", Workspace: "1"},
+					{ID: 11, AppName: "Ghostty", AppBundleID: "com.mitchellh.ghostty", Title: "htop", Workspace: "1"},
+					{ID: 12, AppName: "Slack", AppBundleID: "com.tinyspeck.slackmacgap", Title: "random", Workspace: "1"},
+					{ID: 13, AppName: "Finder", AppBundleID: "com.apple.finder", Title: "Home", Workspace: "5"},
+				},
+			})
+
+			result, err := NewService(conn).Restore(recorded)
+			if err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+			if len(result.Plan.Actions) != 2 {
+				ttt.Fatalf("expected 2 moves, got:\n%s", result.Plan)
+			}
+			if !reflect.DeepEqual(result.Moved, result.Plan) {
+				ttt.Fatalf("expected every move to be done, got:\n%s", result.Moved)
+			}
+			if len(result.Unmatched) != 1 || result.Unmatched[0].AppName != "Safari" {
+				ttt.Fatalf("expected Safari to be unmatched, got %+v", result.Unmatched)
+			}
+
+			workspaces := map[int]string{}
+			for _, window := range conn.State().Windows {
+				workspaces[window.ID] = window.Workspace
+			}
+			expected := map[int]string{10: "2", 11: "1", 12: "3", 13: "5"}
+			if !reflect.DeepEqual(workspaces, expected) {
+				ttt.Fatalf("expected %v, got %v", expected, workspaces)
+			}
+		})
+
+		tt.Run("Write and Read round trip", func(ttt *testing.T) {
+			var buf bytes.Buffer
+			if err := recorded.Write(&buf); err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+			decoded, err := Read(&buf)
+			if err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(decoded, recorded) {
+				ttt.Fatalf("expected %+v, got %+v", recorded, decoded)
+			}
+		})
+	})
+
+	t.Run("Error cases", func(tt *testing.T) {
+		tt.Run("Read a newer schema", func(ttt *testing.T) {
+			if _, err := Read(strings.NewReader(`{"version": 2, "placements": []}`)); err == nil {
+				ttt.Fatal("expected error, got nil")
+			}
+		})
+
+		tt.Run("Restore a nil session", func(ttt *testing.T) {
+			conn := client.NewFakeConnection(client.FakeState{})
+			if _, err := NewService(conn).Restore(nil); err == nil {
+				ttt.Fatal("expected error, got nil")
+			}
+		})
+
+		tt.Run("Restore on a closed connection", func(ttt *testing.T) {
+			conn := client.NewFakeConnection(client.FakeState{
+				Windows: []client.FakeWindow{
+					{ID: 10, AppName: "Ghostty", AppBundleID: "com.mitchellh.ghostty", Title: "zsh", Workspace: "1"},
+				},
+			})
+			if err := conn.CloseConnection(); err != nil {
+				ttt.Fatal(err)
+			}
+
+			if _, err := NewService(conn).Restore(recorded); err == nil {
+				ttt.Fatal("expected error, got nil")
+			}
+		})
+	})
+}
@@ -0,0 +1,123 @@
+package aerospace
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/cristianoliveira/aerospace-ipc/pkg/aerospace/windows"
+)
+
+// State is a snapshot of AeroSpace, the building block for bars, pickers and rule engines.
+//
+// It is encoded to JSON with a versioned schema, see MarshalJSON.
+type State struct {
+	Monitors   []Monitor
+	Workspaces []Workspace
+	Windows    []Window
+
+	// FocusedWindow is nil when the focused workspace has no window.
+	FocusedWindow *Window
+
+	// FocusedWorkspace is the workspace of Workspaces marked as focused,
+	// or nil if none is.
+	FocusedWorkspace *Workspace
+
+	// Mode is the current binding mode.
+	Mode string
+}
+
+// Tree nests the windows of the state under their workspace,
+// and the workspaces under their monitor. See GetTree.
+func (s *State) Tree() *Tree {
+	return buildTree(s.Monitors, s.Workspaces, s.Windows)
+}
+
+// GetState returns a snapshot of the monitors, workspaces, windows, focus and binding mode,
+// sending every command with ctx.
+//
+// The queries are sent concurrently and are not read atomically. If any of them
+// fails, no state is returned and the error joins every failure.
+//
+// It is equivalent to running the commands:
+//
+//	aerospace list-monitors --json --format <format>
+//	aerospace list-workspaces --all --json --format <format>
+//	aerospace list-windows --all --json --format <format>
+//	aerospace list-windows --focused --json --format <format>
+//	aerospace list-modes --current
+//
+// Usage:
+//
+//	state, err := client.GetState(ctx)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(state.Mode, state.FocusedWorkspace.Workspace, len(state.Windows))
+func (a *AeroSpaceWM) GetState(ctx context.Context) (*State, error) {
+	c := a.WithContext(ctx)
+	// The services are created before starting the queries, since their lazy creation is not synchronized.
+	monitorsService, workspacesService, windowsService, modesService := c.Monitors(), c.Workspaces(), c.Windows(), c.Modes()
+
+	var (
+		wg    sync.WaitGroup
+		mu    sync.Mutex
+		errs  []error
+		state State
+	)
+	query := func(name string, fn func() error) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := fn(); err != nil {
+				mu.Lock()
+				defer mu.Unlock()
+				errs = append(errs, fmt.Errorf("%s\n%w", name, err))
+			}
+		}()
+	}
+
+	query("monitors", func() (err error) {
+		state.Monitors, err = monitorsService.GetAllMonitors()
+		return err
+	})
+	query("workspaces", func() (err error) {
+		state.Workspaces, err = workspacesService.GetAllWorkspaces()
+		return err
+	})
+	query("windows", func() (err error) {
+		state.Windows, err = windowsService.GetAllWindows()
+		return err
+	})
+	query("focused window", func() error {
+		focused, err := windowsService.GetWindowsWithOpts(windows.ListWindowsOpts{Focused: true})
+		if len(focused) > 0 {
+			state.FocusedWindow = &focused[0]
+		}
+		return err
+	})
+	query("mode", func() (err error) {
+		state.Mode, err = modesService.Current()
+		return err
+	})
+	wg.Wait()
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, fmt.Errorf("failed to get state\n%w", err)
+	}
+
+	state.resolveFocusedWorkspace()
+	return &state, nil
+}
+
+// resolveFocusedWorkspace points FocusedWorkspace to the workspace marked as focused.
+func (s *State) resolveFocusedWorkspace() {
+	s.FocusedWorkspace = nil
+	for i := range s.Workspaces {
+		if s.Workspaces[i].IsFocused {
+			s.FocusedWorkspace = &s.Workspaces[i]
+			return
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package aerospace
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/cristianoliveira/aerospace-ipc/pkg/client"
+)
+
+func TestGetState(t *testing.T) {
+	t.Run("Happy path", func(tt *testing.T) {
+		tt.Run("Snapshot with a focused window", func(ttt *testing.T) {
+			wm := &AeroSpaceWM{conn: client.NewFakeConnection(client.FakeState{
+				Windows: []client.FakeWindow{
+					{ID: 1, AppName: "Ghostty", Workspace: "1"},
+					{ID: 2, AppName: "Safari", Workspace: "2"},
+				},
+				FocusedWindowID: 2,
+				Mode:            "resize",
+			})}
+
+			state, err := wm.GetState(context.Background())
+			if err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+			if len(state.Monitors) != 1 || len(state.Workspaces) != 2 || len(state.Windows) != 2 {
+				ttt.Fatalf("unexpected state %+v", state)
+			}
+			if state.FocusedWindow == nil || state.FocusedWindow.WindowID != 2 {
+				ttt.Fatalf("expected window 2 focused, got %+v", state.FocusedWindow)
+			}
+			if state.FocusedWorkspace == nil || state.FocusedWorkspace.Workspace != "2" {
+				ttt.Fatalf("expected workspace 2 focused, got %+v", state.FocusedWorkspace)
+			}
+			if state.Mode != "resize" {
+				ttt.Fatalf("expected mode 'resize', got %q", state.Mode)
+			}
+			if tree := state.Tree(); len(tree.Monitors[0].Workspaces) != 2 {
+				ttt.Fatalf("expected both workspaces in the tree, got %+v", tree)
+			}
+		})
+
+		tt.Run("Snapshot without a focused window", func(ttt *testing.T) {
+			wm := &AeroSpaceWM{conn: client.NewFakeConnection(client.FakeState{
+				Workspaces:       []client.FakeWorkspace{{Name: "1"}},
+				FocusedWorkspace: "1",
+			})}
+
+			state, err := wm.GetState(context.Background())
+			if err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+			if state.FocusedWindow != nil {
+				ttt.Fatalf("expected no focused window, got %+v", state.FocusedWindow)
+			}
+			if state.FocusedWorkspace == nil || state.FocusedWorkspace.Workspace != "1" {
+				ttt.Fatalf("expected workspace 1 focused, got %+v", state.FocusedWorkspace)
+			}
+		})
+	})
+
+	t.Run("Error cases", func(tt *testing.T) {
+		tt.Run("Joins the failures", func(ttt *testing.T) {
+			_, err := (&AeroSpaceWM{}).GetState(context.Background())
+			if !errors.Is(err, ErrNotInitialized) {
+				ttt.Fatalf("expected ErrNotInitialized, got %v", err)
+			}
+		})
+
+		tt.Run("Cancelled context", func(ttt *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+
+			wm := &AeroSpaceWM{conn: client.NewFakeConnection(client.FakeState{})}
+			if _, err := wm.GetState(ctx); !errors.Is(err, context.Canceled) {
+				ttt.Fatalf("expected context.Canceled, got %v", err)
+			}
+		})
+	})
+}
@@ -0,0 +1,182 @@
+package aerospace
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// Tree is a snapshot of the monitors, their workspaces and the windows of every workspace.
+type Tree struct {
+	Monitors []MonitorNode `json:"monitors"`
+}
+
+// MonitorNode is a monitor with its workspaces, in the order listed by AeroSpace.
+type MonitorNode struct {
+	Monitor
+	Workspaces []WorkspaceNode `json:"workspaces"`
+}
+
+// WorkspaceNode is a workspace with its windows, in the order listed by AeroSpace.
+type WorkspaceNode struct {
+	Workspace
+	Windows []Window `json:"windows"`
+}
+
+// GetTree returns the monitors, workspaces and windows as one nested structure.
+//
+// The three lists are queried concurrently. Since they are not read atomically,
+// a workspace or window created between the queries, whose monitor or workspace
+// is missing from the other lists, is left out of the tree.
+//
+// It is equivalent to running the commands:
+//
+//	aerospace list-monitors --json --format <format>
+//	aerospace list-workspaces --all --json --format <format>
+//	aerospace list-windows --all --json --format <format>
+//
+// Usage:
+//
+//	tree, err := client.GetTree()
+//	for _, monitor := range tree.Monitors {
+//	    for _, workspace := range monitor.Workspaces {
+//	        fmt.Println(monitor.MonitorName, workspace.Workspace, len(workspace.Windows))
+//	    }
+//	}
+func (a *AeroSpaceWM) GetTree() (*Tree, error) {
+	// The services are created before starting the queries, since their lazy creation is not synchronized.
+	monitorsService, workspacesService, windowsService := a.Monitors(), a.Workspaces(), a.Windows()
+
+	var (
+		wg            sync.WaitGroup
+		monitorList   []Monitor
+		workspaceList []Workspace
+		windowList    []Window
+		monitorsErr   error
+		workspacesErr error
+		windowsErr    error
+	)
+	wg.Add(3)
+	go func() {
+		defer wg.Done()
+		monitorList, monitorsErr = monitorsService.GetAllMonitors()
+	}()
+	go func() {
+		defer wg.Done()
+		workspaceList, workspacesErr = workspacesService.GetAllWorkspaces()
+	}()
+	go func() {
+		defer wg.Done()
+		windowList, windowsErr = windowsService.GetAllWindows()
+	}()
+	wg.Wait()
+
+	if err := errors.Join(monitorsErr, workspacesErr, windowsErr); err != nil {
+		return nil, fmt.Errorf("failed to get tree\n%w", err)
+	}
+	return buildTree(monitorList, workspaceList, windowList), nil
+}
+
+// buildTree nests the windows under their workspace and the workspaces under their monitor.
+func buildTree(monitorList []Monitor, workspaceList []Workspace, windowList []Window) *Tree {
+	windowsByWorkspace := make(map[string][]Window, len(workspaceList))
+	for _, window := range windowList {
+		windowsByWorkspace[window.Workspace] = append(windowsByWorkspace[window.Workspace], window)
+	}
+
+	workspacesByMonitor := make(map[int][]WorkspaceNode, len(monitorList))
+	for _, workspace := range workspaceList {
+		workspacesByMonitor[workspace.MonitorID] = append(workspacesByMonitor[workspace.MonitorID], WorkspaceNode{
+			Workspace: workspace,
+			Windows:   windowsByWorkspace[workspace.Workspace],
+		})
+	}
+
+	tree := &Tree{Monitors: make([]MonitorNode, 0, len(monitorList))}
+	for _, monitor := range monitorList {
+		tree.Monitors = append(tree.Monitors, MonitorNode{
+			Monitor:    monitor,
+			Workspaces: workspacesByMonitor[monitor.MonitorID],
+		})
+	}
+	return tree
+}
+
+// monitorFields and workspaceFields have the fields of Monitor and Workspace but
+// none of their methods, which would otherwise be promoted to the nodes embedding
+// them and encode the nodes without their children.
+type (
+	monitorFields   Monitor
+	workspaceFields Workspace
+)
+
+// monitorNodeJSON is the JSON schema of MonitorNode: the monitor keys and its workspaces.
+type monitorNodeJSON struct {
+	monitorFields
+	Workspaces []WorkspaceNode `json:"workspaces"`
+}
+
+// workspaceNodeJSON is the JSON schema of WorkspaceNode: the workspace keys and its windows.
+type workspaceNodeJSON struct {
+	workspaceFields
+	Windows []Window `json:"windows"`
+}
+
+// MarshalJSON encodes the monitor keys together with its workspaces.
+func (n MonitorNode) MarshalJSON() ([]byte, error) {
+	return json.Marshal(monitorNodeJSON{
+		monitorFields: monitorFields(n.Monitor),
+		Workspaces:    nonNil(n.Workspaces),
+	})
+}
+
+// UnmarshalJSON decodes a monitor node encoded by MarshalJSON.
+func (n *MonitorNode) UnmarshalJSON(data []byte) error {
+	var decoded monitorNodeJSON
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	n.Monitor = Monitor(decoded.monitorFields)
+	n.Workspaces = decoded.Workspaces
+	return nil
+}
+
+// MarshalText encodes the monitor node as its JSON object.
+func (n MonitorNode) MarshalText() ([]byte, error) {
+	return n.MarshalJSON()
+}
+
+// UnmarshalText decodes a monitor node encoded by MarshalText.
+func (n *MonitorNode) UnmarshalText(text []byte) error {
+	return n.UnmarshalJSON(text)
+}
+
+// MarshalJSON encodes the workspace keys together with its windows.
+func (n WorkspaceNode) MarshalJSON() ([]byte, error) {
+	return json.Marshal(workspaceNodeJSON{
+		workspaceFields: workspaceFields(n.Workspace),
+		Windows:         nonNil(n.Windows),
+	})
+}
+
+// UnmarshalJSON decodes a workspace node encoded by MarshalJSON.
+func (n *WorkspaceNode) UnmarshalJSON(data []byte) error {
+	var decoded workspaceNodeJSON
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	n.Workspace = Workspace(decoded.workspaceFields)
+	n.Windows = decoded.Windows
+	return nil
+}
+
+// MarshalText encodes the workspace node as its JSON object.
+func (n WorkspaceNode) MarshalText() ([]byte, error) {
+	return n.MarshalJSON()
+}
+
+// UnmarshalText decodes a workspace node encoded by MarshalText.
+func (n *WorkspaceNode) UnmarshalText(text []byte) error {
+	return n.UnmarshalJSON(text)
+}
@@ -0,0 +1,90 @@
+package aerospace
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/cristianoliveira/aerospace-ipc/pkg/client"
+)
+
+func TestGetTree(t *testing.T) {
+	t.Run("Happy path", func(tt *testing.T) {
+		wm := &AeroSpaceWM{conn: client.NewFakeConnection(client.FakeState{
+			Monitors: []client.FakeMonitor{
+				{ID: 1, Name: "Built-in Retina Display"},
+				{ID: 2, Name: "DELL U2720Q"},
+			},
+			Workspaces: []client.FakeWorkspace{
+				{Name: "1", MonitorID: 1},
+				{Name: "2", MonitorID: 2},
+				{Name: "3", MonitorID: 2},
+			},
+			Windows: []client.FakeWindow{
+				{ID: 1, AppName: "Ghostty", Workspace: "1"},
+				{ID: 2, AppName: "Safari", Workspace: "2"},
+				{ID: 3, AppName: "Slack", Workspace: "2"},
+			},
+		})}
+
+		tree, err := wm.GetTree()
+		if err != nil {
+			tt.Fatalf("unexpected error: %v", err)
+		}
+		if len(tree.Monitors) != 2 {
+			tt.Fatalf("expected 2 monitors, got %+v", tree.Monitors)
+		}
+
+		builtin, dell := tree.Monitors[0], tree.Monitors[1]
+		if len(builtin.Workspaces) != 1 || len(builtin.Workspaces[0].Windows) != 1 {
+			tt.Fatalf("expected workspace 1 with a window on the built-in display, got %+v", builtin)
+		}
+		if dell.MonitorName != "DELL U2720Q" || len(dell.Workspaces) != 2 {
+			tt.Fatalf("expected workspaces 2 and 3 on the DELL, got %+v", dell)
+		}
+		if ws := dell.Workspaces[0]; ws.Workspace.Workspace != "2" || len(ws.Windows) != 2 || ws.Windows[1].AppName != "Slack" {
+			tt.Fatalf("expected Safari and Slack on workspace 2, got %+v", ws)
+		}
+		if ws := dell.Workspaces[1]; ws.Workspace.Workspace != "3" || len(ws.Windows) != 0 {
+			tt.Fatalf("expected workspace 3 to be empty, got %+v", ws)
+		}
+	})
+
+	t.Run("JSON round trip", func(tt *testing.T) {
+		tree := &Tree{Monitors: []MonitorNode{{
+			Monitor: Monitor{MonitorID: 1, MonitorName: "Built-in Retina Display"},
+			Workspaces: []WorkspaceNode{{
+				Workspace: Workspace{Workspace: "1", MonitorID: 1},
+				Windows:   []Window{{WindowID: 1, AppName: "Ghostty", Workspace: "1"}},
+			}},
+		}}}
+
+		data, err := json.Marshal(tree)
+		if err != nil {
+			tt.Fatalf("unexpected error: %v", err)
+		}
+		for _, key := range []string{`"monitor-name"`, `"workspaces"`, `"windows"`, `"app-name"`} {
+			if !strings.Contains(string(data), key) {
+				tt.Fatalf("expected %s in %s", key, data)
+			}
+		}
+
+		var decoded Tree
+		if err := json.Unmarshal(data, &decoded); err != nil {
+			tt.Fatalf("unexpected error: %v", err)
+		}
+		if !reflect.DeepEqual(&decoded, tree) {
+			tt.Fatalf("expected %+v, got %+v", tree, decoded)
+		}
+	})
+
+	t.Run("Error cases", func(tt *testing.T) {
+		wm := &AeroSpaceWM{}
+
+		if _, err := wm.GetTree(); !errors.Is(err, ErrNotInitialized) {
+			tt.Fatalf("expected ErrNotInitialized, got %v", err)
+		}
+	})
+}
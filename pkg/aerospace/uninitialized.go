@@ -0,0 +1,61 @@
+package aerospace
+
+import (
+	"context"
+	"time"
+
+	"github.com/cristianoliveira/aerospace-ipc/pkg/client"
+)
+
+// uninitializedConnection is the connection of an AeroSpaceWM created without one.
+// Every method fails with ErrNotInitialized.
+type uninitializedConnection struct{}
+
+var _ client.AeroSpaceConnection = uninitializedConnection{}
+
+func (uninitializedConnection) CloseConnection() error {
+	return ErrNotInitialized
+}
+
+func (uninitializedConnection) SendCommand(string, []string) (*client.Response, error) {
+	return nil, ErrNotInitialized
+}
+
+func (uninitializedConnection) SendCommandContext(context.Context, string, []string) (*client.Response, error) {
+	return nil, ErrNotInitialized
+}
+
+func (uninitializedConnection) SendCommandWithStdin(context.Context, string, []string, string) (*client.Response, error) {
+	return nil, ErrNotInitialized
+}
+
+func (uninitializedConnection) SendBatch(context.Context, []client.Command) ([]*client.Response, error) {
+	return nil, ErrNotInitialized
+}
+
+func (uninitializedConnection) SendCommandAsync(context.Context, string, []string) <-chan client.Result {
+	result := make(chan client.Result, 1)
+	result <- client.Result{Err: ErrNotInitialized}
+	close(result)
+	return result
+}
+
+func (uninitializedConnection) Ping(context.Context) (time.Duration, error) {
+	return 0, ErrNotInitialized
+}
+
+func (uninitializedConnection) GetSocketPath() (string, error) {
+	return "", ErrNotInitialized
+}
+
+func (uninitializedConnection) GetServerVersion() (string, error) {
+	return "", ErrNotInitialized
+}
+
+func (uninitializedConnection) GetVersionInfo() (*client.VersionInfo, error) {
+	return nil, ErrNotInitialized
+}
+
+func (uninitializedConnection) CheckServerVersion() error {
+	return ErrNotInitialized
+}
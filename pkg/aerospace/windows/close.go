@@ -0,0 +1,99 @@
+package windows
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/cristianoliveira/aerospace-ipc/pkg/client/args"
+	"github.com/cristianoliveira/aerospace-ipc/pkg/client/commands"
+)
+
+// CloseWindowsOpts contains optional parameters for CloseWindowsByApp.
+type CloseWindowsOpts struct {
+	// QuitIfLastWindow quits the application when its last window is closed.
+	QuitIfLastWindow bool
+
+	// Skip reports the windows to leave open, e.g. the main window of the app.
+	// If nil, every window of the app is closed.
+	Skip func(Window) bool
+}
+
+// CloseSummary reports what CloseWindowsByApp did with every window of the app.
+type CloseSummary struct {
+	Closed  []WindowID
+	Skipped []WindowID
+
+	// Failed maps the windows that could not be closed to their error.
+	Failed map[WindowID]error
+}
+
+// CloseWindowsByApp closes the windows of the application with the given
+// bundle ID, one at a time, e.g. to quit all helper windows of an app.
+//
+// A window failing to close does not stop the others. The returned error
+// joins the errors of all failed windows, which are also in the summary.
+//
+// It is equivalent to running the commands:
+//
+//	aerospace list-windows --monitor all --app-bundle-id <bundle-id> --json --format <format>
+//	aerospace close --window-id <window-id> [--quit-if-last-window]  # for each window
+//
+// Usage:
+//
+//	summary, err := windowService.CloseWindowsByApp("com.apple.Preview", windows.CloseWindowsOpts{
+//	    Skip: func(window windows.Window) bool { return window.WindowTitle == "Keep me" },
+//	})
+//	fmt.Printf("closed %d, skipped %d\n", len(summary.Closed), len(summary.Skipped))
+func (s *Service) CloseWindowsByApp(bundleID string, opts ...CloseWindowsOpts) (CloseSummary, error) {
+	var opt CloseWindowsOpts
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+
+	var summary CloseSummary
+	if bundleID == "" {
+		return summary, fmt.Errorf("bundle ID cannot be empty")
+	}
+
+	windows, err := s.GetWindowsByAppBundleID(bundleID)
+	if err != nil {
+		return summary, err
+	}
+
+	var errs []error
+	for _, window := range windows {
+		if opt.Skip != nil && opt.Skip(window) {
+			summary.Skipped = append(summary.Skipped, window.WindowID)
+			continue
+		}
+
+		if err := s.closeWindow(window.WindowID, opt.QuitIfLastWindow); err != nil {
+			if summary.Failed == nil {
+				summary.Failed = make(map[WindowID]error)
+			}
+			summary.Failed[window.WindowID] = err
+			errs = append(errs, err)
+			continue
+		}
+		summary.Closed = append(summary.Closed, window.WindowID)
+	}
+
+	return summary, errors.Join(errs...)
+}
+
+// closeWindow closes the window with the given ID.
+func (s *Service) closeWindow(windowID WindowID, quitIfLastWindow bool) error {
+	cmdArgs := args.New().
+		KV("--window-id", windowID).
+		FlagIf(quitIfLastWindow, "--quit-if-last-window").
+		Build()
+
+	response, err := s.sendCommand(commands.Close, cmdArgs)
+	if err != nil {
+		return fmt.Errorf("failed to close window %d\n%w", windowID, err)
+	}
+	if response.ExitCode != 0 {
+		return fmt.Errorf("failed to close window %d\n%s", windowID, response.StdErr)
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+package windows
+
+import (
+	"reflect"
+	"testing"
+
+	mock_client "github.com/cristianoliveira/aerospace-ipc/internal/mocks"
+	"github.com/cristianoliveira/aerospace-ipc/pkg/client"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCloseWindowsByApp(t *testing.T) {
+	listArgs := []string{
+		"--monitor", "all",
+		"--app-bundle-id", "com.apple.Safari",
+		"--json",
+		"--format", formatArguments,
+	}
+
+	t.Run("Happy path", func(tt *testing.T) {
+		tt.Run("closes every window", func(ttt *testing.T) {
+			ctrl := gomock.NewController(ttt)
+			defer ctrl.Finish()
+
+			mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+			gomock.InOrder(
+				mockConn.EXPECT().
+					SendCommand("list-windows", listArgs).
+					Return(&client.Response{StdOut: safariWindowsJSON}, nil),
+				mockConn.EXPECT().
+					SendCommand("close", []string{"--window-id", "1", "--quit-if-last-window"}).
+					Return(&client.Response{}, nil),
+				mockConn.EXPECT().
+					SendCommand("close", []string{"--window-id", "2", "--quit-if-last-window"}).
+					Return(&client.Response{}, nil),
+				mockConn.EXPECT().
+					SendCommand("close", []string{"--window-id", "3", "--quit-if-last-window"}).
+					Return(&client.Response{}, nil),
+			)
+
+			summary, err := NewService(mockConn).CloseWindowsByApp("com.apple.Safari", CloseWindowsOpts{
+				QuitIfLastWindow: true,
+			})
+			if err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+			expected := CloseSummary{Closed: []WindowID{1, 2, 3}}
+			if !reflect.DeepEqual(summary, expected) {
+				ttt.Fatalf("expected %+v, got %+v", expected, summary)
+			}
+		})
+	})
+
+	t.Run("Error cases", func(tt *testing.T) {
+		tt.Run("summarizes skipped and failed windows", func(ttt *testing.T) {
+			ctrl := gomock.NewController(ttt)
+			defer ctrl.Finish()
+
+			mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+			gomock.InOrder(
+				mockConn.EXPECT().
+					SendCommand("list-windows", listArgs).
+					Return(&client.Response{StdOut: safariWindowsJSON}, nil),
+				mockConn.EXPECT().
+					SendCommand("close", []string{"--window-id", "2"}).
+					Return(&client.Response{ExitCode: 1, StdErr: "Window 2 doesn't exist"}, nil),
+				mockConn.EXPECT().
+					SendCommand("close", []string{"--window-id", "3"}).
+					Return(&client.Response{}, nil),
+			)
+
+			summary, err := NewService(mockConn).CloseWindowsByApp("com.apple.Safari", CloseWindowsOpts{
+				Skip: func(window Window) bool { return window.Workspace == "web" },
+			})
+			if err == nil {
+				ttt.Fatal("expected error, got nil")
+			}
+			if !reflect.DeepEqual(summary.Closed, []WindowID{3}) || !reflect.DeepEqual(summary.Skipped, []WindowID{1}) {
+				ttt.Fatalf("unexpected summary %+v", summary)
+			}
+			if len(summary.Failed) != 1 || summary.Failed[2] == nil {
+				ttt.Fatalf("expected window 2 to fail, got %+v", summary.Failed)
+			}
+		})
+
+		tt.Run("empty bundle ID", func(ttt *testing.T) {
+			ctrl := gomock.NewController(ttt)
+			defer ctrl.Finish()
+
+			mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+			if _, err := NewService(mockConn).CloseWindowsByApp(""); err == nil {
+				ttt.Fatal("expected error, got nil")
+			}
+		})
+	})
+}
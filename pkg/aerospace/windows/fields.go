@@ -0,0 +1,123 @@
+package windows
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Field is a list-windows format variable, used to select the fields to fetch.
+//
+// Fields with a JSON counterpart map to the Window struct field with the same JSON name.
+//
+// See: https://nikitabobko.github.io/AeroSpace/commands#list-windows
+type Field string
+
+// Window fields.
+const (
+	FieldWindowID                    Field = "window-id"
+	FieldTitle                       Field = "window-title"
+	FieldWindowLayout                Field = "window-layout"
+	FieldWindowParentContainerLayout Field = "window-parent-container-layout"
+	FieldIsFullscreen                Field = "window-is-fullscreen"
+)
+
+// Application fields.
+const (
+	FieldAppName       Field = "app-name"
+	FieldAppBundleID   Field = "app-bundle-id"
+	FieldAppPID        Field = "app-pid"
+	FieldAppExecPath   Field = "app-exec-path"
+	FieldAppBundlePath Field = "app-bundle-path"
+)
+
+// Workspace fields, describing the workspace the window belongs to.
+const (
+	FieldWorkspace          Field = "workspace"
+	FieldWorkspaceIsFocused Field = "workspace-is-focused"
+	FieldWorkspaceIsVisible Field = "workspace-is-visible"
+)
+
+// Monitor fields, describing the monitor the window is on.
+const (
+	FieldMonitorID               Field = "monitor-id"
+	FieldMonitorName             Field = "monitor-name"
+	FieldMonitorAppKitNSScreenID Field = "monitor-appkit-nsscreen-screens-id"
+)
+
+// Text layout fields. They have no JSON counterpart and are only useful
+// for plain text output, e.g. when sending raw commands through the connection.
+// The server rejects them together with --json.
+const (
+	FieldRightPadding Field = "right-padding"
+	FieldNewline      Field = "newline"
+	FieldTab          Field = "tab"
+)
+
+var knownFields = map[Field]bool{
+	FieldWindowID:                    true,
+	FieldTitle:                       true,
+	FieldWindowLayout:                true,
+	FieldWindowParentContainerLayout: true,
+	FieldIsFullscreen:                true,
+	FieldAppName:                     true,
+	FieldAppBundleID:                 true,
+	FieldAppPID:                      true,
+	FieldAppExecPath:                 true,
+	FieldAppBundlePath:               true,
+	FieldWorkspace:                   true,
+	FieldWorkspaceIsFocused:          true,
+	FieldWorkspaceIsVisible:          true,
+	FieldMonitorID:                   true,
+	FieldMonitorName:                 true,
+	FieldMonitorAppKitNSScreenID:     true,
+	FieldRightPadding:                true,
+	FieldNewline:                     true,
+	FieldTab:                         true,
+}
+
+// IsValid reports whether f is a documented list-windows format variable.
+func (f Field) IsValid() bool {
+	return knownFields[f]
+}
+
+// IsTextLayout reports whether f is a text layout field, rejected by the server with --json.
+func (f Field) IsTextLayout() bool {
+	return f == FieldRightPadding || f == FieldNewline || f == FieldTab
+}
+
+// BuildFormat returns the --format value interpolating the given fields, separated by spaces.
+//
+// Returns an error if no field is given or a field is unknown.
+//
+// Usage:
+//
+//	format, err := windows.BuildFormat(windows.FieldWindowID, windows.FieldTitle)
+//	// format: "%{window-id} %{window-title}"
+func BuildFormat(fields ...Field) (string, error) {
+	if len(fields) == 0 {
+		return "", fmt.Errorf("at least one field is required")
+	}
+
+	parts := make([]string, 0, len(fields))
+	for _, field := range fields {
+		if !field.IsValid() {
+			return "", fmt.Errorf("unknown window field %q", field)
+		}
+		parts = append(parts, "%{"+string(field)+"}")
+	}
+	return strings.Join(parts, " "), nil
+}
+
+// BuildJSONFormat is BuildFormat for a --json output, so it also refuses the text layout fields.
+//
+// Usage:
+//
+//	format, err := windows.BuildJSONFormat(windows.FieldWindowID, windows.FieldTitle)
+func BuildJSONFormat(fields ...Field) (string, error) {
+	for _, field := range fields {
+		if field.IsTextLayout() {
+			return "", fmt.Errorf("window field %q cannot be used with --json", field)
+		}
+	}
+	return BuildFormat(fields...)
+}
@@ -0,0 +1,150 @@
+package windows
+
+import "regexp"
+
+// Filter selects windows in Service.Find.
+//
+// Create it with ByApp, ByBundleID, TitleMatches, OnWorkspace or Focused and
+// combine filters with Any and Not. Find keeps the windows matching all its filters.
+type Filter struct {
+	match func(window Window, focused *WindowID) bool
+
+	// needsFocus is set when the filter depends on the focused window,
+	// which Find then fetches.
+	needsFocus bool
+}
+
+// ByApp selects the windows of the application with the given name, e.g. "Ghostty".
+func ByApp(name string) Filter {
+	return Filter{match: func(window Window, _ *WindowID) bool {
+		return window.AppName == name
+	}}
+}
+
+// ByBundleID selects the windows of the application with the given bundle ID,
+// e.g. "com.apple.Terminal".
+func ByBundleID(bundleID string) Filter {
+	return Filter{match: func(window Window, _ *WindowID) bool {
+		return window.AppBundleID == bundleID
+	}}
+}
+
+// TitleMatches selects the windows whose title matches pattern.
+func TitleMatches(pattern *regexp.Regexp) Filter {
+	if pattern == nil {
+		panic("ASSERTION: pattern cannot be nil")
+	}
+	return Filter{match: func(window Window, _ *WindowID) bool {
+		return pattern.MatchString(window.WindowTitle)
+	}}
+}
+
+// OnWorkspace selects the windows on the workspace with the given name.
+func OnWorkspace(workspace string) Filter {
+	return Filter{match: func(window Window, _ *WindowID) bool {
+		return window.Workspace == workspace
+	}}
+}
+
+// Focused selects the focused window.
+func Focused() Filter {
+	return Filter{
+		match: func(window Window, focused *WindowID) bool {
+			return focused != nil && window.WindowID == *focused
+		},
+		needsFocus: true,
+	}
+}
+
+// Any selects the windows matching at least one of filters.
+func Any(filters ...Filter) Filter {
+	return Filter{
+		match: func(window Window, focused *WindowID) bool {
+			for _, filter := range filters {
+				if filter.match(window, focused) {
+					return true
+				}
+			}
+			return false
+		},
+		needsFocus: needsFocus(filters),
+	}
+}
+
+// Not selects the windows not matching filter.
+func Not(filter Filter) Filter {
+	return Filter{
+		match: func(window Window, focused *WindowID) bool {
+			return !filter.match(window, focused)
+		},
+		needsFocus: filter.needsFocus,
+	}
+}
+
+func needsFocus(filters []Filter) bool {
+	for _, filter := range filters {
+		if filter.needsFocus {
+			return true
+		}
+	}
+	return false
+}
+
+// Find returns the windows, on all monitors, matching all of filters.
+// Without filters all windows are returned.
+//
+// It is equivalent to running the command:
+//
+//	aerospace list-windows --all --json --format <format>
+//
+// Filters using Focused also run:
+//
+//	aerospace list-windows --focused --json --format <format>
+//
+// Usage:
+//
+//	// Terminal windows on workspace 1
+//	windows, err := windowService.Find(
+//	    windows.ByBundleID("com.apple.Terminal"),
+//	    windows.OnWorkspace("1"),
+//	)
+//
+//	// Browser windows but the focused one
+//	windows, err := windowService.Find(
+//	    windows.TitleMatches(regexp.MustCompile(`(?i)github`)),
+//	    windows.Not(windows.Focused()),
+//	)
+func (s *Service) Find(filters ...Filter) ([]Window, error) {
+	var focused *WindowID
+	if needsFocus(filters) {
+		windows, err := s.GetWindowsWithOpts(ListWindowsOpts{Focused: true})
+		if err != nil {
+			return nil, err
+		}
+		if len(windows) > 0 {
+			focused = &windows[0].WindowID
+		}
+	}
+
+	all, err := s.GetWindowsWithOpts(ListWindowsOpts{})
+	if err != nil {
+		return nil, err
+	}
+
+	windows := []Window{}
+	for _, window := range all {
+		if matchesAll(window, focused, filters) {
+			windows = append(windows, window)
+		}
+	}
+	return windows, nil
+}
+
+func matchesAll(window Window, focused *WindowID, filters []Filter) bool {
+	for _, filter := range filters {
+		if filter.match != nil && !filter.match(window, focused) {
+			return false
+		}
+	}
+	return true
+}
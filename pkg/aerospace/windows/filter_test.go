@@ -0,0 +1,122 @@
+package windows
+
+import (
+	"regexp"
+	"testing"
+
+	mock_client "github.com/cristianoliveira/aerospace-ipc/internal/mocks"
+	"github.com/cristianoliveira/aerospace-ipc/pkg/client"
+	"go.uber.org/mock/gomock"
+)
+
+const findWindowsJSON = `[
+	{"window-id": 1, "app-name": "Ghostty", "app-bundle-id": "com.mitchellh.ghostty", "window-title": "zsh", "workspace": "1"},
+	{"window-id": 2, "app-name": "Safari", "app-bundle-id": "com.apple.Safari", "window-title": "GitHub - PRs", "workspace": "1"},
+	{"window-id": 3, "app-name": "Safari", "app-bundle-id": "com.apple.Safari", "window-title": "Docs", "workspace": "2"}
+]`
+
+func TestFind(t *testing.T) {
+	t.Run("Happy path", func(tt *testing.T) {
+		tests := []struct {
+			name     string
+			filters  []Filter
+			focused  string
+			expected []WindowID
+		}{
+			{
+				name:     "without filters",
+				expected: []WindowID{1, 2, 3},
+			},
+			{
+				name:     "by app",
+				filters:  []Filter{ByApp("Safari")},
+				expected: []WindowID{2, 3},
+			},
+			{
+				name:     "by bundle ID on a workspace",
+				filters:  []Filter{ByBundleID("com.apple.Safari"), OnWorkspace("1")},
+				expected: []WindowID{2},
+			},
+			{
+				name:     "title matching a pattern",
+				filters:  []Filter{TitleMatches(regexp.MustCompile(`(?i)^github`))},
+				expected: []WindowID{2},
+			},
+			{
+				name:     "any of",
+				filters:  []Filter{Any(ByApp("Ghostty"), OnWorkspace("2"))},
+				expected: []WindowID{1, 3},
+			},
+			{
+				name:     "focused",
+				filters:  []Filter{Focused()},
+				focused:  `[{"window-id": 2}]`,
+				expected: []WindowID{2},
+			},
+			{
+				name:     "not focused",
+				filters:  []Filter{ByApp("Safari"), Not(Focused())},
+				focused:  `[{"window-id": 2}]`,
+				expected: []WindowID{3},
+			},
+			{
+				name:     "focused without a focused window",
+				filters:  []Filter{Any(Focused(), ByApp("Ghostty"))},
+				focused:  `[]`,
+				expected: []WindowID{1},
+			},
+		}
+
+		for _, tc := range tests {
+			tt.Run(tc.name, func(ttt *testing.T) {
+				ctrl := gomock.NewController(ttt)
+				defer ctrl.Finish()
+
+				mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+				if tc.focused != "" {
+					mockConn.EXPECT().
+						SendCommand("list-windows", []string{"--focused", "--json", "--format", formatArguments}).
+						Return(&client.Response{StdOut: tc.focused}, nil)
+				}
+				mockConn.EXPECT().
+					SendCommand("list-windows", []string{"--all", "--json", "--format", formatArguments}).
+					Return(&client.Response{StdOut: findWindowsJSON}, nil)
+
+				windows, err := NewService(mockConn).Find(tc.filters...)
+				if err != nil {
+					ttt.Fatalf("unexpected error: %v", err)
+				}
+
+				ids := []WindowID{}
+				for _, window := range windows {
+					ids = append(ids, window.WindowID)
+				}
+				if len(ids) != len(tc.expected) {
+					ttt.Fatalf("expected %v, got %v", tc.expected, ids)
+				}
+				for i := range ids {
+					if ids[i] != tc.expected[i] {
+						ttt.Fatalf("expected %v, got %v", tc.expected, ids)
+					}
+				}
+			})
+		}
+	})
+
+	t.Run("Error cases", func(tt *testing.T) {
+		tt.Run("fails when listing the windows fails", func(ttt *testing.T) {
+			ctrl := gomock.NewController(ttt)
+			defer ctrl.Finish()
+
+			mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+			mockConn.EXPECT().
+				SendCommand("list-windows", gomock.Any()).
+				Return(&client.Response{ExitCode: 1, StdErr: "boom"}, nil)
+
+			_, err := NewService(mockConn).Find(ByApp("Safari"))
+			if err == nil {
+				ttt.Fatal("expected error, got nil")
+			}
+		})
+	})
+}
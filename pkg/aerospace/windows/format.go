@@ -0,0 +1,177 @@
+package windows
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Formatter formats windows as text, e.g. for pickers and status bars.
+//
+// A Formatter either interpolates a Template, using the same %{field}
+// variables as the list-windows --format flag, or joins the values of the
+// selected Fields with Separator.
+//
+// Usage:
+//
+//	picker := windows.Formatter{Template: "%{app-name}: %{window-title} [%{workspace}]"}
+//	fmt.Println(picker.Format(window))
+//
+//	// Output: Brave Browser: Github Page [8]
+type Formatter struct {
+	// Template is the text to interpolate. When set, Fields, Separator and OmitEmpty are ignored.
+	Template string
+
+	// Fields are the fields to print, in order.
+	Fields []Field
+
+	// Separator is placed between the fields. Defaults to " | ".
+	Separator string
+
+	// OmitEmpty skips the fields without a value, e.g. an empty title
+	// or a monitor ID that was not fetched.
+	OmitEmpty bool
+
+	// legacy prints the window as Window.String did before formatters existed.
+	legacy bool
+}
+
+// DefaultFormatter is used by Window.String.
+//
+// By default, it prints the window ID and app name, followed by the title,
+// layouts, workspace and app bundle ID that are set, exactly as Window.String
+// always did. It can be replaced, during initialization, to change how
+// windows are printed:
+//
+//	windows.DefaultFormatter = windows.Formatter{Template: "%{window-id} %{app-name}"}
+var DefaultFormatter = Formatter{legacy: true}
+
+// templateVariable matches a %{field} variable of a Formatter template.
+var templateVariable = regexp.MustCompile(`%\{([a-z-]+)\}`)
+
+// Validate returns an error if the formatter has neither a template nor fields,
+// or uses an unknown field.
+func (f Formatter) Validate() error {
+	fields := f.Fields
+	if f.Template != "" {
+		fields = nil
+		for _, match := range templateVariable.FindAllStringSubmatch(f.Template, -1) {
+			fields = append(fields, Field(match[1]))
+		}
+	} else if len(f.Fields) == 0 && !f.legacy {
+		return fmt.Errorf("formatter requires a template or at least one field")
+	}
+
+	for _, field := range fields {
+		if !field.IsValid() {
+			return fmt.Errorf("unknown window field %q", field)
+		}
+	}
+	return nil
+}
+
+// Format returns the text representation of window.
+//
+// Unknown template variables are kept as is, see Validate.
+func (f Formatter) Format(window Window) string {
+	if f.Template != "" {
+		return templateVariable.ReplaceAllStringFunc(f.Template, func(variable string) string {
+			field := Field(variable[2 : len(variable)-1])
+			if !field.IsValid() {
+				return variable
+			}
+			return fieldValue(window, field)
+		})
+	}
+	if f.legacy {
+		return legacyFormat(window)
+	}
+
+	separator := f.Separator
+	if separator == "" {
+		separator = " | "
+	}
+
+	values := make([]string, 0, len(f.Fields))
+	for _, field := range f.Fields {
+		value := fieldValue(window, field)
+		if value == "" && f.OmitEmpty {
+			continue
+		}
+		values = append(values, value)
+	}
+	return strings.Join(values, separator)
+}
+
+// legacyFormat returns the text representation of window kept by DefaultFormatter.
+// The app name is always printed, even when empty, and followed by a space.
+func legacyFormat(window Window) string {
+	text := fmt.Sprintf("%s | %s ", window.WindowID, window.AppName)
+	if window.WindowTitle != "" {
+		text += "| " + window.WindowTitle
+	}
+	for _, value := range []string{
+		window.WindowLayout,
+		window.WindowParentContainerLayout,
+		window.Workspace,
+		window.AppBundleID,
+	} {
+		if value != "" {
+			text += " | " + value
+		}
+	}
+	return text
+}
+
+// fieldValue returns the value of field in window. Zero numbers,
+// which are not reported by the server, are returned empty.
+func fieldValue(window Window, field Field) string {
+	switch field {
+	case FieldWindowID:
+		return window.WindowID.String()
+	case FieldTitle:
+		return window.WindowTitle
+	case FieldWindowLayout:
+		return window.WindowLayout
+	case FieldWindowParentContainerLayout:
+		return window.WindowParentContainerLayout
+	case FieldIsFullscreen:
+		return strconv.FormatBool(window.IsFullscreen)
+	case FieldAppName:
+		return window.AppName
+	case FieldAppBundleID:
+		return window.AppBundleID
+	case FieldAppPID:
+		return formatNonZero(window.AppPID)
+	case FieldAppExecPath:
+		return window.AppExecPath
+	case FieldAppBundlePath:
+		return window.AppBundlePath
+	case FieldWorkspace:
+		return window.Workspace
+	case FieldWorkspaceIsFocused:
+		return strconv.FormatBool(window.WorkspaceIsFocused)
+	case FieldWorkspaceIsVisible:
+		return strconv.FormatBool(window.WorkspaceIsVisible)
+	case FieldMonitorID:
+		return formatNonZero(window.MonitorID)
+	case FieldMonitorName:
+		return window.MonitorName
+	case FieldMonitorAppKitNSScreenID:
+		return formatNonZero(window.MonitorAppKitNSScreenID)
+	case FieldNewline:
+		return "\n"
+	case FieldTab:
+		return "\t"
+	}
+	// FieldRightPadding has no meaning outside of the server table output.
+	return ""
+}
+
+func formatNonZero(n int) string {
+	if n == 0 {
+		return ""
+	}
+	return strconv.Itoa(n)
+}
@@ -0,0 +1,92 @@
+package windows
+
+import "testing"
+
+func TestFormatter(t *testing.T) {
+	window := Window{
+		WindowID:    6231,
+		WindowTitle: "Github Page",
+		AppName:     "Brave Browser",
+		AppBundleID: "com.brave.Browser",
+		Workspace:   "8",
+	}
+
+	t.Run("Happy path", func(tt *testing.T) {
+		testCases := []struct {
+			title     string
+			formatter Formatter
+			expected  string
+		}{
+			{
+				title:     "template",
+				formatter: Formatter{Template: "%{app-name}: %{window-title} [%{workspace}]"},
+				expected:  "Brave Browser: Github Page [8]",
+			},
+			{
+				title:     "template with unknown variable",
+				formatter: Formatter{Template: "%{window-id} %{unknown}"},
+				expected:  "6231 %{unknown}",
+			},
+			{
+				title:     "fields with the default separator",
+				formatter: Formatter{Fields: []Field{FieldWindowID, FieldMonitorID, FieldAppName}},
+				expected:  "6231 |  | Brave Browser",
+			},
+			{
+				title: "fields omitting empty values",
+				formatter: Formatter{
+					Fields:    []Field{FieldWindowID, FieldMonitorID, FieldAppName},
+					Separator: "\t",
+					OmitEmpty: true,
+				},
+				expected: "6231\tBrave Browser",
+			},
+			{
+				title:     "default formatter",
+				formatter: DefaultFormatter,
+				expected:  "6231 | Brave Browser | Github Page | 8 | com.brave.Browser",
+			},
+		}
+		for _, tc := range testCases {
+			tt.Run(tc.title, func(ttt *testing.T) {
+				if result := tc.formatter.Format(window); result != tc.expected {
+					ttt.Errorf("expected %q, got %q", tc.expected, result)
+				}
+			})
+		}
+
+		tt.Run("default formatter is valid", func(ttt *testing.T) {
+			if err := DefaultFormatter.Validate(); err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+		})
+
+		tt.Run("String uses DefaultFormatter", func(ttt *testing.T) {
+			previous := DefaultFormatter
+			defer func() { DefaultFormatter = previous }()
+
+			DefaultFormatter = Formatter{Template: "%{window-id} %{app-name}"}
+			if result := window.String(); result != "6231 Brave Browser" {
+				ttt.Errorf("expected %q, got %q", "6231 Brave Browser", result)
+			}
+		})
+	})
+
+	t.Run("Error cases", func(tt *testing.T) {
+		testCases := []struct {
+			title     string
+			formatter Formatter
+		}{
+			{title: "empty formatter", formatter: Formatter{}},
+			{title: "unknown field", formatter: Formatter{Fields: []Field{"unknown"}}},
+			{title: "unknown template variable", formatter: Formatter{Template: "%{unknown}"}},
+		}
+		for _, tc := range testCases {
+			tt.Run(tc.title, func(ttt *testing.T) {
+				if err := tc.formatter.Validate(); err == nil {
+					ttt.Fatal("expected error, got nil")
+				}
+			})
+		}
+	})
+}
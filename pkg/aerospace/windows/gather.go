@@ -0,0 +1,68 @@
+package windows
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cristianoliveira/aerospace-ipc/pkg/client"
+	"github.com/cristianoliveira/aerospace-ipc/pkg/client/args"
+	"github.com/cristianoliveira/aerospace-ipc/pkg/client/commands"
+)
+
+// GatherWindowsByApp moves every window of the application with the given
+// bundle ID to workspace, e.g. to collect all browser windows in one place.
+// It returns the number of windows moved.
+//
+// Windows already on the workspace are left alone. The moves are sent as a
+// single batch, so other commands are not interleaved with them, and the
+// batch stops at the first move that fails.
+//
+// It is equivalent to running the commands:
+//
+//	aerospace list-windows --monitor all --app-bundle-id <bundle-id> --json --format <format>
+//	aerospace move-node-to-workspace <workspace> --window-id <window-id>  # for each window
+//
+// Usage:
+//
+//	moved, err := windowService.GatherWindowsByApp("com.apple.Safari", "web")
+func (s *Service) GatherWindowsByApp(bundleID, workspace string) (int, error) {
+	if bundleID == "" {
+		return 0, fmt.Errorf("bundle ID cannot be empty")
+	}
+	if workspace == "" {
+		return 0, fmt.Errorf("workspace cannot be empty")
+	}
+
+	windows, err := s.GetWindowsByAppBundleID(bundleID)
+	if err != nil {
+		return 0, err
+	}
+
+	var moves []client.Command
+	for _, window := range windows {
+		if window.Workspace == workspace {
+			continue
+		}
+		moves = append(moves, client.Command{
+			Args: args.New().
+				Values(commands.MoveNodeToWorkspace, workspace).
+				KV("--window-id", window.WindowID).
+				Build(),
+		})
+	}
+	if len(moves) == 0 {
+		return 0, nil
+	}
+
+	ctx := s.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	responses, err := s.client.SendBatch(ctx, moves)
+	for i, response := range responses {
+		if response.ExitCode != 0 {
+			return i, fmt.Errorf("failed to move window to workspace %s\n%s", workspace, response.StdErr)
+		}
+	}
+	return len(responses), err
+}
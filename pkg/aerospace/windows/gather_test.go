@@ -0,0 +1,112 @@
+package windows
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	mock_client "github.com/cristianoliveira/aerospace-ipc/internal/mocks"
+	"github.com/cristianoliveira/aerospace-ipc/pkg/client"
+	"go.uber.org/mock/gomock"
+)
+
+const safariWindowsJSON = `[
+  {"window-id": 1, "app-bundle-id": "com.apple.Safari", "workspace": "web"},
+  {"window-id": 2, "app-bundle-id": "com.apple.Safari", "workspace": "1"},
+  {"window-id": 3, "app-bundle-id": "com.apple.Safari", "workspace": "2"}
+]`
+
+func TestGatherWindowsByApp(t *testing.T) {
+	listArgs := []string{
+		"--monitor", "all",
+		"--app-bundle-id", "com.apple.Safari",
+		"--json",
+		"--format", formatArguments,
+	}
+	moves := []client.Command{
+		{Args: []string{"move-node-to-workspace", "web", "--window-id", "2"}},
+		{Args: []string{"move-node-to-workspace", "web", "--window-id", "3"}},
+	}
+
+	t.Run("Happy path", func(tt *testing.T) {
+		tt.Run("moves the windows in one batch", func(ttt *testing.T) {
+			ctrl := gomock.NewController(ttt)
+			defer ctrl.Finish()
+
+			mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+			gomock.InOrder(
+				mockConn.EXPECT().
+					SendCommand("list-windows", listArgs).
+					Return(&client.Response{StdOut: safariWindowsJSON}, nil),
+				mockConn.EXPECT().
+					SendBatch(gomock.Any(), moves).
+					Return([]*client.Response{{}, {}}, nil),
+			)
+
+			moved, err := NewService(mockConn).GatherWindowsByApp("com.apple.Safari", "web")
+			if err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+			if moved != 2 {
+				ttt.Fatalf("expected 2 windows moved, got %d", moved)
+			}
+		})
+
+		tt.Run("nothing to move", func(ttt *testing.T) {
+			ctrl := gomock.NewController(ttt)
+			defer ctrl.Finish()
+
+			mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+			mockConn.EXPECT().
+				SendCommand("list-windows", listArgs).
+				Return(&client.Response{StdOut: `[{"window-id": 1, "workspace": "web"}]`}, nil)
+
+			moved, err := NewService(mockConn).GatherWindowsByApp("com.apple.Safari", "web")
+			if err != nil || moved != 0 {
+				ttt.Fatalf("expected no move, got %d and %v", moved, err)
+			}
+		})
+	})
+
+	t.Run("Error cases", func(tt *testing.T) {
+		tt.Run("a move fails", func(ttt *testing.T) {
+			ctrl := gomock.NewController(ttt)
+			defer ctrl.Finish()
+
+			mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+			gomock.InOrder(
+				mockConn.EXPECT().
+					SendCommand("list-windows", listArgs).
+					Return(&client.Response{StdOut: safariWindowsJSON}, nil),
+				mockConn.EXPECT().
+					SendBatch(gomock.Any(), moves).
+					Return([]*client.Response{{}, {ExitCode: 1, StdErr: "Window 3 doesn't exist"}}, nil),
+			)
+
+			moved, err := NewService(mockConn).GatherWindowsByApp("com.apple.Safari", "web")
+			if err == nil || moved != 1 {
+				ttt.Fatalf("expected error after 1 move, got %d and %v", moved, err)
+			}
+		})
+
+		tt.Run("the batch is interrupted", func(ttt *testing.T) {
+			ctrl := gomock.NewController(ttt)
+			defer ctrl.Finish()
+
+			mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+			gomock.InOrder(
+				mockConn.EXPECT().
+					SendCommand("list-windows", listArgs).
+					Return(&client.Response{StdOut: safariWindowsJSON}, nil),
+				mockConn.EXPECT().
+					SendBatch(gomock.Any(), moves).
+					Return([]*client.Response{{}}, context.Canceled),
+			)
+
+			moved, err := NewService(mockConn).GatherWindowsByApp("com.apple.Safari", "web")
+			if !errors.Is(err, context.Canceled) || moved != 1 {
+				ttt.Fatalf("expected context.Canceled after 1 move, got %d and %v", moved, err)
+			}
+		})
+	})
+}
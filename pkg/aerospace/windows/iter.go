@@ -0,0 +1,130 @@
+package windows
+
+import (
+	"encoding/json"
+	"fmt"
+	"iter"
+	"strings"
+
+	"github.com/cristianoliveira/aerospace-ipc/pkg/client/commands"
+)
+
+// All returns an iterator over all windows, on all monitors.
+//
+// Windows are decoded one at a time as the loop consumes them, so breaking
+// early skips decoding the rest of a huge window list. On failure the
+// iterator yields a single zero Window with the error and stops.
+//
+// It is equivalent to running the command:
+//
+//	aerospace list-windows --all --json --format <format>
+//
+// Usage:
+//
+//	for window, err := range windowService.All() {
+//	    if err != nil {
+//	        return err
+//	    }
+//	    if window.AppName == "Slack" {
+//	        break
+//	    }
+//	}
+func (s *Service) All() iter.Seq2[Window, error] {
+	return func(yield func(Window, error) bool) {
+		response, err := s.sendCommand(
+			commands.ListWindows,
+			[]string{
+				"--all",
+				"--json",
+				"--format", formatArguments,
+			},
+		)
+		if err != nil {
+			yield(Window{}, err)
+			return
+		}
+		if response.ExitCode != 0 {
+			yield(Window{}, fmt.Errorf("failed to list windows\n%s", response.StdErr))
+			return
+		}
+
+		err = decodeEach(response.StdOut, func(window Window) bool {
+			return yield(window, nil)
+		})
+		if err != nil {
+			yield(Window{}, fmt.Errorf(
+				"failed to unmarshal windows: %w\nOut:%s\nErr:%s",
+				err,
+				response.StdOut,
+				response.StdErr,
+			))
+		}
+	}
+}
+
+// GetAllWindowsFunc calls fn with every window, on all monitors, until fn returns false.
+//
+// The windows are decoded one at a time and never collected in a slice,
+// which suits consumers looking for the first match.
+//
+// It is equivalent to running the command:
+//
+//	aerospace list-windows --all --json --format <format>
+//
+// Usage:
+//
+//	var slack *windows.Window
+//	err := windowService.GetAllWindowsFunc(func(window windows.Window) bool {
+//	    if window.AppName == "Slack" {
+//	        slack = &window
+//	        return false
+//	    }
+//	    return true
+//	})
+func (s *Service) GetAllWindowsFunc(fn func(Window) bool) error {
+	if fn == nil {
+		panic("ASSERTION: fn cannot be nil")
+	}
+
+	for window, err := range s.All() {
+		if err != nil {
+			return err
+		}
+		if !fn(window) {
+			return nil
+		}
+	}
+	return nil
+}
+
+// decodeEach decodes the elements of the JSON array in out one at a time,
+// passing each to fn until it returns false.
+func decodeEach[T any](out string, fn func(T) bool) error {
+	decoder := json.NewDecoder(strings.NewReader(out))
+	if err := expectDelim(decoder, '['); err != nil {
+		return err
+	}
+
+	for decoder.More() {
+		var element T
+		if err := decoder.Decode(&element); err != nil {
+			return err
+		}
+		if !fn(element) {
+			return nil
+		}
+	}
+
+	return expectDelim(decoder, ']')
+}
+
+func expectDelim(decoder *json.Decoder, delim json.Delim) error {
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+	if token != delim {
+		return fmt.Errorf("expected %q, got %v", delim, token)
+	}
+	return nil
+}
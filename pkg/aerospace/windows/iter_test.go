@@ -0,0 +1,164 @@
+package windows
+
+import (
+	"errors"
+	"testing"
+
+	mock_client "github.com/cristianoliveira/aerospace-ipc/internal/mocks"
+	"github.com/cristianoliveira/aerospace-ipc/pkg/client"
+	"go.uber.org/mock/gomock"
+)
+
+func TestAll(t *testing.T) {
+	listArgs := []string{"--all", "--json", "--format", formatArguments}
+
+	t.Run("Happy path", func(tt *testing.T) {
+		tt.Run("yields every window", func(ttt *testing.T) {
+			ctrl := gomock.NewController(ttt)
+			defer ctrl.Finish()
+
+			mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+			mockConn.EXPECT().
+				SendCommand("list-windows", listArgs).
+				Return(&client.Response{StdOut: findWindowsJSON}, nil)
+
+			ids := []WindowID{}
+			for window, err := range NewService(mockConn).All() {
+				if err != nil {
+					ttt.Fatalf("unexpected error: %v", err)
+				}
+				ids = append(ids, window.WindowID)
+			}
+			if len(ids) != 3 || ids[0] != 1 || ids[2] != 3 {
+				ttt.Fatalf("unexpected windows: %v", ids)
+			}
+		})
+
+		tt.Run("stops decoding when the loop breaks", func(ttt *testing.T) {
+			ctrl := gomock.NewController(ttt)
+			defer ctrl.Finish()
+
+			mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+			// The second element is invalid, it must never be decoded.
+			mockConn.EXPECT().
+				SendCommand("list-windows", listArgs).
+				Return(&client.Response{StdOut: `[{"window-id": 1}, {"window-id": "invalid"}]`}, nil)
+
+			for window, err := range NewService(mockConn).All() {
+				if err != nil {
+					ttt.Fatalf("unexpected error: %v", err)
+				}
+				if window.WindowID != 1 {
+					ttt.Fatalf("unexpected window: %v", window.WindowID)
+				}
+				break
+			}
+		})
+
+		tt.Run("yields nothing for an empty list", func(ttt *testing.T) {
+			ctrl := gomock.NewController(ttt)
+			defer ctrl.Finish()
+
+			mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+			mockConn.EXPECT().
+				SendCommand("list-windows", listArgs).
+				Return(&client.Response{StdOut: "[]"}, nil)
+
+			for range NewService(mockConn).All() {
+				ttt.Fatal("expected no windows")
+			}
+		})
+	})
+
+	t.Run("Error cases", func(tt *testing.T) {
+		tests := []struct {
+			name     string
+			response *client.Response
+			err      error
+		}{
+			{name: "connection error", err: errors.New("connection refused")},
+			{name: "command failure", response: &client.Response{ExitCode: 1, StdErr: "boom"}},
+			{name: "invalid element", response: &client.Response{StdOut: `[{"window-id": "invalid"}]`}},
+			{name: "not an array", response: &client.Response{StdOut: `{"window-id": 1}`}},
+		}
+
+		for _, tc := range tests {
+			tt.Run(tc.name, func(ttt *testing.T) {
+				ctrl := gomock.NewController(ttt)
+				defer ctrl.Finish()
+
+				mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+				mockConn.EXPECT().
+					SendCommand("list-windows", listArgs).
+					Return(tc.response, tc.err)
+
+				var errs int
+				for _, err := range NewService(mockConn).All() {
+					if err != nil {
+						errs++
+					}
+				}
+				if errs != 1 {
+					ttt.Fatalf("expected a single error, got %d", errs)
+				}
+			})
+		}
+	})
+}
+
+func TestGetAllWindowsFunc(t *testing.T) {
+	listArgs := []string{"--all", "--json", "--format", formatArguments}
+
+	t.Run("Happy path", func(tt *testing.T) {
+		tt.Run("stops at the first match", func(ttt *testing.T) {
+			ctrl := gomock.NewController(ttt)
+			defer ctrl.Finish()
+
+			mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+			mockConn.EXPECT().
+				SendCommand("list-windows", listArgs).
+				Return(&client.Response{StdOut: findWindowsJSON}, nil)
+
+			calls := 0
+			var found *Window
+			err := NewService(mockConn).GetAllWindowsFunc(func(window Window) bool {
+				calls++
+				if window.AppName == "Safari" {
+					found = &window
+					return false
+				}
+				return true
+			})
+			if err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+			if found == nil || found.WindowID != 2 || calls != 2 {
+				ttt.Fatalf("expected window 2 after 2 calls, got %v after %d calls", found, calls)
+			}
+		})
+	})
+
+	t.Run("Error cases", func(tt *testing.T) {
+		tt.Run("returns the decoding error", func(ttt *testing.T) {
+			ctrl := gomock.NewController(ttt)
+			defer ctrl.Finish()
+
+			mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+			mockConn.EXPECT().
+				SendCommand("list-windows", listArgs).
+				Return(&client.Response{StdOut: `[{"window-id": 1}, {"window-id": "invalid"}]`}, nil)
+
+			calls := 0
+			err := NewService(mockConn).GetAllWindowsFunc(func(Window) bool {
+				calls++
+				return true
+			})
+			if err == nil {
+				ttt.Fatal("expected error, got nil")
+			}
+			if calls != 1 {
+				ttt.Fatalf("expected 1 call before the error, got %d", calls)
+			}
+		})
+	})
+}
@@ -0,0 +1,68 @@
+package windows
+
+import "encoding/json"
+
+// windowJSON has the fields of Window but none of its methods,
+// so encoding it does not recurse into MarshalJSON.
+type windowJSON Window
+
+// MarshalJSON encodes the window with the same kebab-case keys used by
+// the AeroSpace server, so snapshots can be decoded back into a Window.
+func (w Window) MarshalJSON() ([]byte, error) {
+	return json.Marshal(windowJSON(w))
+}
+
+// UnmarshalJSON decodes a window encoded by MarshalJSON or by the AeroSpace server.
+func (w *Window) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, (*windowJSON)(w))
+}
+
+// MarshalText encodes the window as its JSON object.
+//
+// Structs embedding a Window get MarshalJSON and MarshalText promoted, and are then
+// encoded as the bare window, dropping their own fields. They must define both
+// methods themselves, as EnrichedWindow does.
+func (w Window) MarshalText() ([]byte, error) {
+	return w.MarshalJSON()
+}
+
+// UnmarshalText decodes a window encoded by MarshalText.
+func (w *Window) UnmarshalText(text []byte) error {
+	return w.UnmarshalJSON(text)
+}
+
+// enrichedWindowJSON embeds the window fields without the Window methods,
+// which would otherwise be promoted and drop Process.
+type enrichedWindowJSON struct {
+	windowJSON
+	Process *ProcessInfo `json:"process"`
+}
+
+// MarshalJSON encodes the window keys together with its process.
+func (w EnrichedWindow) MarshalJSON() ([]byte, error) {
+	return json.Marshal(enrichedWindowJSON{
+		windowJSON: windowJSON(w.Window),
+		Process:    w.Process,
+	})
+}
+
+// UnmarshalJSON decodes an enriched window encoded by MarshalJSON.
+func (w *EnrichedWindow) UnmarshalJSON(data []byte) error {
+	var decoded enrichedWindowJSON
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	w.Window = Window(decoded.windowJSON)
+	w.Process = decoded.Process
+	return nil
+}
+
+// MarshalText encodes the enriched window as its JSON object.
+func (w EnrichedWindow) MarshalText() ([]byte, error) {
+	return w.MarshalJSON()
+}
+
+// UnmarshalText decodes an enriched window encoded by MarshalText.
+func (w *EnrichedWindow) UnmarshalText(text []byte) error {
+	return w.UnmarshalJSON(text)
+}
@@ -0,0 +1,99 @@
+package windows
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWindowMarshal(t *testing.T) {
+	window := Window{
+		WindowID:     6231,
+		WindowTitle:  "Github Page",
+		WindowLayout: "floating",
+		AppName:      "Brave Browser",
+		AppBundleID:  "com.brave.Browser",
+		Workspace:    "8",
+		MonitorID:    1,
+	}
+
+	t.Run("Happy path", func(tt *testing.T) {
+		tt.Run("MarshalJSON uses the server keys", func(ttt *testing.T) {
+			data, err := json.Marshal(window)
+			if err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+			for _, key := range []string{`"window-id":6231`, `"app-name":"Brave Browser"`, `"monitor-id":1`} {
+				if !strings.Contains(string(data), key) {
+					ttt.Fatalf("expected %s in %s", key, data)
+				}
+			}
+		})
+
+		tt.Run("JSON round trip", func(ttt *testing.T) {
+			data, err := json.Marshal([]Window{window})
+			if err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+			var decoded []Window
+			if err := json.Unmarshal(data, &decoded); err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+			if len(decoded) != 1 || decoded[0] != window {
+				ttt.Fatalf("expected %+v, got %+v", window, decoded)
+			}
+		})
+
+		tt.Run("text round trip", func(ttt *testing.T) {
+			text, err := window.MarshalText()
+			if err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+			var decoded Window
+			if err := decoded.UnmarshalText(text); err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+			if decoded != window {
+				ttt.Fatalf("expected %+v, got %+v", window, decoded)
+			}
+		})
+
+		tt.Run("EnrichedWindow keeps its process", func(ttt *testing.T) {
+			enriched := EnrichedWindow{
+				Window: window,
+				Process: &ProcessInfo{
+					PID:            4242,
+					ExecutablePath: "/Applications/Brave Browser.app/Contents/MacOS/Brave Browser",
+					StartTime:      time.Date(2025, 1, 2, 9, 30, 0, 0, time.UTC),
+					ParentPID:      1,
+				},
+			}
+
+			data, err := json.Marshal(enriched)
+			if err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+			for _, key := range []string{`"window-id":6231`, `"process":{`, `"pid":4242`} {
+				if !strings.Contains(string(data), key) {
+					ttt.Fatalf("expected %s in %s", key, data)
+				}
+			}
+
+			var decoded EnrichedWindow
+			if err := json.Unmarshal(data, &decoded); err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+			if decoded.Window != window || decoded.Process == nil || *decoded.Process != *enriched.Process {
+				ttt.Fatalf("expected %+v, got %+v", enriched, decoded)
+			}
+		})
+	})
+
+	t.Run("Error cases", func(tt *testing.T) {
+		var decoded Window
+		if err := decoded.UnmarshalText([]byte(`{"window-id": "invalid"}`)); err == nil {
+			tt.Fatal("expected error, got nil")
+		}
+	})
+}
@@ -0,0 +1,173 @@
+package windows
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ProcessInfo describes the process of the application owning a window.
+type ProcessInfo struct {
+	PID            int       `json:"pid"`
+	ExecutablePath string    `json:"executable-path"`
+	StartTime      time.Time `json:"start-time"`
+	ParentPID      int       `json:"parent-pid"`
+}
+
+// ProcessLookup looks up a process by its PID.
+//
+// The default, PSLookup, runs ps(1). A lookup backed by a process library,
+// e.g. gopsutil, can be passed with EnrichOpts instead.
+type ProcessLookup interface {
+	LookupProcess(ctx context.Context, pid int) (*ProcessInfo, error)
+}
+
+// ProcessLookupFunc adapts a function to a ProcessLookup.
+type ProcessLookupFunc func(ctx context.Context, pid int) (*ProcessInfo, error)
+
+// LookupProcess calls f.
+func (f ProcessLookupFunc) LookupProcess(ctx context.Context, pid int) (*ProcessInfo, error) {
+	return f(ctx, pid)
+}
+
+// PSLookup looks up processes with ps(1).
+//
+// It is equivalent to running the command:
+//
+//	ps -o ppid=,lstart=,comm= -p <pid>
+var PSLookup ProcessLookup = ProcessLookupFunc(lookupWithPS)
+
+// EnrichedWindow is a window with the details of its application process.
+type EnrichedWindow struct {
+	Window
+
+	// Process is nil when the window has no app-pid or its lookup failed.
+	Process *ProcessInfo
+}
+
+// EnrichOpts contains optional parameters for EnrichWithProcessInfo.
+type EnrichOpts struct {
+	// Lookup looks up the processes. Defaults to PSLookup.
+	Lookup ProcessLookup
+}
+
+// EnrichWithProcessInfo attaches the executable path, start time and parent PID
+// of their application process to the windows, e.g. for diagnostics or to
+// match rules on the executable.
+//
+// The windows need their app-pid, which the default list-windows format
+// includes. Each process is looked up once, however many windows it owns.
+// A failing lookup leaves the Process of its windows nil: the lookup errors
+// are joined and returned along with all the windows.
+//
+// Usage:
+//
+//	all, err := windowService.GetAllWindows()
+//	if err != nil {
+//	    return err
+//	}
+//	enriched, err := windows.EnrichWithProcessInfo(ctx, all)
+//	for _, window := range enriched {
+//	    if window.Process != nil {
+//	        fmt.Println(window.AppName, window.Process.ExecutablePath)
+//	    }
+//	}
+func EnrichWithProcessInfo(ctx context.Context, windows []Window, opts ...EnrichOpts) ([]EnrichedWindow, error) {
+	var opt EnrichOpts
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	if opt.Lookup == nil {
+		opt.Lookup = PSLookup
+	}
+
+	processes := map[int]*ProcessInfo{}
+	var errs []error
+	enriched := make([]EnrichedWindow, 0, len(windows))
+	for _, window := range windows {
+		if window.AppPID <= 0 {
+			enriched = append(enriched, EnrichedWindow{Window: window})
+			continue
+		}
+
+		process, ok := processes[window.AppPID]
+		if !ok {
+			var err error
+			process, err = opt.Lookup.LookupProcess(ctx, window.AppPID)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("failed to look up process %d of %s\n%w", window.AppPID, window.AppName, err))
+				process = nil
+			}
+			processes[window.AppPID] = process
+		}
+		enriched = append(enriched, EnrichedWindow{Window: window, Process: process})
+	}
+	return enriched, errors.Join(errs...)
+}
+
+// runPS runs ps with args and returns its output. It is a variable so tests can replace it.
+var runPS = func(ctx context.Context, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "ps", args...)
+	// lstart is printed in the C locale format parsed by parsePS.
+	cmd.Env = append(os.Environ(), "LC_ALL=C")
+	output, err := cmd.Output()
+	return string(output), err
+}
+
+// lookupWithPS looks up the process with ps.
+func lookupWithPS(ctx context.Context, pid int) (*ProcessInfo, error) {
+	output, err := runPS(ctx, "-o", "ppid=,lstart=,comm=", "-p", strconv.Itoa(pid))
+	if err != nil {
+		// ps exits with a non-zero code when no process matches.
+		return nil, fmt.Errorf("process %d not found\n%w", pid, err)
+	}
+
+	process, err := parsePS(output)
+	if err != nil {
+		return nil, err
+	}
+	process.PID = pid
+	return process, nil
+}
+
+// lstartLayout is the layout of the ps lstart column, e.g. "Mon Oct 16 09:41:00 2026".
+const lstartLayout = "Mon Jan 2 15:04:05 2006"
+
+// parsePS parses the "ppid lstart comm" line printed by ps.
+// The executable path, last, may contain spaces.
+func parsePS(output string) (*ProcessInfo, error) {
+	rest := strings.TrimSpace(output)
+	// ppid followed by the 5 words of lstart.
+	var fields []string
+	for range 6 {
+		end := strings.IndexAny(rest, " \t")
+		if end < 0 {
+			return nil, fmt.Errorf("failed to parse ps output: %q", output)
+		}
+		fields = append(fields, rest[:end])
+		rest = strings.TrimLeft(rest[end:], " \t")
+	}
+	if rest == "" {
+		return nil, fmt.Errorf("failed to parse ps output: %q", output)
+	}
+
+	parentPID, err := strconv.Atoi(fields[0])
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse parent PID: %w\nOut:%s", err, output)
+	}
+	startTime, err := time.ParseInLocation(lstartLayout, strings.Join(fields[1:], " "), time.Local)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse start time: %w\nOut:%s", err, output)
+	}
+
+	return &ProcessInfo{
+		ExecutablePath: rest,
+		StartTime:      startTime,
+		ParentPID:      parentPID,
+	}, nil
+}
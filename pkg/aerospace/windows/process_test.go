@@ -0,0 +1,98 @@
+package windows
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestEnrichWithProcessInfo(t *testing.T) {
+	slack := &ProcessInfo{PID: 10, ExecutablePath: "/Applications/Slack.app/Contents/MacOS/Slack", ParentPID: 1}
+
+	t.Run("Happy path", func(tt *testing.T) {
+		tt.Run("looks up each process once", func(ttt *testing.T) {
+			var lookups []int
+			lookup := ProcessLookupFunc(func(ctx context.Context, pid int) (*ProcessInfo, error) {
+				lookups = append(lookups, pid)
+				return slack, nil
+			})
+
+			enriched, err := EnrichWithProcessInfo(context.Background(), []Window{
+				{WindowID: 1, AppName: "Slack", AppPID: 10},
+				{WindowID: 2, AppName: "Slack", AppPID: 10},
+				{WindowID: 3, AppName: "Ghostty"},
+			}, EnrichOpts{Lookup: lookup})
+			if err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(lookups, []int{10}) {
+				ttt.Fatalf("expected a single lookup of process 10, got %v", lookups)
+			}
+			if len(enriched) != 3 || enriched[0].Process != slack || enriched[1].Process != slack {
+				ttt.Fatalf("expected the Slack windows to be enriched, got %+v", enriched)
+			}
+			if enriched[2].Process != nil {
+				ttt.Fatalf("expected no process without app-pid, got %+v", enriched[2].Process)
+			}
+		})
+
+		tt.Run("looks up processes with ps", func(ttt *testing.T) {
+			original := runPS
+			defer func() { runPS = original }()
+			runPS = func(ctx context.Context, args ...string) (string, error) {
+				expected := []string{"-o", "ppid=,lstart=,comm=", "-p", "10"}
+				if !reflect.DeepEqual(args, expected) {
+					ttt.Fatalf("expected args %v, got %v", expected, args)
+				}
+				return "    1 Fri Oct 16 09:41:00 2026     /Applications/Google Chrome.app/Contents/MacOS/Google Chrome\n", nil
+			}
+
+			process, err := PSLookup.LookupProcess(context.Background(), 10)
+			if err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+			expected := &ProcessInfo{
+				PID:            10,
+				ExecutablePath: "/Applications/Google Chrome.app/Contents/MacOS/Google Chrome",
+				StartTime:      time.Date(2026, time.October, 16, 9, 41, 0, 0, time.Local),
+				ParentPID:      1,
+			}
+			if !reflect.DeepEqual(process, expected) {
+				ttt.Fatalf("expected %+v, got %+v", expected, process)
+			}
+		})
+	})
+
+	t.Run("Error cases", func(tt *testing.T) {
+		tt.Run("keeps the windows whose lookup failed", func(ttt *testing.T) {
+			lookup := ProcessLookupFunc(func(ctx context.Context, pid int) (*ProcessInfo, error) {
+				if pid == 20 {
+					return nil, errors.New("no such process")
+				}
+				return slack, nil
+			})
+
+			enriched, err := EnrichWithProcessInfo(context.Background(), []Window{
+				{WindowID: 1, AppName: "Slack", AppPID: 10},
+				{WindowID: 2, AppName: "Safari", AppPID: 20},
+			}, EnrichOpts{Lookup: lookup})
+			if err == nil {
+				ttt.Fatal("expected an error")
+			}
+			if len(enriched) != 2 || enriched[0].Process != slack || enriched[1].Process != nil {
+				ttt.Fatalf("expected only the Slack window to be enriched, got %+v", enriched)
+			}
+		})
+
+		tt.Run("fails on unexpected ps output", func(ttt *testing.T) {
+			for _, output := range []string{"", "1 Fri Oct 16 09:41:00 2026", "x Fri Oct 16 09:41:00 2026 /bin/zsh", "1 yesterday at noon, 2026 /bin/zsh"} {
+				if _, err := parsePS(output); err == nil {
+					ttt.Fatalf("expected an error for %q", output)
+				}
+			}
+		})
+	})
+}
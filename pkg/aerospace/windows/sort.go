@@ -0,0 +1,64 @@
+package windows
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+
+	"github.com/cristianoliveira/aerospace-ipc/internal/natural"
+)
+
+// Comparator orders two windows, returning a negative number when a sorts
+// before b, a positive number when it sorts after and zero when they tie.
+type Comparator func(a, b Window) int
+
+// CompareApp orders windows by application name, case-insensitively.
+func CompareApp(a, b Window) int {
+	return cmp.Compare(strings.ToLower(a.AppName), strings.ToLower(b.AppName))
+}
+
+// CompareTitle orders windows by title, case-insensitively.
+func CompareTitle(a, b Window) int {
+	return cmp.Compare(strings.ToLower(a.WindowTitle), strings.ToLower(b.WindowTitle))
+}
+
+// CompareWorkspace orders windows by workspace, "2" before "10".
+func CompareWorkspace(a, b Window) int {
+	return natural.Compare(a.Workspace, b.Workspace)
+}
+
+// CompareMonitor orders windows by monitor ID.
+func CompareMonitor(a, b Window) int {
+	return cmp.Compare(a.MonitorID, b.MonitorID)
+}
+
+// CompareID orders windows by window ID, which follows their creation order.
+func CompareID(a, b Window) int {
+	return cmp.Compare(a.WindowID, b.WindowID)
+}
+
+// Descending reverses comparator.
+func Descending(comparator Comparator) Comparator {
+	return func(a, b Window) int {
+		return comparator(b, a)
+	}
+}
+
+// SortBy sorts windows in place by comparators, the first one taking
+// precedence and the next ones breaking its ties.
+//
+// The sort is stable, so windows tied on every comparator keep their order.
+//
+// Usage:
+//
+//	windows.SortBy(all, windows.CompareWorkspace, windows.CompareApp, windows.CompareTitle)
+func SortBy(windows []Window, comparators ...Comparator) {
+	slices.SortStableFunc(windows, func(a, b Window) int {
+		for _, comparator := range comparators {
+			if c := comparator(a, b); c != 0 {
+				return c
+			}
+		}
+		return 0
+	})
+}
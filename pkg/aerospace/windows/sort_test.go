@@ -0,0 +1,56 @@
+package windows
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSortBy(t *testing.T) {
+	unsorted := []Window{
+		{WindowID: 4, AppName: "safari", WindowTitle: "Docs", Workspace: "10"},
+		{WindowID: 1, AppName: "Ghostty", WindowTitle: "zsh", Workspace: "2"},
+		{WindowID: 3, AppName: "Safari", WindowTitle: "GitHub", Workspace: "2"},
+		{WindowID: 2, AppName: "Ghostty", WindowTitle: "vim", Workspace: "10"},
+	}
+
+	tests := []struct {
+		name        string
+		comparators []Comparator
+		expected    []WindowID
+	}{
+		{
+			name:     "without comparators keeps the order",
+			expected: []WindowID{4, 1, 3, 2},
+		},
+		{
+			name:        "by app is stable",
+			comparators: []Comparator{CompareApp},
+			expected:    []WindowID{1, 2, 4, 3},
+		},
+		{
+			name:        "by workspace then title",
+			comparators: []Comparator{CompareWorkspace, CompareTitle},
+			expected:    []WindowID{3, 1, 4, 2},
+		},
+		{
+			name:        "by ID descending",
+			comparators: []Comparator{Descending(CompareID)},
+			expected:    []WindowID{4, 3, 2, 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			windows := slices.Clone(unsorted)
+			SortBy(windows, tt.comparators...)
+
+			ids := []WindowID{}
+			for _, window := range windows {
+				ids = append(ids, window.WindowID)
+			}
+			if !slices.Equal(ids, tt.expected) {
+				t.Fatalf("expected %v, got %v", tt.expected, ids)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package windows
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// DefaultWaitPollInterval is how often WaitFor lists the windows by default.
+const DefaultWaitPollInterval = 100 * time.Millisecond
+
+// WaitFor blocks until a window matching predicate appears, or ctx is done,
+// and returns the first matching window.
+//
+// The windows are listed every pollInterval, or DefaultWaitPollInterval if it
+// is zero or negative. It returns as soon as listing the windows fails.
+//
+// It is equivalent to running, until a window matches, the command:
+//
+//	aerospace list-windows --all --json --format <format>
+//
+// Usage:
+//
+//	exec.Command("open", "-a", "Ghostty").Run()
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+//	defer cancel()
+//	window, err := windowService.WaitFor(ctx, func(window windows.Window) bool {
+//	    return window.AppBundleID == "com.mitchellh.ghostty"
+//	}, 0)
+func (s *Service) WaitFor(ctx context.Context, predicate func(Window) bool, pollInterval time.Duration) (*Window, error) {
+	if predicate == nil {
+		panic("ASSERTION: predicate cannot be nil")
+	}
+	if pollInterval <= 0 {
+		pollInterval = DefaultWaitPollInterval
+	}
+
+	service := s.WithContext(ctx)
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for {
+		var found *Window
+		err := service.GetAllWindowsFunc(func(window Window) bool {
+			if predicate(window) {
+				found = &window
+				return false
+			}
+			return true
+		})
+		if err != nil {
+			return nil, err
+		}
+		if found != nil {
+			return found, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("failed to wait for window\n%w", context.Cause(ctx))
+		case <-ticker.C:
+		}
+	}
+}
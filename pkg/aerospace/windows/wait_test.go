@@ -0,0 +1,85 @@
+package windows
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	mock_client "github.com/cristianoliveira/aerospace-ipc/internal/mocks"
+	"github.com/cristianoliveira/aerospace-ipc/pkg/client"
+	"go.uber.org/mock/gomock"
+)
+
+func TestWaitFor(t *testing.T) {
+	listArgs := []string{"--all", "--json", "--format", formatArguments}
+	isGhostty := func(window Window) bool {
+		return window.AppName == "Ghostty"
+	}
+
+	t.Run("Happy path", func(tt *testing.T) {
+		tt.Run("returns the window once it appears", func(ttt *testing.T) {
+			ctrl := gomock.NewController(ttt)
+			defer ctrl.Finish()
+
+			ctx := context.Background()
+			mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+			gomock.InOrder(
+				mockConn.EXPECT().
+					SendCommandContext(ctx, "list-windows", listArgs).
+					Return(&client.Response{StdOut: `[{"window-id": 1, "app-name": "Safari"}]`}, nil).
+					Times(2),
+				mockConn.EXPECT().
+					SendCommandContext(ctx, "list-windows", listArgs).
+					Return(&client.Response{StdOut: `[
+					  {"window-id": 1, "app-name": "Safari"},
+					  {"window-id": 2, "app-name": "Ghostty"}
+					]`}, nil),
+			)
+
+			window, err := NewService(mockConn).WaitFor(ctx, isGhostty, time.Millisecond)
+			if err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+			if window.WindowID != 2 {
+				ttt.Fatalf("expected window 2, got %+v", window)
+			}
+		})
+	})
+
+	t.Run("Error cases", func(tt *testing.T) {
+		tt.Run("fails once the context is done", func(ttt *testing.T) {
+			ctrl := gomock.NewController(ttt)
+			defer ctrl.Finish()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+			defer cancel()
+
+			mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+			mockConn.EXPECT().
+				SendCommandContext(ctx, "list-windows", listArgs).
+				Return(&client.Response{StdOut: `[]`}, nil).
+				MinTimes(1)
+
+			_, err := NewService(mockConn).WaitFor(ctx, isGhostty, time.Millisecond)
+			if !errors.Is(err, context.DeadlineExceeded) {
+				ttt.Fatalf("expected context.DeadlineExceeded, got %v", err)
+			}
+		})
+
+		tt.Run("fails when listing the windows fails", func(ttt *testing.T) {
+			ctrl := gomock.NewController(ttt)
+			defer ctrl.Finish()
+
+			ctx := context.Background()
+			mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+			mockConn.EXPECT().
+				SendCommandContext(ctx, "list-windows", listArgs).
+				Return(&client.Response{ExitCode: 1, StdErr: "boom"}, nil)
+
+			if _, err := NewService(mockConn).WaitFor(ctx, isGhostty, time.Millisecond); err == nil {
+				ttt.Fatal("expected error, got nil")
+			}
+		})
+	})
+}
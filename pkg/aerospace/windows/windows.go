@@ -1,17 +1,30 @@
 package windows
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"iter"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/cristianoliveira/aerospace-ipc/pkg/aerospace/focus"
 	"github.com/cristianoliveira/aerospace-ipc/pkg/aerospace/layout"
 	"github.com/cristianoliveira/aerospace-ipc/pkg/client"
+	"github.com/cristianoliveira/aerospace-ipc/pkg/client/args"
+	"github.com/cristianoliveira/aerospace-ipc/pkg/client/commands"
 )
 
+// WindowID identifies a window. It is the same type as client.WindowID, so it
+// can be passed to every service taking a window ID, e.g. focus and layout.
+type WindowID = client.WindowID
+
 // Window represents a window managed by the AeroSpace window manager.
 //
-// See: aerospace list-windows --all --json
+// See: aerospace list-windows --all --json --format <format>
 //
 // Example JSON response:
 //
@@ -23,6 +36,10 @@ import (
 //	    "window-parent-container-layout" : "floating",
 //	    "app-bundle-id" : "com.brave.Browser",
 //	    "app-name" : "Brave Browser",
+//	    "app-pid" : 1234,
+//	    "monitor-id" : 1,
+//	    "monitor-name" : "Built-in Retina Display",
+//	    "window-is-fullscreen" : false
 //	  },
 //	  {
 //	    "window-id" : 10772,
@@ -30,25 +47,51 @@ import (
 //	    "window-layout" : "h_tiles",
 //	    "window-parent-container-layout" : "h_tiles",
 //	    "app-name" : "WhatsApp",
-//	    "app-bundle-id" : "net.whatsapp.WhatsApp"
+//	    "app-bundle-id" : "net.whatsapp.WhatsApp",
+//	    "app-pid" : 5678,
+//	    "monitor-id" : 2,
+//	    "monitor-name" : "DELL U2720Q",
+//	    "window-is-fullscreen" : true
 //	  }
 //	]
 type Window struct {
-	WindowID                    int    `json:"window-id"`
-	WindowTitle                 string `json:"window-title"`
-	WindowLayout                string `json:"window-layout"`
-	WindowParentContainerLayout string `json:"window-parent-container-layout"`
-	AppName                     string `json:"app-name"`
-	AppBundleID                 string `json:"app-bundle-id"`
-	Workspace                   string `json:"workspace"`
+	WindowID                    WindowID `json:"window-id"`
+	WindowTitle                 string   `json:"window-title"`
+	WindowLayout                string   `json:"window-layout"`
+	WindowParentContainerLayout string   `json:"window-parent-container-layout"`
+	AppName                     string   `json:"app-name"`
+	AppBundleID                 string   `json:"app-bundle-id"`
+	Workspace                   string   `json:"workspace"`
+	AppPID                      int      `json:"app-pid,omitempty"`
+	MonitorID                   int      `json:"monitor-id,omitempty"`
+	MonitorName                 string   `json:"monitor-name,omitempty"`
+	IsFullscreen                bool     `json:"window-is-fullscreen,omitempty"`
+
+	// Only fetched when selected with ListWindowsOpts.Fields.
+	AppExecPath             string `json:"app-exec-path,omitempty"`
+	AppBundlePath           string `json:"app-bundle-path,omitempty"`
+	WorkspaceIsFocused      bool   `json:"workspace-is-focused,omitempty"`
+	WorkspaceIsVisible      bool   `json:"workspace-is-visible,omitempty"`
+	MonitorAppKitNSScreenID int    `json:"monitor-appkit-nsscreen-screens-id,omitempty"`
 }
 
-const formatArguments = "%{window-id} %{window-title} %{app-name} %{app-bundle-id} %{workspace} %{window-layout} %{window-parent-container-layout}"
+const formatArguments = "%{window-id} %{window-title} %{app-name} %{app-bundle-id} %{workspace} " +
+	"%{window-layout} %{window-parent-container-layout} " +
+	"%{app-pid} %{monitor-id} %{monitor-name} %{window-is-fullscreen}"
+
+// IsFloating reports whether the window is floating.
+//
+// AeroSpace has no dedicated format variable for it, so it is derived from WindowLayout.
+func (w Window) IsFloating() bool {
+	return w.WindowLayout == "floating"
+}
 
-// String returns a string representation of the Window struct.
+// String returns a string representation of the Window struct,
+// formatted with DefaultFormatter.
 //
-// It includes the window ID, application name, window title (if available),
-// window layout, window parent container layout, workspace, and app bundle ID.
+// By default, it includes the window ID, application name, window title,
+// window layout, window parent container layout, workspace, and app bundle ID,
+// skipping the empty ones.
 //
 // Example:
 //
@@ -65,35 +108,41 @@ const formatArguments = "%{window-id} %{window-title} %{app-name} %{app-bundle-i
 //
 //	// Output: 6231 | Brave Browser | Github Page | floating | floating | 8 | com.brave.Browser
 func (w Window) String() string {
-	builder := fmt.Sprintf("%d | %s ", w.WindowID, w.AppName)
-	if w.WindowTitle != "" {
-		builder += fmt.Sprintf("| %s", w.WindowTitle)
-	}
-	if w.WindowLayout != "" {
-		builder += fmt.Sprintf(" | %s", w.WindowLayout)
+	return DefaultFormatter.Format(w)
+}
+
+// LogValue implements slog.LogValuer, logging the window as a group of
+// its identifying attributes instead of the String output.
+//
+// Usage:
+//
+//	slog.Info("focusing window", "window", window)
+//
+//	// Output: level=INFO msg="focusing window" window.id=6231 window.app="Brave Browser" window.title="Github Page" window.workspace=8
+func (w Window) LogValue() slog.Value {
+	attrs := []slog.Attr{
+		slog.Int("id", int(w.WindowID)),
+		slog.String("app", w.AppName),
 	}
-	if w.WindowParentContainerLayout != "" {
-		builder += fmt.Sprintf(" | %s", w.WindowParentContainerLayout)
+	if w.WindowTitle != "" {
+		attrs = append(attrs, slog.String("title", w.WindowTitle))
 	}
 	if w.Workspace != "" {
-		builder += fmt.Sprintf(" | %s", w.Workspace)
-	}
-	if w.AppBundleID != "" {
-		builder += fmt.Sprintf(" | %s", w.AppBundleID)
+		attrs = append(attrs, slog.String("workspace", w.Workspace))
 	}
-
-	return builder
+	return slog.GroupValue(attrs...)
 }
 
 // Service provides methods to interact with windows in AeroSpaceWM.
 type Service struct {
 	client client.AeroSpaceConnection
+	ctx    context.Context
 }
 
 // SetFocusArgs contains required arguments for SetFocusByWindowID.
 type SetFocusArgs struct {
 	// WindowID specifies the window ID to focus.
-	WindowID int
+	WindowID WindowID
 }
 
 // SetFocusOpts contains optional parameters for SetFocusByWindowID.
@@ -149,6 +198,65 @@ type SetFocusByDFSIndexArgs struct {
 	DFSIndex int
 }
 
+// DebugWindowsOpts contains optional parameters for DebugWindows.
+type DebugWindowsOpts struct {
+	// WindowID specifies the window to print debug information for.
+	// If not set, AeroSpace toggles its interactive debug recording session.
+	WindowID *WindowID
+}
+
+// ListWindowsOpts contains optional filters for listing windows.
+//
+// Without filters all windows are listed.
+type ListWindowsOpts struct {
+	// Monitors filters windows by monitor.
+	// Possible values: monitor IDs (1-based), "focused", "mouse" or "all".
+	Monitors []string
+
+	// Workspaces filters windows by workspace name.
+	// Possible values: workspace names, "focused" or "visible".
+	Workspaces []string
+
+	// AppBundleID filters windows by application bundle ID, e.g. "com.apple.Terminal".
+	AppBundleID *string
+
+	// PID filters windows by application process ID.
+	PID *int
+
+	// Focused lists only the focused window. Incompatible with other filters.
+	Focused bool
+
+	// Fields selects the fields to fetch. Only used by GetWindowsWithOpts.
+	// The remaining Window fields are left empty. If not set, all fields are fetched.
+	Fields []Field
+}
+
+// filterArgs returns the list-windows filter flags for the options.
+func (opt ListWindowsOpts) filterArgs() ([]string, error) {
+	if opt.Focused {
+		if len(opt.Monitors) > 0 || len(opt.Workspaces) > 0 || opt.AppBundleID != nil || opt.PID != nil {
+			return nil, fmt.Errorf("focused cannot be combined with other filters")
+		}
+		return []string{"--focused"}, nil
+	}
+
+	builder := args.New().
+		KVs("--monitor", opt.Monitors...).
+		KVs("--workspace", opt.Workspaces...)
+	if builder.Len() == 0 {
+		if opt.AppBundleID == nil && opt.PID == nil {
+			return []string{"--all"}, nil
+		}
+		// --all cannot be combined with the app filters, "--monitor all" is equivalent.
+		builder.KV("--monitor", "all")
+	}
+	builder.
+		KV("--app-bundle-id", opt.AppBundleID).
+		KV("--pid", opt.PID)
+
+	return builder.Build(), nil
+}
+
 // WindowsService defines the interface for window operations in AeroSpaceWM.
 type WindowsService interface {
 	// GetAllWindows returns all windows currently managed by the window manager.
@@ -160,6 +268,43 @@ type WindowsService interface {
 	// GetFocusedWindow returns the currently focused window.
 	GetFocusedWindow() (*Window, error)
 
+	// DebugWindows returns the raw accessibility diagnostics of windows.
+	DebugWindows(opts ...DebugWindowsOpts) (string, error)
+
+	// GetWindowsWithOpts returns the windows matching the given filters.
+	GetWindowsWithOpts(opts ListWindowsOpts) ([]Window, error)
+
+	// GetWindowsCount returns the number of windows matching the given filters.
+	GetWindowsCount(opts ...ListWindowsOpts) (int, error)
+
+	// GetWindowsByAppBundleID returns the windows of the application with the given bundle ID.
+	GetWindowsByAppBundleID(bundleID string) ([]Window, error)
+
+	// GetWindowsByAppName returns the windows of the application with the given name.
+	GetWindowsByAppName(name string) ([]Window, error)
+
+	// GetWindowsByPID returns the windows of the application with the given process ID.
+	GetWindowsByPID(pid int) ([]Window, error)
+
+	// Find returns the windows matching all of filters.
+	Find(filters ...Filter) ([]Window, error)
+
+	// All returns an iterator over all windows, decoded one at a time.
+	All() iter.Seq2[Window, error]
+
+	// GetAllWindowsFunc calls fn with every window until fn returns false.
+	GetAllWindowsFunc(fn func(Window) bool) error
+
+	// WaitFor blocks until a window matching predicate appears, or ctx is done.
+	WaitFor(ctx context.Context, predicate func(Window) bool, pollInterval time.Duration) (*Window, error)
+
+	// GatherWindowsByApp moves every window of an application to one workspace.
+	// It returns the number of windows moved.
+	GatherWindowsByApp(bundleID, workspace string) (int, error)
+
+	// CloseWindowsByApp closes the windows of an application and summarizes the outcome.
+	CloseWindowsByApp(bundleID string, opts ...CloseWindowsOpts) (CloseSummary, error)
+
 	// SetFocusByWindowID sets the focus to a window specified by its ID.
 	SetFocusByWindowID(args SetFocusArgs) error
 
@@ -197,6 +342,47 @@ func NewService(client client.AeroSpaceConnection) *Service {
 	return &Service{client: client}
 }
 
+// WithContext returns a copy of the service that sends every command with ctx,
+// so requests can be cancelled or bounded by a deadline.
+//
+// Usage:
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+//	defer cancel()
+//	windows, err := windowService.WithContext(ctx).GetAllWindows()
+func (s *Service) WithContext(ctx context.Context) *Service {
+	if ctx == nil {
+		panic("ASSERTION: context cannot be nil")
+	}
+	return &Service{client: s.client, ctx: ctx}
+}
+
+// newFocusService returns a focus service sharing the connection and context.
+func (s *Service) newFocusService() *focus.Service {
+	service := focus.NewService(s.client)
+	if s.ctx != nil {
+		service = service.WithContext(s.ctx)
+	}
+	return service
+}
+
+// newLayoutService returns a layout service sharing the connection and context.
+func (s *Service) newLayoutService() *layout.Service {
+	service := layout.NewService(s.client)
+	if s.ctx != nil {
+		service = service.WithContext(s.ctx)
+	}
+	return service
+}
+
+// sendCommand sends a command using the service context, if any.
+func (s *Service) sendCommand(command string, args []string) (*client.Response, error) {
+	if s.ctx == nil {
+		return s.client.SendCommand(command, args)
+	}
+	return s.client.SendCommandContext(s.ctx, command, args)
+}
+
 // GetAllWindows returns all windows currently managed by the window manager.
 //
 // It is equivalent to running the command:
@@ -211,8 +397,8 @@ func NewService(client client.AeroSpaceConnection) *Service {
 //	fmt.Println("Windows:", windows)
 //	fmt.Println("Error:", err)
 func (s *Service) GetAllWindows() ([]Window, error) {
-	response, err := s.client.SendCommand(
-		"list-windows",
+	response, err := s.sendCommand(
+		commands.ListWindows,
 		[]string{
 			"--all",
 			"--json",
@@ -249,8 +435,8 @@ func (s *Service) GetAllWindows() ([]Window, error) {
 //	fmt.Println("Windows:", windows)
 //	fmt.Println("Error:", err)
 func (s *Service) GetAllWindowsByWorkspace(workspaceName string) ([]Window, error) {
-	response, err := s.client.SendCommand(
-		"list-windows",
+	response, err := s.sendCommand(
+		commands.ListWindows,
 		[]string{
 			"--workspace", workspaceName,
 			"--json",
@@ -288,8 +474,8 @@ func (s *Service) GetAllWindowsByWorkspace(workspaceName string) ([]Window, erro
 //	fmt.Println("Window:", window)
 //	fmt.Println("Error:", err)
 func (s *Service) GetFocusedWindow() (*Window, error) {
-	response, err := s.client.SendCommand(
-		"list-windows",
+	response, err := s.sendCommand(
+		commands.ListWindows,
 		[]string{
 			"--focused",
 			"--json",
@@ -317,6 +503,254 @@ func (s *Service) GetFocusedWindow() (*Window, error) {
 	return &windows[0], nil
 }
 
+// DebugWindows returns the raw accessibility diagnostics of windows.
+//
+// The output is meant to be attached to bug reports, it is returned as is.
+//
+// It is equivalent to running the command:
+//
+//	aerospace debug-windows [--window-id <window-id>]
+//
+// Without a window ID, AeroSpace uses an interactive session: the first call starts
+// recording the windows that appear, the second call stops it and returns the trace.
+//
+// Usage:
+//
+//	// Diagnostics of a specific window
+//	trace, err := windowService.DebugWindows(windows.DebugWindowsOpts{
+//	    WindowID: &windowID,
+//	})
+//	fmt.Println(trace)
+func (s *Service) DebugWindows(opts ...DebugWindowsOpts) (string, error) {
+	var opt DebugWindowsOpts
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+
+	cmdArgs := args.New().KV("--window-id", opt.WindowID).Build()
+
+	response, err := s.sendCommand(commands.DebugWindows, cmdArgs)
+	if err != nil {
+		return "", err
+	}
+
+	if response.ExitCode != 0 {
+		return "", fmt.Errorf("failed to debug windows\n%s", response.StdErr)
+	}
+
+	return response.StdOut, nil
+}
+
+// GetWindowsWithOpts returns the windows matching the given filters.
+//
+// All filters are applied by the server, so there is no need to filter client side.
+// Without filters all windows are returned.
+//
+// It is equivalent to running the command:
+//
+//	aerospace list-windows --all --json --format <format>
+//	aerospace list-windows [--monitor <monitor>...] [--workspace <workspace>...] [--app-bundle-id <id>] [--pid <pid>] --json --format <format>
+//	aerospace list-windows --focused --json --format <format>
+//
+// Returns an error if Focused is combined with other filters.
+//
+// Usage:
+//
+//	// Terminal windows on the focused monitor
+//	windows, err := windowService.GetWindowsWithOpts(windows.ListWindowsOpts{
+//	    Monitors:    []string{"focused"},
+//	    AppBundleID: windows.StringPtr("com.apple.Terminal"),
+//	})
+//
+//	// Only IDs and titles
+//	windows, err := windowService.GetWindowsWithOpts(windows.ListWindowsOpts{
+//	    Fields: []windows.Field{windows.FieldWindowID, windows.FieldTitle},
+//	})
+func (s *Service) GetWindowsWithOpts(opts ListWindowsOpts) ([]Window, error) {
+	cmdArgs, err := opts.filterArgs()
+	if err != nil {
+		return nil, err
+	}
+
+	format := formatArguments
+	if len(opts.Fields) > 0 {
+		format, err = BuildJSONFormat(opts.Fields...)
+		if err != nil {
+			return nil, err
+		}
+	}
+	cmdArgs = append(cmdArgs, "--json", "--format", format)
+
+	response, err := s.sendCommand(commands.ListWindows, cmdArgs)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.ExitCode != 0 {
+		return nil, fmt.Errorf("failed to list windows\n%s", response.StdErr)
+	}
+
+	var windows []Window
+	err = json.Unmarshal([]byte(response.StdOut), &windows)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to unmarshal windows: %w\nOut:%s\nErr:%s",
+			err,
+			response.StdOut,
+			response.StdErr,
+		)
+	}
+	return windows, nil
+}
+
+// GetWindowsByAppBundleID returns the windows of the application with the given bundle ID
+// on all monitors.
+//
+// Servers without the --app-bundle-id filter reject it, so all windows are
+// then listed and filtered by exact AppBundleID match on the client side.
+//
+// It is equivalent to running the command:
+//
+//	aerospace list-windows --monitor all --app-bundle-id <bundle-id> --json --format <format>
+//
+// or, when the server does not support --app-bundle-id, the command:
+//
+//	aerospace list-windows --all --json --format <format>
+//
+// Usage:
+//
+//	windows, err := windowService.GetWindowsByAppBundleID("com.apple.Terminal")
+func (s *Service) GetWindowsByAppBundleID(bundleID string) ([]Window, error) {
+	windows, err := s.GetWindowsWithOpts(ListWindowsOpts{
+		AppBundleID: &bundleID,
+	})
+	if !isUnsupportedFlag(err, "--app-bundle-id") {
+		return windows, err
+	}
+
+	all, err := s.GetWindowsWithOpts(ListWindowsOpts{})
+	if err != nil {
+		return nil, err
+	}
+
+	windows = []Window{}
+	for _, window := range all {
+		if window.AppBundleID == bundleID {
+			windows = append(windows, window)
+		}
+	}
+	return windows, nil
+}
+
+// isUnsupportedFlag reports whether err is the server rejecting flag,
+// e.g. because it is older than the flag.
+func isUnsupportedFlag(err error, flag string) bool {
+	var cmdErr *client.CommandError
+	if !errors.As(err, &cmdErr) {
+		return false
+	}
+
+	stderr := strings.ToLower(cmdErr.StdErr)
+	if !strings.Contains(stderr, flag) {
+		return false
+	}
+	for _, reason := range []string{"unknown", "unsupported", "unrecognized"} {
+		if strings.Contains(stderr, reason) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetWindowsByAppName returns the windows of the application with the given name
+// on all monitors.
+//
+// AeroSpace has no server-side filter for application names, so all windows
+// are listed and filtered by exact AppName match.
+//
+// It is equivalent to running the command:
+//
+//	aerospace list-windows --all --json --format <format>
+//
+// Usage:
+//
+//	windows, err := windowService.GetWindowsByAppName("Terminal")
+func (s *Service) GetWindowsByAppName(name string) ([]Window, error) {
+	all, err := s.GetWindowsWithOpts(ListWindowsOpts{})
+	if err != nil {
+		return nil, err
+	}
+
+	windows := []Window{}
+	for _, window := range all {
+		if window.AppName == name {
+			windows = append(windows, window)
+		}
+	}
+	return windows, nil
+}
+
+// GetWindowsByPID returns the windows of the application with the given process ID
+// on all monitors.
+//
+// It is equivalent to running the command:
+//
+//	aerospace list-windows --monitor all --pid <pid> --json --format <format>
+//
+// Usage:
+//
+//	windows, err := windowService.GetWindowsByPID(cmd.Process.Pid)
+func (s *Service) GetWindowsByPID(pid int) ([]Window, error) {
+	return s.GetWindowsWithOpts(ListWindowsOpts{
+		PID: &pid,
+	})
+}
+
+// GetWindowsCount returns the number of windows matching the given filters.
+//
+// Cheaper than listing the windows, meant for status bars polling frequently.
+//
+// It is equivalent to running the command:
+//
+//	aerospace list-windows --all --count
+//	aerospace list-windows [--monitor <monitor>...] [--workspace <workspace>...] --count
+//	aerospace list-windows --focused --count
+//
+// Usage:
+//
+//	// Windows on the focused workspace
+//	count, err := windowService.GetWindowsCount(windows.ListWindowsOpts{
+//	    Workspaces: []string{"focused"},
+//	})
+func (s *Service) GetWindowsCount(opts ...ListWindowsOpts) (int, error) {
+	var opt ListWindowsOpts
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+
+	cmdArgs, err := opt.filterArgs()
+	if err != nil {
+		return 0, err
+	}
+	cmdArgs = append(cmdArgs, "--count")
+
+	response, err := s.sendCommand(commands.ListWindows, cmdArgs)
+	if err != nil {
+		return 0, err
+	}
+
+	if response.ExitCode != 0 {
+		return 0, fmt.Errorf("failed to count windows\n%s", response.StdErr)
+	}
+
+	count, err := strconv.Atoi(strings.TrimSpace(response.StdOut))
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse windows count: %w\nOut:%s", err, response.StdOut)
+	}
+
+	return count, nil
+}
+
 // SetFocusByWindowID sets the focus to a window specified by its ID.
 //
 // It is equivalent to running the command:
@@ -357,7 +791,7 @@ func (s *Service) SetFocusByWindowID(args SetFocusArgs) error {
 //
 // Deprecated: Use client.Focus().SetFocusByWindowID() instead. This method is kept for backward compatibility.
 func (s *Service) SetFocusByWindowIDWithOpts(args SetFocusArgs, opts SetFocusOpts) error {
-	focusService := focus.NewService(s.client)
+	focusService := s.newFocusService()
 	focusOpts := focus.SetFocusOpts{
 		IgnoreFloating: opts.IgnoreFloating,
 	}
@@ -407,7 +841,7 @@ func (s *Service) SetFocusByDirection(args SetFocusByDirectionArgs) error {
 //
 // Deprecated: Use client.Focus().SetFocusByDirection() instead. This method is kept for backward compatibility.
 func (s *Service) SetFocusByDirectionWithOpts(args SetFocusByDirectionArgs, opts SetFocusByDirectionOpts) error {
-	focusService := focus.NewService(s.client)
+	focusService := s.newFocusService()
 	focusOpts := focus.SetFocusOpts{
 		IgnoreFloating:  opts.IgnoreFloating,
 		Boundaries:      opts.Boundaries,
@@ -457,7 +891,7 @@ func (s *Service) SetFocusByDFS(args SetFocusByDFSArgs) error {
 //
 // Deprecated: Use client.Focus().SetFocusByDFS() instead. This method is kept for backward compatibility.
 func (s *Service) SetFocusByDFSWithOpts(args SetFocusByDFSArgs, opts SetFocusByDFSOpts) error {
-	focusService := focus.NewService(s.client)
+	focusService := s.newFocusService()
 	focusOpts := focus.SetFocusOpts{
 		IgnoreFloating:  opts.IgnoreFloating,
 		Boundaries:      opts.Boundaries,
@@ -482,7 +916,7 @@ func (s *Service) SetFocusByDFSWithOpts(args SetFocusByDFSArgs, opts SetFocusByD
 //
 // Deprecated: Use client.Focus().SetFocusByDFSIndex() instead. This method is kept for backward compatibility.
 func (s *Service) SetFocusByDFSIndex(args SetFocusByDFSIndexArgs) error {
-	focusService := focus.NewService(s.client)
+	focusService := s.newFocusService()
 	return focusService.SetFocusByDFSIndex(args.DFSIndex)
 }
 
@@ -497,7 +931,7 @@ type SetLayoutArgs struct {
 // SetLayoutOpts contains optional parameters for SetLayout.
 type SetLayoutOpts struct {
 	// WindowID specifies the window ID to set layout for. If not set, the focused window is used.
-	WindowID *int
+	WindowID *WindowID
 }
 
 // SetLayout sets the layout for the focused window.
@@ -547,7 +981,7 @@ func (s *Service) SetLayout(args SetLayoutArgs) error {
 // Usage:
 //
 //	// Set layout for specific window
-//	windowID := 12345
+//	windowID := windows.WindowID(12345)
 //	err := windowService.SetLayoutWithOpts(windows.SetLayoutArgs{
 //	    Layouts: []string{"floating"},
 //	}, windows.SetLayoutOpts{
@@ -563,10 +997,21 @@ func (s *Service) SetLayout(args SetLayoutArgs) error {
 //
 // Deprecated: Use client.Layout().SetLayout() instead. This method is kept for backward compatibility.
 func (s *Service) SetLayoutWithOpts(args SetLayoutArgs, opts SetLayoutOpts) error {
-	layoutService := layout.NewService(s.client)
+	layoutService := s.newLayoutService()
 	layoutOpts := layout.SetLayoutOpts{
 		WindowID: opts.WindowID,
 	}
 	return layoutService.SetLayout(args.Layouts, layoutOpts)
 }
 
+// Helper functions for creating pointers (useful for API usage)
+
+// IntPtr returns a pointer to the given int value.
+func IntPtr(v int) *int {
+	return &v
+}
+
+// StringPtr returns a pointer to the given string value.
+func StringPtr(v string) *string {
+	return &v
+}
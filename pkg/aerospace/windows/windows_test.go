@@ -1,8 +1,11 @@
 package windows
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"log/slog"
+	"slices"
 	"testing"
 
 	mock_client "github.com/cristianoliveira/aerospace-ipc/internal/mocks"
@@ -112,6 +115,47 @@ func TestWindowService(t *testing.T) {
 			}
 		})
 
+		t.Run("GetAllWindows decodes pid, monitor and flags", func(tt *testing.T) {
+			ctrl := gomock.NewController(tt)
+			defer ctrl.Finish()
+
+			mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+			service := NewService(mockConn)
+
+			mockConn.EXPECT().
+				SendCommand("list-windows", gomock.Any()).
+				Return(
+					&client.Response{
+						StdOut: `[{
+							"window-id": 6231,
+							"app-name": "Brave Browser",
+							"window-layout": "floating",
+							"app-pid": 1234,
+							"monitor-id": 2,
+							"monitor-name": "DELL U2720Q",
+							"window-is-fullscreen": true
+						}]`,
+					},
+					nil,
+				)
+
+			windows, err := service.GetAllWindows()
+			if err != nil {
+				tt.Fatalf("unexpected error: %v", err)
+			}
+
+			window := windows[0]
+			if window.AppPID != 1234 || window.MonitorID != 2 || window.MonitorName != "DELL U2720Q" {
+				tt.Errorf("unexpected window %+v", window)
+			}
+			if !window.IsFullscreen {
+				tt.Errorf("expected window to be fullscreen")
+			}
+			if !window.IsFloating() {
+				tt.Errorf("expected window to be floating")
+			}
+		})
+
 		t.Run("GetAllWindowsByWorkspace", func(tt *testing.T) {
 			ctrl := gomock.NewController(tt)
 			defer ctrl.Finish()
@@ -160,6 +204,312 @@ func TestWindowService(t *testing.T) {
 			}
 		})
 
+		t.Run("DebugWindows", func(tt *testing.T) {
+			ctrl := gomock.NewController(tt)
+			defer ctrl.Finish()
+
+			mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+			service := NewService(mockConn)
+
+			trace := "Window 123456\nAXRole: AXWindow\n"
+			mockConn.EXPECT().
+				SendCommand("debug-windows", []string{"--window-id", "123456"}).
+				Return(&client.Response{StdOut: trace}, nil)
+
+			windowID := WindowID(123456)
+			result, err := service.DebugWindows(DebugWindowsOpts{WindowID: &windowID})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result != trace {
+				t.Errorf("expected raw trace %q, got %q", trace, result)
+			}
+		})
+
+		t.Run("GetWindowsWithOpts", func(tt *testing.T) {
+			tests := []struct {
+				name     string
+				opts     ListWindowsOpts
+				expected []string
+			}{
+				{
+					name:     "no filters",
+					expected: []string{"--all"},
+				},
+				{
+					name: "by monitor and workspace",
+					opts: ListWindowsOpts{
+						Monitors:   []string{"1", "2"},
+						Workspaces: []string{"terminal"},
+					},
+					expected: []string{"--monitor", "1", "2", "--workspace", "terminal"},
+				},
+				{
+					name: "by app on all monitors",
+					opts: ListWindowsOpts{
+						AppBundleID: StringPtr("com.apple.Terminal"),
+						PID:         IntPtr(1234),
+					},
+					expected: []string{"--monitor", "all", "--app-bundle-id", "com.apple.Terminal", "--pid", "1234"},
+				},
+				{
+					name: "by app on a workspace",
+					opts: ListWindowsOpts{
+						Workspaces:  []string{"focused"},
+						AppBundleID: StringPtr("com.apple.Terminal"),
+					},
+					expected: []string{"--workspace", "focused", "--app-bundle-id", "com.apple.Terminal"},
+				},
+				{
+					name:     "focused",
+					opts:     ListWindowsOpts{Focused: true},
+					expected: []string{"--focused"},
+				},
+			}
+
+			for _, tc := range tests {
+				tt.Run(tc.name, func(ttt *testing.T) {
+					ctrl := gomock.NewController(ttt)
+					defer ctrl.Finish()
+
+					mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+					service := NewService(mockConn)
+
+					expected := append(tc.expected, "--json", "--format", formatArguments)
+					mockConn.EXPECT().
+						SendCommand("list-windows", expected).
+						Return(&client.Response{StdOut: `[{"window-id": 1, "app-name": "Terminal"}]`}, nil)
+
+					windows, err := service.GetWindowsWithOpts(tc.opts)
+					if err != nil {
+						ttt.Fatalf("unexpected error: %v", err)
+					}
+					if len(windows) != 1 || windows[0].WindowID != 1 {
+						ttt.Errorf("unexpected windows %+v", windows)
+					}
+				})
+			}
+		})
+
+		t.Run("GetWindowsWithOpts with fields", func(tt *testing.T) {
+			ctrl := gomock.NewController(tt)
+			defer ctrl.Finish()
+
+			mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+			service := NewService(mockConn)
+
+			mockConn.EXPECT().
+				SendCommand(
+					"list-windows",
+					[]string{
+						"--all",
+						"--json",
+						"--format", "%{window-id} %{window-title}",
+					},
+				).
+				Return(&client.Response{StdOut: `[{"window-id": 1, "window-title": "zsh"}]`}, nil)
+
+			windows, err := service.GetWindowsWithOpts(ListWindowsOpts{
+				Fields: []Field{FieldWindowID, FieldTitle},
+			})
+			if err != nil {
+				tt.Fatalf("unexpected error: %v", err)
+			}
+			if windows[0].WindowID != 1 || windows[0].WindowTitle != "zsh" || windows[0].AppName != "" {
+				tt.Errorf("unexpected window %+v", windows[0])
+			}
+		})
+
+		t.Run("GetWindowsByAppBundleID", func(tt *testing.T) {
+			ctrl := gomock.NewController(tt)
+			defer ctrl.Finish()
+
+			mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+			service := NewService(mockConn)
+
+			mockConn.EXPECT().
+				SendCommand(
+					"list-windows",
+					[]string{
+						"--monitor", "all",
+						"--app-bundle-id", "com.apple.Terminal",
+						"--json",
+						"--format", formatArguments,
+					},
+				).
+				Return(&client.Response{StdOut: `[{"window-id": 1, "app-bundle-id": "com.apple.Terminal"}]`}, nil)
+
+			windows, err := service.GetWindowsByAppBundleID("com.apple.Terminal")
+			if err != nil {
+				tt.Fatalf("unexpected error: %v", err)
+			}
+			if len(windows) != 1 || windows[0].WindowID != 1 {
+				tt.Errorf("unexpected windows %+v", windows)
+			}
+		})
+
+		t.Run("GetWindowsByAppBundleID falls back to client-side filtering", func(tt *testing.T) {
+			ctrl := gomock.NewController(tt)
+			defer ctrl.Finish()
+
+			mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+			service := NewService(mockConn)
+
+			filterArgs := []string{
+				"--monitor", "all",
+				"--app-bundle-id", "com.apple.Terminal",
+				"--json",
+				"--format", formatArguments,
+			}
+			gomock.InOrder(
+				mockConn.EXPECT().
+					SendCommand("list-windows", filterArgs).
+					Return(nil, &client.CommandError{
+						Command:  "list-windows",
+						Args:     filterArgs,
+						ExitCode: 2,
+						StdErr:   "Unknown flag '--app-bundle-id'",
+					}),
+				mockConn.EXPECT().
+					SendCommand("list-windows", []string{"--all", "--json", "--format", formatArguments}).
+					Return(&client.Response{StdOut: `[
+						{"window-id": 1, "app-bundle-id": "com.apple.Terminal"},
+						{"window-id": 2, "app-bundle-id": "com.apple.Safari"}
+					]`}, nil),
+			)
+
+			windows, err := service.GetWindowsByAppBundleID("com.apple.Terminal")
+			if err != nil {
+				tt.Fatalf("unexpected error: %v", err)
+			}
+			if len(windows) != 1 || windows[0].WindowID != 1 {
+				tt.Errorf("unexpected windows %+v", windows)
+			}
+		})
+
+		t.Run("GetWindowsByAppBundleID does not fall back on other errors", func(tt *testing.T) {
+			ctrl := gomock.NewController(tt)
+			defer ctrl.Finish()
+
+			mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+			service := NewService(mockConn)
+
+			mockConn.EXPECT().
+				SendCommand("list-windows", gomock.Any()).
+				Return(nil, &client.CommandError{Command: "list-windows", ExitCode: 1, StdErr: "boom"})
+
+			if _, err := service.GetWindowsByAppBundleID("com.apple.Terminal"); err == nil {
+				tt.Fatal("expected error, got nil")
+			}
+		})
+
+		t.Run("GetWindowsByAppName", func(tt *testing.T) {
+			ctrl := gomock.NewController(tt)
+			defer ctrl.Finish()
+
+			mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+			service := NewService(mockConn)
+
+			mockConn.EXPECT().
+				SendCommand(
+					"list-windows",
+					[]string{
+						"--all",
+						"--json",
+						"--format", formatArguments,
+					},
+				).
+				Return(&client.Response{StdOut: `[
+					{"window-id": 1, "app-name": "Terminal"},
+					{"window-id": 2, "app-name": "Safari"},
+					{"window-id": 3, "app-name": "Terminal"}
+				]`}, nil)
+
+			windows, err := service.GetWindowsByAppName("Terminal")
+			if err != nil {
+				tt.Fatalf("unexpected error: %v", err)
+			}
+			if len(windows) != 2 || windows[0].WindowID != 1 || windows[1].WindowID != 3 {
+				tt.Errorf("unexpected windows %+v", windows)
+			}
+		})
+
+		t.Run("GetWindowsByPID", func(tt *testing.T) {
+			ctrl := gomock.NewController(tt)
+			defer ctrl.Finish()
+
+			mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+			service := NewService(mockConn)
+
+			mockConn.EXPECT().
+				SendCommand(
+					"list-windows",
+					[]string{
+						"--monitor", "all",
+						"--pid", "4321",
+						"--json",
+						"--format", formatArguments,
+					},
+				).
+				Return(&client.Response{StdOut: `[{"window-id": 1, "app-pid": 4321}]`}, nil)
+
+			windows, err := service.GetWindowsByPID(4321)
+			if err != nil {
+				tt.Fatalf("unexpected error: %v", err)
+			}
+			if len(windows) != 1 || windows[0].AppPID != 4321 {
+				tt.Errorf("unexpected windows %+v", windows)
+			}
+		})
+
+		t.Run("GetWindowsCount", func(tt *testing.T) {
+			tests := []struct {
+				name     string
+				opts     []ListWindowsOpts
+				expected []string
+			}{
+				{
+					name:     "all windows",
+					expected: []string{"--all", "--count"},
+				},
+				{
+					name: "by monitor and workspace",
+					opts: []ListWindowsOpts{{
+						Monitors:   []string{"1"},
+						Workspaces: []string{"focused", "terminal"},
+					}},
+					expected: []string{"--monitor", "1", "--workspace", "focused", "terminal", "--count"},
+				},
+				{
+					name:     "focused window",
+					opts:     []ListWindowsOpts{{Focused: true}},
+					expected: []string{"--focused", "--count"},
+				},
+			}
+
+			for _, tc := range tests {
+				tt.Run(tc.name, func(ttt *testing.T) {
+					ctrl := gomock.NewController(ttt)
+					defer ctrl.Finish()
+
+					mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+					service := NewService(mockConn)
+
+					mockConn.EXPECT().
+						SendCommand("list-windows", tc.expected).
+						Return(&client.Response{StdOut: "3\n"}, nil)
+
+					count, err := service.GetWindowsCount(tc.opts...)
+					if err != nil {
+						ttt.Fatalf("unexpected error: %v", err)
+					}
+					if count != 3 {
+						ttt.Errorf("expected 3 windows, got %d", count)
+					}
+				})
+			}
+		})
+
 		t.Run("GetFocusedWindow", func(tt *testing.T) {
 			ctrl := gomock.NewController(tt)
 			defer ctrl.Finish()
@@ -506,7 +856,7 @@ func TestWindowService(t *testing.T) {
 				mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
 				service := NewService(mockConn)
 
-				windowID := 123456
+				windowID := WindowID(123456)
 				mockConn.EXPECT().
 					SendCommand("layout", []string{"floating", "--window-id", "123456"}).
 					Return(
@@ -536,7 +886,7 @@ func TestWindowService(t *testing.T) {
 				mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
 				service := NewService(mockConn)
 
-				windowID := 123456
+				windowID := WindowID(123456)
 				mockConn.EXPECT().
 					SendCommand("layout", []string{"floating", "tiling", "--window-id", "123456"}).
 					Return(
@@ -718,6 +1068,104 @@ func TestWindowService(t *testing.T) {
 			}
 		})
 
+		t.Run("DebugWindows non-zero exit code", func(tt *testing.T) {
+			ctrl := gomock.NewController(tt)
+			defer ctrl.Finish()
+
+			mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+			service := NewService(mockConn)
+
+			mockConn.EXPECT().
+				SendCommand("debug-windows", []string{}).
+				Return(&client.Response{StdErr: "already recording", ExitCode: 1}, nil)
+
+			_, err := service.DebugWindows()
+			if err == nil {
+				t.Fatal("expected error, got nil")
+			}
+		})
+
+		t.Run("GetWindowsWithOpts focused with app filter", func(tt *testing.T) {
+			ctrl := gomock.NewController(tt)
+			defer ctrl.Finish()
+
+			mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+			service := NewService(mockConn)
+
+			_, err := service.GetWindowsWithOpts(ListWindowsOpts{
+				Focused: true,
+				PID:     IntPtr(1234),
+			})
+			if err == nil {
+				t.Fatal("expected error, got nil")
+			}
+		})
+
+		t.Run("GetWindowsWithOpts non-zero exit code", func(tt *testing.T) {
+			ctrl := gomock.NewController(tt)
+			defer ctrl.Finish()
+
+			mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+			service := NewService(mockConn)
+
+			mockConn.EXPECT().
+				SendCommand("list-windows", gomock.Any()).
+				Return(&client.Response{StdErr: "Unknown flag", ExitCode: 1}, nil)
+
+			_, err := service.GetWindowsWithOpts(ListWindowsOpts{})
+			if err == nil {
+				t.Fatal("expected error, got nil")
+			}
+		})
+
+		t.Run("GetWindowsWithOpts empty field", func(tt *testing.T) {
+			ctrl := gomock.NewController(tt)
+			defer ctrl.Finish()
+
+			mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+			service := NewService(mockConn)
+
+			_, err := service.GetWindowsWithOpts(ListWindowsOpts{
+				Fields: []Field{FieldWindowID, ""},
+			})
+			if err == nil {
+				t.Fatal("expected error, got nil")
+			}
+		})
+
+		t.Run("GetWindowsCount focused with other filters", func(tt *testing.T) {
+			ctrl := gomock.NewController(tt)
+			defer ctrl.Finish()
+
+			mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+			service := NewService(mockConn)
+
+			_, err := service.GetWindowsCount(ListWindowsOpts{
+				Focused:    true,
+				Workspaces: []string{"1"},
+			})
+			if err == nil {
+				t.Fatal("expected error, got nil")
+			}
+		})
+
+		t.Run("GetWindowsCount invalid output", func(tt *testing.T) {
+			ctrl := gomock.NewController(tt)
+			defer ctrl.Finish()
+
+			mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+			service := NewService(mockConn)
+
+			mockConn.EXPECT().
+				SendCommand("list-windows", []string{"--all", "--count"}).
+				Return(&client.Response{StdOut: "not a number"}, nil)
+
+			_, err := service.GetWindowsCount()
+			if err == nil {
+				t.Fatal("expected error, got nil")
+			}
+		})
+
 		t.Run("SetFocusByWindowIDError", func(tt *testing.T) {
 			ctrl := gomock.NewController(tt)
 			defer ctrl.Finish()
@@ -738,3 +1186,37 @@ func TestWindowService(t *testing.T) {
 		})
 	})
 }
+
+func TestServiceWithContext(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+	service := NewService(mockConn).WithContext(ctx)
+
+	// Deprecated methods delegate to the focus service, which must inherit the context.
+	mockConn.EXPECT().
+		SendCommandContext(ctx, "focus", []string{"--window-id", "42"}).
+		Return(&client.Response{StdOut: ""}, nil)
+
+	err := service.SetFocusByWindowID(SetFocusArgs{WindowID: 42})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestWindowLogValue(t *testing.T) {
+	window := Window{WindowID: 6231, AppName: "Brave Browser", WindowTitle: "Github Page", Workspace: "8"}
+
+	value := window.LogValue()
+	expected := []slog.Attr{
+		slog.Int("id", 6231),
+		slog.String("app", "Brave Browser"),
+		slog.String("title", "Github Page"),
+		slog.String("workspace", "8"),
+	}
+	if value.Kind() != slog.KindGroup || !slices.EqualFunc(value.Group(), expected, slog.Attr.Equal) {
+		t.Fatalf("expected %v, got %v", expected, value)
+	}
+}
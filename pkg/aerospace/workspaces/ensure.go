@@ -0,0 +1,72 @@
+package workspaces
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/cristianoliveira/aerospace-ipc/pkg/client"
+	"github.com/cristianoliveira/aerospace-ipc/pkg/client/args"
+	"github.com/cristianoliveira/aerospace-ipc/pkg/client/commands"
+)
+
+// workspaceWindow is a window listed by EnsureWindowOnWorkspace.
+type workspaceWindow struct {
+	WindowID client.WindowID `json:"window-id"`
+}
+
+// EnsureWindowOnWorkspace moves the window to the workspace only if it is not
+// already there, so scripts can run it repeatedly. It reports whether the
+// window was moved.
+//
+// The workspace must be a name, not "next" or "prev". The move is sent with
+// --fail-if-noop, so a window that reached the workspace in the meantime
+// fails the call instead of being reported as moved.
+//
+// It is equivalent to running the commands:
+//
+//	aerospace list-windows --workspace <workspace> --json --format %{window-id}
+//	aerospace move-node-to-workspace --window-id <window-id> --fail-if-noop <workspace>
+//
+// Usage:
+//
+//	moved, err := workspaceService.EnsureWindowOnWorkspace(windowID, "terminal")
+//	if err == nil && moved {
+//	    log.Println("window moved to terminal")
+//	}
+func (s *Service) EnsureWindowOnWorkspace(windowID client.WindowID, workspace string) (bool, error) {
+	if workspace == "" {
+		return false, fmt.Errorf("workspace cannot be empty")
+	}
+
+	cmdArgs := args.New().
+		KV("--workspace", workspace).
+		Flag("--json").
+		KV("--format", "%{window-id}").
+		Build()
+
+	response, err := s.sendCommand(commands.ListWindows, cmdArgs)
+	if err != nil {
+		return false, err
+	}
+	if response.ExitCode != 0 {
+		return false, fmt.Errorf("failed to list windows of workspace %s\n%s", workspace, response.StdErr)
+	}
+
+	var windows []workspaceWindow
+	if err := json.Unmarshal([]byte(response.StdOut), &windows); err != nil {
+		return false, fmt.Errorf("failed to unmarshal windows: %w\nOut:%s\nErr:%s", err, response.StdOut, response.StdErr)
+	}
+	if slices.Contains(windows, workspaceWindow{WindowID: windowID}) {
+		return false, nil
+	}
+
+	err = s.MoveWindowToWorkspaceWithOpts(
+		MoveWindowToWorkspaceArgs{WorkspaceName: workspace},
+		MoveWindowToWorkspaceOpts{WindowID: &windowID, FailIfNoop: true},
+	)
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
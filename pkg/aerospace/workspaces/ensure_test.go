@@ -0,0 +1,88 @@
+package workspaces
+
+import (
+	"testing"
+
+	mock_client "github.com/cristianoliveira/aerospace-ipc/internal/mocks"
+	"github.com/cristianoliveira/aerospace-ipc/pkg/client"
+	"go.uber.org/mock/gomock"
+)
+
+func TestEnsureWindowOnWorkspace(t *testing.T) {
+	listArgs := []string{"--workspace", "terminal", "--json", "--format", "%{window-id}"}
+
+	t.Run("Happy path", func(tt *testing.T) {
+		tt.Run("already on the workspace", func(ttt *testing.T) {
+			ctrl := gomock.NewController(ttt)
+			defer ctrl.Finish()
+
+			mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+			mockConn.EXPECT().
+				SendCommand("list-windows", listArgs).
+				Return(&client.Response{StdOut: `[{"window-id": 1}, {"window-id": 42}]`}, nil)
+
+			moved, err := NewService(mockConn).EnsureWindowOnWorkspace(42, "terminal")
+			if err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+			if moved {
+				ttt.Fatal("expected the window not to be moved")
+			}
+		})
+
+		tt.Run("moves the window", func(ttt *testing.T) {
+			ctrl := gomock.NewController(ttt)
+			defer ctrl.Finish()
+
+			mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+			gomock.InOrder(
+				mockConn.EXPECT().
+					SendCommand("list-windows", listArgs).
+					Return(&client.Response{StdOut: `[{"window-id": 1}]`}, nil),
+				mockConn.EXPECT().
+					SendCommand("move-node-to-workspace", []string{"terminal", "--window-id", "42", "--fail-if-noop"}).
+					Return(&client.Response{}, nil),
+			)
+
+			moved, err := NewService(mockConn).EnsureWindowOnWorkspace(42, "terminal")
+			if err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+			if !moved {
+				ttt.Fatal("expected the window to be moved")
+			}
+		})
+	})
+
+	t.Run("Error cases", func(tt *testing.T) {
+		tt.Run("move fails", func(ttt *testing.T) {
+			ctrl := gomock.NewController(ttt)
+			defer ctrl.Finish()
+
+			mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+			gomock.InOrder(
+				mockConn.EXPECT().
+					SendCommand("list-windows", listArgs).
+					Return(&client.Response{StdOut: `[]`}, nil),
+				mockConn.EXPECT().
+					SendCommand("move-node-to-workspace", []string{"terminal", "--window-id", "42", "--fail-if-noop"}).
+					Return(&client.Response{ExitCode: 1, StdErr: "Window 42 doesn't exist"}, nil),
+			)
+
+			moved, err := NewService(mockConn).EnsureWindowOnWorkspace(42, "terminal")
+			if err == nil || moved {
+				ttt.Fatalf("expected error without move, got %v and %v", err, moved)
+			}
+		})
+
+		tt.Run("empty workspace", func(ttt *testing.T) {
+			ctrl := gomock.NewController(ttt)
+			defer ctrl.Finish()
+
+			mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+			if _, err := NewService(mockConn).EnsureWindowOnWorkspace(42, ""); err == nil {
+				ttt.Fatal("expected error, got nil")
+			}
+		})
+	})
+}
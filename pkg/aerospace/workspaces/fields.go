@@ -0,0 +1,98 @@
+package workspaces
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Field is a list-workspaces format variable.
+//
+// Fields with a JSON counterpart map to the Workspace struct field with the same JSON name.
+//
+// See: https://nikitabobko.github.io/AeroSpace/commands#list-workspaces
+type Field string
+
+// Workspace fields.
+const (
+	FieldWorkspace                   Field = "workspace"
+	FieldWorkspaceIsFocused          Field = "workspace-is-focused"
+	FieldWorkspaceIsVisible          Field = "workspace-is-visible"
+	FieldWorkspaceIsEffectivelyEmpty Field = "workspace-is-effectively-empty"
+)
+
+// Monitor fields, describing the monitor the workspace is on.
+const (
+	FieldMonitorID               Field = "monitor-id"
+	FieldMonitorName             Field = "monitor-name"
+	FieldMonitorAppKitNSScreenID Field = "monitor-appkit-nsscreen-screens-id"
+)
+
+// Text layout fields. They have no JSON counterpart and are only useful
+// for plain text output, e.g. when sending raw commands through the connection.
+// The server rejects them together with --json.
+const (
+	FieldRightPadding Field = "right-padding"
+	FieldNewline      Field = "newline"
+	FieldTab          Field = "tab"
+)
+
+var knownFields = map[Field]bool{
+	FieldWorkspace:                   true,
+	FieldWorkspaceIsFocused:          true,
+	FieldWorkspaceIsVisible:          true,
+	FieldWorkspaceIsEffectivelyEmpty: true,
+	FieldMonitorID:                   true,
+	FieldMonitorName:                 true,
+	FieldMonitorAppKitNSScreenID:     true,
+	FieldRightPadding:                true,
+	FieldNewline:                     true,
+	FieldTab:                         true,
+}
+
+// IsValid reports whether f is a documented list-workspaces format variable.
+func (f Field) IsValid() bool {
+	return knownFields[f]
+}
+
+// IsTextLayout reports whether f is a text layout field, rejected by the server with --json.
+func (f Field) IsTextLayout() bool {
+	return f == FieldRightPadding || f == FieldNewline || f == FieldTab
+}
+
+// BuildFormat returns the --format value interpolating the given fields, separated by spaces.
+//
+// The result can be used as ListWorkspacesOpts.Format.
+// Returns an error if no field is given or a field is unknown.
+//
+// Usage:
+//
+//	format, err := workspaces.BuildFormat(workspaces.FieldWorkspace, workspaces.FieldMonitorID)
+//	// format: "%{workspace} %{monitor-id}"
+func BuildFormat(fields ...Field) (string, error) {
+	if len(fields) == 0 {
+		return "", fmt.Errorf("at least one field is required")
+	}
+
+	parts := make([]string, 0, len(fields))
+	for _, field := range fields {
+		if !field.IsValid() {
+			return "", fmt.Errorf("unknown workspace field %q", field)
+		}
+		parts = append(parts, "%{"+string(field)+"}")
+	}
+	return strings.Join(parts, " "), nil
+}
+
+// BuildJSONFormat is BuildFormat for a --json output, so it also refuses the text layout fields.
+//
+// Usage:
+//
+//	format, err := workspaces.BuildJSONFormat(workspaces.FieldWorkspace, workspaces.FieldMonitorID)
+func BuildJSONFormat(fields ...Field) (string, error) {
+	for _, field := range fields {
+		if field.IsTextLayout() {
+			return "", fmt.Errorf("workspace field %q cannot be used with --json", field)
+		}
+	}
+	return BuildFormat(fields...)
+}
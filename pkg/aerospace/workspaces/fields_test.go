@@ -0,0 +1,49 @@
+package workspaces
+
+import "testing"
+
+func TestBuildFormat(t *testing.T) {
+	t.Run("Happy path", func(tt *testing.T) {
+		tt.Run("default format is built from fields", func(ttt *testing.T) {
+			format, err := BuildFormat(
+				FieldWorkspace, FieldMonitorID, FieldMonitorName,
+				FieldWorkspaceIsVisible, FieldWorkspaceIsFocused, FieldWorkspaceIsEffectivelyEmpty,
+			)
+			if err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+			if format != formatArguments {
+				ttt.Fatalf("expected %q, got %q", formatArguments, format)
+			}
+		})
+	})
+
+	t.Run("Error cases", func(tt *testing.T) {
+		tests := []struct {
+			name   string
+			fields []Field
+		}{
+			{name: "no fields"},
+			{name: "unknown field", fields: []Field{FieldWorkspace, "workspace-color"}},
+		}
+
+		for _, tc := range tests {
+			tt.Run(tc.name, func(ttt *testing.T) {
+				if _, err := BuildFormat(tc.fields...); err == nil {
+					ttt.Fatal("expected error, got nil")
+				}
+			})
+		}
+
+		tt.Run("text layout fields with --json", func(ttt *testing.T) {
+			if _, err := BuildFormat(FieldWorkspace, FieldNewline); err != nil {
+				ttt.Fatalf("unexpected error for a text format: %v", err)
+			}
+			for _, field := range []Field{FieldRightPadding, FieldNewline, FieldTab} {
+				if _, err := BuildJSONFormat(FieldWorkspace, field); err == nil {
+					ttt.Fatalf("expected error for %q, got nil", field)
+				}
+			}
+		})
+	})
+}
@@ -0,0 +1,70 @@
+package workspaces
+
+import (
+	"strconv"
+
+	"github.com/cristianoliveira/aerospace-ipc/pkg/client"
+)
+
+// NextFreeNumeric returns the smallest positive number, as a workspace name,
+// that is not the name of a workspace with windows.
+//
+// Empty workspaces, such as persistent ones, are considered free.
+//
+// It is equivalent to running the command:
+//
+//	aerospace list-workspaces --all --json --format <format>
+//
+// Usage:
+//
+//	name, err := workspaceService.NextFreeNumeric()
+//	// name: "4" when workspaces 1, 2 and 3 have windows
+func (s *Service) NextFreeNumeric() (string, error) {
+	workspaces, err := s.GetAllWorkspaces()
+	if err != nil {
+		return "", err
+	}
+
+	used := make(map[int]bool, len(workspaces))
+	for _, workspace := range workspaces {
+		if workspace.IsEffectivelyEmpty {
+			continue
+		}
+		if n, err := strconv.Atoi(workspace.Workspace); err == nil {
+			used[n] = true
+		}
+	}
+
+	n := 1
+	for used[n] {
+		n++
+	}
+	return strconv.Itoa(n), nil
+}
+
+// MoveWindowToNewWorkspace moves the window to the workspace returned by
+// NextFreeNumeric and returns its name.
+//
+// It is equivalent to running the commands:
+//
+//	aerospace list-workspaces --all --json --format <format>
+//	aerospace move-node-to-workspace <workspace> --window-id <window-id>
+//
+// Usage:
+//
+//	name, err := workspaceService.MoveWindowToNewWorkspace(windowID)
+func (s *Service) MoveWindowToNewWorkspace(windowID client.WindowID) (string, error) {
+	name, err := s.NextFreeNumeric()
+	if err != nil {
+		return "", err
+	}
+
+	err = s.MoveWindowToWorkspaceWithOpts(
+		MoveWindowToWorkspaceArgs{WorkspaceName: name},
+		MoveWindowToWorkspaceOpts{WindowID: &windowID},
+	)
+	if err != nil {
+		return "", err
+	}
+	return name, nil
+}
@@ -0,0 +1,84 @@
+package workspaces
+
+import (
+	"testing"
+
+	mock_client "github.com/cristianoliveira/aerospace-ipc/internal/mocks"
+	"github.com/cristianoliveira/aerospace-ipc/pkg/client"
+	"go.uber.org/mock/gomock"
+)
+
+func TestNextFreeNumeric(t *testing.T) {
+	listArgs := []string{"--all", "--json", "--format", formatArguments}
+
+	testCases := []struct {
+		title    string
+		out      string
+		expected string
+	}{
+		{
+			title:    "no workspaces",
+			out:      `[]`,
+			expected: "1",
+		},
+		{
+			title: "first gap",
+			out: `[
+			  {"workspace": "1"}, {"workspace": "2"}, {"workspace": "4"},
+			  {"workspace": "terminal"}
+			]`,
+			expected: "3",
+		},
+		{
+			title: "empty workspaces are free",
+			out: `[
+			  {"workspace": "1"},
+			  {"workspace": "2", "workspace-is-effectively-empty": true},
+			  {"workspace": "3"}
+			]`,
+			expected: "2",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.title, func(tt *testing.T) {
+			ctrl := gomock.NewController(tt)
+			defer ctrl.Finish()
+
+			mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+			mockConn.EXPECT().
+				SendCommand("list-workspaces", listArgs).
+				Return(&client.Response{StdOut: tc.out}, nil)
+
+			name, err := NewService(mockConn).NextFreeNumeric()
+			if err != nil {
+				tt.Fatalf("unexpected error: %v", err)
+			}
+			if name != tc.expected {
+				tt.Fatalf("expected %q, got %q", tc.expected, name)
+			}
+		})
+	}
+}
+
+func TestMoveWindowToNewWorkspace(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+	gomock.InOrder(
+		mockConn.EXPECT().
+			SendCommand("list-workspaces", []string{"--all", "--json", "--format", formatArguments}).
+			Return(&client.Response{StdOut: `[{"workspace": "1"}, {"workspace": "2"}]`}, nil),
+		mockConn.EXPECT().
+			SendCommand("move-node-to-workspace", []string{"3", "--window-id", "42"}).
+			Return(&client.Response{}, nil),
+	)
+
+	name, err := NewService(mockConn).MoveWindowToNewWorkspace(42)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if name != "3" {
+		t.Fatalf("expected workspace 3, got %q", name)
+	}
+}
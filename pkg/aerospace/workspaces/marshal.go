@@ -0,0 +1,31 @@
+package workspaces
+
+import "encoding/json"
+
+// workspaceJSON has the fields of Workspace but none of its methods,
+// so encoding it does not recurse into MarshalJSON.
+type workspaceJSON Workspace
+
+// MarshalJSON encodes the workspace with the same kebab-case keys used by
+// the AeroSpace server, so snapshots can be decoded back into a Workspace.
+func (w Workspace) MarshalJSON() ([]byte, error) {
+	return json.Marshal(workspaceJSON(w))
+}
+
+// UnmarshalJSON decodes a workspace encoded by MarshalJSON or by the AeroSpace server.
+func (w *Workspace) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, (*workspaceJSON)(w))
+}
+
+// MarshalText encodes the workspace as its JSON object.
+//
+// Beware when embedding a Workspace: the promoted MarshalJSON and MarshalText
+// encode only the workspace, so the embedding struct must define its own.
+func (w Workspace) MarshalText() ([]byte, error) {
+	return w.MarshalJSON()
+}
+
+// UnmarshalText decodes a workspace encoded by MarshalText.
+func (w *Workspace) UnmarshalText(text []byte) error {
+	return w.UnmarshalJSON(text)
+}
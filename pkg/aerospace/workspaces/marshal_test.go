@@ -0,0 +1,44 @@
+package workspaces
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestWorkspaceMarshal(t *testing.T) {
+	workspace := Workspace{
+		Workspace:   "42",
+		MonitorID:   1,
+		MonitorName: "Built-in Retina Display",
+		IsVisible:   true,
+		IsFocused:   true,
+	}
+
+	t.Run("Happy path", func(tt *testing.T) {
+		tt.Run("MarshalJSON uses the server keys", func(ttt *testing.T) {
+			data, err := json.Marshal(workspace)
+			if err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+			expected := `{"workspace":"42","monitor-id":1,"monitor-name":"Built-in Retina Display",` +
+				`"workspace-is-visible":true,"workspace-is-focused":true}`
+			if string(data) != expected {
+				ttt.Fatalf("expected %s, got %s", expected, data)
+			}
+		})
+
+		tt.Run("text round trip", func(ttt *testing.T) {
+			text, err := workspace.MarshalText()
+			if err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+			var decoded Workspace
+			if err := decoded.UnmarshalText(text); err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+			if decoded != workspace {
+				ttt.Fatalf("expected %+v, got %+v", workspace, decoded)
+			}
+		})
+	})
+}
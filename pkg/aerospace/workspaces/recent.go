@@ -0,0 +1,87 @@
+package workspaces
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/cristianoliveira/aerospace-ipc/pkg/client/args"
+	"github.com/cristianoliveira/aerospace-ipc/pkg/client/commands"
+)
+
+// ErrNoFocusHistory indicates that the service has no FocusHistory, see WithFocusHistory.
+var ErrNoFocusHistory = errors.New("no focus history")
+
+// FocusHistory provides the order in which the workspaces were focused,
+// tracked on the client side since AeroSpace only remembers the previous one.
+//
+// It is implemented by history.Tracker.
+type FocusHistory interface {
+	// RecentlyFocusedWorkspaces returns up to n workspaces, most recently focused first,
+	// starting with the focused one.
+	RecentlyFocusedWorkspaces(n int) []string
+}
+
+// WithFocusHistory returns a copy of the service using history for RecentlyFocused
+// and FocusNthPrevious. The services of aerospace.Client use Client.History().
+//
+// Usage:
+//
+//	tracker := history.NewTracker(conn)
+//	go tracker.Run(ctx)
+//	workspaceService := workspaces.NewService(conn).WithFocusHistory(tracker)
+func (s *Service) WithFocusHistory(history FocusHistory) *Service {
+	return &Service{client: s.client, ctx: s.ctx, history: history}
+}
+
+// RecentlyFocused returns up to n workspaces, most recently focused first,
+// starting with the focused one.
+//
+// Returns ErrNoFocusHistory if the service has no FocusHistory.
+//
+// Usage:
+//
+//	recent, err := workspaceService.RecentlyFocused(3)
+func (s *Service) RecentlyFocused(n int) ([]string, error) {
+	if s.history == nil {
+		return nil, ErrNoFocusHistory
+	}
+	return s.history.RecentlyFocusedWorkspaces(n), nil
+}
+
+// FocusNthPrevious focuses the workspace focused n workspaces ago.
+// FocusNthPrevious(1) is the previous workspace, as with MoveBackAndForth.
+//
+// It is equivalent to running the command:
+//
+//	aerospace workspace <workspace>
+//
+// Returns ErrNoFocusHistory if the service has no FocusHistory, or an error
+// if fewer than n workspaces were focused before the current one.
+//
+// Usage:
+//
+//	// Cycle through the last 3 workspaces
+//	err := workspaceService.FocusNthPrevious(2)
+func (s *Service) FocusNthPrevious(n int) error {
+	if n < 1 {
+		return fmt.Errorf("n must be at least 1, got %d", n)
+	}
+
+	recent, err := s.RecentlyFocused(n + 1)
+	if err != nil {
+		return err
+	}
+	if len(recent) <= n {
+		return fmt.Errorf("only %d workspaces were focused before the current one, cannot go back %d", max(len(recent)-1, 0), n)
+	}
+
+	workspace := recent[n]
+	response, err := s.sendCommand(commands.Workspace, args.New().Values(workspace).Build())
+	if err != nil {
+		return err
+	}
+	if response.ExitCode != 0 {
+		return fmt.Errorf("failed to focus workspace %s\n%s", workspace, response.StdErr)
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package workspaces
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	mock_client "github.com/cristianoliveira/aerospace-ipc/internal/mocks"
+	"github.com/cristianoliveira/aerospace-ipc/pkg/client"
+	"go.uber.org/mock/gomock"
+)
+
+// staticHistory is a FocusHistory of fixed workspaces, most recently focused first.
+type staticHistory []string
+
+func (h staticHistory) RecentlyFocusedWorkspaces(n int) []string {
+	return h[:min(n, len(h))]
+}
+
+func TestRecentlyFocused(t *testing.T) {
+	history := staticHistory{"3", "1", "2"}
+
+	t.Run("Happy path", func(tt *testing.T) {
+		tt.Run("RecentlyFocused", func(ttt *testing.T) {
+			ctrl := gomock.NewController(ttt)
+			defer ctrl.Finish()
+
+			mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+			recent, err := NewService(mockConn).WithFocusHistory(history).RecentlyFocused(2)
+			if err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(recent, []string{"3", "1"}) {
+				ttt.Fatalf("expected [3 1], got %v", recent)
+			}
+		})
+
+		tt.Run("FocusNthPrevious", func(ttt *testing.T) {
+			ctrl := gomock.NewController(ttt)
+			defer ctrl.Finish()
+
+			mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+			mockConn.EXPECT().
+				SendCommand("workspace", []string{"2"}).
+				Return(&client.Response{}, nil)
+
+			if err := NewService(mockConn).WithFocusHistory(history).FocusNthPrevious(2); err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+		})
+	})
+
+	t.Run("Error cases", func(tt *testing.T) {
+		tt.Run("without focus history", func(ttt *testing.T) {
+			ctrl := gomock.NewController(ttt)
+			defer ctrl.Finish()
+
+			mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+			if _, err := NewService(mockConn).RecentlyFocused(2); !errors.Is(err, ErrNoFocusHistory) {
+				ttt.Fatalf("expected ErrNoFocusHistory, got %v", err)
+			}
+			if err := NewService(mockConn).FocusNthPrevious(1); !errors.Is(err, ErrNoFocusHistory) {
+				ttt.Fatalf("expected ErrNoFocusHistory, got %v", err)
+			}
+		})
+
+		tt.Run("not enough workspaces focused", func(ttt *testing.T) {
+			ctrl := gomock.NewController(ttt)
+			defer ctrl.Finish()
+
+			mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+			service := NewService(mockConn).WithFocusHistory(history)
+			for _, n := range []int{0, 3} {
+				if err := service.FocusNthPrevious(n); err == nil {
+					ttt.Fatalf("expected error for %d, got nil", n)
+				}
+			}
+		})
+
+		tt.Run("focusing fails", func(ttt *testing.T) {
+			ctrl := gomock.NewController(ttt)
+			defer ctrl.Finish()
+
+			mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+			mockConn.EXPECT().
+				SendCommand("workspace", []string{"1"}).
+				Return(&client.Response{ExitCode: 1, StdErr: "boom"}, nil)
+
+			if err := NewService(mockConn).WithFocusHistory(history).FocusNthPrevious(1); err == nil {
+				ttt.Fatal("expected error, got nil")
+			}
+		})
+	})
+}
@@ -0,0 +1,68 @@
+package workspaces
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/cristianoliveira/aerospace-ipc/internal/natural"
+)
+
+// Comparator orders two workspaces, returning a negative number when a sorts
+// before b, a positive number when it sorts after and zero when they tie.
+type Comparator func(a, b Workspace) int
+
+// CompareName orders workspaces by name, "2" before "10".
+func CompareName(a, b Workspace) int {
+	return natural.Compare(a.Workspace, b.Workspace)
+}
+
+// CompareMonitor orders workspaces by monitor ID.
+func CompareMonitor(a, b Workspace) int {
+	return cmp.Compare(a.MonitorID, b.MonitorID)
+}
+
+// CompareVisible orders visible workspaces first.
+func CompareVisible(a, b Workspace) int {
+	return compareTrueFirst(a.IsVisible, b.IsVisible)
+}
+
+// CompareFocused orders the focused workspace first.
+func CompareFocused(a, b Workspace) int {
+	return compareTrueFirst(a.IsFocused, b.IsFocused)
+}
+
+func compareTrueFirst(a, b bool) int {
+	switch {
+	case a == b:
+		return 0
+	case a:
+		return -1
+	}
+	return 1
+}
+
+// Descending reverses comparator.
+func Descending(comparator Comparator) Comparator {
+	return func(a, b Workspace) int {
+		return comparator(b, a)
+	}
+}
+
+// SortBy sorts workspaces in place by comparators, the first one taking
+// precedence and the next ones breaking its ties.
+//
+// The sort is stable, so workspaces tied on every comparator keep their order.
+//
+// Usage:
+//
+//	workspaces.SortBy(all, workspaces.CompareMonitor, workspaces.CompareName)
+func SortBy(workspaces []Workspace, comparators ...Comparator) {
+	slices.SortStableFunc(workspaces, func(a, b Workspace) int {
+		for _, comparator := range comparators {
+			if c := comparator(a, b); c != 0 {
+				return c
+			}
+		}
+		return 0
+	})
+}
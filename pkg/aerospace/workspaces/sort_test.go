@@ -0,0 +1,57 @@
+package workspaces
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSortBy(t *testing.T) {
+	unsorted := []Workspace{
+		{Workspace: "10", MonitorID: 1},
+		{Workspace: "code", MonitorID: 2, IsVisible: true},
+		{Workspace: "2", MonitorID: 2},
+		{Workspace: "1", MonitorID: 1, IsVisible: true, IsFocused: true},
+	}
+
+	tests := []struct {
+		name        string
+		comparators []Comparator
+		expected    []string
+	}{
+		{
+			name:        "by name",
+			comparators: []Comparator{CompareName},
+			expected:    []string{"1", "2", "10", "code"},
+		},
+		{
+			name:        "by monitor then name",
+			comparators: []Comparator{CompareMonitor, CompareName},
+			expected:    []string{"1", "10", "2", "code"},
+		},
+		{
+			name:        "visible first is stable",
+			comparators: []Comparator{CompareVisible},
+			expected:    []string{"code", "1", "10", "2"},
+		},
+		{
+			name:        "focused first, then by name descending",
+			comparators: []Comparator{CompareFocused, Descending(CompareName)},
+			expected:    []string{"1", "code", "10", "2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			workspaces := slices.Clone(unsorted)
+			SortBy(workspaces, tt.comparators...)
+
+			names := []string{}
+			for _, workspace := range workspaces {
+				names = append(names, workspace.Workspace)
+			}
+			if !slices.Equal(names, tt.expected) {
+				t.Fatalf("expected %v, got %v", tt.expected, names)
+			}
+		})
+	}
+}
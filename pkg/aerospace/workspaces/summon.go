@@ -0,0 +1,47 @@
+package workspaces
+
+import (
+	"fmt"
+
+	"github.com/cristianoliveira/aerospace-ipc/pkg/client/args"
+	"github.com/cristianoliveira/aerospace-ipc/pkg/client/commands"
+)
+
+// SummonAndFocus moves the workspace to the focused monitor and focuses it.
+//
+// Neither command is sent with --fail-if-noop, so summoning a workspace that
+// is already on the focused monitor, or already focused, succeeds.
+//
+// It is equivalent to running the commands:
+//
+//	aerospace summon-workspace <workspace>
+//	aerospace workspace <workspace>
+//
+// Usage:
+//
+//	err := workspaceService.SummonAndFocus("terminal")
+func (s *Service) SummonAndFocus(workspace string) error {
+	if workspace == "" {
+		return fmt.Errorf("workspace cannot be empty")
+	}
+
+	cmdArgs := args.New().Values(workspace).Build()
+
+	response, err := s.sendCommand(commands.SummonWorkspace, cmdArgs)
+	if err != nil {
+		return err
+	}
+	if response.ExitCode != 0 {
+		return fmt.Errorf("failed to summon workspace %s\n%s", workspace, response.StdErr)
+	}
+
+	response, err = s.sendCommand(commands.Workspace, cmdArgs)
+	if err != nil {
+		return err
+	}
+	if response.ExitCode != 0 {
+		return fmt.Errorf("failed to focus workspace %s\n%s", workspace, response.StdErr)
+	}
+
+	return nil
+}
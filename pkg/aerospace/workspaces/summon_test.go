@@ -0,0 +1,56 @@
+package workspaces
+
+import (
+	"testing"
+
+	mock_client "github.com/cristianoliveira/aerospace-ipc/internal/mocks"
+	"github.com/cristianoliveira/aerospace-ipc/pkg/client"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSummonAndFocus(t *testing.T) {
+	t.Run("Happy path", func(tt *testing.T) {
+		ctrl := gomock.NewController(tt)
+		defer ctrl.Finish()
+
+		mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+		gomock.InOrder(
+			mockConn.EXPECT().
+				SendCommand("summon-workspace", []string{"terminal"}).
+				Return(&client.Response{}, nil),
+			mockConn.EXPECT().
+				SendCommand("workspace", []string{"terminal"}).
+				Return(&client.Response{}, nil),
+		)
+
+		if err := NewService(mockConn).SummonAndFocus("terminal"); err != nil {
+			tt.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("Error cases", func(tt *testing.T) {
+		tt.Run("summon fails", func(ttt *testing.T) {
+			ctrl := gomock.NewController(ttt)
+			defer ctrl.Finish()
+
+			mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+			mockConn.EXPECT().
+				SendCommand("summon-workspace", []string{"terminal"}).
+				Return(&client.Response{ExitCode: 1, StdErr: "boom"}, nil)
+
+			if err := NewService(mockConn).SummonAndFocus("terminal"); err == nil {
+				ttt.Fatal("expected error, got nil")
+			}
+		})
+
+		tt.Run("empty workspace", func(ttt *testing.T) {
+			ctrl := gomock.NewController(ttt)
+			defer ctrl.Finish()
+
+			mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+			if err := NewService(mockConn).SummonAndFocus(""); err == nil {
+				ttt.Fatal("expected error, got nil")
+			}
+		})
+	})
+}
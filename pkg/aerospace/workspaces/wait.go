@@ -0,0 +1,51 @@
+package workspaces
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// DefaultWaitPollInterval is how often WaitForFocus queries the focused workspace.
+const DefaultWaitPollInterval = 100 * time.Millisecond
+
+// WaitForFocus blocks until the workspace is focused, or ctx is done.
+//
+// The focused workspace is queried every DefaultWaitPollInterval.
+// It returns as soon as the query fails.
+//
+// It is equivalent to running, until the workspace is focused, the command:
+//
+//	aerospace list-workspaces --focused --json --format <format>
+//
+// Usage:
+//
+//	err := workspaceService.MoveBackAndForth()
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+//	defer cancel()
+//	err = workspaceService.WaitForFocus(ctx, "2")
+func (s *Service) WaitForFocus(ctx context.Context, workspace string) error {
+	if workspace == "" {
+		return fmt.Errorf("workspace cannot be empty")
+	}
+
+	service := s.WithContext(ctx)
+	ticker := time.NewTicker(DefaultWaitPollInterval)
+	defer ticker.Stop()
+	for {
+		focused, err := service.GetFocusedWorkspace()
+		if err != nil {
+			return err
+		}
+		if focused.Workspace == workspace {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("failed to wait for workspace %s to be focused\n%w", workspace, context.Cause(ctx))
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package workspaces
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	mock_client "github.com/cristianoliveira/aerospace-ipc/internal/mocks"
+	"github.com/cristianoliveira/aerospace-ipc/pkg/client"
+	"go.uber.org/mock/gomock"
+)
+
+func TestWaitForFocus(t *testing.T) {
+	focusedArgs := []string{"--focused", "--json", "--format", formatArguments}
+
+	t.Run("Happy path", func(tt *testing.T) {
+		tt.Run("returns once the workspace is focused", func(ttt *testing.T) {
+			ctrl := gomock.NewController(ttt)
+			defer ctrl.Finish()
+
+			ctx := context.Background()
+			mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+			gomock.InOrder(
+				mockConn.EXPECT().
+					SendCommandContext(ctx, "list-workspaces", focusedArgs).
+					Return(&client.Response{StdOut: `[{"workspace": "1"}]`}, nil),
+				mockConn.EXPECT().
+					SendCommandContext(ctx, "list-workspaces", focusedArgs).
+					Return(&client.Response{StdOut: `[{"workspace": "2"}]`}, nil),
+			)
+
+			if err := NewService(mockConn).WaitForFocus(ctx, "2"); err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+		})
+	})
+
+	t.Run("Error cases", func(tt *testing.T) {
+		tt.Run("fails once the context is done", func(ttt *testing.T) {
+			ctrl := gomock.NewController(ttt)
+			defer ctrl.Finish()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+			defer cancel()
+
+			mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+			mockConn.EXPECT().
+				SendCommandContext(ctx, "list-workspaces", focusedArgs).
+				Return(&client.Response{StdOut: `[{"workspace": "1"}]`}, nil).
+				MinTimes(1)
+
+			err := NewService(mockConn).WaitForFocus(ctx, "2")
+			if !errors.Is(err, context.DeadlineExceeded) {
+				ttt.Fatalf("expected context.DeadlineExceeded, got %v", err)
+			}
+		})
+
+		tt.Run("fails when the query fails", func(ttt *testing.T) {
+			ctrl := gomock.NewController(ttt)
+			defer ctrl.Finish()
+
+			ctx := context.Background()
+			mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+			mockConn.EXPECT().
+				SendCommandContext(ctx, "list-workspaces", focusedArgs).
+				Return(nil, errors.New("connection closed"))
+
+			if err := NewService(mockConn).WaitForFocus(ctx, "2"); err == nil {
+				ttt.Fatal("expected error, got nil")
+			}
+		})
+
+		tt.Run("fails without a workspace", func(ttt *testing.T) {
+			ctrl := gomock.NewController(ttt)
+			defer ctrl.Finish()
+
+			mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+			if err := NewService(mockConn).WaitForFocus(context.Background(), ""); err == nil {
+				ttt.Fatal("expected error, got nil")
+			}
+		})
+	})
+}
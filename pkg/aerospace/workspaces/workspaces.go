@@ -1,33 +1,123 @@
 package workspaces
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
 
+	"github.com/cristianoliveira/aerospace-ipc/internal/validate"
 	"github.com/cristianoliveira/aerospace-ipc/pkg/client"
+	"github.com/cristianoliveira/aerospace-ipc/pkg/client/args"
+	"github.com/cristianoliveira/aerospace-ipc/pkg/client/commands"
 )
 
 // Workspace represents a workspace in AeroSpaceWM.
 //
-// See: aerospace list-workspaces --all --json
+// See: aerospace list-workspaces --all --json --format <format>
 //
 // Example JSON response:
 //
 //	[
 //	  {
 //	    "workspace": "42",
+//	    "monitor-id": 1,
+//	    "monitor-name": "Built-in Retina Display",
+//	    "workspace-is-visible": true,
+//	    "workspace-is-focused": true,
+//	    "workspace-is-effectively-empty": false
 //	  },
 //	  {
 //	    "workspace": "terminal",
+//	    "monitor-id": 2,
+//	    "monitor-name": "DELL U2720Q",
+//	    "workspace-is-visible": false,
+//	    "workspace-is-focused": false,
+//	    "workspace-is-effectively-empty": true
 //	  }
 //	]
 type Workspace struct {
-	Workspace string `json:"workspace"`
+	Workspace   string `json:"workspace"`
+	MonitorID   int    `json:"monitor-id,omitempty"`
+	MonitorName string `json:"monitor-name,omitempty"`
+
+	// IsVisible reports whether the workspace is shown on its monitor.
+	IsVisible bool `json:"workspace-is-visible,omitempty"`
+
+	// IsFocused reports whether the workspace is the focused one.
+	IsFocused bool `json:"workspace-is-focused,omitempty"`
+
+	// IsEffectivelyEmpty reports whether the workspace has no windows,
+	// the same condition used by the --empty filter.
+	IsEffectivelyEmpty bool `json:"workspace-is-effectively-empty,omitempty"`
+}
+
+// LogValue implements slog.LogValuer, logging the workspace as a group of its attributes.
+//
+// Usage:
+//
+//	slog.Info("switching workspace", "workspace", workspace)
+//
+//	// Output: level=INFO msg="switching workspace" workspace.name=42 workspace.monitor=1 workspace.focused=true
+func (w Workspace) LogValue() slog.Value {
+	attrs := []slog.Attr{slog.String("name", w.Workspace)}
+	if w.MonitorID != 0 {
+		attrs = append(attrs, slog.Int("monitor", w.MonitorID))
+	}
+	if w.IsVisible {
+		attrs = append(attrs, slog.Bool("visible", true))
+	}
+	if w.IsFocused {
+		attrs = append(attrs, slog.Bool("focused", true))
+	}
+	return slog.GroupValue(attrs...)
 }
 
+const formatArguments = "%{workspace} %{monitor-id} %{monitor-name} " +
+	"%{workspace-is-visible} %{workspace-is-focused} %{workspace-is-effectively-empty}"
+
 // Service provides methods to interact with workspaces in AeroSpaceWM.
 type Service struct {
-	client client.AeroSpaceConnection
+	client  client.AeroSpaceConnection
+	ctx     context.Context
+	history FocusHistory
+}
+
+// ListWorkspacesOpts contains optional parameters for GetAllWorkspaces.
+type ListWorkspacesOpts struct {
+	// Monitors filters workspaces by monitor. If not set, workspaces of all monitors are listed.
+	// Possible values: monitor IDs (1-based), "focused", "mouse" or "all".
+	Monitors []string
+
+	// Visible filters the workspaces by visibility. Requires Monitors.
+	// true lists only visible workspaces, false lists only invisible ones.
+	Visible *bool
+
+	// Empty filters the workspaces by emptiness. Requires Monitors.
+	// true lists only empty workspaces, false lists only non-empty ones.
+	Empty *bool
+
+	// Format overrides the default output format. Use BuildJSONFormat to compose it,
+	// the text layout fields are rejected since the output is JSON.
+	// See: https://nikitabobko.github.io/AeroSpace/commands#list-workspaces
+	Format *string
+}
+
+// filterArgs returns the list-workspaces filter flags for the options.
+func (opt ListWorkspacesOpts) filterArgs() ([]string, error) {
+	if len(opt.Monitors) == 0 && (opt.Visible != nil || opt.Empty != nil) {
+		return nil, fmt.Errorf("visible and empty filters require at least one monitor")
+	}
+
+	builder := args.New().
+		KVs("--monitor", opt.Monitors...).
+		FlagIf(len(opt.Monitors) == 0, "--all").
+		Toggle("--visible", opt.Visible).
+		Toggle("--empty", opt.Empty)
+
+	return builder.Build(), nil
 }
 
 // MoveWindowToWorkspaceArgs contains required arguments for MoveWindowToWorkspace.
@@ -41,7 +131,7 @@ type MoveWindowToWorkspaceArgs struct {
 // MoveWindowToWorkspaceOpts contains optional parameters for MoveWindowToWorkspace.
 type MoveWindowToWorkspaceOpts struct {
 	// WindowID specifies the window ID to move. If not set, the focused window is moved.
-	WindowID *int
+	WindowID *client.WindowID
 
 	// FocusFollowsWindow makes the window receive focus after moving.
 	// This is a shortcut for manually running aerospace-workspace/aerospace-focus
@@ -63,6 +153,11 @@ type MoveWindowToWorkspaceOpts struct {
 	// NoStdin ignores the list of workspaces from stdin, even if provided.
 	// Incompatible with Stdin.
 	NoStdin bool
+
+	// StdinWorkspaces is the list of workspaces passed on stdin, e.g. to pick
+	// the "next" workspace among them. Setting it implies Stdin.
+	// Incompatible with NoStdin.
+	StdinWorkspaces []string
 }
 
 // MoveWorkspaceToMonitorArgs contains arguments for MoveWorkspaceToMonitor.
@@ -96,19 +191,56 @@ type WorkspacesService interface {
 	// GetFocusedWorkspace returns the currently focused workspace.
 	GetFocusedWorkspace() (*Workspace, error)
 
+	// GetAllWorkspaces returns the workspaces matching the given options.
+	GetAllWorkspaces(opts ...ListWorkspacesOpts) ([]Workspace, error)
+
+	// GetWorkspacesCount returns the number of workspaces matching the given options.
+	GetWorkspacesCount(opts ...ListWorkspacesOpts) (int, error)
+
+	// GetWorkspacesByMonitor returns the workspaces of the given monitor.
+	GetWorkspacesByMonitor(monitor string) ([]Workspace, error)
+
+	// GetVisibleWorkspaces returns the workspaces visible on any monitor.
+	GetVisibleWorkspaces() ([]Workspace, error)
+
+	// GetEmptyWorkspaces returns the empty workspaces of all monitors.
+	GetEmptyWorkspaces() ([]Workspace, error)
+
 	// MoveWindowToWorkspace moves the focused window to a specified workspace.
 	MoveWindowToWorkspace(args MoveWindowToWorkspaceArgs) error
 
 	// MoveWindowToWorkspaceWithOpts moves a window to a specified workspace with options.
 	// opts must be provided and contains optional parameters.
-	MoveWindowToWorkspaceWithOpts(args MoveWindowToWorkspaceArgs, opts MoveWindowToWorkspaceOpts) error
+	MoveWindowToWorkspaceWithOpts(target MoveWindowToWorkspaceArgs, opts MoveWindowToWorkspaceOpts) error
 
 	// MoveBackAndForth switches between the focused workspace and previously focused workspace.
 	MoveBackAndForth() error
 
+	// EnsureWindowOnWorkspace moves the window to the workspace only if it is not already there.
+	// It reports whether the window was moved.
+	EnsureWindowOnWorkspace(windowID client.WindowID, workspace string) (bool, error)
+
+	// NextFreeNumeric returns the smallest numeric workspace name without windows.
+	NextFreeNumeric() (string, error)
+
+	// MoveWindowToNewWorkspace moves the window to the workspace returned by NextFreeNumeric.
+	MoveWindowToNewWorkspace(windowID client.WindowID) (string, error)
+
+	// SummonAndFocus moves the workspace to the focused monitor and focuses it.
+	SummonAndFocus(workspace string) error
+
+	// WaitForFocus blocks until the workspace is focused, or ctx is done.
+	WaitForFocus(ctx context.Context, workspace string) error
+
+	// RecentlyFocused returns up to n workspaces, most recently focused first.
+	RecentlyFocused(n int) ([]string, error)
+
+	// FocusNthPrevious focuses the workspace focused n workspaces ago.
+	FocusNthPrevious(n int) error
+
 	// MoveWorkspaceToMonitor moves a workspace to a monitor.
 	// Supports three modes: direction-based (left|down|up|right), order-based (next|prev), or pattern-based.
-	MoveWorkspaceToMonitor(args MoveWorkspaceToMonitorArgs, opts MoveWorkspaceToMonitorOpts) error
+	MoveWorkspaceToMonitor(target MoveWorkspaceToMonitorArgs, opts MoveWorkspaceToMonitorOpts) error
 }
 
 // NewService creates a new workspace service with the given AeroSpace client connection.
@@ -116,11 +248,43 @@ func NewService(client client.AeroSpaceConnection) *Service {
 	return &Service{client: client}
 }
 
+// WithContext returns a copy of the service that sends every command with ctx,
+// so requests can be cancelled or bounded by a deadline.
+//
+// Usage:
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+//	defer cancel()
+//	workspace, err := workspaceService.WithContext(ctx).GetFocusedWorkspace()
+func (s *Service) WithContext(ctx context.Context) *Service {
+	if ctx == nil {
+		panic("ASSERTION: context cannot be nil")
+	}
+	return &Service{client: s.client, ctx: ctx, history: s.history}
+}
+
+// sendCommand sends a command using the service context, if any.
+func (s *Service) sendCommand(command string, args []string) (*client.Response, error) {
+	if s.ctx == nil {
+		return s.client.SendCommand(command, args)
+	}
+	return s.client.SendCommandContext(s.ctx, command, args)
+}
+
+// sendCommandWithStdin sends the command with stdin, using the service context if set.
+func (s *Service) sendCommandWithStdin(command string, args []string, stdin string) (*client.Response, error) {
+	ctx := s.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return s.client.SendCommandWithStdin(ctx, command, args, stdin)
+}
+
 // GetFocusedWorkspace returns the currently focused workspace.
 //
 // It is equivalent to running the command:
 //
-//	aerospace list-workspaces --focused --json
+//	aerospace list-workspaces --focused --json --format <format>
 //
 // The result differs from the `list-workspaces` command by only returning
 // the focused workspace.
@@ -131,11 +295,12 @@ func NewService(client client.AeroSpaceConnection) *Service {
 //	fmt.Println("Workspace:", workspace)
 //	fmt.Println("Error:", err)
 func (s *Service) GetFocusedWorkspace() (*Workspace, error) {
-	response, err := s.client.SendCommand(
-		"list-workspaces",
+	response, err := s.sendCommand(
+		commands.ListWorkspaces,
 		[]string{
 			"--focused",
 			"--json",
+			"--format", formatArguments,
 		},
 	)
 	if err != nil {
@@ -154,6 +319,162 @@ func (s *Service) GetFocusedWorkspace() (*Workspace, error) {
 	return &workspaces[0], nil
 }
 
+// GetAllWorkspaces returns the workspaces matching the given options.
+//
+// Without options all workspaces are returned.
+//
+// It is equivalent to running the command:
+//
+//	aerospace list-workspaces --all --json --format <format>
+//	aerospace list-workspaces --monitor <monitor>... [--visible [no]] [--empty [no]] --json --format <format>
+//
+// Returns an error if Visible or Empty is set without Monitors.
+//
+// Usage:
+//
+//	// All workspaces
+//	workspaces, err := workspaceService.GetAllWorkspaces()
+//
+//	// Non-empty workspaces on the focused monitor
+//	workspaces, err := workspaceService.GetAllWorkspaces(workspaces.ListWorkspacesOpts{
+//	    Monitors: []string{"focused"},
+//	    Empty:    workspaces.BoolPtr(false),
+//	})
+func (s *Service) GetAllWorkspaces(opts ...ListWorkspacesOpts) ([]Workspace, error) {
+	var opt ListWorkspacesOpts
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+
+	cmdArgs, err := opt.filterArgs()
+	if err != nil {
+		return nil, err
+	}
+
+	format := formatArguments
+	if opt.Format != nil {
+		format = *opt.Format
+		for _, field := range []Field{FieldRightPadding, FieldNewline, FieldTab} {
+			if strings.Contains(format, "%{"+string(field)+"}") {
+				return nil, fmt.Errorf("workspace field %q cannot be used with --json", field)
+			}
+		}
+	}
+	cmdArgs = append(cmdArgs, "--json", "--format", format)
+
+	response, err := s.sendCommand(commands.ListWorkspaces, cmdArgs)
+	if err != nil {
+		return nil, err
+	}
+
+	var workspaces []Workspace
+	err = json.Unmarshal([]byte(response.StdOut), &workspaces)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to unmarshal workspaces: %w\nOut:%s\nErr:%s",
+			err,
+			response.StdOut,
+			response.StdErr,
+		)
+	}
+	return workspaces, nil
+}
+
+// GetWorkspacesByMonitor returns the workspaces of the given monitor.
+//
+// monitor can be a monitor ID (1-based), "focused" or "mouse".
+//
+// It is equivalent to running the command:
+//
+//	aerospace list-workspaces --monitor <monitor> --json --format <format>
+//
+// Usage:
+//
+//	workspaces, err := workspaceService.GetWorkspacesByMonitor("focused")
+func (s *Service) GetWorkspacesByMonitor(monitor string) ([]Workspace, error) {
+	return s.GetAllWorkspaces(ListWorkspacesOpts{
+		Monitors: []string{monitor},
+	})
+}
+
+// GetVisibleWorkspaces returns the workspaces visible on any monitor, one per monitor.
+//
+// It is equivalent to running the command:
+//
+//	aerospace list-workspaces --monitor all --visible --json --format <format>
+//
+// Usage:
+//
+//	workspaces, err := workspaceService.GetVisibleWorkspaces()
+func (s *Service) GetVisibleWorkspaces() ([]Workspace, error) {
+	return s.GetAllWorkspaces(ListWorkspacesOpts{
+		Monitors: []string{"all"},
+		Visible:  BoolPtr(true),
+	})
+}
+
+// GetEmptyWorkspaces returns the empty workspaces of all monitors.
+//
+// It is equivalent to running the command:
+//
+//	aerospace list-workspaces --monitor all --empty --json --format <format>
+//
+// Usage:
+//
+//	workspaces, err := workspaceService.GetEmptyWorkspaces()
+func (s *Service) GetEmptyWorkspaces() ([]Workspace, error) {
+	return s.GetAllWorkspaces(ListWorkspacesOpts{
+		Monitors: []string{"all"},
+		Empty:    BoolPtr(true),
+	})
+}
+
+// GetWorkspacesCount returns the number of workspaces matching the given options.
+//
+// Accepts the same filters as GetAllWorkspaces, Format is ignored.
+// Cheaper than listing the workspaces, meant for status bars polling frequently.
+//
+// It is equivalent to running the command:
+//
+//	aerospace list-workspaces --all --count
+//	aerospace list-workspaces --monitor <monitor>... [--visible [no]] [--empty [no]] --count
+//
+// Usage:
+//
+//	// Non-empty workspaces
+//	count, err := workspaceService.GetWorkspacesCount(workspaces.ListWorkspacesOpts{
+//	    Monitors: []string{"all"},
+//	    Empty:    workspaces.BoolPtr(false),
+//	})
+func (s *Service) GetWorkspacesCount(opts ...ListWorkspacesOpts) (int, error) {
+	var opt ListWorkspacesOpts
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+
+	cmdArgs, err := opt.filterArgs()
+	if err != nil {
+		return 0, err
+	}
+	cmdArgs = append(cmdArgs, "--count")
+
+	response, err := s.sendCommand(commands.ListWorkspaces, cmdArgs)
+	if err != nil {
+		return 0, err
+	}
+
+	if response.ExitCode != 0 {
+		return 0, fmt.Errorf("failed to count workspaces\n%s", response.StdErr)
+	}
+
+	count, err := strconv.Atoi(strings.TrimSpace(response.StdOut))
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse workspaces count: %w\nOut:%s", err, response.StdOut)
+	}
+
+	return count, nil
+}
+
 // MoveWindowToWorkspace moves the focused window to a specified workspace.
 //
 // args.WorkspaceName can be a workspace name (e.g., "42", "terminal") or "next"/"prev"
@@ -176,7 +497,7 @@ func (s *Service) MoveWindowToWorkspace(args MoveWindowToWorkspaceArgs) error {
 
 // MoveWindowToWorkspaceWithOpts moves a window to a specified workspace with options.
 //
-// args.WorkspaceName can be a workspace name (e.g., "42", "terminal") or "next"/"prev"
+// target.WorkspaceName can be a workspace name (e.g., "42", "terminal") or "next"/"prev"
 // to move to the next or previous workspace.
 // opts must be provided and contains optional parameters.
 //
@@ -209,34 +530,37 @@ func (s *Service) MoveWindowToWorkspace(args MoveWindowToWorkspaceArgs) error {
 //	}, workspaces.MoveWindowToWorkspaceOpts{
 //	    WrapAround: true,
 //	})
-func (s *Service) MoveWindowToWorkspaceWithOpts(args MoveWindowToWorkspaceArgs, opts MoveWindowToWorkspaceOpts) error {
+//
+//	// Move to the next workspace among the given ones
+//	err := workspaceService.MoveWindowToWorkspaceWithOpts(workspaces.MoveWindowToWorkspaceArgs{
+//	    WorkspaceName: "next",
+//	}, workspaces.MoveWindowToWorkspaceOpts{
+//	    StdinWorkspaces: []string{"1", "3", "terminal"},
+//	})
+func (s *Service) MoveWindowToWorkspaceWithOpts(target MoveWindowToWorkspaceArgs, opts MoveWindowToWorkspaceOpts) error {
 	// Validate incompatible options
-	if opts.Stdin && opts.NoStdin {
+	stdin := opts.Stdin || len(opts.StdinWorkspaces) > 0
+	if stdin && opts.NoStdin {
 		return fmt.Errorf("cannot specify both --stdin and --no-stdin options")
 	}
 
-	cmdArgs := []string{args.WorkspaceName}
-
-	if opts.WindowID != nil {
-		cmdArgs = append(cmdArgs, "--window-id", fmt.Sprintf("%d", *opts.WindowID))
-	}
-	if opts.FocusFollowsWindow {
-		cmdArgs = append(cmdArgs, "--focus-follows-window")
-	}
-	if opts.FailIfNoop {
-		cmdArgs = append(cmdArgs, "--fail-if-noop")
-	}
-	if opts.WrapAround {
-		cmdArgs = append(cmdArgs, "--wrap-around")
+	cmdArgs := args.New().
+		Values(target.WorkspaceName).
+		KV("--window-id", opts.WindowID).
+		FlagIf(opts.FocusFollowsWindow, "--focus-follows-window").
+		FlagIf(opts.FailIfNoop, "--fail-if-noop").
+		FlagIf(opts.WrapAround, "--wrap-around").
+		FlagIf(stdin, "--stdin").
+		FlagIf(opts.NoStdin, "--no-stdin").
+		Build()
+
+	var response *client.Response
+	var err error
+	if len(opts.StdinWorkspaces) > 0 {
+		response, err = s.sendCommandWithStdin(commands.MoveNodeToWorkspace, cmdArgs, strings.Join(opts.StdinWorkspaces, "\n")+"\n")
+	} else {
+		response, err = s.sendCommand(commands.MoveNodeToWorkspace, cmdArgs)
 	}
-	if opts.Stdin {
-		cmdArgs = append(cmdArgs, "--stdin")
-	}
-	if opts.NoStdin {
-		cmdArgs = append(cmdArgs, "--no-stdin")
-	}
-
-	response, err := s.client.SendCommand("move-node-to-workspace", cmdArgs)
 	if err != nil {
 		return err
 	}
@@ -264,7 +588,7 @@ func (s *Service) MoveWindowToWorkspaceWithOpts(args MoveWindowToWorkspaceArgs,
 //
 //	err := workspaceService.MoveBackAndForth()
 func (s *Service) MoveBackAndForth() error {
-	response, err := s.client.SendCommand("workspace-back-and-forth", []string{})
+	response, err := s.sendCommand(commands.WorkspaceBackAndForth, []string{})
 	if err != nil {
 		return err
 	}
@@ -283,7 +607,7 @@ func (s *Service) MoveBackAndForth() error {
 //  2. Order-based: Move workspace to next or previous monitor (next|prev)
 //  3. Pattern-based: Move workspace to monitor matching pattern(s)
 //
-// Exactly one of args.Direction, args.Order, or args.Patterns must be specified.
+// Exactly one of target.Direction, target.Order, or target.Patterns must be specified.
 //
 // It is equivalent to running the command:
 //
@@ -316,62 +640,42 @@ func (s *Service) MoveBackAndForth() error {
 //	err := workspaceService.MoveWorkspaceToMonitor(workspaces.MoveWorkspaceToMonitorArgs{
 //	    Patterns: []string{"HDMI-1", "DP-1"},
 //	}, workspaces.MoveWorkspaceToMonitorOpts{})
-func (s *Service) MoveWorkspaceToMonitor(args MoveWorkspaceToMonitorArgs, opts MoveWorkspaceToMonitorOpts) error {
+func (s *Service) MoveWorkspaceToMonitor(target MoveWorkspaceToMonitorArgs, opts MoveWorkspaceToMonitorOpts) error {
 	// Validate that exactly one mode is specified
-	modesSet := 0
-	if args.Direction != "" {
-		modesSet++
-	}
-	if args.Order != "" {
-		modesSet++
-	}
-	if len(args.Patterns) > 0 {
-		modesSet++
-	}
-
-	if modesSet == 0 {
-		return fmt.Errorf("must specify exactly one of: Direction, Order, or Patterns")
-	}
-	if modesSet > 1 {
-		return fmt.Errorf("cannot specify multiple modes; must specify exactly one of: Direction, Order, or Patterns")
+	if err := validate.ExactlyOne(
+		validate.Set("Direction", target.Direction != ""),
+		validate.Set("Order", target.Order != ""),
+		validate.Set("Patterns", len(target.Patterns) > 0),
+	); err != nil {
+		return err
 	}
-
-	// Validate direction if specified
-	if args.Direction != "" {
-		validDirections := map[string]bool{"left": true, "down": true, "up": true, "right": true}
-		if !validDirections[args.Direction] {
-			return fmt.Errorf("invalid direction %q, must be one of: left, down, up, right", args.Direction)
+	if target.Direction != "" {
+		if err := validate.OneOf("direction", target.Direction, validate.Directions...); err != nil {
+			return err
 		}
 	}
-
-	// Validate order if specified
-	if args.Order != "" {
-		if args.Order != "next" && args.Order != "prev" {
-			return fmt.Errorf("invalid order %q, must be one of: next, prev", args.Order)
+	if target.Order != "" {
+		if err := validate.OneOf("order", target.Order, validate.Orders...); err != nil {
+			return err
 		}
 	}
 
-	// Build command arguments
-	cmdArgs := []string{}
-
-	// Add optional flags
-	if opts.Workspace != nil {
-		cmdArgs = append(cmdArgs, "--workspace", *opts.Workspace)
-	}
-	if opts.WrapAround {
-		cmdArgs = append(cmdArgs, "--wrap-around")
-	}
+	// Build command arguments, optional flags first
+	builder := args.New().
+		KV("--workspace", opts.Workspace).
+		FlagIf(opts.WrapAround, "--wrap-around")
 
 	// Add the mode-specific argument(s)
-	if args.Direction != "" {
-		cmdArgs = append(cmdArgs, args.Direction)
-	} else if args.Order != "" {
-		cmdArgs = append(cmdArgs, args.Order)
-	} else if len(args.Patterns) > 0 {
-		cmdArgs = append(cmdArgs, args.Patterns...)
+	if target.Direction != "" {
+		builder.Values(target.Direction)
+	} else if target.Order != "" {
+		builder.Values(target.Order)
+	} else if len(target.Patterns) > 0 {
+		builder.Values(target.Patterns...)
 	}
+	cmdArgs := builder.Build()
 
-	response, err := s.client.SendCommand("move-workspace-to-monitor", cmdArgs)
+	response, err := s.sendCommand(commands.MoveWorkspaceToMonitor, cmdArgs)
 	if err != nil {
 		return err
 	}
@@ -382,3 +686,15 @@ func (s *Service) MoveWorkspaceToMonitor(args MoveWorkspaceToMonitorArgs, opts M
 
 	return nil
 }
+
+// Helper functions for creating pointers (useful for API usage)
+
+// BoolPtr returns a pointer to the given bool value.
+func BoolPtr(v bool) *bool {
+	return &v
+}
+
+// StringPtr returns a pointer to the given string value.
+func StringPtr(v string) *string {
+	return &v
+}
@@ -1,8 +1,11 @@
 package workspaces
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"log/slog"
+	"slices"
 	"testing"
 
 	mock_client "github.com/cristianoliveira/aerospace-ipc/internal/mocks"
@@ -26,7 +29,7 @@ func TestWorkspaceService(t *testing.T) {
 			service := NewService(mockConn)
 
 			workspaces := []Workspace{
-				{Workspace: "42"},
+				{Workspace: "42", MonitorID: 1, IsVisible: true, IsFocused: true},
 			}
 
 			dataJSON, err := json.Marshal(workspaces)
@@ -40,6 +43,7 @@ func TestWorkspaceService(t *testing.T) {
 					[]string{
 						"--focused",
 						"--json",
+						"--format", formatArguments,
 					},
 				).
 				Return(
@@ -57,6 +61,96 @@ func TestWorkspaceService(t *testing.T) {
 			if workspace.Workspace != "42" {
 				t.Fatalf("expected workspace '42', got '%s'", workspace.Workspace)
 			}
+			if !workspace.IsVisible || !workspace.IsFocused || workspace.IsEffectivelyEmpty {
+				t.Fatalf("unexpected workspace flags %+v", workspace)
+			}
+		})
+
+		t.Run("GetAllWorkspaces", func(tt *testing.T) {
+			tt.Run("all workspaces", func(ttt *testing.T) {
+				ctrl := gomock.NewController(ttt)
+				defer ctrl.Finish()
+
+				mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+				service := NewService(mockConn)
+
+				workspaces := []Workspace{
+					{Workspace: "1", MonitorID: 1, MonitorName: "Built-in Retina Display"},
+					{Workspace: "terminal", MonitorID: 2, MonitorName: "DELL U2720Q"},
+				}
+				dataJSON, err := json.Marshal(workspaces)
+				if err != nil {
+					ttt.Fatalf("failed to marshal workspaces response: %v", err)
+				}
+
+				mockConn.EXPECT().
+					SendCommand(
+						"list-workspaces",
+						[]string{
+							"--all",
+							"--json",
+							"--format", formatArguments,
+						},
+					).
+					Return(
+						&client.Response{
+							StdOut: string(dataJSON),
+						},
+						nil,
+					)
+
+				result, err := service.GetAllWorkspaces()
+				if err != nil {
+					ttt.Fatalf("unexpected error: %v", err)
+				}
+
+				if len(result) != 2 {
+					ttt.Fatalf("expected 2 workspaces, got %d", len(result))
+				}
+				if result[1].Workspace != "terminal" || result[1].MonitorID != 2 || result[1].MonitorName != "DELL U2720Q" {
+					ttt.Fatalf("unexpected workspace %+v", result[1])
+				}
+			})
+
+			tt.Run("with all options", func(ttt *testing.T) {
+				ctrl := gomock.NewController(ttt)
+				defer ctrl.Finish()
+
+				mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+				service := NewService(mockConn)
+
+				mockConn.EXPECT().
+					SendCommand(
+						"list-workspaces",
+						[]string{
+							"--monitor", "focused", "2",
+							"--visible",
+							"--empty", "no",
+							"--json",
+							"--format", "%{workspace}",
+						},
+					).
+					Return(
+						&client.Response{
+							StdOut: `[{"workspace": "42"}]`,
+						},
+						nil,
+					)
+
+				result, err := service.GetAllWorkspaces(ListWorkspacesOpts{
+					Monitors: []string{"focused", "2"},
+					Visible:  BoolPtr(true),
+					Empty:    BoolPtr(false),
+					Format:   StringPtr("%{workspace}"),
+				})
+				if err != nil {
+					ttt.Fatalf("unexpected error: %v", err)
+				}
+
+				if len(result) != 1 || result[0].Workspace != "42" {
+					ttt.Fatalf("unexpected workspaces %+v", result)
+				}
+			})
 		})
 
 		t.Run("MoveWindowToWorkspace", func(tt *testing.T) {
@@ -96,7 +190,7 @@ func TestWorkspaceService(t *testing.T) {
 				mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
 				service := NewService(mockConn)
 
-				windowID := 12345
+				windowID := client.WindowID(12345)
 				mockConn.EXPECT().
 					SendCommand(
 						"move-node-to-workspace",
@@ -129,7 +223,7 @@ func TestWorkspaceService(t *testing.T) {
 				mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
 				service := NewService(mockConn)
 
-				windowID := 12345
+				windowID := client.WindowID(12345)
 				mockConn.EXPECT().
 					SendCommand(
 						"move-node-to-workspace",
@@ -193,6 +287,40 @@ func TestWorkspaceService(t *testing.T) {
 				}
 			})
 
+			tt.Run("with stdin workspaces", func(ttt *testing.T) {
+				ctrl := gomock.NewController(ttt)
+				defer ctrl.Finish()
+
+				mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+				service := NewService(mockConn)
+
+				mockConn.EXPECT().
+					SendCommandWithStdin(
+						gomock.Any(),
+						"move-node-to-workspace",
+						[]string{
+							"next",
+							"--stdin",
+						},
+						"1\n3\nterminal\n",
+					).
+					Return(
+						&client.Response{
+							StdOut: "",
+						},
+						nil,
+					)
+
+				err := service.MoveWindowToWorkspaceWithOpts(MoveWindowToWorkspaceArgs{
+					WorkspaceName: "next",
+				}, MoveWindowToWorkspaceOpts{
+					StdinWorkspaces: []string{"1", "3", "terminal"},
+				})
+				if err != nil {
+					ttt.Fatalf("unexpected error: %v", err)
+				}
+			})
+
 			tt.Run("with no-stdin option", func(ttt *testing.T) {
 				ctrl := gomock.NewController(ttt)
 				defer ctrl.Finish()
@@ -543,6 +671,88 @@ func TestWorkspaceService(t *testing.T) {
 				}
 			})
 		})
+		t.Run("Convenience queries", func(tt *testing.T) {
+			tests := []struct {
+				name     string
+				call     func(service *Service) ([]Workspace, error)
+				expected []string
+			}{
+				{
+					name: "GetWorkspacesByMonitor",
+					call: func(service *Service) ([]Workspace, error) {
+						return service.GetWorkspacesByMonitor("2")
+					},
+					expected: []string{"--monitor", "2"},
+				},
+				{
+					name: "GetVisibleWorkspaces",
+					call: func(service *Service) ([]Workspace, error) {
+						return service.GetVisibleWorkspaces()
+					},
+					expected: []string{"--monitor", "all", "--visible"},
+				},
+				{
+					name: "GetEmptyWorkspaces",
+					call: func(service *Service) ([]Workspace, error) {
+						return service.GetEmptyWorkspaces()
+					},
+					expected: []string{"--monitor", "all", "--empty"},
+				},
+			}
+
+			for _, tc := range tests {
+				tt.Run(tc.name, func(ttt *testing.T) {
+					ctrl := gomock.NewController(ttt)
+					defer ctrl.Finish()
+
+					mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+					service := NewService(mockConn)
+
+					expected := append(tc.expected, "--json", "--format", formatArguments)
+					mockConn.EXPECT().
+						SendCommand("list-workspaces", expected).
+						Return(&client.Response{StdOut: `[{"workspace": "1"}]`}, nil)
+
+					result, err := tc.call(service)
+					if err != nil {
+						ttt.Fatalf("unexpected error: %v", err)
+					}
+					if len(result) != 1 || result[0].Workspace != "1" {
+						ttt.Fatalf("unexpected workspaces %+v", result)
+					}
+				})
+			}
+		})
+
+		t.Run("GetWorkspacesCount", func(tt *testing.T) {
+			ctrl := gomock.NewController(tt)
+			defer ctrl.Finish()
+
+			mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+			service := NewService(mockConn)
+
+			mockConn.EXPECT().
+				SendCommand(
+					"list-workspaces",
+					[]string{
+						"--monitor", "all",
+						"--empty", "no",
+						"--count",
+					},
+				).
+				Return(&client.Response{StdOut: "4\n"}, nil)
+
+			count, err := service.GetWorkspacesCount(ListWorkspacesOpts{
+				Monitors: []string{"all"},
+				Empty:    BoolPtr(false),
+			})
+			if err != nil {
+				tt.Fatalf("unexpected error: %v", err)
+			}
+			if count != 4 {
+				tt.Fatalf("expected 4 workspaces, got %d", count)
+			}
+		})
 	})
 
 	t.Run("Error cases", func(tt *testing.T) {
@@ -559,6 +769,7 @@ func TestWorkspaceService(t *testing.T) {
 					[]string{
 						"--focused",
 						"--json",
+						"--format", formatArguments,
 					},
 				).
 				Return(nil, fmt.Errorf("no focused workspace found")).
@@ -583,6 +794,7 @@ func TestWorkspaceService(t *testing.T) {
 					[]string{
 						"--focused",
 						"--json",
+						"--format", formatArguments,
 					},
 				).
 				Return(&client.Response{StdOut: "[]"}, nil).
@@ -607,6 +819,7 @@ func TestWorkspaceService(t *testing.T) {
 					[]string{
 						"--focused",
 						"--json",
+						"--format", formatArguments,
 					},
 				).
 				Return(&client.Response{StdOut: "invalid json"}, nil).
@@ -618,6 +831,61 @@ func TestWorkspaceService(t *testing.T) {
 			}
 		})
 
+		t.Run("GetAllWorkspaces filters without monitors", func(tt *testing.T) {
+			ctrl := gomock.NewController(tt)
+			defer ctrl.Finish()
+
+			mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+			service := NewService(mockConn)
+
+			_, err := service.GetAllWorkspaces(ListWorkspacesOpts{
+				Visible: BoolPtr(true),
+			})
+			if err == nil {
+				t.Fatal("expected error, got nil")
+			}
+		})
+
+		t.Run("GetAllWorkspaces refuses text layout fields", func(tt *testing.T) {
+			ctrl := gomock.NewController(tt)
+			defer ctrl.Finish()
+
+			mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+			service := NewService(mockConn)
+
+			_, err := service.GetAllWorkspaces(ListWorkspacesOpts{
+				Format: StringPtr("%{workspace}%{newline}"),
+			})
+			if err == nil {
+				t.Fatal("expected error, got nil")
+			}
+		})
+
+		t.Run("GetAllWorkspaces JSON unmarshal error", func(tt *testing.T) {
+			ctrl := gomock.NewController(tt)
+			defer ctrl.Finish()
+
+			mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+			service := NewService(mockConn)
+
+			mockConn.EXPECT().
+				SendCommand(
+					"list-workspaces",
+					[]string{
+						"--all",
+						"--json",
+						"--format", formatArguments,
+					},
+				).
+				Return(&client.Response{StdOut: "invalid json"}, nil).
+				Times(1)
+
+			_, err := service.GetAllWorkspaces()
+			if err == nil {
+				t.Fatal("expected error for invalid JSON, got nil")
+			}
+		})
+
 		t.Run("MoveWindowToWorkspaceWithOpts incompatible options", func(tt *testing.T) {
 			ctrl := gomock.NewController(tt)
 			defer ctrl.Finish()
@@ -639,6 +907,24 @@ func TestWorkspaceService(t *testing.T) {
 			}
 		})
 
+		t.Run("MoveWindowToWorkspaceWithOpts stdin workspaces with no-stdin", func(tt *testing.T) {
+			ctrl := gomock.NewController(tt)
+			defer ctrl.Finish()
+
+			mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+			service := NewService(mockConn)
+
+			err := service.MoveWindowToWorkspaceWithOpts(MoveWindowToWorkspaceArgs{
+				WorkspaceName: "next",
+			}, MoveWindowToWorkspaceOpts{
+				StdinWorkspaces: []string{"1"},
+				NoStdin:         true,
+			})
+			if err == nil || err.Error() != "cannot specify both --stdin and --no-stdin options" {
+				tt.Fatalf("expected incompatible options error, got: %v", err)
+			}
+		})
+
 		t.Run("MoveWindowToWorkspaceWithOpts connection error", func(tt *testing.T) {
 			ctrl := gomock.NewController(tt)
 			defer ctrl.Finish()
@@ -869,5 +1155,54 @@ func TestWorkspaceService(t *testing.T) {
 				t.Fatal("expected error, got nil")
 			}
 		})
+
+		t.Run("GetWorkspacesCount non-zero exit code", func(tt *testing.T) {
+			ctrl := gomock.NewController(tt)
+			defer ctrl.Finish()
+
+			mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+			service := NewService(mockConn)
+
+			mockConn.EXPECT().
+				SendCommand("list-workspaces", []string{"--all", "--count"}).
+				Return(&client.Response{StdErr: "Unknown flag", ExitCode: 1}, nil)
+
+			_, err := service.GetWorkspacesCount()
+			if err == nil {
+				t.Fatal("expected error, got nil")
+			}
+		})
 	})
 }
+
+func TestServiceWithContext(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockConn := mock_client.NewMockAeroSpaceConnection(ctrl)
+	service := NewService(mockConn).WithContext(ctx)
+
+	mockConn.EXPECT().
+		SendCommandContext(ctx, "workspace-back-and-forth", []string{}).
+		Return(&client.Response{StdOut: ""}, nil)
+
+	err := service.MoveBackAndForth()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestWorkspaceLogValue(t *testing.T) {
+	workspace := Workspace{Workspace: "42", MonitorID: 1, IsVisible: true}
+
+	value := workspace.LogValue()
+	expected := []slog.Attr{
+		slog.String("name", "42"),
+		slog.Int("monitor", 1),
+		slog.Bool("visible", true),
+	}
+	if value.Kind() != slog.KindGroup || !slices.EqualFunc(value.Group(), expected, slog.Attr.Equal) {
+		t.Fatalf("expected %v, got %v", expected, value)
+	}
+}
@@ -0,0 +1,138 @@
+// Package args builds the argument list of AeroSpace commands.
+//
+// It translates option values to CLI arguments consistently, so callers do
+// not format numbers or check optional values by hand.
+//
+// Usage:
+//
+//	cmdArgs := args.New().
+//	    Flag("--ignore-floating").
+//	    KV("--window-id", windowID).
+//	    Values("left").
+//	    Build()
+//	response, err := conn.SendCommand(commands.Focus, cmdArgs)
+package args
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/cristianoliveira/aerospace-ipc/pkg/client"
+)
+
+// Builder accumulates command arguments in order.
+//
+// Every method returns the builder so calls can be chained.
+type Builder struct {
+	args []string
+}
+
+// New returns an empty Builder.
+func New() *Builder {
+	return &Builder{args: []string{}}
+}
+
+// Flag appends flags, e.g. "--json".
+func (b *Builder) Flag(flags ...string) *Builder {
+	b.args = append(b.args, flags...)
+	return b
+}
+
+// FlagIf appends flag only when enabled is true.
+//
+// Usage:
+//
+//	args.New().FlagIf(opts.WrapAround, "--wrap-around")
+func (b *Builder) FlagIf(enabled bool, flag string) *Builder {
+	if enabled {
+		b.args = append(b.args, flag)
+	}
+	return b
+}
+
+// Toggle appends flag followed by "no" when value is false, flag alone when
+// value is true and nothing when value is nil, e.g. "--visible [no]".
+func (b *Builder) Toggle(flag string, value *bool) *Builder {
+	if value == nil {
+		return b
+	}
+	b.args = append(b.args, flag)
+	if !*value {
+		b.args = append(b.args, "no")
+	}
+	return b
+}
+
+// KV appends key followed by value.
+//
+// value can be a string, an integer, a bool or a fmt.Stringer. Pointers to
+// strings, ints and window IDs are dereferenced and nil pointers append nothing, so
+// optional fields can be passed as they are:
+//
+//	args.New().KV("--window-id", opts.WindowID) // opts.WindowID is an *int
+func (b *Builder) KV(key string, value any) *Builder {
+	var formatted string
+	switch v := value.(type) {
+	case *string:
+		if v == nil {
+			return b
+		}
+		formatted = *v
+	case *int:
+		if v == nil {
+			return b
+		}
+		formatted = strconv.Itoa(*v)
+	case *client.WindowID:
+		if v == nil {
+			return b
+		}
+		formatted = v.String()
+	case string:
+		formatted = v
+	case int:
+		formatted = strconv.Itoa(v)
+	case int32:
+		formatted = strconv.FormatInt(int64(v), 10)
+	case int64:
+		formatted = strconv.FormatInt(v, 10)
+	case uint32:
+		formatted = strconv.FormatUint(uint64(v), 10)
+	case uint64:
+		formatted = strconv.FormatUint(v, 10)
+	case bool:
+		formatted = strconv.FormatBool(v)
+	case fmt.Stringer:
+		formatted = v.String()
+	default:
+		panic(fmt.Sprintf("ASSERTION: unsupported value type %T for %s", value, key))
+	}
+	b.args = append(b.args, key, formatted)
+	return b
+}
+
+// KVs appends key followed by values, when there are any, e.g. "--monitor 1 2".
+func (b *Builder) KVs(key string, values ...string) *Builder {
+	if len(values) == 0 {
+		return b
+	}
+	b.args = append(b.args, key)
+	b.args = append(b.args, values...)
+	return b
+}
+
+// Values appends positional values, e.g. a direction or workspace name.
+func (b *Builder) Values(values ...string) *Builder {
+	b.args = append(b.args, values...)
+	return b
+}
+
+// Len returns the number of arguments appended so far.
+func (b *Builder) Len() int {
+	return len(b.args)
+}
+
+// Build returns the arguments. It is never nil.
+func (b *Builder) Build() []string {
+	return append([]string{}, b.args...)
+}
@@ -0,0 +1,90 @@
+package args
+
+import (
+	"reflect"
+	"testing"
+)
+
+type name string
+
+func (n name) String() string { return string(n) }
+
+func TestBuilder(t *testing.T) {
+	t.Run("Happy path", func(tt *testing.T) {
+		windowID := 42
+		workspace := "3"
+		yes, no := true, false
+
+		tests := []struct {
+			name     string
+			builder  *Builder
+			expected []string
+		}{
+			{
+				name:     "empty",
+				builder:  New(),
+				expected: []string{},
+			},
+			{
+				name:     "flags, key values and values in order",
+				builder:  New().Flag("--json").KV("--window-id", 42).Values("left"),
+				expected: []string{"--json", "--window-id", "42", "left"},
+			},
+			{
+				name:     "conditional flags",
+				builder:  New().FlagIf(true, "--wrap-around").FlagIf(false, "--fail-if-noop"),
+				expected: []string{"--wrap-around"},
+			},
+			{
+				name:     "toggles",
+				builder:  New().Toggle("--visible", &yes).Toggle("--empty", &no).Toggle("--focused", nil),
+				expected: []string{"--visible", "--empty", "no"},
+			},
+			{
+				name: "pointer values",
+				builder: New().
+					KV("--window-id", &windowID).
+					KV("--workspace", &workspace).
+					KV("--pid", (*int)(nil)).
+					KV("--app-bundle-id", (*string)(nil)),
+				expected: []string{"--window-id", "42", "--workspace", "3"},
+			},
+			{
+				name: "other value types",
+				builder: New().
+					KV("--a", int32(-1)).
+					KV("--b", int64(2)).
+					KV("--c", uint32(3)).
+					KV("--d", uint64(4)).
+					KV("--e", true).
+					KV("--f", name("main")),
+				expected: []string{"--a", "-1", "--b", "2", "--c", "3", "--d", "4", "--e", "true", "--f", "main"},
+			},
+			{
+				name:     "key with several values",
+				builder:  New().KVs("--monitor", "1", "2").KVs("--workspace"),
+				expected: []string{"--monitor", "1", "2"},
+			},
+		}
+
+		for _, tc := range tests {
+			tt.Run(tc.name, func(ttt *testing.T) {
+				got := tc.builder.Build()
+				if !reflect.DeepEqual(got, tc.expected) {
+					ttt.Fatalf("expected %#v, got %#v", tc.expected, got)
+				}
+			})
+		}
+	})
+
+	t.Run("Error cases", func(tt *testing.T) {
+		tt.Run("panics on unsupported value types", func(ttt *testing.T) {
+			defer func() {
+				if recover() == nil {
+					ttt.Fatal("expected a panic")
+				}
+			}()
+			New().KV("--ratio", 0.5)
+		})
+	})
+}
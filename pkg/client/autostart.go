@@ -0,0 +1,67 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os/exec"
+	"time"
+)
+
+const (
+	// autoStartTimeout bounds how long to wait for a launched server
+	// when no dial timeout is set.
+	autoStartTimeout = 10 * time.Second
+
+	// autoStartPollInterval is how often the socket is dialed while the server starts.
+	autoStartPollInterval = 100 * time.Millisecond
+)
+
+// startServer launches the AeroSpace app. It is a variable so tests can replace it.
+var startServer = func(ctx context.Context) error {
+	output, err := exec.CommandContext(ctx, "open", "-a", "AeroSpace").CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("failed to launch AeroSpace\n%w\n%s", err, output)
+	}
+	return nil
+}
+
+// dialOrStart dials the socket, launching AeroSpace first when it is not
+// running and WithAutoStart is enabled.
+//
+// It waits for the socket to accept connections for up to the dial timeout,
+// or autoStartTimeout if none is set.
+func (c *AeroSpaceSocketConnection) dialOrStart() (net.Conn, error) {
+	conn, err := c.dial()
+	if !c.autoStart || !errors.Is(err, ErrServerNotRunning) {
+		return conn, err
+	}
+
+	timeout := c.dialTimeout
+	if timeout <= 0 {
+		timeout = autoStartTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	c.logger().Debug("AeroSpace is not running, launching it", "socket", c.socketPath)
+	if err := startServer(ctx); err != nil {
+		return nil, err
+	}
+
+	ticker := time.NewTicker(autoStartPollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("AeroSpace did not start within %s\n%w", timeout, err)
+		case <-ticker.C:
+		}
+
+		conn, err = c.dial()
+		if !errors.Is(err, ErrServerNotRunning) {
+			return conn, err
+		}
+	}
+}
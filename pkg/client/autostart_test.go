@@ -0,0 +1,98 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// stubStartServer replaces the AeroSpace launcher for the duration of the test.
+func stubStartServer(t *testing.T, start func(ctx context.Context) error) {
+	previous := startServer
+	startServer = start
+	t.Cleanup(func() { startServer = previous })
+}
+
+func TestWithAutoStart(t *testing.T) {
+	t.Run("Happy path", func(tt *testing.T) {
+		tt.Run("launches AeroSpace and connects once the socket appears", func(ttt *testing.T) {
+			socketPath := filepath.Join(ttt.TempDir(), "aerospace.sock")
+			stubStartServer(ttt, func(ctx context.Context) error {
+				// The server takes a while to create its socket
+				time.AfterFunc(150*time.Millisecond, func() {
+					listener, err := net.Listen("unix", socketPath)
+					if err != nil {
+						return
+					}
+					ttt.Cleanup(func() { listener.Close() })
+					go func() {
+						conn, err := listener.Accept()
+						if err != nil {
+							return
+						}
+						serve(conn, Response{StdOut: "ok"}, 0)
+					}()
+				})
+				return nil
+			})
+
+			connection, err := NewAeroSpaceSocketConnection(socketPath, WithAutoStart(true))
+			if err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+			defer connection.CloseConnection()
+
+			response, err := connection.SendCommand("list-modes", []string{"--current"})
+			if err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+			if response.StdOut != "ok" {
+				ttt.Fatalf("expected stdout 'ok', got %q", response.StdOut)
+			}
+		})
+	})
+
+	t.Run("Error cases", func(tt *testing.T) {
+		tt.Run("does not launch AeroSpace by default", func(ttt *testing.T) {
+			stubStartServer(ttt, func(ctx context.Context) error {
+				ttt.Fatal("unexpected launch of AeroSpace")
+				return nil
+			})
+
+			_, err := NewAeroSpaceSocketConnection(filepath.Join(ttt.TempDir(), "missing.sock"))
+			if !errors.Is(err, ErrServerNotRunning) {
+				ttt.Fatalf("expected ErrServerNotRunning, got %v", err)
+			}
+		})
+
+		tt.Run("fails when the socket does not appear in time", func(ttt *testing.T) {
+			stubStartServer(ttt, func(ctx context.Context) error { return nil })
+
+			_, err := NewAeroSpaceSocketConnection(
+				filepath.Join(ttt.TempDir(), "missing.sock"),
+				WithAutoStart(true),
+				WithDialTimeout(200*time.Millisecond),
+			)
+			if err == nil || !strings.Contains(err.Error(), "AeroSpace did not start within 200ms") {
+				ttt.Fatalf("expected start timeout error, got %v", err)
+			}
+			if !errors.Is(err, ErrServerNotRunning) {
+				ttt.Fatalf("expected ErrServerNotRunning, got %v", err)
+			}
+		})
+
+		tt.Run("fails when AeroSpace cannot be launched", func(ttt *testing.T) {
+			launchErr := errors.New("Unable to find application named 'AeroSpace'")
+			stubStartServer(ttt, func(ctx context.Context) error { return launchErr })
+
+			_, err := NewAeroSpaceSocketConnection(filepath.Join(ttt.TempDir(), "missing.sock"), WithAutoStart(true))
+			if !errors.Is(err, launchErr) {
+				ttt.Fatalf("expected launch error, got %v", err)
+			}
+		})
+	})
+}
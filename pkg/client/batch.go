@@ -0,0 +1,87 @@
+package client
+
+import (
+	"context"
+	"fmt"
+)
+
+// SendBatch sends the commands in order while holding the connection for the
+// whole batch, so tools applying many moves or layouts at once pay for a
+// single lock acquisition and are not interleaved with other commands.
+//
+// The Args of every command start with the command name, as on the wire,
+// and its Stdin is passed to the command. The other fields are set by the
+// connection, as for SendCommand.
+//
+// The batch stops at the first failing command. The responses of the
+// commands that succeeded before it are returned with its error.
+//
+// Usage:
+//
+//	responses, err := conn.SendBatch(ctx, []client.Command{
+//	    {Args: []string{"move-node-to-workspace", "1", "--window-id", "42"}},
+//	    {Args: []string{"move-node-to-workspace", "2", "--window-id", "43"}},
+//	})
+func (c *AeroSpaceSocketConnection) SendBatch(ctx context.Context, commands []Command) ([]*Response, error) {
+	if err := checkBatch(commands); err != nil {
+		return nil, err
+	}
+	if err := c.checkOpen(); err != nil {
+		return nil, err
+	}
+
+	responses, err := c.sendBatch(ctx, commands)
+	for i, response := range responses {
+		reportWarning(c.stderrPolicy, c.onWarning, commands[i].Args[0], commands[i].Args[1:], response)
+	}
+	return responses, err
+}
+
+// sendBatch sends the commands one after the other, holding c.mu for the whole batch.
+func (c *AeroSpaceSocketConnection) sendBatch(ctx context.Context, commands []Command) ([]*Response, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	responses := make([]*Response, 0, len(commands))
+	for _, cmd := range commands {
+		id := commandIDs.Add(1)
+		command, args := cmd.Args[0], cmd.Args[1:]
+		response, err := c.trace(ctx, id, command, args, func() (*Response, error) {
+			// The batch keeps the connection, even while backing off.
+			return c.sendLocked(ctx, id, command, args, cmd.Stdin, true)
+		})
+		if err != nil {
+			return responses, err
+		}
+		responses = append(responses, response)
+	}
+	return responses, nil
+}
+
+// checkBatch returns an error if one of the commands has no command name.
+func checkBatch(commands []Command) error {
+	for i, cmd := range commands {
+		if len(cmd.Args) == 0 || cmd.Args[0] == "" {
+			return fmt.Errorf("invalid batch\nmissing command name in command %d", i)
+		}
+	}
+	return nil
+}
+
+// sendEach sends the commands one by one on conn, stopping at the first failure,
+// for connections that cannot hold the socket for a whole batch.
+func sendEach(ctx context.Context, conn AeroSpaceConnection, commands []Command) ([]*Response, error) {
+	if err := checkBatch(commands); err != nil {
+		return nil, err
+	}
+
+	responses := make([]*Response, 0, len(commands))
+	for _, cmd := range commands {
+		response, err := sendWithStdin(ctx, conn, cmd.Args[0], cmd.Args[1:], cmd.Stdin)
+		if err != nil {
+			return responses, err
+		}
+		responses = append(responses, response)
+	}
+	return responses, nil
+}
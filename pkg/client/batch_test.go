@@ -0,0 +1,131 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"slices"
+	"testing"
+)
+
+// serveArgs answers every command on conn with its name as stdout,
+// failing the "fail" command, and records the commands received.
+func serveArgs(conn net.Conn, received chan<- Command) {
+	decoder := json.NewDecoder(conn)
+	for {
+		var cmd Command
+		if err := decoder.Decode(&cmd); err != nil {
+			return
+		}
+		received <- cmd
+		response := Response{StdOut: cmd.Args[0]}
+		if cmd.Args[0] == "fail" {
+			response = Response{ExitCode: 1, StdErr: "boom"}
+		}
+		responseBytes, _ := json.Marshal(response)
+		if _, err := conn.Write(responseBytes); err != nil {
+			return
+		}
+	}
+}
+
+func TestSendBatch(t *testing.T) {
+	t.Run("Happy path", func(tt *testing.T) {
+		tt.Run("sends the commands in order", func(ttt *testing.T) {
+			clientConn, serverConn := net.Pipe()
+			defer serverConn.Close()
+			received := make(chan Command, 10)
+			go serveArgs(serverConn, received)
+
+			connection := &AeroSpaceSocketConnection{Conn: clientConn}
+			responses, err := connection.SendBatch(context.Background(), []Command{
+				{Args: []string{"focus", "left"}},
+				{Args: []string{"move-node-to-workspace", "next", "--stdin"}, Stdin: "1\n3\n"},
+				{Args: []string{"layout", "tiles"}},
+			})
+			if err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+
+			var stdouts []string
+			for _, response := range responses {
+				stdouts = append(stdouts, response.StdOut)
+			}
+			if !slices.Equal(stdouts, []string{"focus", "move-node-to-workspace", "layout"}) {
+				ttt.Fatalf("unexpected responses: %v", stdouts)
+			}
+			<-received
+			if cmd := <-received; cmd.Stdin != "1\n3\n" {
+				ttt.Fatalf("expected the stdin to be sent, got %+v", cmd)
+			}
+			if stats := connection.Stats(); stats.CommandsSent != 3 {
+				ttt.Fatalf("expected 3 commands sent, got %d", stats.CommandsSent)
+			}
+		})
+
+		tt.Run("is sent as a whole by the queue", func(ttt *testing.T) {
+			fake := NewFakeConnection(FakeState{})
+			queued := NewQueuedConnection(fake)
+			defer queued.CloseConnection()
+
+			responses, err := queued.SendBatch(context.Background(), []Command{
+				{Args: []string{"list-modes", "--current"}},
+				{Args: []string{"list-modes"}},
+			})
+			if err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+			if len(responses) != 2 || len(fake.Sent()) != 2 {
+				ttt.Fatalf("expected 2 responses and commands, got %d and %d", len(responses), len(fake.Sent()))
+			}
+		})
+	})
+
+	t.Run("Error cases", func(tt *testing.T) {
+		tt.Run("stops at the first failing command", func(ttt *testing.T) {
+			clientConn, serverConn := net.Pipe()
+			defer serverConn.Close()
+			received := make(chan Command, 10)
+			go serveArgs(serverConn, received)
+
+			connection := &AeroSpaceSocketConnection{Conn: clientConn}
+			responses, err := connection.SendBatch(context.Background(), []Command{
+				{Args: []string{"focus", "left"}},
+				{Args: []string{"fail"}},
+				{Args: []string{"layout", "tiles"}},
+			})
+			if err == nil {
+				ttt.Fatal("expected an error")
+			}
+			if _, ok := CommandID(err); !ok {
+				ttt.Fatalf("expected a command ID in %v", err)
+			}
+			if len(responses) != 1 || responses[0].StdOut != "focus" {
+				ttt.Fatalf("expected the response of the first command only, got %+v", responses)
+			}
+			if len(received) != 2 {
+				ttt.Fatalf("expected 2 commands to be sent, got %d", len(received))
+			}
+		})
+
+		tt.Run("rejects commands without a name", func(ttt *testing.T) {
+			connection := &AeroSpaceSocketConnection{}
+
+			_, err := connection.SendBatch(context.Background(), []Command{{Args: []string{"focus", "left"}}, {}})
+			if err == nil {
+				ttt.Fatal("expected an error")
+			}
+		})
+
+		tt.Run("rejects batches after close", func(ttt *testing.T) {
+			fake := NewFakeConnection(FakeState{})
+			if err := fake.CloseConnection(); err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+
+			if _, err := fake.SendBatch(context.Background(), []Command{{Args: []string{"list-modes"}}}); err == nil {
+				ttt.Fatal("expected an error")
+			}
+		})
+	})
+}
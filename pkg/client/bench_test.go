@@ -0,0 +1,56 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/cristianoliveira/aerospace-ipc/internal/fakeserver"
+)
+
+// windowsJSON returns a list-windows --json output with count windows.
+func windowsJSON(count int) string {
+	windows := make([]map[string]any, count)
+	for i := range windows {
+		windows[i] = map[string]any{
+			"window-id":    i,
+			"window-title": fmt.Sprintf("Window %d", i),
+			"app-name":     "Terminal",
+			"workspace":    "1",
+		}
+	}
+	data, _ := json.Marshal(windows)
+	return string(data)
+}
+
+func BenchmarkSendCommand(b *testing.B) {
+	benchmarks := []struct {
+		name   string
+		stdout string
+	}{
+		{"small response", "main"},
+		{"10 windows", windowsJSON(10)},
+		{"1000 windows", windowsJSON(1000)},
+	}
+
+	for _, bm := range benchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			socketPath := fakeserver.Start(b, func(args []string) fakeserver.Response {
+				return fakeserver.Response{StdOut: bm.stdout}
+			})
+			connection, err := NewAeroSpaceSocketConnection(socketPath)
+			if err != nil {
+				b.Fatalf("failed to connect: %v", err)
+			}
+			defer connection.CloseConnection()
+
+			b.ReportAllocs()
+			b.SetBytes(int64(len(bm.stdout)))
+			for b.Loop() {
+				if _, err := connection.SendCommand("list-windows", []string{"--all", "--json"}); err != nil {
+					b.Fatalf("unexpected error: %v", err)
+				}
+			}
+		})
+	}
+}
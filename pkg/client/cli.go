@@ -0,0 +1,379 @@
+package client
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"os/exec"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/cristianoliveira/aerospace-ipc/internal/constants"
+	"github.com/cristianoliveira/aerospace-ipc/pkg/client/commands"
+)
+
+// defaultCLIBinary is the AeroSpace CLI looked up in PATH.
+const defaultCLIBinary = "aerospace"
+
+// serverVersionPrefix prefixes the server version in the output of `aerospace --version`.
+const serverVersionPrefix = "AeroSpace.app server version:"
+
+// cliWaitDelay bounds how long the output of a killed CLI is drained.
+const cliWaitDelay = time.Second
+
+// AeroSpaceCLIConnection implements AeroSpaceConnection by running the aerospace binary,
+// so the same API works where the socket is unavailable, e.g. in sandboxed processes.
+//
+// It is slower than AeroSpaceSocketConnection, since every command spawns a process.
+type AeroSpaceCLIConnection struct {
+	// Binary is the path or name of the AeroSpace CLI. Defaults to "aerospace".
+	Binary string
+
+	MinMajorVersion int
+	MinMinorVersion int
+
+	// Logger receives diagnostics such as the version negotiation.
+	// If nil, slog.Default() is used.
+	Logger *slog.Logger
+
+	// StderrPolicy and OnWarning handle the standard error of successful
+	// commands, as WithStderrPolicy and WithWarningHandler do for sockets.
+	StderrPolicy StderrPolicy
+	OnWarning    WarningHandler
+
+	closed atomic.Bool
+}
+
+// NewAeroSpaceCLIConnection creates a connection running the given AeroSpace CLI binary.
+// An empty binary uses "aerospace" from PATH.
+//
+// Usage:
+//
+//	conn := client.NewAeroSpaceCLIConnection("/opt/homebrew/bin/aerospace")
+//	windowsService := windows.NewService(conn)
+func NewAeroSpaceCLIConnection(binary string) *AeroSpaceCLIConnection {
+	return &AeroSpaceCLIConnection{
+		Binary:          binary,
+		MinMajorVersion: constants.AeroSpaceSocketClientMajor,
+		MinMinorVersion: constants.AeroSpaceSocketClientMinor,
+	}
+}
+
+// CloseConnection marks the connection as closed. There is nothing to release.
+//
+// It returns ErrClosed if the connection was already closed.
+func (c *AeroSpaceCLIConnection) CloseConnection() error {
+	if c.closed.Swap(true) {
+		return ErrClosed
+	}
+	return nil
+}
+
+// SendCommand runs the command with the AeroSpace CLI:
+//
+//	aerospace <command> <args...>
+//
+// It fails the same way AeroSpaceSocketConnection.SendCommand does.
+func (c *AeroSpaceCLIConnection) SendCommand(command string, args []string) (*Response, error) {
+	return c.SendCommandContext(context.Background(), command, args)
+}
+
+// SendCommandContext runs the command with the AeroSpace CLI, killing it if ctx is done.
+func (c *AeroSpaceCLIConnection) SendCommandContext(ctx context.Context, command string, args []string) (*Response, error) {
+	return c.SendCommandWithStdin(ctx, command, args, "")
+}
+
+// SendCommandWithStdin runs the command with the AeroSpace CLI, writing stdin to its standard input.
+func (c *AeroSpaceCLIConnection) SendCommandWithStdin(ctx context.Context, command string, args []string, stdin string) (*Response, error) {
+	if c.closed.Load() {
+		return nil, ErrClosed
+	}
+
+	response, err := c.run(ctx, append([]string{command}, args...), stdin)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err = checkResponse(command, args, response, c.StderrPolicy)
+	if err != nil {
+		return nil, err
+	}
+	reportWarning(c.StderrPolicy, c.OnWarning, command, args, response)
+	return response, nil
+}
+
+// SendBatch runs the commands one after the other with the AeroSpace CLI,
+// stopping at the first failure.
+func (c *AeroSpaceCLIConnection) SendBatch(ctx context.Context, commands []Command) ([]*Response, error) {
+	return sendEach(ctx, c, commands)
+}
+
+// SendCommandAsync runs the command in the background and delivers its outcome on the returned channel.
+func (c *AeroSpaceCLIConnection) SendCommandAsync(ctx context.Context, command string, args []string) <-chan Result {
+	// Buffered, so the result can be dropped without leaking the goroutine.
+	result := make(chan Result, 1)
+	go func() {
+		defer close(result)
+		response, err := c.SendCommandContext(ctx, command, args)
+		result <- Result{Response: response, Err: err}
+	}()
+	return result
+}
+
+// Ping runs a lightweight command and returns how long it took, process spawn included.
+func (c *AeroSpaceCLIConnection) Ping(ctx context.Context) (time.Duration, error) {
+	start := time.Now()
+	_, err := c.SendCommandContext(ctx, commands.Config, []string{"--config-path"})
+	if err != nil {
+		return 0, fmt.Errorf("failed to ping server\n%w", err)
+	}
+
+	return time.Since(start), nil
+}
+
+// GetSocketPath returns an error, the CLI finds the socket on its own.
+func (c *AeroSpaceCLIConnection) GetSocketPath() (string, error) {
+	return "", fmt.Errorf("missing socket path\nthe CLI connection does not use a socket")
+}
+
+// GetServerVersion returns the server version reported by `aerospace --version`.
+func (c *AeroSpaceCLIConnection) GetServerVersion() (string, error) {
+	if c.closed.Load() {
+		return "", ErrClosed
+	}
+
+	response, err := c.run(context.Background(), []string{"--version"}, "")
+	if err != nil {
+		return "", fmt.Errorf("failed to get server version\n%w", err)
+	}
+	if response.ExitCode != 0 {
+		return "", fmt.Errorf("failed to get server version\n%s", response.StdErr)
+	}
+
+	for line := range strings.Lines(response.StdOut) {
+		if version, ok := strings.CutPrefix(line, serverVersionPrefix); ok {
+			return strings.TrimSpace(version), nil
+		}
+	}
+	return "", fmt.Errorf("failed to get server version\nunexpected output: %s", response.StdOut)
+}
+
+// GetVersionInfo retrieves and parses the version of the AeroSpace server.
+//
+// See AeroSpaceSocketConnection.GetVersionInfo.
+func (c *AeroSpaceCLIConnection) GetVersionInfo() (*VersionInfo, error) {
+	serverVersion, err := c.GetServerVersion()
+	if err != nil {
+		return nil, err
+	}
+
+	return negotiateVersion(c.logger(), serverVersion, c.MinMajorVersion, c.MinMinorVersion)
+}
+
+// CheckServerVersion checks if the server version meets the minimum requirements.
+func (c *AeroSpaceCLIConnection) CheckServerVersion() error {
+	info, err := c.GetVersionInfo()
+	if err != nil {
+		return err
+	}
+
+	return checkVersion(info, c.MinMajorVersion, c.MinMinorVersion)
+}
+
+func (c *AeroSpaceCLIConnection) logger() *slog.Logger {
+	if c.Logger == nil {
+		return slog.Default()
+	}
+	return c.Logger
+}
+
+// run runs the CLI with args and captures its output.
+//
+// A non-zero exit code is reported in the response, not as an error.
+func (c *AeroSpaceCLIConnection) run(ctx context.Context, args []string, stdin string) (*Response, error) {
+	binary := cmp.Or(c.Binary, defaultCLIBinary)
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, binary, args...)
+	if stdin != "" {
+		cmd.Stdin = strings.NewReader(stdin)
+	}
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	// Do not wait for children of a killed CLI holding the output pipes.
+	cmd.WaitDelay = cliWaitDelay
+
+	err := cmd.Run()
+	if ctx.Err() != nil {
+		err = fmt.Errorf("failed to run %s\n%w", binary, context.Cause(ctx))
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return nil, &timeoutError{err: err}
+		}
+		return nil, err
+	}
+	var exitErr *exec.ExitError
+	if err != nil && !errors.As(err, &exitErr) {
+		return nil, fmt.Errorf("failed to run %s\n%w", binary, err)
+	}
+
+	return &Response{
+		StdOut:   stdout.String(),
+		StdErr:   stderr.String(),
+		ExitCode: int32(cmd.ProcessState.ExitCode()),
+	}, nil
+}
+
+// FallbackConnection sends commands on a primary connection, usually a socket,
+// and falls back to the AeroSpace CLI when the server cannot be reached through it
+// or for the commands that are not supported over IPC.
+//
+// Commands only fall back to the CLI when the primary connection could not be dialed,
+// so the server never received them. A command whose connection broke (EOF, broken pipe
+// or connection reset) is not run with the CLI, since the server may have executed it
+// right before closing the connection and it would run twice.
+//
+// The other AeroSpaceConnection methods are delegated to the primary connection.
+type FallbackConnection struct {
+	AeroSpaceConnection
+
+	// CLI runs the commands the primary connection cannot send.
+	CLI *AeroSpaceCLIConnection
+
+	// UseCLI reports whether a command must always be run with the CLI,
+	// e.g. because one of its flags is not supported over IPC. Optional.
+	UseCLI func(command string, args []string) bool
+}
+
+// NewFallbackConnection returns a FallbackConnection sending commands on primary
+// and falling back to cli.
+//
+// Usage:
+//
+//	conn := client.NewFallbackConnection(socketConn, client.NewAeroSpaceCLIConnection(""))
+//	conn.UseCLI = func(command string, args []string) bool {
+//	    return command == "list-apps"
+//	}
+func NewFallbackConnection(primary AeroSpaceConnection, cli *AeroSpaceCLIConnection) *FallbackConnection {
+	if primary == nil {
+		panic("ASSERTION: connection cannot be nil")
+	}
+	if cli == nil {
+		panic("ASSERTION: CLI connection cannot be nil")
+	}
+
+	return &FallbackConnection{
+		AeroSpaceConnection: primary,
+		CLI:                 cli,
+	}
+}
+
+// SendCommand sends the command on the primary connection or the CLI.
+func (f *FallbackConnection) SendCommand(command string, args []string) (*Response, error) {
+	return f.SendCommandContext(context.Background(), command, args)
+}
+
+// SendCommandContext sends the command on the primary connection, and runs it with
+// the CLI instead if UseCLI requires it or the primary connection cannot reach the server.
+func (f *FallbackConnection) SendCommandContext(ctx context.Context, command string, args []string) (*Response, error) {
+	return f.SendCommandWithStdin(ctx, command, args, "")
+}
+
+// SendCommandWithStdin is like SendCommandContext but passes stdin to the command.
+func (f *FallbackConnection) SendCommandWithStdin(ctx context.Context, command string, args []string, stdin string) (*Response, error) {
+	if f.UseCLI != nil && f.UseCLI(command, args) {
+		return f.CLI.SendCommandWithStdin(ctx, command, args, stdin)
+	}
+
+	response, err := sendWithStdin(ctx, f.AeroSpaceConnection, command, args, stdin)
+	if err != nil && ctx.Err() == nil && isUnreachable(err) {
+		return f.CLI.SendCommandWithStdin(ctx, command, args, stdin)
+	}
+	return response, err
+}
+
+// SendBatch sends the commands on the primary connection and runs the rest of the batch
+// with the CLI if the server cannot be reached. As with SendCommandWithStdin, the batch
+// does not fall back when the connection broke, since the command in flight may have run. If UseCLI selects one of the commands,
+// they are all sent one by one as with SendCommandWithStdin.
+func (f *FallbackConnection) SendBatch(ctx context.Context, commands []Command) ([]*Response, error) {
+	if f.UseCLI != nil && slices.ContainsFunc(commands, func(cmd Command) bool {
+		return len(cmd.Args) > 0 && f.UseCLI(cmd.Args[0], cmd.Args[1:])
+	}) {
+		return sendEach(ctx, f, commands)
+	}
+
+	responses, err := f.AeroSpaceConnection.SendBatch(ctx, commands)
+	if err != nil && ctx.Err() == nil && isUnreachable(err) {
+		rest, err := f.CLI.SendBatch(ctx, commands[len(responses):])
+		return append(responses, rest...), err
+	}
+	return responses, err
+}
+
+// SendCommandAsync sends the command in the background and delivers its outcome on the returned channel.
+func (f *FallbackConnection) SendCommandAsync(ctx context.Context, command string, args []string) <-chan Result {
+	// Buffered, so the result can be dropped without leaking the goroutine.
+	result := make(chan Result, 1)
+	go func() {
+		defer close(result)
+		response, err := f.SendCommandContext(ctx, command, args)
+		result <- Result{Response: response, Err: err}
+	}()
+	return result
+}
+
+// CloseConnection closes both the primary and the CLI connections.
+func (f *FallbackConnection) CloseConnection() error {
+	_ = f.CLI.CloseConnection()
+	return f.AeroSpaceConnection.CloseConnection()
+}
+
+// isUnreachable reports whether err means the socket could not be dialed,
+// so the command never reached the server. Broken connections are excluded,
+// since the server may have executed the command before closing them.
+func isUnreachable(err error) bool {
+	var opErr *net.OpError
+	return errors.Is(err, ErrServerNotRunning) || (errors.As(err, &opErr) && opErr.Op == "dial")
+}
+
+// AeroSpaceCLIFallbackConnector connects with Connector and, if the socket is unavailable,
+// falls back to the AeroSpace CLI, so consumers get the same API regardless of transport.
+type AeroSpaceCLIFallbackConnector struct {
+	// Connector opens the socket connection. Defaults to AeroSpaceDefaultConnector.
+	Connector AeroSpaceConnector
+
+	// Binary is the path or name of the AeroSpace CLI. Defaults to "aerospace".
+	Binary string
+}
+
+// Connect returns a FallbackConnection if the socket connection is established,
+// or an AeroSpaceCLIConnection if it fails and the CLI binary is found.
+//
+// It returns the socket connection error if the CLI binary is not found either.
+func (c *AeroSpaceCLIFallbackConnector) Connect() (AeroSpaceConnection, error) {
+	connector := c.Connector
+	if connector == nil {
+		connector = &AeroSpaceDefaultConnector{}
+	}
+	cli := NewAeroSpaceCLIConnection(c.Binary)
+
+	conn, err := connector.Connect()
+	if conn != nil {
+		// The socket is reachable, err may still report e.g. a version mismatch.
+		return NewFallbackConnection(conn, cli), err
+	}
+
+	binary := cmp.Or(c.Binary, defaultCLIBinary)
+	if _, lookErr := exec.LookPath(binary); lookErr != nil {
+		return nil, fmt.Errorf("%w\nno CLI fallback\n%w", err, lookErr)
+	}
+
+	cli.logger().Debug("AeroSpace socket is unavailable, falling back to the CLI", "binary", binary, "error", err)
+	return cli, nil
+}
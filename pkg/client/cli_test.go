@@ -0,0 +1,224 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeCLI writes a fake aerospace binary and returns its path.
+func fakeCLI(t *testing.T) string {
+	t.Helper()
+	binary := filepath.Join(t.TempDir(), "aerospace")
+	script := `#!/bin/sh
+case "$1" in
+--version)
+	echo "aerospace CLI client version: 0.20.1-Beta abc123"
+	echo "AeroSpace.app server version: 0.20.1-Beta abc123"
+	;;
+list-modes)
+	echo "main"
+	;;
+fail)
+	echo "boom" >&2
+	exit 2
+	;;
+stdin)
+	cat
+	;;
+warn)
+	echo "ok"
+	echo "deprecated" >&2
+	;;
+sleep)
+	exec sleep 5
+	;;
+esac
+`
+	if err := os.WriteFile(binary, []byte(script), 0o755); err != nil {
+		t.Fatalf("failed to write fake CLI: %v", err)
+	}
+	return binary
+}
+
+// unreachableConn fails every command with err.
+type unreachableConn struct {
+	AeroSpaceConnection
+
+	err error
+}
+
+func (c *unreachableConn) SendCommandContext(ctx context.Context, command string, args []string) (*Response, error) {
+	return nil, c.err
+}
+
+func (c *unreachableConn) SendBatch(ctx context.Context, commands []Command) ([]*Response, error) {
+	return nil, c.err
+}
+
+// failingConnector fails to connect with err.
+type failingConnector struct {
+	err error
+}
+
+func (c *failingConnector) Connect() (AeroSpaceConnection, error) {
+	return nil, c.err
+}
+
+func TestAeroSpaceCLIConnection(t *testing.T) {
+	t.Run("Happy path", func(tt *testing.T) {
+		tt.Run("runs the command with the binary", func(ttt *testing.T) {
+			conn := NewAeroSpaceCLIConnection(fakeCLI(ttt))
+
+			response, err := conn.SendCommand("list-modes", []string{"--current"})
+			if err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+			if response.StdOut != "main\n" {
+				ttt.Fatalf("expected stdout 'main\\n', got %q", response.StdOut)
+			}
+		})
+
+		tt.Run("checks the server version", func(ttt *testing.T) {
+			conn := NewAeroSpaceCLIConnection(fakeCLI(ttt))
+
+			info, err := conn.GetVersionInfo()
+			if err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+			if info.Raw != "0.20.1-Beta abc123" || !info.Compatible {
+				ttt.Fatalf("unexpected version info: %+v", info)
+			}
+			if err := conn.CheckServerVersion(); err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+		})
+	})
+
+	t.Run("Error cases", func(tt *testing.T) {
+		tt.Run("fails on a non-zero exit code", func(ttt *testing.T) {
+			conn := NewAeroSpaceCLIConnection(fakeCLI(ttt))
+
+			_, err := conn.SendCommand("fail", nil)
+			if err == nil || !strings.Contains(err.Error(), "exit code 2\nboom") {
+				ttt.Fatalf("expected the exit code and stderr, got %v", err)
+			}
+		})
+
+		tt.Run("kills the command when the context is done", func(ttt *testing.T) {
+			conn := NewAeroSpaceCLIConnection(fakeCLI(ttt))
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+
+			_, err := conn.SendCommandContext(ctx, "sleep", nil)
+			if !errors.Is(err, context.DeadlineExceeded) || !errors.Is(err, ErrTimeout) {
+				ttt.Fatalf("expected context.DeadlineExceeded and ErrTimeout, got %v", err)
+			}
+		})
+
+		tt.Run("fails when the binary is missing", func(ttt *testing.T) {
+			conn := NewAeroSpaceCLIConnection(filepath.Join(ttt.TempDir(), "missing"))
+
+			if _, err := conn.SendCommand("list-modes", nil); err == nil {
+				ttt.Fatal("expected an error")
+			}
+		})
+
+		tt.Run("rejects commands after close", func(ttt *testing.T) {
+			conn := NewAeroSpaceCLIConnection(fakeCLI(ttt))
+			if err := conn.CloseConnection(); err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+
+			if _, err := conn.SendCommand("list-modes", nil); !errors.Is(err, ErrClosed) {
+				ttt.Fatalf("expected ErrClosed, got %v", err)
+			}
+		})
+	})
+}
+
+func TestFallbackConnection(t *testing.T) {
+	t.Run("Happy path", func(tt *testing.T) {
+		tt.Run("falls back to the CLI when the server is unreachable", func(ttt *testing.T) {
+			primary := &unreachableConn{err: fmt.Errorf("failed to reconnect\n%w", ErrServerNotRunning)}
+			conn := NewFallbackConnection(primary, NewAeroSpaceCLIConnection(fakeCLI(ttt)))
+
+			response, err := conn.SendCommand("list-modes", nil)
+			if err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+			if response.StdOut != "main\n" {
+				ttt.Fatalf("expected stdout 'main\\n', got %q", response.StdOut)
+			}
+		})
+
+		tt.Run("runs the commands selected by UseCLI with the CLI", func(ttt *testing.T) {
+			primary := &unreachableConn{err: errors.New("unsupported flag")}
+			conn := NewFallbackConnection(primary, NewAeroSpaceCLIConnection(fakeCLI(ttt)))
+			conn.UseCLI = func(command string, args []string) bool {
+				return command == "list-modes"
+			}
+
+			if _, err := conn.SendCommand("list-modes", nil); err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+		})
+
+		tt.Run("connects with the CLI when the socket is unavailable", func(ttt *testing.T) {
+			connector := &AeroSpaceCLIFallbackConnector{
+				Connector: &failingConnector{err: ErrServerNotRunning},
+				Binary:    fakeCLI(ttt),
+			}
+
+			conn, err := connector.Connect()
+			if err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+			if _, ok := conn.(*AeroSpaceCLIConnection); !ok {
+				ttt.Fatalf("expected a CLI connection, got %T", conn)
+			}
+		})
+	})
+
+	t.Run("Error cases", func(tt *testing.T) {
+		tt.Run("does not fall back on command errors", func(ttt *testing.T) {
+			primary := &unreachableConn{err: errors.New("command failed with exit code 2")}
+			conn := NewFallbackConnection(primary, NewAeroSpaceCLIConnection(fakeCLI(ttt)))
+
+			_, err := conn.SendCommand("list-modes", nil)
+			if err != primary.err {
+				ttt.Fatalf("expected the primary error, got %v", err)
+			}
+		})
+
+		tt.Run("does not fall back when the connection broke", func(ttt *testing.T) {
+			primary := &unreachableConn{err: fmt.Errorf("failed to read response\n%w", io.EOF)}
+			conn := NewFallbackConnection(primary, NewAeroSpaceCLIConnection(fakeCLI(ttt)))
+
+			if _, err := conn.SendCommand("list-modes", nil); err != primary.err {
+				ttt.Fatalf("expected the primary error, got %v", err)
+			}
+			if _, err := conn.SendBatch(context.Background(), []Command{{Command: "list-modes"}}); err != primary.err {
+				ttt.Fatalf("expected the primary error, got %v", err)
+			}
+		})
+
+		tt.Run("fails to connect without the CLI binary", func(ttt *testing.T) {
+			connector := &AeroSpaceCLIFallbackConnector{
+				Connector: &failingConnector{err: ErrServerNotRunning},
+				Binary:    filepath.Join(ttt.TempDir(), "missing"),
+			}
+
+			_, err := connector.Connect()
+			if !errors.Is(err, ErrServerNotRunning) {
+				ttt.Fatalf("expected ErrServerNotRunning, got %v", err)
+			}
+		})
+	})
+}
@@ -0,0 +1,50 @@
+// Package commands lists the names of the AeroSpace commands.
+//
+// The constants are untyped, so they can be passed anywhere a command name is
+// expected instead of a string literal.
+//
+// Usage:
+//
+//	response, err := conn.SendCommand(commands.ListModes, []string{"--current"})
+//
+// See https://nikitabobko.github.io/AeroSpace/commands for what each command does.
+package commands
+
+const (
+	BalanceSizes              = "balance-sizes"
+	Close                     = "close"
+	CloseAllWindowsButCurrent = "close-all-windows-but-current"
+	Config                    = "config"
+	DebugWindows              = "debug-windows"
+	Enable                    = "enable"
+	FlattenWorkspaceTree      = "flatten-workspace-tree"
+	Focus                     = "focus"
+	FocusBackAndForth         = "focus-back-and-forth"
+	FocusMonitor              = "focus-monitor"
+	Fullscreen                = "fullscreen"
+	JoinWith                  = "join-with"
+	Layout                    = "layout"
+	ListApps                  = "list-apps"
+	ListExecEnvVars           = "list-exec-env-vars"
+	ListModes                 = "list-modes"
+	ListMonitors              = "list-monitors"
+	ListWindows               = "list-windows"
+	ListWorkspaces            = "list-workspaces"
+	MacosNativeFullscreen     = "macos-native-fullscreen"
+	MacosNativeMinimize       = "macos-native-minimize"
+	Mode                      = "mode"
+	Move                      = "move"
+	MoveMouse                 = "move-mouse"
+	MoveNodeToMonitor         = "move-node-to-monitor"
+	MoveNodeToWorkspace       = "move-node-to-workspace"
+	MoveWorkspaceToMonitor    = "move-workspace-to-monitor"
+	ReloadConfig              = "reload-config"
+	Resize                    = "resize"
+	Split                     = "split"
+	SummonWorkspace           = "summon-workspace"
+	Swap                      = "swap"
+	TriggerBinding            = "trigger-binding"
+	Volume                    = "volume"
+	Workspace                 = "workspace"
+	WorkspaceBackAndForth     = "workspace-back-and-forth"
+)
@@ -1,7 +1,9 @@
 package client
 
 import (
+	"errors"
 	"fmt"
+	"slices"
 
 	"github.com/cristianoliveira/aerospace-ipc/internal/socket"
 )
@@ -21,15 +23,22 @@ type AeroSpaceConnector interface {
 // AeroSpaceDefaultConnector is the default implementation of AeroSpaceConnector.
 //
 // In most cases, you will use this connector to connect to the AeroSpace socket.
-type AeroSpaceDefaultConnector struct{}
+type AeroSpaceDefaultConnector struct {
+	// Options customize the created connection, e.g. WithDialTimeout.
+	Options []Option
+}
 
 func (c *AeroSpaceDefaultConnector) Connect() (AeroSpaceConnection, error) {
 	socketPath, err := socket.GetSocketPath()
 	if err != nil {
-		return nil, fmt.Errorf("failed to get socket path\n %w", err)
+		// With WithAutoStart, the connection launches AeroSpace on the default socket path.
+		if !errors.Is(err, ErrServerNotRunning) || !autoStartEnabled(c.Options) {
+			return nil, fmt.Errorf("failed to get socket path\n %w", err)
+		}
+		socketPath = socket.DefaultSocketPath()
 	}
 
-	client, err := NewAeroSpaceSocketConnection(socketPath)
+	client, err := NewAeroSpaceSocketConnection(socketPath, c.Options...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to creat socket connection\n%w", err)
 	}
@@ -43,6 +52,9 @@ func (c *AeroSpaceDefaultConnector) Connect() (AeroSpaceConnection, error) {
 type AeroSpaceCustomConnector struct {
 	// SocketPath is the custom socket path for the AeroSpace connection.
 	SocketPath string
+
+	// Options customize the created connection, e.g. WithCommandTimeout.
+	Options []Option
 }
 
 // Connect establishes a connection to the AeroSpace socket and validates the server version
@@ -54,7 +66,7 @@ func (c *AeroSpaceCustomConnector) Connect() (AeroSpaceConnection, error) {
 		return nil, fmt.Errorf("socket path cannot be empty")
 	}
 
-	client, err := NewAeroSpaceSocketConnection(c.SocketPath)
+	client, err := NewAeroSpaceSocketConnection(c.SocketPath, c.Options...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create a socket connection\n%w", err)
 	}
@@ -66,6 +78,37 @@ func (c *AeroSpaceCustomConnector) Connect() (AeroSpaceConnection, error) {
 	return client, nil
 }
 
+// DiscoverSocketPaths returns the live AeroSpace socket paths, most relevant first.
+//
+// If the environment variable AEROSPACESOCK is set, it is the only candidate.
+// Otherwise the candidates are the default socket path of the current user
+// followed by any other /tmp/bobko.aerospace-*.sock. Stale socket files are skipped.
+//
+// The other sockets may belong to other users, so the default connector
+// never uses them. Pick one explicitly, e.g. with WithSocketPath.
+//
+// Returns an error listing every candidate and why it was rejected if none is live.
+//
+// Usage:
+//
+//	socketPaths, err := client.DiscoverSocketPaths()
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	conn, err := client.NewAeroSpaceSocketConnection(socketPaths[0])
+func DiscoverSocketPaths() ([]string, error) {
+	return socket.DiscoverSocketPaths()
+}
+
+// autoStartEnabled reports whether opts enable WithAutoStart.
+func autoStartEnabled(opts []Option) bool {
+	probe := &AeroSpaceSocketConnection{}
+	for _, opt := range opts {
+		opt(probe)
+	}
+	return probe.autoStart
+}
+
 var defaultConnector AeroSpaceConnector = &AeroSpaceDefaultConnector{}
 
 // SetDefaultConnector sets the default AeroSpaceConnector.
@@ -86,3 +129,45 @@ func GetDefaultConnector() AeroSpaceConnector {
 	}
 	return defaultConnector
 }
+
+// ConfigureConnector returns a copy of connector adding opts to the socket connections it creates.
+//
+// It supports AeroSpaceDefaultConnector, AeroSpaceCustomConnector and AeroSpaceCLIFallbackConnector,
+// and returns an error for the other connectors, e.g. a FakeConnection, instead of ignoring opts.
+//
+// Usage:
+//
+//	connector, err := client.ConfigureConnector(client.GetDefaultConnector(), client.WithDialTimeout(time.Second))
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	conn, err := connector.Connect()
+func ConfigureConnector(connector AeroSpaceConnector, opts ...Option) (AeroSpaceConnector, error) {
+	if len(opts) == 0 {
+		return connector, nil
+	}
+
+	switch c := connector.(type) {
+	case *AeroSpaceDefaultConnector:
+		configured := *c
+		configured.Options = append(slices.Clone(c.Options), opts...)
+		return &configured, nil
+	case *AeroSpaceCustomConnector:
+		configured := *c
+		configured.Options = append(slices.Clone(c.Options), opts...)
+		return &configured, nil
+	case *AeroSpaceCLIFallbackConnector:
+		inner := c.Connector
+		if inner == nil {
+			inner = &AeroSpaceDefaultConnector{}
+		}
+		inner, err := ConfigureConnector(inner, opts...)
+		if err != nil {
+			return nil, err
+		}
+		configured := *c
+		configured.Connector = inner
+		return &configured, nil
+	}
+	return nil, fmt.Errorf("connection options cannot be used with the connector %T", connector)
+}
@@ -0,0 +1,179 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/cristianoliveira/aerospace-ipc/internal/exceptions"
+)
+
+// ErrServerNotRunning is returned when no AeroSpace server is listening on the socket,
+// because the socket file is missing or refuses connections.
+//
+// Usage:
+//
+//	conn, err := client.NewAeroSpaceSocketConnection(socketPath)
+//	if errors.Is(err, client.ErrServerNotRunning) {
+//	    fmt.Println("Start AeroSpace first")
+//	}
+var ErrServerNotRunning = exceptions.ErrServerNotRunning
+
+// ErrClosing is returned for commands sent once CloseConnection has been called,
+// and for the in-flight command interrupted when the close timeout passes.
+var ErrClosing = errors.New("connection is closing")
+
+// ErrClosed is returned for commands sent after CloseConnection has closed the connection,
+// so use-after-close programming errors can be told apart from transport failures.
+//
+// Usage:
+//
+//	_, err := conn.SendCommand("list-modes", []string{"--current"})
+//	if errors.Is(err, client.ErrClosed) {
+//	    panic("connection used after close")
+//	}
+var ErrClosed = errors.New("connection is closed")
+
+// ErrRateLimited is returned by a RateLimitedConnection configured to drop
+// the commands sent over its rate limit.
+var ErrRateLimited = errors.New("rate limit exceeded")
+
+// ErrTimeout matches the errors of commands whose socket reads or writes hit a deadline,
+// set by WithCommandTimeout or by the context, so callers can tell them apart
+// from protocol errors and retry.
+//
+// Usage:
+//
+//	_, err := conn.SendCommand("list-windows", []string{"--all", "--json"})
+//	if errors.Is(err, client.ErrTimeout) {
+//	    // AeroSpace is busy, try again later
+//	}
+var ErrTimeout = errors.New("timeout")
+
+// timeoutError marks err as a timeout for errors.Is(err, ErrTimeout), keeping its message.
+type timeoutError struct {
+	err error
+}
+
+func (e *timeoutError) Error() string {
+	return e.err.Error()
+}
+
+func (e *timeoutError) Unwrap() error {
+	return e.err
+}
+
+func (e *timeoutError) Is(target error) bool {
+	return target == ErrTimeout
+}
+
+// isTimeout reports whether err is a deadline hit by a socket read or write.
+func isTimeout(err error) bool {
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// TimeoutError is returned when a command does not complete within
+// the timeout configured with WithCommandTimeout.
+//
+// Usage:
+//
+//	var timeoutErr *client.TimeoutError
+//	if errors.As(err, &timeoutErr) {
+//	    fmt.Printf("%s took longer than %s\n", timeoutErr.Command, timeoutErr.Timeout)
+//	}
+type TimeoutError struct {
+	// Command is the command that timed out.
+	Command string
+
+	// Timeout is the configured command timeout.
+	Timeout time.Duration
+}
+
+func (e *TimeoutError) Error() string {
+	return fmt.Sprintf("command %s timed out after %s", e.Command, e.Timeout)
+}
+
+// Unwrap returns context.DeadlineExceeded, so timeouts can be handled
+// the same way as expired context deadlines.
+func (e *TimeoutError) Unwrap() error {
+	return context.DeadlineExceeded
+}
+
+// Is reports whether target is ErrTimeout.
+func (e *TimeoutError) Is(target error) bool {
+	return target == ErrTimeout
+}
+
+// CommandError is returned when AeroSpace runs a command that fails,
+// reporting a non-zero exit code, or writing to stderr under StderrError.
+//
+// Usage:
+//
+//	var cmdErr *client.CommandError
+//	if errors.As(err, &cmdErr) && cmdErr.ExitCode == 2 {
+//	    fmt.Println("AeroSpace says:", cmdErr.StdErr)
+//	}
+type CommandError struct {
+	// Command is the command that failed.
+	Command string
+	// Args are the arguments the command was sent with.
+	Args []string
+
+	// ExitCode is the exit code reported by AeroSpace.
+	ExitCode int32
+	// StdErr is the standard error of the command.
+	StdErr string
+	// StdOut is the standard output of the command.
+	StdOut string
+}
+
+func (e *CommandError) Error() string {
+	if e.ExitCode != 0 {
+		return fmt.Sprintf("command failed with exit code %d\n%s", e.ExitCode, e.StdErr)
+	}
+	return fmt.Sprintf("command error\n%s", e.StdErr)
+}
+
+// commandIDs generates the correlation IDs of the commands, unique within the process.
+var commandIDs atomic.Uint64
+
+// correlatedError annotates the error of a command with its correlation ID, name and args.
+type correlatedError struct {
+	id      uint64
+	command string
+	args    []string
+	err     error
+}
+
+func (e *correlatedError) Error() string {
+	return fmt.Sprintf("command #%d (%s)\n%s", e.id, strings.Join(append([]string{e.command}, e.args...), " "), e.err)
+}
+
+func (e *correlatedError) Unwrap() error {
+	return e.err
+}
+
+// CommandID returns the correlation ID of the command that failed with err,
+// so failures of concurrent commands can be matched with the "id" attribute
+// of the debug logs.
+//
+// Returns false if err does not come from SendCommand or SendCommandContext.
+//
+// Usage:
+//
+//	_, err := conn.SendCommand("list-windows", []string{"--all", "--json"})
+//	if id, ok := client.CommandID(err); ok {
+//	    log.Printf("command #%d failed: %v", id, err)
+//	}
+func CommandID(err error) (uint64, bool) {
+	var correlated *correlatedError
+	if errors.As(err, &correlated) {
+		return correlated.id, true
+	}
+	return 0, false
+}
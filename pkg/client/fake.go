@@ -0,0 +1,879 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cristianoliveira/aerospace-ipc/internal/constants"
+	"github.com/cristianoliveira/aerospace-ipc/pkg/client/commands"
+)
+
+// FakeWindow is a window in the state of a FakeConnection.
+type FakeWindow struct {
+	ID          int
+	Title       string
+	AppName     string
+	AppBundleID string
+	AppPID      int
+
+	// Workspace is the name of the workspace holding the window.
+	Workspace string
+
+	// Layout is the window layout, e.g. "floating". Defaults to "h_tiles".
+	Layout string
+}
+
+// FakeWorkspace is a workspace in the state of a FakeConnection.
+type FakeWorkspace struct {
+	Name string
+
+	// MonitorID is the ID of the monitor the workspace is assigned to.
+	// Defaults to the first monitor.
+	MonitorID int
+
+	// Visible marks the workspace shown on its monitor. The focused workspace is
+	// always visible, and so is the first workspace of a monitor showing none.
+	Visible bool
+}
+
+// FakeMonitor is a monitor in the state of a FakeConnection.
+type FakeMonitor struct {
+	// ID is the 1-based sequential number of the monitor.
+	ID   int
+	Name string
+}
+
+// FakeState seeds a FakeConnection.
+//
+// Workspaces referenced by windows are created if missing, and a single
+// "Built-in Retina Display" monitor is used if none is given.
+type FakeState struct {
+	Windows    []FakeWindow
+	Workspaces []FakeWorkspace
+	Monitors   []FakeMonitor
+
+	// FocusedWindowID is the ID of the focused window, or 0 for none.
+	FocusedWindowID int
+
+	// FocusedWorkspace is the name of the focused workspace.
+	// Defaults to the workspace of the focused window, or the first workspace.
+	FocusedWorkspace string
+
+	// Mode is the current binding mode. Defaults to "main".
+	Mode string
+
+	// ServerVersion is the reported server version. Defaults to a version
+	// compatible with this client.
+	ServerVersion string
+}
+
+// FakeConnection is an in-memory AeroSpaceConnection, so downstream projects
+// can run their tests where AeroSpace is not available, e.g. on Linux CI.
+//
+// It answers the commands used by the services from a FakeState and
+// updates it as AeroSpace would:
+//
+//	list-windows, list-workspaces, list-monitors, list-modes, config --config-path,
+//	focus, workspace, workspace-back-and-forth, move-node-to-workspace, close
+//
+// Other commands fail as unknown. Windows have no geometry, so focus directions
+// follow the window order: left and right behave as dfs-prev and dfs-next,
+// and up and down never move the focus.
+type FakeConnection struct {
+	mu                sync.Mutex
+	state             FakeState
+	previousWorkspace string
+	lastFocused       map[string]int
+	sent              []Command
+	closed            bool
+}
+
+// NewFakeConnection returns a FakeConnection seeded with state.
+//
+// Usage:
+//
+//	conn := client.NewFakeConnection(client.FakeState{
+//	    Windows: []client.FakeWindow{
+//	        {ID: 1, AppName: "Ghostty", Title: "zsh", Workspace: "1"},
+//	        {ID: 2, AppName: "Safari", Title: "GitHub", Workspace: "2"},
+//	    },
+//	    FocusedWindowID: 1,
+//	})
+//	windowsService := windows.NewService(conn)
+func NewFakeConnection(state FakeState) *FakeConnection {
+	f := &FakeConnection{
+		state:       cloneFakeState(state),
+		lastFocused: map[string]int{},
+	}
+	f.normalize()
+	return f
+}
+
+// Connect returns f, so a FakeConnection can be used as the default connector.
+//
+// Usage:
+//
+//	client.SetDefaultConnector(client.NewFakeConnection(state))
+//	aerospaceClient, err := aerospace.NewClient()
+func (f *FakeConnection) Connect() (AeroSpaceConnection, error) {
+	return f, nil
+}
+
+// State returns a copy of the current state, e.g. to assert on the effect of commands.
+func (f *FakeConnection) State() FakeState {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return cloneFakeState(f.state)
+}
+
+// Sent returns the commands sent so far, in order.
+// As on the wire, the Args of every command start with the command name.
+func (f *FakeConnection) Sent() []Command {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return slices.Clone(f.sent)
+}
+
+// CloseConnection closes the fake connection.
+//
+// It returns ErrClosed if the connection was already closed.
+func (f *FakeConnection) CloseConnection() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.closed {
+		return ErrClosed
+	}
+	f.closed = true
+	return nil
+}
+
+// SendCommand answers the command from the fake state.
+func (f *FakeConnection) SendCommand(command string, args []string) (*Response, error) {
+	return f.SendCommandContext(context.Background(), command, args)
+}
+
+// SendCommandContext answers the command from the fake state, unless ctx is already done.
+func (f *FakeConnection) SendCommandContext(ctx context.Context, command string, args []string) (*Response, error) {
+	return f.SendCommandWithStdin(ctx, command, args, "")
+}
+
+// SendCommandWithStdin answers the command from the fake state, recording stdin
+// with the sent command. The fake state does not depend on stdin.
+func (f *FakeConnection) SendCommandWithStdin(ctx context.Context, command string, args []string, stdin string) (*Response, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("failed to send command\n%w", context.Cause(ctx))
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.closed {
+		return nil, ErrClosed
+	}
+	f.sent = append(f.sent, Command{Args: append([]string{command}, args...), Stdin: stdin})
+
+	stdout, err := f.handle(command, parseFakeArgs(args))
+	response := &Response{
+		ServerVersion: f.state.ServerVersion,
+		StdOut:        stdout,
+	}
+	if err != nil {
+		response.ExitCode = 1
+		response.StdErr = err.Error()
+	}
+	return checkResponse(command, args, response, StderrWarn)
+}
+
+// SendBatch answers the commands one after the other, stopping at the first failure.
+func (f *FakeConnection) SendBatch(ctx context.Context, commands []Command) ([]*Response, error) {
+	return sendEach(ctx, f, commands)
+}
+
+// SendCommandAsync answers the command in the background and delivers its outcome on the returned channel.
+func (f *FakeConnection) SendCommandAsync(ctx context.Context, command string, args []string) <-chan Result {
+	// Buffered, so the result can be dropped without leaking the goroutine.
+	result := make(chan Result, 1)
+	go func() {
+		defer close(result)
+		response, err := f.SendCommandContext(ctx, command, args)
+		result <- Result{Response: response, Err: err}
+	}()
+	return result
+}
+
+// Ping answers like a live server.
+func (f *FakeConnection) Ping(ctx context.Context) (time.Duration, error) {
+	start := time.Now()
+	_, err := f.SendCommandContext(ctx, commands.Config, []string{"--config-path"})
+	if err != nil {
+		return 0, fmt.Errorf("failed to ping server\n%w", err)
+	}
+
+	return time.Since(start), nil
+}
+
+// GetSocketPath returns an error, the fake connection does not use a socket.
+func (f *FakeConnection) GetSocketPath() (string, error) {
+	return "", fmt.Errorf("missing socket path\nthe fake connection does not use a socket")
+}
+
+// GetServerVersion returns the server version of the fake state.
+func (f *FakeConnection) GetServerVersion() (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.state.ServerVersion, nil
+}
+
+// GetVersionInfo parses the server version of the fake state.
+func (f *FakeConnection) GetVersionInfo() (*VersionInfo, error) {
+	serverVersion, _ := f.GetServerVersion()
+	return negotiateVersion(
+		slog.Default(),
+		serverVersion,
+		constants.AeroSpaceSocketClientMajor,
+		constants.AeroSpaceSocketClientMinor,
+	)
+}
+
+// CheckServerVersion checks the server version of the fake state.
+func (f *FakeConnection) CheckServerVersion() error {
+	info, err := f.GetVersionInfo()
+	if err != nil {
+		return err
+	}
+
+	return checkVersion(info, constants.AeroSpaceSocketClientMajor, constants.AeroSpaceSocketClientMinor)
+}
+
+// normalize fills the defaults of the state and makes it consistent.
+func (f *FakeConnection) normalize() {
+	s := &f.state
+	if len(s.Monitors) == 0 {
+		s.Monitors = []FakeMonitor{{ID: 1, Name: "Built-in Retina Display"}}
+	}
+	if s.Mode == "" {
+		s.Mode = "main"
+	}
+	if s.ServerVersion == "" {
+		s.ServerVersion = fmt.Sprintf(
+			"%d.%d.0-Beta fake",
+			constants.AeroSpaceSocketClientMajor,
+			constants.AeroSpaceSocketClientMinor,
+		)
+	}
+
+	for i := range s.Windows {
+		if s.Windows[i].Layout == "" {
+			s.Windows[i].Layout = "h_tiles"
+		}
+		f.ensureWorkspace(s.Windows[i].Workspace)
+	}
+	for i := range s.Workspaces {
+		if s.Workspaces[i].MonitorID == 0 {
+			s.Workspaces[i].MonitorID = s.Monitors[0].ID
+		}
+	}
+
+	if window := f.window(s.FocusedWindowID); window == nil {
+		s.FocusedWindowID = 0
+	} else if s.FocusedWorkspace == "" {
+		s.FocusedWorkspace = window.Workspace
+	}
+	if s.FocusedWorkspace == "" && len(s.Workspaces) > 0 {
+		s.FocusedWorkspace = s.Workspaces[0].Name
+	}
+	if s.FocusedWorkspace != "" {
+		f.ensureWorkspace(s.FocusedWorkspace)
+		f.show(s.FocusedWorkspace)
+	}
+
+	for _, monitor := range s.Monitors {
+		if !slices.ContainsFunc(s.Workspaces, func(w FakeWorkspace) bool { return w.MonitorID == monitor.ID && w.Visible }) {
+			if i := slices.IndexFunc(s.Workspaces, func(w FakeWorkspace) bool { return w.MonitorID == monitor.ID }); i >= 0 {
+				s.Workspaces[i].Visible = true
+			}
+		}
+	}
+}
+
+// handle runs command on the state and returns its standard output.
+func (f *FakeConnection) handle(command string, args fakeArgs) (string, error) {
+	switch command {
+	case commands.ListWindows:
+		return f.listWindows(args)
+	case commands.ListWorkspaces:
+		return f.listWorkspaces(args)
+	case commands.ListMonitors:
+		return f.listMonitors(args)
+	case commands.ListModes:
+		return f.state.Mode + "\n", nil
+	case commands.Config:
+		if !args.has("--config-path") {
+			return "", fmt.Errorf("the fake connection only supports config --config-path")
+		}
+		return "/fake/.aerospace.toml\n", nil
+	case commands.Focus:
+		return "", f.focus(args)
+	case commands.Workspace:
+		name, err := f.resolveWorkspace(args.positional, args.has("--wrap-around"))
+		if err != nil {
+			return "", err
+		}
+		f.focusWorkspace(name)
+		return "", nil
+	case commands.WorkspaceBackAndForth:
+		if f.previousWorkspace == "" {
+			return "", fmt.Errorf("no previous workspace")
+		}
+		f.focusWorkspace(f.previousWorkspace)
+		return "", nil
+	case commands.MoveNodeToWorkspace:
+		return "", f.moveNodeToWorkspace(args)
+	case commands.Close:
+		window, err := f.targetWindow(args)
+		if err != nil {
+			return "", err
+		}
+		f.removeWindow(window.ID)
+		return "", nil
+	}
+	return "", fmt.Errorf("unknown command '%s'", command)
+}
+
+func (f *FakeConnection) listWindows(args fakeArgs) (string, error) {
+	var windows []FakeWindow
+	for _, window := range f.state.Windows {
+		if f.matchesWindow(window, args) {
+			windows = append(windows, window)
+		}
+	}
+
+	if args.has("--count") {
+		return fmt.Sprintf("%d\n", len(windows)), nil
+	}
+	if !args.has("--json") {
+		var out strings.Builder
+		for _, window := range windows {
+			fmt.Fprintf(&out, "%d | %s | %s\n", window.ID, window.AppName, window.Title)
+		}
+		return out.String(), nil
+	}
+
+	type windowJSON struct {
+		WindowID                    int    `json:"window-id"`
+		WindowTitle                 string `json:"window-title"`
+		WindowLayout                string `json:"window-layout"`
+		WindowParentContainerLayout string `json:"window-parent-container-layout"`
+		AppName                     string `json:"app-name"`
+		AppBundleID                 string `json:"app-bundle-id"`
+		AppPID                      int    `json:"app-pid"`
+		Workspace                   string `json:"workspace"`
+		WorkspaceIsFocused          bool   `json:"workspace-is-focused"`
+		WorkspaceIsVisible          bool   `json:"workspace-is-visible"`
+		MonitorID                   int    `json:"monitor-id"`
+		MonitorName                 string `json:"monitor-name"`
+	}
+	out := []windowJSON{}
+	for _, window := range windows {
+		workspace := f.workspace(window.Workspace)
+		monitor := f.monitor(workspace.MonitorID)
+		out = append(out, windowJSON{
+			WindowID:                    window.ID,
+			WindowTitle:                 window.Title,
+			WindowLayout:                window.Layout,
+			WindowParentContainerLayout: "h_tiles",
+			AppName:                     window.AppName,
+			AppBundleID:                 window.AppBundleID,
+			AppPID:                      window.AppPID,
+			Workspace:                   window.Workspace,
+			WorkspaceIsFocused:          window.Workspace == f.state.FocusedWorkspace,
+			WorkspaceIsVisible:          workspace.Visible,
+			MonitorID:                   monitor.ID,
+			MonitorName:                 monitor.Name,
+		})
+	}
+	return marshalFake(out)
+}
+
+func (f *FakeConnection) matchesWindow(window FakeWindow, args fakeArgs) bool {
+	if args.has("--focused") {
+		return window.ID == f.state.FocusedWindowID
+	}
+	if monitors, ok := args.flags["--monitor"]; ok && !f.matchesMonitor(f.workspace(window.Workspace).MonitorID, monitors) {
+		return false
+	}
+	if workspaces, ok := args.flags["--workspace"]; ok && !f.matchesWorkspace(window.Workspace, workspaces) {
+		return false
+	}
+	if bundleID, ok := args.value("--app-bundle-id"); ok && window.AppBundleID != bundleID {
+		return false
+	}
+	if pid, ok := args.value("--pid"); ok && strconv.Itoa(window.AppPID) != pid {
+		return false
+	}
+	return true
+}
+
+func (f *FakeConnection) listWorkspaces(args fakeArgs) (string, error) {
+	var workspaces []FakeWorkspace
+	for _, workspace := range f.state.Workspaces {
+		if f.matchesWorkspaceFilters(workspace, args) {
+			workspaces = append(workspaces, workspace)
+		}
+	}
+
+	if args.has("--count") {
+		return fmt.Sprintf("%d\n", len(workspaces)), nil
+	}
+	if !args.has("--json") {
+		var out strings.Builder
+		for _, workspace := range workspaces {
+			fmt.Fprintln(&out, workspace.Name)
+		}
+		return out.String(), nil
+	}
+
+	type workspaceJSON struct {
+		Workspace          string `json:"workspace"`
+		MonitorID          int    `json:"monitor-id"`
+		MonitorName        string `json:"monitor-name"`
+		IsVisible          bool   `json:"workspace-is-visible"`
+		IsFocused          bool   `json:"workspace-is-focused"`
+		IsEffectivelyEmpty bool   `json:"workspace-is-effectively-empty"`
+	}
+	out := []workspaceJSON{}
+	for _, workspace := range workspaces {
+		out = append(out, workspaceJSON{
+			Workspace:          workspace.Name,
+			MonitorID:          workspace.MonitorID,
+			MonitorName:        f.monitor(workspace.MonitorID).Name,
+			IsVisible:          workspace.Visible,
+			IsFocused:          workspace.Name == f.state.FocusedWorkspace,
+			IsEffectivelyEmpty: f.isEmpty(workspace.Name),
+		})
+	}
+	return marshalFake(out)
+}
+
+func (f *FakeConnection) matchesWorkspaceFilters(workspace FakeWorkspace, args fakeArgs) bool {
+	if args.has("--focused") {
+		return workspace.Name == f.state.FocusedWorkspace
+	}
+	if monitors, ok := args.flags["--monitor"]; ok && !f.matchesMonitor(workspace.MonitorID, monitors) {
+		return false
+	}
+	if visible, ok := args.bool("--visible"); ok && workspace.Visible != visible {
+		return false
+	}
+	if empty, ok := args.bool("--empty"); ok && f.isEmpty(workspace.Name) != empty {
+		return false
+	}
+	return true
+}
+
+func (f *FakeConnection) listMonitors(args fakeArgs) (string, error) {
+	focusedMonitorID := f.focusedMonitorID()
+
+	type monitorJSON struct {
+		MonitorID               int    `json:"monitor-id"`
+		MonitorName             string `json:"monitor-name"`
+		AppKitNSScreenScreensID int    `json:"monitor-appkit-nsscreen-screens-id"`
+	}
+	out := []monitorJSON{}
+	for _, monitor := range f.state.Monitors {
+		// The mouse is assumed to be on the focused monitor.
+		isFocused := monitor.ID == focusedMonitorID
+		if focused, ok := args.bool("--focused"); ok && isFocused != focused {
+			continue
+		}
+		if mouse, ok := args.bool("--mouse"); ok && isFocused != mouse {
+			continue
+		}
+		out = append(out, monitorJSON{
+			MonitorID:               monitor.ID,
+			MonitorName:             monitor.Name,
+			AppKitNSScreenScreensID: monitor.ID,
+		})
+	}
+
+	if !args.has("--json") {
+		var lines strings.Builder
+		for _, monitor := range out {
+			fmt.Fprintf(&lines, "%d | %s\n", monitor.MonitorID, monitor.MonitorName)
+		}
+		return lines.String(), nil
+	}
+	return marshalFake(out)
+}
+
+func (f *FakeConnection) focus(args fakeArgs) error {
+	if id, ok := args.value("--window-id"); ok {
+		window := f.windowByArg(id)
+		if window == nil {
+			return fmt.Errorf("window %s not found", id)
+		}
+		f.focusWindow(window)
+		return nil
+	}
+	if len(args.positional) == 0 {
+		return fmt.Errorf("focus requires a direction, dfs-next, dfs-prev or --window-id")
+	}
+
+	var step int
+	switch args.positional[0] {
+	case "left", "dfs-prev":
+		step = -1
+	case "right", "dfs-next":
+		step = 1
+	case "up", "down":
+		return nil
+	default:
+		return fmt.Errorf("unsupported focus target '%s'", args.positional[0])
+	}
+
+	ids := f.workspaceWindowIDs(f.state.FocusedWorkspace)
+	current := slices.Index(ids, f.state.FocusedWindowID)
+	if current < 0 {
+		return nil
+	}
+	next := current + step
+	if next < 0 || next >= len(ids) {
+		action, _ := args.value("--boundaries-action")
+		if action != "wrap-around-the-workspace" {
+			return nil
+		}
+		next = (next + len(ids)) % len(ids)
+	}
+	f.state.FocusedWindowID = ids[next]
+	return nil
+}
+
+func (f *FakeConnection) moveNodeToWorkspace(args fakeArgs) error {
+	window, err := f.targetWindow(args)
+	if err != nil {
+		return err
+	}
+	target, err := f.resolveWorkspace(args.positional, args.has("--wrap-around"))
+	if err != nil {
+		return err
+	}
+	if window.Workspace == target {
+		if args.has("--fail-if-noop") {
+			return fmt.Errorf("window is already in workspace %s", target)
+		}
+		return nil
+	}
+
+	source := window.Workspace
+	f.ensureWorkspace(target)
+	window.Workspace = target
+	if args.has("--focus-follows-window") {
+		f.focusWindow(window)
+		return nil
+	}
+	if window.ID == f.state.FocusedWindowID {
+		f.state.FocusedWindowID = first(f.workspaceWindowIDs(source))
+	}
+	return nil
+}
+
+// targetWindow returns the window given by --window-id, or the focused window.
+func (f *FakeConnection) targetWindow(args fakeArgs) (*FakeWindow, error) {
+	if id, ok := args.value("--window-id"); ok {
+		window := f.windowByArg(id)
+		if window == nil {
+			return nil, fmt.Errorf("window %s not found", id)
+		}
+		return window, nil
+	}
+
+	window := f.window(f.state.FocusedWindowID)
+	if window == nil {
+		return nil, fmt.Errorf("no window is focused")
+	}
+	return window, nil
+}
+
+// resolveWorkspace returns the workspace named by the first positional argument,
+// resolving next and prev among the workspaces sorted by name.
+func (f *FakeConnection) resolveWorkspace(positional []string, wrapAround bool) (string, error) {
+	if len(positional) == 0 {
+		return "", fmt.Errorf("missing workspace name")
+	}
+
+	name := positional[0]
+	if name != "next" && name != "prev" {
+		return name, nil
+	}
+
+	names := make([]string, 0, len(f.state.Workspaces))
+	for _, workspace := range f.state.Workspaces {
+		names = append(names, workspace.Name)
+	}
+	slices.Sort(names)
+
+	current := slices.Index(names, f.state.FocusedWorkspace)
+	next := current + 1
+	if name == "prev" {
+		next = current - 1
+	}
+	if next < 0 || next >= len(names) {
+		if !wrapAround {
+			return "", fmt.Errorf("no %s workspace", name)
+		}
+		next = (next + len(names)) % len(names)
+	}
+	return names[next], nil
+}
+
+// focusWindow focuses window and its workspace.
+func (f *FakeConnection) focusWindow(window *FakeWindow) {
+	f.focusWorkspace(window.Workspace)
+	f.state.FocusedWindowID = window.ID
+}
+
+// focusWorkspace focuses the workspace name, creating it if needed,
+// and the window last focused in it.
+func (f *FakeConnection) focusWorkspace(name string) {
+	if name == f.state.FocusedWorkspace {
+		return
+	}
+	f.lastFocused[f.state.FocusedWorkspace] = f.state.FocusedWindowID
+	f.previousWorkspace = f.state.FocusedWorkspace
+
+	f.ensureWorkspace(name)
+	f.show(name)
+	f.state.FocusedWorkspace = name
+
+	ids := f.workspaceWindowIDs(name)
+	if last := f.lastFocused[name]; slices.Contains(ids, last) {
+		f.state.FocusedWindowID = last
+	} else {
+		f.state.FocusedWindowID = first(ids)
+	}
+}
+
+// show makes the workspace name the visible one on its monitor.
+func (f *FakeConnection) show(name string) {
+	monitorID := f.workspace(name).MonitorID
+	for i := range f.state.Workspaces {
+		if f.state.Workspaces[i].MonitorID == monitorID {
+			f.state.Workspaces[i].Visible = f.state.Workspaces[i].Name == name
+		}
+	}
+}
+
+// ensureWorkspace creates the workspace name on the focused monitor if it does not exist.
+func (f *FakeConnection) ensureWorkspace(name string) {
+	if name == "" || slices.ContainsFunc(f.state.Workspaces, func(w FakeWorkspace) bool { return w.Name == name }) {
+		return
+	}
+
+	monitorID := f.focusedMonitorID()
+	if monitorID == 0 && len(f.state.Monitors) > 0 {
+		monitorID = f.state.Monitors[0].ID
+	}
+	f.state.Workspaces = append(f.state.Workspaces, FakeWorkspace{Name: name, MonitorID: monitorID})
+}
+
+func (f *FakeConnection) removeWindow(id int) {
+	i := slices.IndexFunc(f.state.Windows, func(w FakeWindow) bool { return w.ID == id })
+	workspace := f.state.Windows[i].Workspace
+	f.state.Windows = slices.Delete(f.state.Windows, i, i+1)
+	if id == f.state.FocusedWindowID {
+		f.state.FocusedWindowID = first(f.workspaceWindowIDs(workspace))
+	}
+}
+
+func (f *FakeConnection) matchesMonitor(monitorID int, monitors []string) bool {
+	focusedMonitorID := f.focusedMonitorID()
+	for _, monitor := range monitors {
+		switch monitor {
+		case "all":
+			return true
+		case "focused", "mouse":
+			if monitorID == focusedMonitorID {
+				return true
+			}
+		default:
+			if strconv.Itoa(monitorID) == monitor {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (f *FakeConnection) matchesWorkspace(name string, workspaces []string) bool {
+	for _, workspace := range workspaces {
+		switch workspace {
+		case "focused":
+			if name == f.state.FocusedWorkspace {
+				return true
+			}
+		case "visible":
+			if f.workspace(name).Visible {
+				return true
+			}
+		default:
+			if name == workspace {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// focusedMonitorID returns the ID of the monitor of the focused workspace, or 0 if there is none.
+func (f *FakeConnection) focusedMonitorID() int {
+	if focused := f.workspace(f.state.FocusedWorkspace); focused != nil {
+		return focused.MonitorID
+	}
+	return 0
+}
+
+func (f *FakeConnection) isEmpty(workspace string) bool {
+	return len(f.workspaceWindowIDs(workspace)) == 0
+}
+
+func (f *FakeConnection) workspaceWindowIDs(workspace string) []int {
+	var ids []int
+	for _, window := range f.state.Windows {
+		if window.Workspace == workspace {
+			ids = append(ids, window.ID)
+		}
+	}
+	return ids
+}
+
+func (f *FakeConnection) window(id int) *FakeWindow {
+	for i := range f.state.Windows {
+		if f.state.Windows[i].ID == id {
+			return &f.state.Windows[i]
+		}
+	}
+	return nil
+}
+
+func (f *FakeConnection) windowByArg(id string) *FakeWindow {
+	windowID, err := strconv.Atoi(id)
+	if err != nil {
+		return nil
+	}
+	return f.window(windowID)
+}
+
+func (f *FakeConnection) workspace(name string) *FakeWorkspace {
+	for i := range f.state.Workspaces {
+		if f.state.Workspaces[i].Name == name {
+			return &f.state.Workspaces[i]
+		}
+	}
+	return nil
+}
+
+func (f *FakeConnection) monitor(id int) FakeMonitor {
+	for _, monitor := range f.state.Monitors {
+		if monitor.ID == id {
+			return monitor
+		}
+	}
+	return FakeMonitor{ID: id}
+}
+
+// fakeArgs are the parsed arguments of a command sent to a FakeConnection.
+type fakeArgs struct {
+	positional []string
+	flags      map[string][]string
+}
+
+// fakeListFlags take every following argument up to the next flag.
+var fakeListFlags = []string{"--monitor", "--workspace"}
+
+// fakeValueFlags take the following argument.
+var fakeValueFlags = []string{
+	"--window-id", "--pid", "--app-bundle-id", "--format",
+	"--dfs-index", "--boundaries", "--boundaries-action",
+}
+
+// parseFakeArgs parses args the way the AeroSpace CLI does. Boolean flags
+// may be followed by "yes" or "no", e.g. --visible no.
+func parseFakeArgs(args []string) fakeArgs {
+	parsed := fakeArgs{flags: map[string][]string{}}
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if !strings.HasPrefix(arg, "--") {
+			parsed.positional = append(parsed.positional, arg)
+			continue
+		}
+
+		values := []string{}
+		switch {
+		case slices.Contains(fakeListFlags, arg):
+			for i+1 < len(args) && !strings.HasPrefix(args[i+1], "--") {
+				i++
+				values = append(values, args[i])
+			}
+		case slices.Contains(fakeValueFlags, arg):
+			if i+1 < len(args) {
+				i++
+				values = append(values, args[i])
+			}
+		case i+1 < len(args) && (args[i+1] == "yes" || args[i+1] == "no"):
+			i++
+			values = append(values, args[i])
+		}
+		parsed.flags[arg] = values
+	}
+	return parsed
+}
+
+func (a fakeArgs) has(flag string) bool {
+	_, ok := a.flags[flag]
+	return ok
+}
+
+func (a fakeArgs) value(flag string) (string, bool) {
+	values, ok := a.flags[flag]
+	if !ok || len(values) == 0 {
+		return "", false
+	}
+	return values[0], true
+}
+
+// bool returns the value of a boolean flag, true unless followed by "no".
+func (a fakeArgs) bool(flag string) (bool, bool) {
+	values, ok := a.flags[flag]
+	if !ok {
+		return false, false
+	}
+	return len(values) == 0 || values[0] != "no", true
+}
+
+func marshalFake(v any) (string, error) {
+	out, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(out) + "\n", nil
+}
+
+func cloneFakeState(state FakeState) FakeState {
+	state.Windows = slices.Clone(state.Windows)
+	state.Workspaces = slices.Clone(state.Workspaces)
+	state.Monitors = slices.Clone(state.Monitors)
+	return state
+}
+
+func first(ids []int) int {
+	if len(ids) == 0 {
+		return 0
+	}
+	return ids[0]
+}
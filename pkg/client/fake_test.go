@@ -0,0 +1,175 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func newTestFakeConnection() *FakeConnection {
+	return NewFakeConnection(FakeState{
+		Windows: []FakeWindow{
+			{ID: 1, AppName: "Ghostty", AppBundleID: "com.mitchellh.ghostty", Title: "zsh", Workspace: "1"},
+			{ID: 2, AppName: "Safari", AppBundleID: "com.apple.Safari", Title: "GitHub", Workspace: "1"},
+			{ID: 3, AppName: "Slack", AppBundleID: "com.tinyspeck.slackmacgap", Title: "general", Workspace: "2"},
+		},
+		Workspaces:      []FakeWorkspace{{Name: "1"}, {Name: "2"}, {Name: "3"}},
+		FocusedWindowID: 1,
+	})
+}
+
+// fakeWindowIDs sends list-windows with args and returns the listed window IDs.
+func fakeWindowIDs(t *testing.T, conn *FakeConnection, args ...string) []int {
+	t.Helper()
+	response, err := conn.SendCommand("list-windows", append(args, "--json"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var windows []struct {
+		WindowID int `json:"window-id"`
+	}
+	if err := json.Unmarshal([]byte(response.StdOut), &windows); err != nil {
+		t.Fatalf("failed to unmarshal windows: %v", err)
+	}
+	ids := []int{}
+	for _, window := range windows {
+		ids = append(ids, window.WindowID)
+	}
+	return ids
+}
+
+func TestFakeConnection(t *testing.T) {
+	t.Run("Happy path", func(tt *testing.T) {
+		tt.Run("lists windows with filters", func(ttt *testing.T) {
+			conn := newTestFakeConnection()
+
+			testCases := map[string]struct {
+				args     []string
+				expected []int
+			}{
+				"all":               {[]string{"--all"}, []int{1, 2, 3}},
+				"focused":           {[]string{"--focused"}, []int{1}},
+				"workspace":         {[]string{"--workspace", "2"}, []int{3}},
+				"focused workspace": {[]string{"--workspace", "focused"}, []int{1, 2}},
+				"bundle id":         {[]string{"--monitor", "all", "--app-bundle-id", "com.apple.Safari"}, []int{2}},
+			}
+			for name, tc := range testCases {
+				ids := fakeWindowIDs(ttt, conn, tc.args...)
+				if !slices.Equal(ids, tc.expected) {
+					ttt.Errorf("%s: expected %v, got %v", name, tc.expected, ids)
+				}
+			}
+
+			response, err := conn.SendCommand("list-windows", []string{"--workspace", "1", "--count"})
+			if err != nil || response.StdOut != "2\n" {
+				ttt.Fatalf("expected a count of 2, got %v (error: %v)", response, err)
+			}
+		})
+
+		tt.Run("lists workspaces with filters", func(ttt *testing.T) {
+			conn := newTestFakeConnection()
+
+			response, err := conn.SendCommand("list-workspaces", []string{"--monitor", "focused", "--empty", "no"})
+			if err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+			if response.StdOut != "1\n2\n" {
+				ttt.Fatalf("expected workspaces 1 and 2, got %q", response.StdOut)
+			}
+
+			response, err = conn.SendCommand("list-workspaces", []string{"--focused", "--json"})
+			if err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+			var workspaces []struct {
+				Workspace string `json:"workspace"`
+				IsFocused bool   `json:"workspace-is-focused"`
+				IsVisible bool   `json:"workspace-is-visible"`
+			}
+			if err := json.Unmarshal([]byte(response.StdOut), &workspaces); err != nil {
+				ttt.Fatalf("failed to unmarshal workspaces: %v", err)
+			}
+			if len(workspaces) != 1 || workspaces[0].Workspace != "1" || !workspaces[0].IsFocused || !workspaces[0].IsVisible {
+				ttt.Fatalf("unexpected focused workspace %+v", workspaces)
+			}
+		})
+
+		tt.Run("updates the state on commands", func(ttt *testing.T) {
+			conn := newTestFakeConnection()
+
+			commands := [][]string{
+				{"focus", "dfs-next"},
+				{"move-node-to-workspace", "3", "--focus-follows-window"},
+				{"workspace", "2"},
+				{"workspace-back-and-forth"},
+			}
+			for _, command := range commands {
+				if _, err := conn.SendCommand(command[0], command[1:]); err != nil {
+					ttt.Fatalf("%v: unexpected error: %v", command, err)
+				}
+			}
+
+			state := conn.State()
+			if state.FocusedWorkspace != "3" || state.FocusedWindowID != 2 {
+				ttt.Fatalf("expected window 2 focused on workspace 3, got %+v", state)
+			}
+			if ids := fakeWindowIDs(ttt, conn, "--workspace", "1"); len(ids) != 1 || ids[0] != 1 {
+				ttt.Fatalf("expected window 1 left on workspace 1, got %v", ids)
+			}
+			sent := conn.Sent()
+			if len(sent) != len(commands)+1 {
+				ttt.Fatalf("expected every command to be recorded, got %v", sent)
+			}
+			// Recorded as on the wire, the command name first.
+			if last := sent[len(commands)-1]; last.Command != "" || !slices.Equal(last.Args, []string{"workspace-back-and-forth"}) {
+				ttt.Fatalf("expected the wire shape of the last command sent, got %+v", last)
+			}
+		})
+
+		tt.Run("closes windows", func(ttt *testing.T) {
+			conn := newTestFakeConnection()
+
+			if _, err := conn.SendCommand("close", nil); err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+			state := conn.State()
+			if len(state.Windows) != 2 || state.FocusedWindowID != 2 {
+				ttt.Fatalf("expected window 1 closed and window 2 focused, got %+v", state)
+			}
+		})
+
+		tt.Run("reports a compatible server version", func(ttt *testing.T) {
+			if err := newTestFakeConnection().CheckServerVersion(); err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+		})
+	})
+
+	t.Run("Error cases", func(tt *testing.T) {
+		tt.Run("fails unknown commands", func(ttt *testing.T) {
+			_, err := newTestFakeConnection().SendCommand("resize", []string{"smart", "+50"})
+			if err == nil || !strings.Contains(err.Error(), "unknown command 'resize'") {
+				ttt.Fatalf("expected an unknown command error, got %v", err)
+			}
+		})
+
+		tt.Run("fails to focus a missing window", func(ttt *testing.T) {
+			_, err := newTestFakeConnection().SendCommand("focus", []string{"--window-id", "42"})
+			if err == nil || !strings.Contains(err.Error(), "window 42 not found") {
+				ttt.Fatalf("expected a not found error, got %v", err)
+			}
+		})
+
+		tt.Run("rejects commands after close", func(ttt *testing.T) {
+			conn := newTestFakeConnection()
+			_ = conn.CloseConnection()
+
+			if _, err := conn.SendCommand("list-modes", nil); !errors.Is(err, ErrClosed) {
+				ttt.Fatalf("expected ErrClosed, got %v", err)
+			}
+		})
+	})
+}
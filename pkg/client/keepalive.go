@@ -0,0 +1,164 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Health is the state of the AeroSpace server as seen by a KeepAlive.
+type Health int
+
+const (
+	// HealthUnknown is the state until the first ping completes.
+	HealthUnknown Health = iota
+	// HealthUp means the last ping succeeded.
+	HealthUp
+	// HealthDown means the last ping failed.
+	HealthDown
+)
+
+func (h Health) String() string {
+	switch h {
+	case HealthUnknown:
+		return "unknown"
+	case HealthUp:
+		return "up"
+	case HealthDown:
+		return "down"
+	}
+	return fmt.Sprintf("Health(%d)", int(h))
+}
+
+// HealthEvent describes a transition of the server health.
+type HealthEvent struct {
+	// Previous is the health before the transition.
+	Previous Health
+
+	// Current is the health after the transition.
+	Current Health
+
+	// Latency is the latency of the ping that succeeded, when Current is HealthUp.
+	Latency time.Duration
+
+	// Err is the error of the ping that failed, when Current is HealthDown.
+	Err error
+}
+
+// KeepAliveOpts configures a KeepAlive.
+type KeepAliveOpts struct {
+	// Interval is the time between two pings. Required.
+	Interval time.Duration
+
+	// Timeout bounds each ping. Defaults to Interval.
+	Timeout time.Duration
+
+	// OnChange is called, from the KeepAlive goroutine, on every health transition.
+	OnChange func(HealthEvent)
+}
+
+// KeepAlive pings the AeroSpace server at an interval in the background
+// and reports the transitions of its health.
+//
+// Pings go through the connection like any command, so a connection configured
+// with WithReconnect also recovers from AeroSpace restarts between two pings.
+type KeepAlive struct {
+	conn AeroSpaceConnection
+	opts KeepAliveOpts
+
+	mu     sync.Mutex
+	health Health
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// StartKeepAlive starts pinging the server on conn, immediately and then every opts.Interval,
+// until Stop is called.
+//
+// Usage:
+//
+//	keepAlive := client.StartKeepAlive(conn, client.KeepAliveOpts{
+//	    Interval: 5 * time.Second,
+//	    OnChange: func(event client.HealthEvent) {
+//	        log.Printf("AeroSpace is %s (%v)", event.Current, event.Err)
+//	    },
+//	})
+//	defer keepAlive.Stop()
+func StartKeepAlive(conn AeroSpaceConnection, opts KeepAliveOpts) *KeepAlive {
+	if conn == nil {
+		panic("ASSERTION: connection cannot be nil")
+	}
+	if opts.Interval <= 0 {
+		panic("ASSERTION: keep-alive interval must be positive")
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = opts.Interval
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	k := &KeepAlive{
+		conn:   conn,
+		opts:   opts,
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+	go k.run(ctx)
+	return k
+}
+
+// Health returns the health reported by the last ping.
+func (k *KeepAlive) Health() Health {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	return k.health
+}
+
+// Stop stops pinging the server and waits for the background goroutine to exit.
+// It does not close the connection.
+func (k *KeepAlive) Stop() {
+	k.cancel()
+	<-k.done
+}
+
+// run pings the server until ctx is done.
+func (k *KeepAlive) run(ctx context.Context) {
+	defer close(k.done)
+
+	ticker := time.NewTicker(k.opts.Interval)
+	defer ticker.Stop()
+	for {
+		k.ping(ctx)
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// ping pings the server once and reports the health transition, if any.
+func (k *KeepAlive) ping(ctx context.Context) {
+	pingCtx, cancel := context.WithTimeout(ctx, k.opts.Timeout)
+	defer cancel()
+	latency, err := k.conn.Ping(pingCtx)
+	if ctx.Err() != nil {
+		// Stopped while pinging, the failure says nothing about the server.
+		return
+	}
+
+	event := HealthEvent{Current: HealthUp, Latency: latency}
+	if err != nil {
+		event = HealthEvent{Current: HealthDown, Err: err}
+	}
+
+	k.mu.Lock()
+	event.Previous = k.health
+	k.health = event.Current
+	k.mu.Unlock()
+
+	if event.Previous != event.Current && k.opts.OnChange != nil {
+		k.opts.OnChange(event)
+	}
+}
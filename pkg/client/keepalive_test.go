@@ -0,0 +1,87 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// flakyConn answers pings while up is true.
+type flakyConn struct {
+	AeroSpaceConnection
+
+	up atomic.Bool
+}
+
+func (c *flakyConn) Ping(ctx context.Context) (time.Duration, error) {
+	if !c.up.Load() {
+		return 0, errors.New("connection refused")
+	}
+	return time.Millisecond, nil
+}
+
+func TestKeepAlive(t *testing.T) {
+	t.Run("Happy path", func(tt *testing.T) {
+		tt.Run("reports health transitions", func(ttt *testing.T) {
+			conn := &flakyConn{}
+			conn.up.Store(true)
+
+			events := make(chan HealthEvent, 10)
+			keepAlive := StartKeepAlive(conn, KeepAliveOpts{
+				Interval: 5 * time.Millisecond,
+				OnChange: func(event HealthEvent) { events <- event },
+			})
+			defer keepAlive.Stop()
+
+			event := <-events
+			if event.Previous != HealthUnknown || event.Current != HealthUp || event.Latency != time.Millisecond {
+				ttt.Fatalf("unexpected first event: %+v", event)
+			}
+
+			conn.up.Store(false)
+			event = <-events
+			if event.Previous != HealthUp || event.Current != HealthDown || event.Err == nil {
+				ttt.Fatalf("unexpected down event: %+v", event)
+			}
+			if keepAlive.Health() != HealthDown {
+				ttt.Fatalf("expected health down, got %s", keepAlive.Health())
+			}
+
+			conn.up.Store(true)
+			event = <-events
+			if event.Previous != HealthDown || event.Current != HealthUp {
+				ttt.Fatalf("unexpected up event: %+v", event)
+			}
+		})
+
+		tt.Run("does not report steady health", func(ttt *testing.T) {
+			conn := &flakyConn{}
+			conn.up.Store(true)
+
+			var changes atomic.Int32
+			keepAlive := StartKeepAlive(conn, KeepAliveOpts{
+				Interval: time.Millisecond,
+				OnChange: func(event HealthEvent) { changes.Add(1) },
+			})
+			time.Sleep(20 * time.Millisecond)
+			keepAlive.Stop()
+
+			if n := changes.Load(); n != 1 {
+				ttt.Fatalf("expected a single transition, got %d", n)
+			}
+		})
+	})
+
+	t.Run("Error cases", func(tt *testing.T) {
+		tt.Run("panics without an interval", func(ttt *testing.T) {
+			defer func() {
+				if r := recover(); r == nil {
+					ttt.Fatal("expected a panic")
+				}
+			}()
+			StartKeepAlive(&flakyConn{}, KeepAliveOpts{})
+		})
+	})
+}
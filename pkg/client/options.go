@@ -0,0 +1,168 @@
+package client
+
+import (
+	"context"
+	"log/slog"
+	"net"
+	"time"
+)
+
+// Option configures an AeroSpaceSocketConnection.
+//
+// Usage:
+//
+//	conn, err := client.NewAeroSpaceSocketConnection(
+//	    socketPath,
+//	    client.WithCommandTimeout(2*time.Second),
+//	)
+type Option func(*AeroSpaceSocketConnection)
+
+// WithCommandTimeout bounds every command sent on the connection by timeout.
+//
+// The timeout is applied as read/write deadlines on the underlying transport.
+// A command that does not complete in time fails with a *TimeoutError and
+// the connection is closed, since the late response could otherwise be read
+// as the answer to the next command.
+//
+// A zero or negative timeout disables it, which is the default.
+func WithCommandTimeout(timeout time.Duration) Option {
+	return func(c *AeroSpaceSocketConnection) {
+		c.commandTimeout = timeout
+	}
+}
+
+// WithReconnect makes the connection survive AeroSpace server restarts.
+//
+// When a command fails because the server closed the connection (EOF,
+// broken pipe or connection reset), the socket is dialed again and the
+// command is resent, up to attempts times. A connection closed after an
+// aborted request is also re-established on the next command.
+//
+// Since the server may have executed a command right before closing
+// the connection, a resent command can run twice.
+//
+// Zero disables reconnection, which is the default.
+func WithReconnect(attempts int) Option {
+	return func(c *AeroSpaceSocketConnection) {
+		c.reconnectAttempts = attempts
+	}
+}
+
+// WithLogger sets the Logger of the connection, receiving diagnostics such as
+// the version negotiation and the debug logs of every command.
+func WithLogger(logger *slog.Logger) Option {
+	return func(c *AeroSpaceSocketConnection) {
+		c.Logger = logger
+	}
+}
+
+// WithRetry retries commands failing with transient errors, such as the
+// socket not being available while AeroSpace restarts, following policy.
+//
+// Every service built on the connection benefits from the retries.
+// Other commands can use the connection while a command backs off, except
+// within a batch, and retries stop as soon as the command context is done
+// or the connection is closed.
+//
+// Usage:
+//
+//	conn, err := client.NewAeroSpaceSocketConnection(
+//	    socketPath,
+//	    client.WithRetry(client.RetryPolicy{
+//	        MaxAttempts: 3,
+//	        Backoff:     50 * time.Millisecond,
+//	    }),
+//	)
+func WithRetry(policy RetryPolicy) Option {
+	return func(c *AeroSpaceSocketConnection) {
+		c.retry = policy
+	}
+}
+
+// Dialer opens connections to the AeroSpace socket.
+//
+// *net.Dialer implements it and is used by default. Tests can inject
+// a fake to control how the socket is opened.
+type Dialer interface {
+	DialContext(ctx context.Context, network, address string) (net.Conn, error)
+}
+
+// WithDialer sets the Dialer used to open, and reopen, the socket connection.
+//
+// Usage:
+//
+//	conn, err := client.NewAeroSpaceSocketConnection(
+//	    socketPath,
+//	    client.WithDialer(&net.Dialer{KeepAlive: -1}),
+//	)
+func WithDialer(dialer Dialer) Option {
+	if dialer == nil {
+		panic("ASSERTION: dialer cannot be nil")
+	}
+	return func(c *AeroSpaceSocketConnection) {
+		c.dialer = dialer
+	}
+}
+
+// WithDialTimeout bounds how long opening the socket connection may take.
+//
+// A zero or negative timeout disables it, which is the default.
+func WithDialTimeout(timeout time.Duration) Option {
+	return func(c *AeroSpaceSocketConnection) {
+		c.dialTimeout = timeout
+	}
+}
+
+// WithNetwork connects to address on the given network instead of the
+// local unix socket, e.g. "tcp" and "127.0.0.1:9999".
+//
+// It allows controlling AeroSpace on another Mac whose socket is forwarded
+// through an SSH tunnel:
+//
+//	ssh -N -L 9999:/tmp/bobko.aerospace-me.sock me@other-mac
+//
+// The address replaces the socket path given to NewAeroSpaceSocketConnection,
+// which can then be empty.
+//
+// Usage:
+//
+//	conn, err := client.NewAeroSpaceSocketConnection(
+//	    "",
+//	    client.WithNetwork("tcp", "127.0.0.1:9999"),
+//	)
+func WithNetwork(network, address string) Option {
+	return func(c *AeroSpaceSocketConnection) {
+		c.network = network
+		c.socketPath = address
+	}
+}
+
+// WithAutoStart launches AeroSpace when it is not running while connecting,
+// so CLI tools built on the library "just work".
+//
+// It runs `open -a AeroSpace`, waits for the socket to accept connections
+// and then connects. The wait is bounded by WithDialTimeout, or 10 seconds
+// if no dial timeout is set.
+//
+// Usage:
+//
+//	conn, err := client.NewAeroSpaceSocketConnection(
+//	    socketPath,
+//	    client.WithAutoStart(true),
+//	)
+func WithAutoStart(enabled bool) Option {
+	return func(c *AeroSpaceSocketConnection) {
+		c.autoStart = enabled
+	}
+}
+
+// defaultCloseTimeout is how long CloseConnection waits for the command in flight by default.
+const defaultCloseTimeout = 5 * time.Second
+
+// WithCloseTimeout sets how long CloseConnection waits for the command
+// in flight to finish before interrupting it. Defaults to 5 seconds.
+func WithCloseTimeout(timeout time.Duration) Option {
+	return func(c *AeroSpaceSocketConnection) {
+		c.closeTimeout = timeout
+	}
+}
@@ -0,0 +1,40 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"sync"
+)
+
+// maxPooledBufferSize is the largest encode buffer kept for reuse,
+// so an unusually large command does not pin its memory forever.
+const maxPooledBufferSize = 64 * 1024
+
+// encodeBufferPool reuses the buffers commands are encoded into.
+var encodeBufferPool = sync.Pool{
+	New: func() any {
+		return new(bytes.Buffer)
+	},
+}
+
+// encodeCommand encodes cmd as JSON into a pooled buffer.
+// The buffer must be released with putEncodeBuffer once sent.
+func encodeCommand(cmd Command) (*bytes.Buffer, error) {
+	buf := encodeBufferPool.Get().(*bytes.Buffer)
+	buf.Reset()
+	if err := json.NewEncoder(buf).Encode(cmd); err != nil {
+		putEncodeBuffer(buf)
+		return nil, err
+	}
+	// Encode terminates the value with a newline, which json.Marshal does not.
+	buf.Truncate(buf.Len() - 1)
+	return buf, nil
+}
+
+// putEncodeBuffer returns buf to the pool.
+func putEncodeBuffer(buf *bytes.Buffer) {
+	if buf.Cap() > maxPooledBufferSize {
+		return
+	}
+	encodeBufferPool.Put(buf)
+}
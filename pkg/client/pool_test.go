@@ -0,0 +1,35 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func TestEncodeCommand(t *testing.T) {
+	windowID := uint64(42)
+	workspace := "<dev>"
+	commands := []Command{
+		{Args: []string{"list-windows", "--all", "--json"}},
+		{Args: []string{"workspace", "a & b"}, WindowID: &windowID, Workspace: &workspace},
+	}
+
+	for _, cmd := range commands {
+		expected, err := json.Marshal(cmd)
+		if err != nil {
+			t.Fatalf("failed to marshal command: %v", err)
+		}
+
+		// Encoding twice exercises a reused buffer.
+		for range 2 {
+			buf, err := encodeCommand(cmd)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !bytes.Equal(buf.Bytes(), expected) {
+				t.Fatalf("expected %s, got %s", expected, buf.Bytes())
+			}
+			putEncodeBuffer(buf)
+		}
+	}
+}
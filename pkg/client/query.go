@@ -0,0 +1,59 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// Query sends command with args on conn and decodes its JSON output into T.
+//
+// It covers commands the services do not wrap yet without hand-rolling the
+// error handling and unmarshalling. Remember to pass "--json" when the
+// command needs it. A non-zero exit code fails with a *CommandError.
+//
+// Usage:
+//
+//	type app struct {
+//	    PID  int    `json:"app-pid"`
+//	    Name string `json:"app-name"`
+//	}
+//	apps, err := client.Query[[]app](ctx, conn, "list-apps", "--json")
+func Query[T any](ctx context.Context, conn AeroSpaceConnection, command string, args ...string) (T, error) {
+	var result T
+
+	response, err := Send(ctx, conn, command, args...)
+	if err != nil {
+		return result, err
+	}
+	// Not every connection turns failures into errors, e.g. mocks.
+	if _, err := checkResponse(command, args, response, StderrWarn); err != nil {
+		return result, err
+	}
+
+	if err := json.Unmarshal([]byte(response.StdOut), &result); err != nil {
+		return result, fmt.Errorf(
+			"failed to unmarshal %s output: %w\nOut:%s\nErr:%s",
+			command,
+			err,
+			response.StdOut,
+			response.StdErr,
+		)
+	}
+	return result, nil
+}
+
+// Send sends command with args on conn.
+//
+// It is SendCommandContext taking the arguments as variadic parameters,
+// so one-off commands do not need a []string literal.
+//
+// Usage:
+//
+//	response, err := client.Send(ctx, conn, "workspace", "next", "--wrap-around")
+func Send(ctx context.Context, conn AeroSpaceConnection, command string, args ...string) (*Response, error) {
+	if args == nil {
+		args = []string{}
+	}
+	return conn.SendCommandContext(ctx, command, args)
+}
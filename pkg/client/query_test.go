@@ -0,0 +1,89 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestQuery(t *testing.T) {
+	t.Run("Happy path", func(tt *testing.T) {
+		tt.Run("decodes the command output", func(ttt *testing.T) {
+			conn := newTestFakeConnection()
+
+			type window struct {
+				WindowID int    `json:"window-id"`
+				AppName  string `json:"app-name"`
+			}
+			windows, err := Query[[]window](context.Background(), conn, "list-windows", "--workspace", "2", "--json")
+			if err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+			if len(windows) != 1 || windows[0].WindowID != 3 || windows[0].AppName != "Slack" {
+				ttt.Fatalf("unexpected windows: %+v", windows)
+			}
+		})
+	})
+
+	t.Run("Error cases", func(tt *testing.T) {
+		tt.Run("fails on a non-zero exit code", func(ttt *testing.T) {
+			conn := &responseConnection{response: &Response{ExitCode: 1, StdErr: "unknown command"}}
+
+			_, err := Query[[]string](context.Background(), conn, "list-apps", "--json")
+			var cmdErr *CommandError
+			if !errors.As(err, &cmdErr) {
+				ttt.Fatalf("expected *CommandError, got %v", err)
+			}
+			if cmdErr.Command != "list-apps" || cmdErr.StdErr != "unknown command" {
+				ttt.Fatalf("unexpected error: %+v", cmdErr)
+			}
+		})
+
+		tt.Run("fails on invalid JSON", func(ttt *testing.T) {
+			conn := &responseConnection{response: &Response{StdOut: "not json"}}
+
+			_, err := Query[[]string](context.Background(), conn, "list-apps")
+			if err == nil {
+				ttt.Fatal("expected error, got nil")
+			}
+		})
+	})
+}
+
+func TestSend(t *testing.T) {
+	t.Run("Happy path", func(tt *testing.T) {
+		tt.Run("sends the variadic arguments", func(ttt *testing.T) {
+			conn := newTestFakeConnection()
+
+			if _, err := Send(context.Background(), conn, "workspace", "2"); err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+			if got := conn.State().FocusedWorkspace; got != "2" {
+				ttt.Fatalf("expected workspace 2 to be focused, got %q", got)
+			}
+		})
+
+		tt.Run("sends empty arguments without any", func(ttt *testing.T) {
+			conn := &responseConnection{response: &Response{}}
+
+			if _, err := Send(context.Background(), conn, "balance-sizes"); err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+			if conn.args == nil || len(conn.args) != 0 {
+				ttt.Fatalf("expected empty arguments, got %#v", conn.args)
+			}
+		})
+	})
+}
+
+// responseConnection answers every command with response.
+type responseConnection struct {
+	AeroSpaceConnection
+	response *Response
+	args     []string
+}
+
+func (c *responseConnection) SendCommandContext(ctx context.Context, command string, args []string) (*Response, error) {
+	c.args = args
+	return c.response, nil
+}
@@ -0,0 +1,282 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/cristianoliveira/aerospace-ipc/pkg/client/commands"
+)
+
+// Priority orders the commands waiting in a QueuedConnection.
+type Priority int
+
+const (
+	// PriorityLow is for bulk queries, e.g. refreshing the whole state.
+	PriorityLow Priority = iota
+	// PriorityNormal is for commands that are neither interactive nor bulk.
+	PriorityNormal
+	// PriorityHigh is for interactive commands, e.g. focus and move.
+	PriorityHigh
+)
+
+func (p Priority) String() string {
+	switch p {
+	case PriorityLow:
+		return "low"
+	case PriorityNormal:
+		return "normal"
+	case PriorityHigh:
+		return "high"
+	}
+	return fmt.Sprintf("Priority(%d)", int(p))
+}
+
+type priorityKey struct{}
+
+// WithPriority returns a context marking the commands sent with it as priority,
+// overriding the QueuedConnection classifier.
+//
+// Usage:
+//
+//	ctx := client.WithPriority(context.Background(), client.PriorityHigh)
+//	windows, err := aerospaceClient.WithContext(ctx).Windows().GetFocusedWindow()
+func WithPriority(ctx context.Context, priority Priority) context.Context {
+	return context.WithValue(ctx, priorityKey{}, priority)
+}
+
+// DefaultPriority classifies a command by its name.
+//
+// Queries (list-*, debug-windows and config) are low priority, commands
+// acting on windows, workspaces and focus are high priority and any other
+// command is normal priority.
+func DefaultPriority(command string) Priority {
+	switch {
+	case strings.HasPrefix(command, "list-"), command == commands.DebugWindows, command == commands.Config:
+		return PriorityLow
+	case strings.HasPrefix(command, "focus"),
+		strings.HasPrefix(command, "move"),
+		strings.HasPrefix(command, "workspace"),
+		command == commands.Layout, command == commands.Resize, command == commands.JoinWith,
+		command == commands.Fullscreen, command == commands.Close, command == commands.Swap:
+		return PriorityHigh
+	}
+	return PriorityNormal
+}
+
+// queuedCommand is a command, or a batch, waiting in a QueuedConnection.
+type queuedCommand struct {
+	ctx context.Context
+	// send sends the command and delivers its outcome.
+	send func()
+	// fail delivers err as the outcome, without sending the command.
+	fail func(err error)
+}
+
+// QueuedConnection sends commands one at a time through a priority queue,
+// so interactive commands are not starved behind long state refreshes.
+//
+// A command already being sent is never interrupted, but queued high priority
+// commands are always sent before queued lower priority ones. Commands with
+// the same priority are sent in order.
+//
+// The other AeroSpaceConnection methods are delegated to the wrapped connection.
+type QueuedConnection struct {
+	AeroSpaceConnection
+
+	// Classify returns the priority of a command sent without WithPriority.
+	// Defaults to DefaultPriority.
+	Classify func(command string) Priority
+
+	mu     sync.Mutex
+	ready  *sync.Cond
+	queues [PriorityHigh + 1][]*queuedCommand
+	closed bool
+}
+
+// NewQueuedConnection returns a QueuedConnection sending commands on conn.
+//
+// Usage:
+//
+//	queued := client.NewQueuedConnection(conn)
+//	defer queued.CloseConnection()
+//	windowsService := windows.NewService(queued)
+func NewQueuedConnection(conn AeroSpaceConnection) *QueuedConnection {
+	if conn == nil {
+		panic("ASSERTION: connection cannot be nil")
+	}
+
+	q := &QueuedConnection{
+		AeroSpaceConnection: conn,
+		Classify:            DefaultPriority,
+	}
+	q.ready = sync.NewCond(&q.mu)
+	go q.run()
+	return q
+}
+
+// SendCommand queues the command and waits for its response.
+func (q *QueuedConnection) SendCommand(command string, args []string) (*Response, error) {
+	return q.SendCommandContext(context.Background(), command, args)
+}
+
+// SendCommandContext queues the command and waits for its response.
+//
+// If ctx is done while the command is queued, it is dropped without being sent.
+func (q *QueuedConnection) SendCommandContext(ctx context.Context, command string, args []string) (*Response, error) {
+	return q.SendCommandWithStdin(ctx, command, args, "")
+}
+
+// SendCommandWithStdin queues the command with its stdin and waits for its response.
+func (q *QueuedConnection) SendCommandWithStdin(ctx context.Context, command string, args []string, stdin string) (*Response, error) {
+	select {
+	case r := <-q.enqueue(ctx, command, args, stdin):
+		return r.Response, r.Err
+	case <-ctx.Done():
+		return nil, fmt.Errorf("failed to send command\n%w", context.Cause(ctx))
+	}
+}
+
+// SendCommandAsync queues the command and delivers its outcome on the returned channel.
+func (q *QueuedConnection) SendCommandAsync(ctx context.Context, command string, args []string) <-chan Result {
+	return q.enqueue(ctx, command, args, "")
+}
+
+// enqueue queues the command and returns the channel its outcome is delivered on.
+func (q *QueuedConnection) enqueue(ctx context.Context, command string, args []string, stdin string) <-chan Result {
+	// Buffered, so the result can be dropped without blocking the queue.
+	result := make(chan Result, 1)
+	deliver := func(r Result) {
+		result <- r
+		close(result)
+	}
+	q.push(q.priority(ctx, command), &queuedCommand{
+		ctx: ctx,
+		send: func() {
+			var r Result
+			r.Response, r.Err = sendWithStdin(ctx, q.AeroSpaceConnection, command, args, stdin)
+			deliver(r)
+		},
+		fail: func(err error) {
+			deliver(Result{Err: err})
+		},
+	})
+	return result
+}
+
+// SendBatch queues the batch as a single entry, with the highest priority among
+// its commands, and waits for its responses. The batch is sent with the SendBatch
+// method of the wrapped connection, so queued commands are not interleaved with it.
+func (q *QueuedConnection) SendBatch(ctx context.Context, commands []Command) ([]*Response, error) {
+	if err := checkBatch(commands); err != nil {
+		return nil, err
+	}
+
+	type batchResult struct {
+		responses []*Response
+		err       error
+	}
+	// Buffered, so the result can be dropped without blocking the queue.
+	result := make(chan batchResult, 1)
+	priority := PriorityLow
+	for _, cmd := range commands {
+		priority = max(priority, q.priority(ctx, cmd.Args[0]))
+	}
+	q.push(priority, &queuedCommand{
+		ctx: ctx,
+		send: func() {
+			responses, err := q.AeroSpaceConnection.SendBatch(ctx, commands)
+			result <- batchResult{responses: responses, err: err}
+		},
+		fail: func(err error) {
+			result <- batchResult{err: err}
+		},
+	})
+
+	select {
+	case r := <-result:
+		return r.responses, r.err
+	case <-ctx.Done():
+		return nil, fmt.Errorf("failed to send command\n%w", context.Cause(ctx))
+	}
+}
+
+// push adds cmd to the queue of the given priority, or fails it with ErrClosed.
+func (q *QueuedConnection) push(priority Priority, cmd *queuedCommand) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed {
+		cmd.fail(ErrClosed)
+		return
+	}
+
+	q.queues[priority] = append(q.queues[priority], cmd)
+	q.ready.Signal()
+}
+
+// CloseConnection stops the queue, failing queued commands with ErrClosed,
+// and closes the wrapped connection.
+func (q *QueuedConnection) CloseConnection() error {
+	q.mu.Lock()
+	q.closed = true
+	for priority := range q.queues {
+		for _, cmd := range q.queues[priority] {
+			cmd.fail(ErrClosed)
+		}
+		q.queues[priority] = nil
+	}
+	q.ready.Broadcast()
+	q.mu.Unlock()
+
+	return q.AeroSpaceConnection.CloseConnection()
+}
+
+// priority returns the priority of command, from ctx or the classifier.
+func (q *QueuedConnection) priority(ctx context.Context, command string) Priority {
+	priority, ok := ctx.Value(priorityKey{}).(Priority)
+	if !ok {
+		classify := q.Classify
+		if classify == nil {
+			classify = DefaultPriority
+		}
+		priority = classify(command)
+	}
+	return min(max(priority, PriorityLow), PriorityHigh)
+}
+
+// run sends the queued commands, highest priority first, until the queue is closed.
+func (q *QueuedConnection) run() {
+	for {
+		cmd := q.next()
+		if cmd == nil {
+			return
+		}
+
+		if err := cmd.ctx.Err(); err != nil {
+			cmd.fail(fmt.Errorf("failed to send command\n%w", context.Cause(cmd.ctx)))
+			continue
+		}
+		cmd.send()
+	}
+}
+
+// next waits for a queued command and removes it from the queue.
+// It returns nil once the queue is closed.
+func (q *QueuedConnection) next() *queuedCommand {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for {
+		if q.closed {
+			return nil
+		}
+		for priority := PriorityHigh; priority >= PriorityLow; priority-- {
+			if queue := q.queues[priority]; len(queue) > 0 {
+				cmd := queue[0]
+				q.queues[priority] = queue[1:]
+				return cmd
+			}
+		}
+		q.ready.Wait()
+	}
+}
@@ -0,0 +1,156 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+// recordingConn records the commands sent and blocks each one until released.
+type recordingConn struct {
+	AeroSpaceConnection
+
+	mu      sync.Mutex
+	sent    []string
+	started chan string
+	release chan struct{}
+}
+
+func newRecordingConn() *recordingConn {
+	return &recordingConn{
+		started: make(chan string, 10),
+		release: make(chan struct{}),
+	}
+}
+
+func (c *recordingConn) SendCommandContext(ctx context.Context, command string, args []string) (*Response, error) {
+	c.started <- command
+	<-c.release
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.sent = append(c.sent, command)
+	return &Response{StdOut: command}, nil
+}
+
+func (c *recordingConn) CloseConnection() error {
+	return nil
+}
+
+func TestQueuedConnection(t *testing.T) {
+	t.Run("Happy path", func(tt *testing.T) {
+		tt.Run("sends high priority commands first", func(ttt *testing.T) {
+			conn := newRecordingConn()
+			queued := NewQueuedConnection(conn)
+			defer queued.CloseConnection()
+
+			refresh := queued.SendCommandAsync(context.Background(), "list-windows", nil)
+			// Wait for the refresh to be in flight before queuing more commands.
+			<-conn.started
+
+			results := []<-chan Result{
+				queued.SendCommandAsync(context.Background(), "list-workspaces", nil),
+				queued.SendCommandAsync(context.Background(), "config", nil),
+				queued.SendCommandAsync(context.Background(), "focus", nil),
+				queued.SendCommandAsync(WithPriority(context.Background(), PriorityHigh), "list-modes", nil),
+			}
+			close(conn.release)
+
+			for _, result := range append(results, refresh) {
+				if r := <-result; r.Err != nil {
+					ttt.Fatalf("unexpected error: %v", r.Err)
+				}
+			}
+			expected := []string{"list-windows", "focus", "list-modes", "list-workspaces", "config"}
+			if !reflect.DeepEqual(conn.sent, expected) {
+				ttt.Fatalf("expected %v, got %v", expected, conn.sent)
+			}
+		})
+
+		tt.Run("returns the response of SendCommand", func(ttt *testing.T) {
+			conn := newRecordingConn()
+			close(conn.release)
+			queued := NewQueuedConnection(conn)
+			defer queued.CloseConnection()
+
+			response, err := queued.SendCommand("focus", []string{"left"})
+			if err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+			if response.StdOut != "focus" {
+				ttt.Fatalf("expected stdout 'focus', got %q", response.StdOut)
+			}
+		})
+	})
+
+	t.Run("Error cases", func(tt *testing.T) {
+		tt.Run("drops commands whose context is done while queued", func(ttt *testing.T) {
+			conn := newRecordingConn()
+			queued := NewQueuedConnection(conn)
+			defer queued.CloseConnection()
+
+			refresh := queued.SendCommandAsync(context.Background(), "list-windows", nil)
+			<-conn.started
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+			defer cancel()
+			_, err := queued.SendCommandContext(ctx, "focus", nil)
+			if !errors.Is(err, context.DeadlineExceeded) {
+				ttt.Fatalf("expected context.DeadlineExceeded, got %v", err)
+			}
+
+			close(conn.release)
+			<-refresh
+			if _, err := queued.SendCommand("list-modes", nil); err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+			expected := []string{"list-windows", "list-modes"}
+			if !reflect.DeepEqual(conn.sent, expected) {
+				ttt.Fatalf("expected %v, got %v", expected, conn.sent)
+			}
+		})
+
+		tt.Run("fails queued and new commands on close", func(ttt *testing.T) {
+			conn := newRecordingConn()
+			queued := NewQueuedConnection(conn)
+
+			refresh := queued.SendCommandAsync(context.Background(), "list-windows", nil)
+			<-conn.started
+			pending := queued.SendCommandAsync(context.Background(), "focus", nil)
+
+			if err := queued.CloseConnection(); err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+			if r := <-pending; !errors.Is(r.Err, ErrClosed) {
+				ttt.Fatalf("expected ErrClosed for the queued command, got %v", r.Err)
+			}
+			if _, err := queued.SendCommand("focus", nil); !errors.Is(err, ErrClosed) {
+				ttt.Fatalf("expected ErrClosed for a new command, got %v", err)
+			}
+
+			close(conn.release)
+			if r := <-refresh; r.Err != nil {
+				ttt.Fatalf("expected the in-flight command to finish, got %v", r.Err)
+			}
+		})
+	})
+}
+
+func TestDefaultPriority(t *testing.T) {
+	testCases := map[string]Priority{
+		"list-windows":           PriorityLow,
+		"config":                 PriorityLow,
+		"focus":                  PriorityHigh,
+		"move-node-to-workspace": PriorityHigh,
+		"workspace":              PriorityHigh,
+		"reload-config":          PriorityNormal,
+	}
+	for command, expected := range testCases {
+		if priority := DefaultPriority(command); priority != expected {
+			t.Errorf("expected %s to be %s priority, got %s", command, expected, priority)
+		}
+	}
+}
@@ -0,0 +1,318 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// RateLimit configures a RateLimitedConnection.
+type RateLimit struct {
+	// Rate is the sustained number of commands sent per second.
+	// Zero disables the limit.
+	Rate float64
+
+	// Burst is the number of commands that can be sent at once before Rate applies.
+	// Defaults to 1.
+	Burst int
+
+	// Drop fails the commands over the limit with ErrRateLimited
+	// instead of waiting for the limit to allow them.
+	Drop bool
+
+	// Debounce shares the response of a query with the identical queries,
+	// same command and args, sent while it is in flight or within this interval
+	// after it succeeded. Only the queries classified as PriorityLow by
+	// DefaultPriority (list-*, config...) are debounced. Zero disables it.
+	Debounce time.Duration
+}
+
+// RateLimitStats counts the commands handled by a RateLimitedConnection.
+type RateLimitStats struct {
+	// Sent is the number of commands sent to the wrapped connection.
+	Sent uint64
+
+	// Dropped is the number of commands failed with ErrRateLimited.
+	Dropped uint64
+
+	// Coalesced is the number of queries answered with the response of an identical query.
+	Coalesced uint64
+}
+
+// RateLimitedConnection limits the rate of the commands sent on a connection,
+// so naive pollers cannot hammer the AeroSpace socket.
+//
+// The other AeroSpaceConnection methods are delegated to the wrapped connection.
+type RateLimitedConnection struct {
+	AeroSpaceConnection
+
+	limit  RateLimit
+	bucket *tokenBucket
+
+	mu        sync.Mutex
+	debounced map[string]*debouncedQuery
+
+	sent      atomic.Uint64
+	dropped   atomic.Uint64
+	coalesced atomic.Uint64
+}
+
+// debouncedQuery is the shared outcome of a debounced query.
+type debouncedQuery struct {
+	done     chan struct{}
+	response *Response
+	err      error
+	expires  time.Time
+
+	// canceled is set if the query failed because its context was done.
+	canceled bool
+}
+
+// NewRateLimitedConnection returns a RateLimitedConnection sending commands on conn.
+//
+// Usage:
+//
+//	limited := client.NewRateLimitedConnection(conn, client.RateLimit{
+//	    Rate:     20,
+//	    Burst:    5,
+//	    Debounce: 100 * time.Millisecond,
+//	})
+//	defer limited.CloseConnection()
+//	windowsService := windows.NewService(limited)
+func NewRateLimitedConnection(conn AeroSpaceConnection, limit RateLimit) *RateLimitedConnection {
+	if conn == nil {
+		panic("ASSERTION: connection cannot be nil")
+	}
+	if limit.Rate < 0 || limit.Burst < 0 || limit.Debounce < 0 {
+		panic("ASSERTION: rate limit cannot be negative")
+	}
+
+	r := &RateLimitedConnection{
+		AeroSpaceConnection: conn,
+		limit:               limit,
+		debounced:           map[string]*debouncedQuery{},
+	}
+	if limit.Rate > 0 {
+		r.bucket = newTokenBucket(limit.Rate, max(limit.Burst, 1))
+	}
+	return r
+}
+
+// Stats returns the counters of the commands handled so far.
+func (r *RateLimitedConnection) Stats() RateLimitStats {
+	return RateLimitStats{
+		Sent:      r.sent.Load(),
+		Dropped:   r.dropped.Load(),
+		Coalesced: r.coalesced.Load(),
+	}
+}
+
+// SendCommand sends the command once the rate limit allows it.
+func (r *RateLimitedConnection) SendCommand(command string, args []string) (*Response, error) {
+	return r.SendCommandContext(context.Background(), command, args)
+}
+
+// SendCommandContext sends the command once the rate limit allows it.
+//
+// Debounced queries may return a response shared with other callers,
+// so it must not be modified.
+func (r *RateLimitedConnection) SendCommandContext(ctx context.Context, command string, args []string) (*Response, error) {
+	if r.limit.Debounce == 0 || DefaultPriority(command) != PriorityLow {
+		return r.send(ctx, command, args, "")
+	}
+
+	key := command + "\x00" + strings.Join(args, "\x00")
+	for {
+		r.mu.Lock()
+		now := time.Now()
+		query, ok := r.debounced[key]
+		if ok && query.shared(now) {
+			r.mu.Unlock()
+			select {
+			case <-query.done:
+			case <-ctx.Done():
+				return nil, fmt.Errorf("failed to send command\n%w", context.Cause(ctx))
+			}
+			if query.canceled && ctx.Err() == nil {
+				// The query was aborted by the context of its sender, not ours.
+				continue
+			}
+			r.coalesced.Add(1)
+			return query.response, query.err
+		}
+		r.sweep(now)
+		query = &debouncedQuery{done: make(chan struct{})}
+		r.debounced[key] = query
+		r.mu.Unlock()
+
+		query.response, query.err = r.send(ctx, command, args, "")
+		r.mu.Lock()
+		if query.err != nil {
+			// Failures are not shared with later queries, only with the waiting ones.
+			delete(r.debounced, key)
+			query.canceled = ctx.Err() != nil
+		} else {
+			query.expires = time.Now().Add(r.limit.Debounce)
+		}
+		r.mu.Unlock()
+		close(query.done)
+
+		return query.response, query.err
+	}
+}
+
+// sweep deletes the debounced queries that expired at now, so queries
+// that are never repeated do not pile up. It must be called with mu held.
+func (r *RateLimitedConnection) sweep(now time.Time) {
+	for key, query := range r.debounced {
+		if !query.shared(now) {
+			delete(r.debounced, key)
+		}
+	}
+}
+
+// SendCommandWithStdin sends the command with its stdin once the rate limit allows it.
+// Commands with stdin are never debounced.
+func (r *RateLimitedConnection) SendCommandWithStdin(ctx context.Context, command string, args []string, stdin string) (*Response, error) {
+	if stdin == "" {
+		return r.SendCommandContext(ctx, command, args)
+	}
+	return r.send(ctx, command, args, stdin)
+}
+
+// SendBatch sends the batch once the rate limit allows all of its commands.
+// The tokens of the whole batch are taken at once, so a dropped or canceled
+// batch does not use any of them. Batches are never debounced.
+func (r *RateLimitedConnection) SendBatch(ctx context.Context, commands []Command) ([]*Response, error) {
+	if r.bucket != nil && len(commands) > 0 {
+		if err := r.wait(ctx, len(commands)); err != nil {
+			return nil, err
+		}
+	}
+
+	r.sent.Add(uint64(len(commands)))
+	return r.AeroSpaceConnection.SendBatch(ctx, commands)
+}
+
+// SendCommandAsync sends the command once the rate limit allows it and
+// delivers its outcome on the returned channel.
+func (r *RateLimitedConnection) SendCommandAsync(ctx context.Context, command string, args []string) <-chan Result {
+	// Buffered, so the result can be dropped without leaking the goroutine.
+	result := make(chan Result, 1)
+	go func() {
+		defer close(result)
+		response, err := r.SendCommandContext(ctx, command, args)
+		result <- Result{Response: response, Err: err}
+	}()
+	return result
+}
+
+// send waits for the rate limit, or drops the command, and sends it.
+func (r *RateLimitedConnection) send(ctx context.Context, command string, args []string, stdin string) (*Response, error) {
+	if r.bucket != nil {
+		if err := r.wait(ctx, 1); err != nil {
+			return nil, err
+		}
+	}
+
+	r.sent.Add(1)
+	return sendWithStdin(ctx, r.AeroSpaceConnection, command, args, stdin)
+}
+
+// wait takes n tokens from the bucket, waiting for them unless the limit drops commands.
+func (r *RateLimitedConnection) wait(ctx context.Context, n int) error {
+	if r.limit.Drop {
+		if !r.bucket.take(time.Now(), n) {
+			r.dropped.Add(uint64(n))
+			return ErrRateLimited
+		}
+		return nil
+	}
+
+	delay := r.bucket.reserve(time.Now(), n)
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		r.bucket.cancel(n)
+		return fmt.Errorf("failed to send command\n%w", context.Cause(ctx))
+	}
+}
+
+// shared reports whether the query outcome can be shared at now,
+// that is while it is in flight or until it expires.
+func (q *debouncedQuery) shared(now time.Time) bool {
+	select {
+	case <-q.done:
+		return now.Before(q.expires)
+	default:
+		return true
+	}
+}
+
+// tokenBucket refills rate tokens per second, up to burst tokens.
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(rate float64, burst int) *tokenBucket {
+	return &tokenBucket{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// refill adds the tokens accumulated since the last call. It must be called with mu held.
+func (b *tokenBucket) refill(now time.Time) {
+	if now.After(b.last) {
+		b.tokens = min(b.tokens+now.Sub(b.last).Seconds()*b.rate, b.burst)
+		b.last = now
+	}
+}
+
+// take takes n tokens if they are all available, or none.
+func (b *tokenBucket) take(now time.Time, n int) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.refill(now)
+	if b.tokens < float64(n) {
+		return false
+	}
+	b.tokens -= float64(n)
+	return true
+}
+
+// reserve takes n tokens, possibly going into debt, and returns
+// how long to wait until the tokens are actually available.
+func (b *tokenBucket) reserve(now time.Time, n int) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.refill(now)
+	b.tokens -= float64(n)
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+// cancel gives back n reserved tokens that were not used.
+func (b *tokenBucket) cancel(n int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.tokens = min(b.tokens+float64(n), b.burst)
+}
@@ -0,0 +1,242 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// countingConn counts the commands sent and answers them after delay.
+type countingConn struct {
+	AeroSpaceConnection
+
+	delay time.Duration
+	sent  atomic.Int32
+}
+
+func (c *countingConn) SendCommandContext(ctx context.Context, command string, args []string) (*Response, error) {
+	c.sent.Add(1)
+	select {
+	case <-time.After(c.delay):
+		return &Response{StdOut: command}, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (c *countingConn) SendBatch(ctx context.Context, commands []Command) ([]*Response, error) {
+	responses := make([]*Response, 0, len(commands))
+	for _, command := range commands {
+		response, err := c.SendCommandContext(ctx, command.Command, command.Args)
+		if err != nil {
+			return responses, err
+		}
+		responses = append(responses, response)
+	}
+	return responses, nil
+}
+
+func TestRateLimitedConnection(t *testing.T) {
+	t.Run("Happy path", func(tt *testing.T) {
+		tt.Run("waits for the rate limit", func(ttt *testing.T) {
+			conn := &countingConn{}
+			limited := NewRateLimitedConnection(conn, RateLimit{Rate: 50, Burst: 2})
+
+			start := time.Now()
+			for range 4 {
+				if _, err := limited.SendCommand("focus", []string{"left"}); err != nil {
+					ttt.Fatalf("unexpected error: %v", err)
+				}
+			}
+			// 2 commands are sent at once, the next 2 wait 20ms each.
+			if elapsed := time.Since(start); elapsed < 35*time.Millisecond {
+				ttt.Fatalf("expected the commands to be throttled, took %s", elapsed)
+			}
+			if stats := limited.Stats(); stats.Sent != 4 || stats.Dropped != 0 {
+				ttt.Fatalf("unexpected stats: %+v", stats)
+			}
+		})
+
+		tt.Run("coalesces identical queries", func(ttt *testing.T) {
+			conn := &countingConn{delay: 20 * time.Millisecond}
+			limited := NewRateLimitedConnection(conn, RateLimit{Debounce: time.Second})
+
+			var wg sync.WaitGroup
+			for range 5 {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					response, err := limited.SendCommand("list-windows", []string{"--all"})
+					if err != nil || response.StdOut != "list-windows" {
+						ttt.Errorf("unexpected response %v, error: %v", response, err)
+					}
+				}()
+			}
+			wg.Wait()
+			// Within the debounce interval
+			if _, err := limited.SendCommand("list-windows", []string{"--all"}); err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+
+			if sent := conn.sent.Load(); sent != 1 {
+				ttt.Fatalf("expected a single query to be sent, got %d", sent)
+			}
+			if stats := limited.Stats(); stats.Sent != 1 || stats.Coalesced != 5 {
+				ttt.Fatalf("unexpected stats: %+v", stats)
+			}
+		})
+
+		tt.Run("forgets expired queries", func(ttt *testing.T) {
+			conn := &countingConn{}
+			limited := NewRateLimitedConnection(conn, RateLimit{Debounce: time.Millisecond})
+
+			if _, err := limited.SendCommand("list-windows", []string{"--all"}); err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+			time.Sleep(5 * time.Millisecond)
+			if _, err := limited.SendCommand("list-windows", []string{"--focused"}); err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(limited.debounced) != 1 {
+				ttt.Fatalf("expected only the last query to be kept, got %d", len(limited.debounced))
+			}
+		})
+
+		tt.Run("does not debounce commands nor different queries", func(ttt *testing.T) {
+			conn := &countingConn{}
+			limited := NewRateLimitedConnection(conn, RateLimit{Debounce: time.Second})
+
+			for _, command := range [][]string{
+				{"focus", "left"},
+				{"focus", "left"},
+				{"list-windows", "--all"},
+				{"list-windows", "--focused"},
+			} {
+				if _, err := limited.SendCommand(command[0], command[1:]); err != nil {
+					ttt.Fatalf("unexpected error: %v", err)
+				}
+			}
+			if sent := conn.sent.Load(); sent != 4 {
+				ttt.Fatalf("expected 4 commands to be sent, got %d", sent)
+			}
+		})
+	})
+
+	t.Run("Error cases", func(tt *testing.T) {
+		tt.Run("drops commands over the limit", func(ttt *testing.T) {
+			conn := &countingConn{}
+			limited := NewRateLimitedConnection(conn, RateLimit{Rate: 1, Burst: 2, Drop: true})
+
+			var dropped int
+			for range 4 {
+				_, err := limited.SendCommand("focus", []string{"left"})
+				if errors.Is(err, ErrRateLimited) {
+					dropped++
+				} else if err != nil {
+					ttt.Fatalf("unexpected error: %v", err)
+				}
+			}
+			if dropped != 2 {
+				ttt.Fatalf("expected 2 dropped commands, got %d", dropped)
+			}
+			if stats := limited.Stats(); stats.Sent != 2 || stats.Dropped != 2 {
+				ttt.Fatalf("unexpected stats: %+v", stats)
+			}
+		})
+
+		tt.Run("drops a whole batch over the limit without using its tokens", func(ttt *testing.T) {
+			conn := &countingConn{}
+			limited := NewRateLimitedConnection(conn, RateLimit{Rate: 0.1, Burst: 2, Drop: true})
+
+			batch := []Command{
+				{Command: "focus", Args: []string{"left"}},
+				{Command: "focus", Args: []string{"right"}},
+				{Command: "focus", Args: []string{"up"}},
+			}
+			if _, err := limited.SendBatch(context.Background(), batch); !errors.Is(err, ErrRateLimited) {
+				ttt.Fatalf("expected ErrRateLimited, got %v", err)
+			}
+			// The tokens are still available for a batch within the limit.
+			if _, err := limited.SendBatch(context.Background(), batch[:2]); err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+			if stats := limited.Stats(); stats.Sent != 2 || stats.Dropped != 3 {
+				ttt.Fatalf("unexpected stats: %+v", stats)
+			}
+		})
+
+		tt.Run("gives back the tokens of a canceled batch", func(ttt *testing.T) {
+			conn := &countingConn{}
+			limited := NewRateLimitedConnection(conn, RateLimit{Rate: 0.1, Burst: 2})
+
+			batch := []Command{
+				{Command: "focus", Args: []string{"left"}},
+				{Command: "focus", Args: []string{"right"}},
+				{Command: "focus", Args: []string{"up"}},
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+			defer cancel()
+			if _, err := limited.SendBatch(ctx, batch); !errors.Is(err, context.DeadlineExceeded) {
+				ttt.Fatalf("expected context.DeadlineExceeded, got %v", err)
+			}
+
+			start := time.Now()
+			if _, err := limited.SendBatch(context.Background(), batch[:2]); err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+			if elapsed := time.Since(start); elapsed > time.Second {
+				ttt.Fatalf("expected the tokens of the canceled batch to be given back, waited %s", elapsed)
+			}
+			if sent := conn.sent.Load(); sent != 2 {
+				ttt.Fatalf("expected 2 commands to be sent, got %d", sent)
+			}
+		})
+
+		tt.Run("stops waiting when the context is done", func(ttt *testing.T) {
+			conn := &countingConn{}
+			limited := NewRateLimitedConnection(conn, RateLimit{Rate: 0.1})
+			if _, err := limited.SendCommand("focus", []string{"left"}); err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+			defer cancel()
+			_, err := limited.SendCommandContext(ctx, "focus", []string{"left"})
+			if !errors.Is(err, context.DeadlineExceeded) {
+				ttt.Fatalf("expected context.DeadlineExceeded, got %v", err)
+			}
+			if sent := conn.sent.Load(); sent != 1 {
+				ttt.Fatalf("expected a single command to be sent, got %d", sent)
+			}
+		})
+
+		tt.Run("does not share a failure caused by the context of another query", func(ttt *testing.T) {
+			conn := &countingConn{delay: 50 * time.Millisecond}
+			limited := NewRateLimitedConnection(conn, RateLimit{Debounce: time.Second})
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+			defer cancel()
+			failed := make(chan error, 1)
+			go func() {
+				_, err := limited.SendCommandContext(ctx, "list-windows", []string{"--all"})
+				failed <- err
+			}()
+			time.Sleep(5 * time.Millisecond)
+
+			response, err := limited.SendCommand("list-windows", []string{"--all"})
+			if err != nil || response.StdOut != "list-windows" {
+				ttt.Fatalf("unexpected response %v, error: %v", response, err)
+			}
+			if err := <-failed; !errors.Is(err, context.DeadlineExceeded) {
+				ttt.Fatalf("expected context.DeadlineExceeded, got %v", err)
+			}
+			if sent := conn.sent.Load(); sent != 2 {
+				ttt.Fatalf("expected the query to be sent again, got %d", sent)
+			}
+		})
+	})
+}
@@ -0,0 +1,49 @@
+package client
+
+import (
+	"math"
+	"math/rand/v2"
+	"time"
+)
+
+// RetryPolicy configures how commands failing with transient errors are retried.
+//
+// Transient errors are failures to dial the socket, connections closed by
+// the server (EOF, broken pipe, connection reset) and socket timeouts that
+// are not caused by the command context or timeout.
+//
+// The delay between attempts grows exponentially from Backoff and is jittered,
+// so several clients do not hammer a restarting server at the same time.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of times a command is sent, including the first one.
+	// Values lower than 2 disable retries.
+	MaxAttempts int
+
+	// Backoff is the delay before the first retry. It doubles on each subsequent retry.
+	Backoff time.Duration
+
+	// MaxBackoff caps the delay between retries. Zero means no cap.
+	MaxBackoff time.Duration
+}
+
+// delay returns the jittered delay to wait after the given failed attempt (1-based).
+//
+// The delay is picked uniformly in [d/2, d], where d is the exponential backoff.
+func (p RetryPolicy) delay(attempt int) time.Duration {
+	backoff := p.Backoff
+	for i := 1; i < attempt; i++ {
+		if (p.MaxBackoff > 0 && backoff >= p.MaxBackoff) || backoff > math.MaxInt64/2 {
+			break
+		}
+		backoff *= 2
+	}
+	if p.MaxBackoff > 0 && backoff > p.MaxBackoff {
+		backoff = p.MaxBackoff
+	}
+	if backoff <= 0 {
+		return 0
+	}
+
+	half := backoff / 2
+	return half + rand.N(backoff-half+1)
+}
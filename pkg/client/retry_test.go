@@ -0,0 +1,30 @@
+package client
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRetryPolicyDelay(t *testing.T) {
+	testCases := []struct {
+		title    string
+		policy   RetryPolicy
+		attempt  int
+		expected time.Duration
+	}{
+		{"first retry", RetryPolicy{Backoff: 100 * time.Millisecond}, 1, 100 * time.Millisecond},
+		{"grows exponentially", RetryPolicy{Backoff: 100 * time.Millisecond}, 3, 400 * time.Millisecond},
+		{"is capped", RetryPolicy{Backoff: 100 * time.Millisecond, MaxBackoff: 250 * time.Millisecond}, 5, 250 * time.Millisecond},
+		{"without backoff", RetryPolicy{}, 2, 0},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.title, func(tt *testing.T) {
+			for range 100 {
+				delay := tc.policy.delay(tc.attempt)
+				if delay < tc.expected/2 || delay > tc.expected {
+					tt.Fatalf("expected delay in [%s, %s], got %s", tc.expected/2, tc.expected, delay)
+				}
+			}
+		})
+	}
+}
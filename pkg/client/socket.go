@@ -1,39 +1,56 @@
 package client
 
 import (
+	"bufio"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"net"
 	"os"
 	"strconv"
-	"strings"
 	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
 
 	"github.com/cristianoliveira/aerospace-ipc/internal/constants"
-	"github.com/cristianoliveira/aerospace-ipc/internal/exceptions"
+	"github.com/cristianoliveira/aerospace-ipc/internal/socket"
+	"github.com/cristianoliveira/aerospace-ipc/pkg/client/commands"
 )
 
 // Command represents the JSON structure for AeroSpace socket commands.
-// This wlll mostly mirror https://github.com/nikitabobko/AeroSpace/blob/main/Sources/Common/model/clientServer.swift#L76
-type Command struct {
-	Command string   `json:"command"`
-	Args    []string `json:"args"`
-	Stdin   string   `json:"stdin"`
-	// For Version: 0.20.0 and above
-	// Pass null if not available
-	WindowID  *uint64 `json:"windowId"`
-	Workspace *string `json:"workspace"`
+//
+// The wire format is defined once in internal/socket, shared by every
+// implementation of the protocol, so the JSON tags cannot drift.
+type Command = socket.Command
+
+// Result is the outcome of a command sent with SendCommandAsync.
+type Result struct {
+	Response *Response
+	Err      error
 }
 
 // Response represents the JSON structure from AeroSpace socket response.
-type Response struct {
-	ServerVersion string `json:"serverVersionAndHash"` // Fornat: "0.0.1-Beta <hash>"
-	StdErr        string `json:"stderr"`
-	StdOut        string `json:"stdout"`
-	ExitCode      int32  `json:"exitCode"`
+//
+// Its Warnings method returns what a successful command printed to stderr,
+// which is not an error unless WithStderrPolicy(StderrError) is set.
+type Response = socket.Response
+
+// sendWithStdin sends the command on conn, with SendCommandContext unless there is stdin,
+// so decorators only relay SendCommandWithStdin for the commands that need it.
+func sendWithStdin(ctx context.Context, conn AeroSpaceConnection, command string, args []string, stdin string) (*Response, error) {
+	if stdin == "" {
+		return conn.SendCommandContext(ctx, command, args)
+	}
+	return conn.SendCommandWithStdin(ctx, command, args, stdin)
 }
 
+// readBufferSize is the size of the socket reads. Most responses fit in a single read.
+const readBufferSize = 4096
+
 // AeroSpaceConnection is an interface interacting with a AeroSpace socket.
 //
 // It provides methods to execute low-level commands and manage the connection.
@@ -49,12 +66,34 @@ type AeroSpaceConnection interface {
 	// Returns a Response struct containing the server version, standard error, standard output, and exit code.
 	SendCommand(command string, args []string) (*Response, error)
 
+	// SendCommandContext is like SendCommand but aborts the request when ctx is done.
+	//
+	// Returns an error wrapping ctx.Err() if the context is done before the response is read.
+	SendCommandContext(ctx context.Context, command string, args []string) (*Response, error)
+
+	// SendCommandWithStdin is like SendCommandContext but passes stdin to the command,
+	// for flags such as --stdin that read their input from it.
+	SendCommandWithStdin(ctx context.Context, command string, args []string, stdin string) (*Response, error)
+
+	// SendBatch sends the commands in order, stopping at the first failure,
+	// and returns the responses of the commands that succeeded.
+	SendBatch(ctx context.Context, commands []Command) ([]*Response, error)
+
+	// SendCommandAsync sends the command without blocking and delivers its outcome on the returned channel.
+	SendCommandAsync(ctx context.Context, command string, args []string) <-chan Result
+
+	// Ping performs a lightweight round-trip to the AeroSpace server and returns its latency.
+	Ping(ctx context.Context) (time.Duration, error)
+
 	// GetSocketPath returns the socket path for the AeroSpace connection.
 	GetSocketPath() (string, error)
 
 	// GetServerVersion returns the version of the AeroSpace server.
 	GetServerVersion() (string, error)
 
+	// GetVersionInfo returns how the version of the AeroSpace server was parsed and checked.
+	GetVersionInfo() (*VersionInfo, error)
+
 	// CheckServerVersion validates the version of the AeroSpace server.
 	CheckServerVersion() error
 }
@@ -65,10 +104,43 @@ type AeroSpaceSocketConnection struct {
 	socketPath      string
 	MinMajorVersion int
 	MinMinorVersion int
-	Conn            net.Conn
+	Conn            Transport
+
+	// Logger receives diagnostics such as the version negotiation.
+	// If nil, slog.Default() is used.
+	Logger *slog.Logger
+
+	network           string
+	dialer            Dialer
+	dialTimeout       time.Duration
+	autoStart         bool
+	closeTimeout      time.Duration
+	commandTimeout    time.Duration
+	reconnectAttempts int
+	retry             RetryPolicy
+	stderrPolicy      StderrPolicy
+	onWarning         WarningHandler
+
+	// closing is set once CloseConnection is called.
+	closing atomic.Bool
+	// closingCh is closed once CloseConnection is called, see closingSignal.
+	closingCh   chan struct{}
+	closingOnce sync.Once
+	// closed is set once CloseConnection has closed the connection.
+	closed atomic.Bool
+	// inflight is the connection used by the command in flight, if any.
+	inflight atomic.Pointer[Transport]
+
+	// decoder decodes the responses read from decoderConn. It is kept
+	// across commands so its read buffer is not allocated on every call.
+	decoder     *json.Decoder
+	decoderConn Transport
+
+	stats connectionStats
 }
 
 // GetSocketPath returns the socket path for the AeroSpace connection.
+// For connections using WithNetwork, it is the network address.
 //
 // It returns an error if the socket path is not set.
 func (c *AeroSpaceSocketConnection) GetSocketPath() (string, error) {
@@ -81,10 +153,25 @@ func (c *AeroSpaceSocketConnection) GetSocketPath() (string, error) {
 
 // CloseConnection closes the connection to the AeroSpace socket.
 //
-// It returns an error if the connection cannot be closed.
+// New commands are rejected with ErrClosing as soon as it is called, while
+// the command in flight, if any, is given up to the close timeout to finish
+// (see WithCloseTimeout). After that, the in-flight command is interrupted
+// and fails with ErrClosing. Once closed, commands fail with ErrClosed.
+//
+// It returns ErrClosed if the connection was already closed,
+// or an error if the connection cannot be closed.
 func (c *AeroSpaceSocketConnection) CloseConnection() error {
-	c.mu.Lock()
+	if c.closed.Load() {
+		return ErrClosed
+	}
+	if !c.closing.Swap(true) {
+		close(c.closingSignal())
+	}
+	c.drain()
 	defer c.mu.Unlock()
+	if c.closed.Swap(true) {
+		return ErrClosed
+	}
 	if c.Conn != nil {
 		err := c.Conn.Close()
 		if err != nil {
@@ -94,6 +181,53 @@ func (c *AeroSpaceSocketConnection) CloseConnection() error {
 	return nil
 }
 
+// closingSignal returns a channel closed once CloseConnection is called.
+func (c *AeroSpaceSocketConnection) closingSignal() chan struct{} {
+	c.closingOnce.Do(func() {
+		c.closingCh = make(chan struct{})
+	})
+	return c.closingCh
+}
+
+// checkOpen returns ErrClosed or ErrClosing if CloseConnection was called.
+func (c *AeroSpaceSocketConnection) checkOpen() error {
+	if c.closed.Load() {
+		return ErrClosed
+	}
+	if c.closing.Load() {
+		return ErrClosing
+	}
+	return nil
+}
+
+// drain acquires c.mu, waiting for the command in flight to finish.
+// Once the close timeout passes, the in-flight command is interrupted.
+func (c *AeroSpaceSocketConnection) drain() {
+	locked := make(chan struct{})
+	go func() {
+		c.mu.Lock()
+		close(locked)
+	}()
+
+	timeout := c.closeTimeout
+	if timeout <= 0 {
+		timeout = defaultCloseTimeout
+	}
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case <-locked:
+		return
+	case <-timer.C:
+	}
+
+	if conn := c.inflight.Load(); conn != nil {
+		interrupt(*conn)
+	}
+	<-locked
+}
+
 // GetServerVersion retrieves the version of the AeroSpace server.
 // It sends a command to the server to get its version and returns it.
 func (c *AeroSpaceSocketConnection) GetServerVersion() (string, error) {
@@ -101,7 +235,7 @@ func (c *AeroSpaceSocketConnection) GetServerVersion() (string, error) {
 		return "", fmt.Errorf("connection is not established")
 	}
 	// Send the command to get the server version
-	res, err := c.SendCommand("config", []string{"--config-path"})
+	res, err := c.SendCommand(commands.Config, []string{"--config-path"})
 	if err != nil {
 		return "", fmt.Errorf("failed to get server version\n%w", err)
 	}
@@ -113,47 +247,67 @@ func (c *AeroSpaceSocketConnection) GetServerVersion() (string, error) {
 	return res.ServerVersion, nil
 }
 
-// CheckServerVersion checks if the server version meets the minimum requirements.
-// It compares the server version against the minimum major and minor versions.
-func (c *AeroSpaceSocketConnection) CheckServerVersion() error {
-	serverVersion, err := c.GetServerVersion()
+// Ping performs a lightweight round-trip to the AeroSpace server and returns its latency,
+// so supervisors can health-check the server without parsing window lists.
+//
+// It is equivalent to running the command:
+//
+//	aerospace config --config-path
+//
+// Returns an error if the server does not answer, e.g. before ctx is done.
+//
+// Usage:
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+//	defer cancel()
+//	latency, err := client.Connection().Ping(ctx)
+//	if err != nil {
+//	    log.Printf("AeroSpace is not responding: %v", err)
+//	}
+func (c *AeroSpaceSocketConnection) Ping(ctx context.Context) (time.Duration, error) {
+	start := time.Now()
+	_, err := c.SendCommandContext(ctx, commands.Config, []string{"--config-path"})
 	if err != nil {
-		return fmt.Errorf("failed to get server version\n%w", err)
+		return 0, fmt.Errorf("failed to ping server\n%w", err)
 	}
 
-	if serverVersion == "" {
-		return fmt.Errorf("server version is empty")
-	}
-	parts := strings.Split(serverVersion, "-")
-	versionParts := strings.Split(parts[0], ".")
-	if len(versionParts) < 2 {
-		fmt.Printf("[WARN] Invalid server version format: %s\n", serverVersion)
-	}
+	return time.Since(start), nil
+}
 
-	intMajor, err := strconv.Atoi(versionParts[0])
+// GetVersionInfo retrieves and parses the version of the AeroSpace server.
+//
+// The returned VersionInfo contains the raw version string reported by the server,
+// how it was parsed, and the constraint applied by this client.
+// The negotiation is logged at debug level on the connection Logger.
+//
+// Returns an error if the version cannot be retrieved or parsed.
+// A version that does not satisfy the constraint is not an error,
+// check VersionInfo.Compatible instead.
+func (c *AeroSpaceSocketConnection) GetVersionInfo() (*VersionInfo, error) {
+	serverVersion, err := c.GetServerVersion()
 	if err != nil {
-		return fmt.Errorf("failed to parse major version from %s\n%w", serverVersion, err)
+		return nil, fmt.Errorf("failed to get server version\n%w", err)
 	}
 
-	intMinor, err := strconv.Atoi(versionParts[1])
+	return negotiateVersion(c.logger(), serverVersion, c.MinMajorVersion, c.MinMinorVersion)
+}
+
+// CheckServerVersion checks if the server version meets the minimum requirements.
+// It compares the server version against the minimum major and minor versions.
+func (c *AeroSpaceSocketConnection) CheckServerVersion() error {
+	info, err := c.GetVersionInfo()
 	if err != nil {
-		return fmt.Errorf("failed to parse minor version from %s\n%w", serverVersion, err)
-	}
-
-	// Since AeroSpace may have breaking changes even in minor versions,
-	// I'll enforce exact match on major and minor versions
-	// Last breaking change was on from 0.19.x to 0.20.0
-	if intMajor != c.MinMajorVersion ||
-		intMajor == c.MinMajorVersion && intMinor != c.MinMinorVersion {
-		versionJoined := strings.Join(versionParts, ".")
-		return exceptions.NewErrVersionMismatch(
-			c.MinMajorVersion,
-			c.MinMinorVersion,
-			versionJoined,
-		)
+		return err
 	}
 
-	return nil
+	return checkVersion(info, c.MinMajorVersion, c.MinMinorVersion)
+}
+
+func (c *AeroSpaceSocketConnection) logger() *slog.Logger {
+	if c.Logger == nil {
+		return slog.Default()
+	}
+	return c.Logger
 }
 
 // SendCommand sends a raw command to the AeroSpace socket and returns a raw response.
@@ -175,18 +329,112 @@ func (c *AeroSpaceSocketConnection) CheckServerVersion() error {
 //	fmt.Println("Standard Output:", response.StdOut)
 //	fmt.Println("Standard Error:", response.StdErr)
 func (c *AeroSpaceSocketConnection) SendCommand(command string, args []string) (*Response, error) {
+	return c.SendCommandContext(context.Background(), command, args)
+}
+
+// SendCommandContext sends a raw command to the AeroSpace socket and returns a raw response,
+// aborting the request when ctx is done.
+//
+// The context deadline, if any, is applied to the socket reads and writes,
+// together with the timeout set by WithCommandTimeout, whichever comes first.
+// Cancelling the context unblocks a hung read. Since the response of an aborted
+// request could still arrive later, the connection is closed in that case and
+// subsequent commands fail until a new connection is created, unless
+// WithReconnect is set.
+//
+// Returns an error wrapping ctx.Err() if the context is done before the response is read.
+//
+// Usage:
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+//	defer cancel()
+//	response, err := client.SendCommandContext(ctx, "list-windows", []string{"--all", "--json"})
+//	if errors.Is(err, context.DeadlineExceeded) {
+//	  fmt.Println("AeroSpace did not answer in time")
+//	}
+//
+// Every invocation gets a correlation ID, included in the returned error and in the
+// debug logs of the connection Logger, see CommandID.
+func (c *AeroSpaceSocketConnection) SendCommandContext(ctx context.Context, command string, args []string) (*Response, error) {
+	return c.SendCommandWithStdin(ctx, command, args, "")
+}
+
+// SendCommandWithStdin is like SendCommandContext but passes stdin to the command,
+// as the AeroSpace CLI does with its own standard input.
+//
+// Usage:
+//
+//	// Move the focused window to the workspace after the focused one among "1" and "3"
+//	response, err := client.SendCommandWithStdin(ctx, "move-node-to-workspace", []string{"next", "--stdin"}, "1\n3\n")
+func (c *AeroSpaceSocketConnection) SendCommandWithStdin(ctx context.Context, command string, args []string, stdin string) (*Response, error) {
+	id := commandIDs.Add(1)
+	response, err := c.trace(ctx, id, command, args, func() (*Response, error) {
+		return c.sendCommand(ctx, id, command, args, stdin)
+	})
+	if err == nil {
+		reportWarning(c.stderrPolicy, c.onWarning, command, args, response)
+	}
+	return response, err
+}
+
+// trace runs send for the command identified by id, logging it at debug level
+// and annotating its error with the correlation ID.
+func (c *AeroSpaceSocketConnection) trace(ctx context.Context, id uint64, command string, args []string, send func() (*Response, error)) (*Response, error) {
+	logger := c.logger()
+	debug := logger.Enabled(ctx, slog.LevelDebug)
+	if debug {
+		logger.Debug("sending AeroSpace command", "id", id, "command", command, "args", args)
+	}
+
+	start := time.Now()
+	response, err := send()
+	if err != nil {
+		err = &correlatedError{id: id, command: command, args: args, err: err}
+	}
+
+	if debug {
+		logger.Debug(
+			"AeroSpace command done",
+			"id", id,
+			"command", command,
+			"duration", time.Since(start),
+			"error", err,
+		)
+	}
+	return response, err
+}
+
+// sendCommand sends the command identified by id and returns its response.
+func (c *AeroSpaceSocketConnection) sendCommand(ctx context.Context, id uint64, command string, args []string, stdin string) (*Response, error) {
+	if err := c.checkOpen(); err != nil {
+		return nil, err
+	}
 	c.mu.Lock()
 	defer c.mu.Unlock()
-	if c.Conn == nil {
+	return c.sendLocked(ctx, id, command, args, stdin, false)
+}
+
+// sendLocked sends the command identified by id and returns its response.
+// Unless keepLock is set, c.mu is released while backing off between retries.
+//
+// Must be called with c.mu held.
+func (c *AeroSpaceSocketConnection) sendLocked(ctx context.Context, id uint64, command string, args []string, stdin string, keepLock bool) (*Response, error) {
+	// The connection may have been closed while waiting for the lock.
+	if err := c.checkOpen(); err != nil {
+		return nil, err
+	}
+	if c.Conn == nil && !c.canRedial() {
 		return nil, fmt.Errorf("connection is not established")
 	}
-
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("failed to send command\n%w", err)
+	}
 	// Merge command and arguments into the Command struct
 	commandArgs := append([]string{command}, args...)
 	cmd := Command{
 		Command: "", // This field is deprecated and not used
 		Args:    commandArgs,
-		Stdin:   "",
+		Stdin:   stdin,
 	}
 
 	// For Version: 0.20.0 and above, we can pass the window ID via env variable
@@ -206,75 +454,394 @@ func (c *AeroSpaceSocketConnection) SendCommand(command string, args []string) (
 		cmd.Workspace = &workspace
 	}
 
-	cmdBytes, err := json.Marshal(cmd)
+	cmdBuf, err := encodeCommand(cmd)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal command\n%w", err)
 	}
+	defer putEncodeBuffer(cmdBuf)
+	cmdBytes := cmdBuf.Bytes()
+
+	if c.commandTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeoutCause(ctx, c.commandTimeout, &TimeoutError{
+			Command: command,
+			Timeout: c.commandTimeout,
+		})
+		defer cancel()
+	}
 
-	_, err = c.Conn.Write(cmdBytes)
-	if err != nil {
-		return nil, fmt.Errorf("failed to send command\n%w", err)
+	start := time.Now()
+	response, err := c.sendWithRetry(ctx, id, command, cmdBytes, keepLock)
+	if err == nil {
+		response, err = checkResponse(command, args, response, c.stderrPolicy)
 	}
+	c.stats.record(time.Since(start), err)
 
-	var responseData []byte
-	buf := make([]byte, 4096)
-	for {
-		n, err := c.Conn.Read(buf)
-		if err != nil {
-			if err == io.EOF {
-				break
-			}
-			return nil, fmt.Errorf(
-				"failed to read response\n%w\ndata\n%s",
-				err,
-				responseData,
-			)
+	return response, err
+}
+
+// sendWithRetry sends the command, retrying transient failures
+// as configured by WithRetry. Unless keepLock is set, c.mu is released
+// while backing off, so the other commands are not held up.
+//
+// Must be called with c.mu held.
+func (c *AeroSpaceSocketConnection) sendWithRetry(ctx context.Context, id uint64, command string, cmdBytes []byte, keepLock bool) (*Response, error) {
+	for attempt := 1; ; attempt++ {
+		response, err := c.send(ctx, id, cmdBytes)
+		if err == nil {
+			return response, nil
+		}
+		if attempt >= c.retry.MaxAttempts || ctx.Err() != nil || c.closing.Load() || !isTransient(err) {
+			return nil, err
+		}
+
+		delay := c.retry.delay(attempt)
+		c.logger().Debug(
+			"AeroSpace command failed, retrying",
+			"id", id,
+			"command", command,
+			"attempt", attempt,
+			"delay", delay,
+			"error", err,
+		)
+
+		if err := c.backoff(ctx, delay, keepLock, err); err != nil {
+			return nil, err
 		}
-		responseData = append(responseData, buf[:n]...)
-		if n < len(buf) {
-			break
+		// The connection may have been closed while the lock was released.
+		if err := c.checkOpen(); err != nil {
+			return nil, err
 		}
 	}
+}
 
-	var response Response
-	err = json.Unmarshal(responseData, &response)
-	if err != nil {
-		return nil, fmt.Errorf(
-			"failed to unmarshal socket response\n%w\ndata\n%s",
-			err,
-			responseData,
+// backoff waits for delay before retrying the command that failed with err,
+// releasing c.mu meanwhile unless keepLock is set. It stops early, returning
+// an error wrapping err, when ctx is done or CloseConnection is called.
+//
+// Must be called with c.mu held, which is held again when it returns.
+func (c *AeroSpaceSocketConnection) backoff(ctx context.Context, delay time.Duration, keepLock bool, err error) error {
+	if !keepLock {
+		c.mu.Unlock()
+		defer c.mu.Lock()
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return fmt.Errorf("%w\n%w", context.Cause(ctx), err)
+	case <-c.closingSignal():
+		return fmt.Errorf("%w\n%w", ErrClosing, err)
+	case <-timer.C:
+		return nil
+	}
+}
+
+// send sends the command once, reconnecting as configured by WithReconnect
+// when the server closed the connection.
+//
+// Must be called with c.mu held.
+func (c *AeroSpaceSocketConnection) send(ctx context.Context, id uint64, cmdBytes []byte) (*Response, error) {
+	for attempt := 0; ; attempt++ {
+		// The connection is nil after an aborted request or a broken pipe.
+		if c.Conn == nil {
+			if err := c.reconnect(); err != nil {
+				return nil, err
+			}
+		}
+
+		response, err := c.roundTrip(ctx, cmdBytes)
+		if err == nil {
+			return response, nil
+		}
+		if ctx.Err() != nil || c.closing.Load() || !isBrokenConnection(err) {
+			return nil, err
+		}
+
+		if c.canRedial() {
+			_ = c.Conn.Close()
+			c.Conn = nil
+		}
+		if attempt >= c.reconnectAttempts {
+			return nil, err
+		}
+
+		c.logger().Debug(
+			"AeroSpace connection broken, reconnecting",
+			"id", id,
+			"attempt", attempt+1,
+			"error", err,
 		)
 	}
+}
 
-	if response.ExitCode != 0 {
-		return nil, fmt.Errorf("command failed with exit code %d\n%s", response.ExitCode, response.StdErr)
+// canRedial reports whether a closed connection is dialed again on the next command.
+func (c *AeroSpaceSocketConnection) canRedial() bool {
+	return c.reconnectAttempts > 0 || c.retry.MaxAttempts > 1
+}
+
+// roundTrip writes the command to the connection and decodes its response.
+//
+// Must be called with c.mu held.
+func (c *AeroSpaceSocketConnection) roundTrip(ctx context.Context, cmdBytes []byte) (*Response, error) {
+	conn := c.Conn
+	c.inflight.Store(&conn)
+	defer c.inflight.Store(nil)
+
+	response, err := c.exchange(ctx, cmdBytes)
+	if err != nil && c.closing.Load() {
+		return nil, fmt.Errorf("%w\n%w", ErrClosing, err)
 	}
+	return response, err
+}
 
-	if response.StdErr != "" {
-		return nil, fmt.Errorf("command error\n%s", response.StdErr)
+// exchange writes the command to the connection and decodes its response.
+//
+// The response is decoded straight from the socket, so large outputs such as
+// list-windows --all are not buffered in full before being parsed.
+//
+// Must be called with c.mu held.
+func (c *AeroSpaceSocketConnection) exchange(ctx context.Context, cmdBytes []byte) (*Response, error) {
+	if ctx.Done() != nil {
+		stop := c.watchContext(ctx)
+		defer stop()
+	}
+
+	if err := c.writeAll(cmdBytes); err != nil {
+		return nil, c.abortOnContext(ctx, fmt.Errorf("failed to send command\n%w", err))
+	}
+
+	var response Response
+	if err := c.responseDecoder().Decode(&response); err != nil {
+		// A decoder keeps returning the first read error it saw.
+		c.decoder = nil
+		var syntaxErr *json.SyntaxError
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &syntaxErr) || errors.As(err, &typeErr) {
+			// The rest of the response is still unread, so the next
+			// command would decode it. Drop the connection instead.
+			_ = c.Conn.Close()
+			c.Conn = nil
+			return nil, fmt.Errorf("failed to unmarshal socket response\n%w", err)
+		}
+		return nil, c.abortOnContext(ctx, fmt.Errorf("failed to read response\n%w", err))
 	}
 
 	return &response, nil
 }
 
-// NewAeroSpaceSocketConnection creates a new AeroSpaceSocketConnection.
-// It initializes the connection to the AeroSpace socket.
-func NewAeroSpaceSocketConnection(socketPath string) (*AeroSpaceSocketConnection, error) {
-	if socketPath == "" {
-		return nil, fmt.Errorf("socket path cannot be empty")
+// responseDecoder returns the decoder for the current connection, creating
+// a new one after the connection was replaced.
+//
+// Must be called with c.mu held.
+func (c *AeroSpaceSocketConnection) responseDecoder() *json.Decoder {
+	if c.decoder == nil || c.decoderConn != c.Conn {
+		c.decoder = json.NewDecoder(bufio.NewReaderSize((*statsReader)(c), readBufferSize))
+		c.decoderConn = c.Conn
+	}
+	return c.decoder
+}
+
+// writeAll writes data to the connection, continuing after short writes
+// so large commands are not truncated. Every write is still bounded by
+// the deadline set by watchContext.
+//
+// Must be called with c.mu held.
+func (c *AeroSpaceSocketConnection) writeAll(data []byte) error {
+	for len(data) > 0 {
+		n, err := c.Conn.Write(data)
+		c.stats.bytesWritten.Add(uint64(n))
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			return io.ErrShortWrite
+		}
+		data = data[n:]
 	}
+	return nil
+}
 
-	conn, err := net.Dial("unix", socketPath)
+// reconnect dials the socket path again, replacing the current connection.
+//
+// Must be called with c.mu held.
+func (c *AeroSpaceSocketConnection) reconnect() error {
+	if c.socketPath == "" {
+		return fmt.Errorf("failed to reconnect\nmissing socket path")
+	}
+
+	conn, err := c.dial()
 	if err != nil {
-		return nil, fmt.Errorf("failed to connect to socket\n %w", err)
+		return fmt.Errorf("failed to reconnect to socket\n%w", err)
+	}
+	c.Conn = conn
+	c.stats.reconnects.Add(1)
+	return nil
+}
+
+// dial opens a new connection to the socket path using the configured Dialer.
+//
+// Returns an error matching ErrServerNotRunning if the socket is missing
+// or refuses the connection.
+func (c *AeroSpaceSocketConnection) dial() (net.Conn, error) {
+	dialer := c.dialer
+	if dialer == nil {
+		dialer = &net.Dialer{}
+	}
+
+	ctx := context.Background()
+	if c.dialTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.dialTimeout)
+		defer cancel()
+	}
+
+	network := c.network
+	if network == "" {
+		network = "unix"
+	}
+
+	conn, err := dialer.DialContext(ctx, network, c.socketPath)
+	if errors.Is(err, syscall.ENOENT) || errors.Is(err, syscall.ECONNREFUSED) {
+		return nil, fmt.Errorf("%w\n%w", ErrServerNotRunning, err)
+	}
+	return conn, err
+}
+
+// isBrokenConnection reports whether err means the server closed the connection,
+// e.g. because it restarted.
+func isBrokenConnection(err error) bool {
+	return errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrClosedPipe) ||
+		errors.Is(err, syscall.EPIPE) ||
+		errors.Is(err, syscall.ECONNRESET)
+}
+
+// isTransient reports whether a failed command may succeed if retried:
+// the socket could not be dialed, the connection broke or a socket operation timed out.
+func isTransient(err error) bool {
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		return true
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+
+	return isBrokenConnection(err)
+}
+
+// SendCommandAsync sends the command in the background, so UI event loops
+// are not blocked while AeroSpace answers, and delivers the outcome on the
+// returned channel. The channel receives exactly one Result and is then closed.
+//
+// Commands are still sent one at a time on the connection, but concurrent
+// async commands are not guaranteed to be sent in call order. Wait for a
+// Result before sending a command that depends on it.
+//
+// Usage:
+//
+//	result := client.SendCommandAsync(ctx, "focus", []string{"left"})
+//	// ... keep handling UI events ...
+//	if r := <-result; r.Err != nil {
+//	    log.Printf("focus failed: %v", r.Err)
+//	}
+func (c *AeroSpaceSocketConnection) SendCommandAsync(ctx context.Context, command string, args []string) <-chan Result {
+	// Buffered, so the result can be dropped without leaking the goroutine.
+	result := make(chan Result, 1)
+	go func() {
+		defer close(result)
+		response, err := c.SendCommandContext(ctx, command, args)
+		result <- Result{Response: response, Err: err}
+	}()
+	return result
+}
+
+// watchContext applies the ctx deadline to the connection and interrupts
+// pending I/O when ctx is done. The returned function must be called once
+// the request is over; it clears the deadline.
+func (c *AeroSpaceSocketConnection) watchContext(ctx context.Context) func() {
+	conn := c.Conn
+	if deadline, ok := ctx.Deadline(); ok {
+		setDeadline(conn, deadline)
 	}
 
+	done := make(chan struct{})
+	finished := make(chan struct{})
+	go func() {
+		defer close(finished)
+		select {
+		case <-ctx.Done():
+			interrupt(conn)
+		case <-done:
+		}
+	}()
+
+	return func() {
+		close(done)
+		<-finished
+		setDeadline(conn, time.Time{})
+	}
+}
+
+// abortOnContext closes the connection if err was caused by ctx being done,
+// so a late response is not read as the answer to the next command.
+// It returns err, wrapping the cause of ctx being done in that case.
+//
+// Errors caused by a deadline, rather than a cancellation or a close,
+// are marked to match ErrTimeout.
+//
+// Must be called with c.mu held.
+func (c *AeroSpaceSocketConnection) abortOnContext(ctx context.Context, err error) error {
+	deadline, hasDeadline := ctx.Deadline()
+	if hasDeadline && !time.Now().Before(deadline) && isTimeout(err) {
+		// The socket deadline may fire slightly before the context notices it.
+		<-ctx.Done()
+	}
+
+	if ctx.Err() == nil {
+		if isTimeout(err) && !c.closing.Load() {
+			// A deadline set on the transport itself.
+			return &timeoutError{err: err}
+		}
+		return err
+	}
+
+	_ = c.Conn.Close()
+	c.Conn = nil
+	err = fmt.Errorf("%w\n%w", context.Cause(ctx), err)
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return &timeoutError{err: err}
+	}
+	return err
+}
+
+// NewAeroSpaceSocketConnection creates a new AeroSpaceSocketConnection.
+// It initializes the connection to the AeroSpace socket.
+//
+// Options such as WithCommandTimeout customize the connection.
+func NewAeroSpaceSocketConnection(socketPath string, opts ...Option) (*AeroSpaceSocketConnection, error) {
 	client := &AeroSpaceSocketConnection{
 		socketPath:      socketPath,
 		MinMajorVersion: constants.AeroSpaceSocketClientMajor,
 		MinMinorVersion: constants.AeroSpaceSocketClientMinor,
-		Conn:            conn,
 	}
+	for _, opt := range opts {
+		opt(client)
+	}
+
+	if client.socketPath == "" {
+		return nil, fmt.Errorf("socket path cannot be empty")
+	}
+
+	conn, err := client.dialOrStart()
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to socket\n %w", err)
+	}
+	client.Conn = conn
 
 	return client, nil
 }
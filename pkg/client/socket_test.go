@@ -1,12 +1,21 @@
 package client
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
+	"log/slog"
 	"net"
+	"os"
+	"path/filepath"
+	"reflect"
 	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/cristianoliveira/aerospace-ipc/internal/exceptions"
 	"github.com/cristianoliveira/aerospace-ipc/internal/mocks/net"
@@ -92,7 +101,7 @@ func TestSocketClient(t *testing.T) {
 			gomock.InOrder(
 				mockConn.EXPECT().
 					Write(gomock.Any()).
-					Return(0, nil),
+					DoAndReturn(acceptWrite),
 
 				mockConn.EXPECT().
 					Read(gomock.Any()).
@@ -195,7 +204,7 @@ func TestCheckServerVersion(t *testing.T) {
 				gomock.InOrder(
 					mockConn.EXPECT().
 						Write(gomock.Any()).
-						Return(len(cmdBytes), nil),
+						DoAndReturn(acceptWrite),
 
 					mockConn.EXPECT().
 						Read(gomock.Any()).
@@ -279,7 +288,7 @@ func TestCheckServerVersion(t *testing.T) {
 					gomock.InOrder(
 						mockConn.EXPECT().
 							Write(gomock.Any()).
-							Return(len(cmdBytes), nil),
+							DoAndReturn(acceptWrite),
 
 						mockConn.EXPECT().
 							Read(gomock.Any()).
@@ -299,7 +308,7 @@ func TestCheckServerVersion(t *testing.T) {
 				expectedErrorMsg: "server version is empty",
 			},
 			{
-				name:            "invalid version format - only major version (causes panic)",
+				name:            "invalid version format - only major version",
 				minMajorVersion: 0,
 				minMinorVersion: 20,
 				serverVersion:   "0",
@@ -316,7 +325,7 @@ func TestCheckServerVersion(t *testing.T) {
 					gomock.InOrder(
 						mockConn.EXPECT().
 							Write(gomock.Any()).
-							Return(len(cmdBytes), nil),
+							DoAndReturn(acceptWrite),
 
 						mockConn.EXPECT().
 							Read(gomock.Any()).
@@ -333,7 +342,7 @@ func TestCheckServerVersion(t *testing.T) {
 							Times(1),
 					)
 				},
-				expectedErrorMsg: "panic", // The function panics when versionParts[1] is accessed
+				expectedErrorMsg: "invalid server version format",
 			},
 			{
 				name:            "non-numeric major version",
@@ -353,7 +362,7 @@ func TestCheckServerVersion(t *testing.T) {
 					gomock.InOrder(
 						mockConn.EXPECT().
 							Write(gomock.Any()).
-							Return(len(cmdBytes), nil),
+							DoAndReturn(acceptWrite),
 
 						mockConn.EXPECT().
 							Read(gomock.Any()).
@@ -390,7 +399,7 @@ func TestCheckServerVersion(t *testing.T) {
 					gomock.InOrder(
 						mockConn.EXPECT().
 							Write(gomock.Any()).
-							Return(len(cmdBytes), nil),
+							DoAndReturn(acceptWrite),
 
 						mockConn.EXPECT().
 							Read(gomock.Any()).
@@ -427,7 +436,7 @@ func TestCheckServerVersion(t *testing.T) {
 					gomock.InOrder(
 						mockConn.EXPECT().
 							Write(gomock.Any()).
-							Return(len(cmdBytes), nil),
+							DoAndReturn(acceptWrite),
 
 						mockConn.EXPECT().
 							Read(gomock.Any()).
@@ -464,7 +473,7 @@ func TestCheckServerVersion(t *testing.T) {
 					gomock.InOrder(
 						mockConn.EXPECT().
 							Write(gomock.Any()).
-							Return(len(cmdBytes), nil),
+							DoAndReturn(acceptWrite),
 
 						mockConn.EXPECT().
 							Read(gomock.Any()).
@@ -501,7 +510,7 @@ func TestCheckServerVersion(t *testing.T) {
 					gomock.InOrder(
 						mockConn.EXPECT().
 							Write(gomock.Any()).
-							Return(len(cmdBytes), nil),
+							DoAndReturn(acceptWrite),
 
 						mockConn.EXPECT().
 							Read(gomock.Any()).
@@ -538,7 +547,7 @@ func TestCheckServerVersion(t *testing.T) {
 					gomock.InOrder(
 						mockConn.EXPECT().
 							Write(gomock.Any()).
-							Return(len(cmdBytes), nil),
+							DoAndReturn(acceptWrite),
 
 						mockConn.EXPECT().
 							Read(gomock.Any()).
@@ -575,7 +584,7 @@ func TestCheckServerVersion(t *testing.T) {
 					gomock.InOrder(
 						mockConn.EXPECT().
 							Write(gomock.Any()).
-							Return(len(cmdBytes), nil),
+							DoAndReturn(acceptWrite),
 
 						mockConn.EXPECT().
 							Read(gomock.Any()).
@@ -612,7 +621,7 @@ func TestCheckServerVersion(t *testing.T) {
 					gomock.InOrder(
 						mockConn.EXPECT().
 							Write(gomock.Any()).
-							Return(len(cmdBytes), nil),
+							DoAndReturn(acceptWrite),
 
 						mockConn.EXPECT().
 							Read(gomock.Any()).
@@ -649,7 +658,7 @@ func TestCheckServerVersion(t *testing.T) {
 					gomock.InOrder(
 						mockConn.EXPECT().
 							Write(gomock.Any()).
-							Return(len(cmdBytes), nil),
+							DoAndReturn(acceptWrite),
 
 						mockConn.EXPECT().
 							Read(gomock.Any()).
@@ -686,7 +695,7 @@ func TestCheckServerVersion(t *testing.T) {
 					gomock.InOrder(
 						mockConn.EXPECT().
 							Write(gomock.Any()).
-							Return(len(cmdBytes), nil),
+							DoAndReturn(acceptWrite),
 
 						mockConn.EXPECT().
 							Read(gomock.Any()).
@@ -723,7 +732,7 @@ func TestCheckServerVersion(t *testing.T) {
 					gomock.InOrder(
 						mockConn.EXPECT().
 							Write(gomock.Any()).
-							Return(len(cmdBytes), nil),
+							DoAndReturn(acceptWrite),
 
 						mockConn.EXPECT().
 							Read(gomock.Any()).
@@ -760,7 +769,7 @@ func TestCheckServerVersion(t *testing.T) {
 					gomock.InOrder(
 						mockConn.EXPECT().
 							Write(gomock.Any()).
-							Return(len(cmdBytes), nil),
+							DoAndReturn(acceptWrite),
 
 						mockConn.EXPECT().
 							Read(gomock.Any()).
@@ -802,18 +811,6 @@ func TestCheckServerVersion(t *testing.T) {
 					socketPath:      "/tmp/aerospace.sock",
 				}
 
-				// Handle panic case for invalid version format
-				if tc.expectedErrorMsg == "panic" {
-					defer func() {
-						if r := recover(); r == nil {
-							tt.Fatalf("expected panic for invalid version format, but no panic occurred")
-						}
-					}()
-					_ = connection.CheckServerVersion()
-					tt.Fatalf("expected panic, but function completed")
-					return
-				}
-
 				err := connection.CheckServerVersion()
 				if err == nil {
 					tt.Fatalf("expected error containing '%s', got nil", tc.expectedErrorMsg)
@@ -839,3 +836,1025 @@ func TestCheckServerVersion(t *testing.T) {
 		}
 	})
 }
+
+// acceptWrite mocks a successful write of p.
+func acceptWrite(p []byte) (int, error) {
+	return len(p), nil
+}
+
+// serve answers every request received on conn with response, after delay.
+func serve(conn net.Conn, response Response, delay time.Duration) {
+	responseBytes, _ := json.Marshal(response)
+	buf := make([]byte, 4096)
+	for {
+		if _, err := conn.Read(buf); err != nil {
+			return
+		}
+		time.Sleep(delay)
+		if _, err := conn.Write(responseBytes); err != nil {
+			return
+		}
+	}
+}
+
+func TestSendCommandContext(t *testing.T) {
+	t.Run("Happy path", func(tt *testing.T) {
+		tt.Run("returns the response before the deadline", func(ttt *testing.T) {
+			clientConn, serverConn := net.Pipe()
+			defer serverConn.Close()
+			go serve(serverConn, Response{StdOut: "ok"}, 0)
+
+			connection := &AeroSpaceSocketConnection{Conn: clientConn}
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+
+			response, err := connection.SendCommandContext(ctx, "list-modes", []string{"--current"})
+			if err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+			if response.StdOut != "ok" {
+				ttt.Fatalf("expected stdout 'ok', got %q", response.StdOut)
+			}
+
+			// The deadline must not leak into the next command.
+			response, err = connection.SendCommand("list-modes", []string{"--current"})
+			if err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+			if response.StdOut != "ok" {
+				ttt.Fatalf("expected stdout 'ok', got %q", response.StdOut)
+			}
+		})
+	})
+
+	t.Run("Error cases", func(tt *testing.T) {
+		tt.Run("fails when the deadline is exceeded", func(ttt *testing.T) {
+			clientConn, serverConn := net.Pipe()
+			defer serverConn.Close()
+			go serve(serverConn, Response{StdOut: "late"}, time.Second)
+
+			connection := &AeroSpaceSocketConnection{Conn: clientConn}
+			ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+			defer cancel()
+
+			_, err := connection.SendCommandContext(ctx, "list-modes", []string{"--current"})
+			if !errors.Is(err, context.DeadlineExceeded) {
+				ttt.Fatalf("expected context.DeadlineExceeded, got %v", err)
+			}
+			if !errors.Is(err, ErrTimeout) {
+				ttt.Fatalf("expected ErrTimeout, got %v", err)
+			}
+			if connection.Conn != nil {
+				ttt.Fatalf("expected connection to be closed after an aborted request")
+			}
+		})
+
+		tt.Run("unblocks a hung read when cancelled", func(ttt *testing.T) {
+			clientConn, serverConn := net.Pipe()
+			defer serverConn.Close()
+			go serve(serverConn, Response{StdOut: "late"}, time.Second)
+
+			connection := &AeroSpaceSocketConnection{Conn: clientConn}
+			ctx, cancel := context.WithCancel(context.Background())
+			time.AfterFunc(20*time.Millisecond, cancel)
+
+			_, err := connection.SendCommandContext(ctx, "list-modes", []string{"--current"})
+			if !errors.Is(err, context.Canceled) {
+				ttt.Fatalf("expected context.Canceled, got %v", err)
+			}
+			if errors.Is(err, ErrTimeout) {
+				ttt.Fatalf("expected a cancellation not to match ErrTimeout, got %v", err)
+			}
+		})
+
+		tt.Run("does not send when the context is already done", func(ttt *testing.T) {
+			ctrl := gomock.NewController(ttt)
+			defer ctrl.Finish()
+
+			// No expectations: any write fails the test.
+			mockConn := net_mock.NewMockConn(ctrl)
+			connection := &AeroSpaceSocketConnection{Conn: mockConn}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+
+			_, err := connection.SendCommandContext(ctx, "list-modes", []string{"--current"})
+			if !errors.Is(err, context.Canceled) {
+				ttt.Fatalf("expected context.Canceled, got %v", err)
+			}
+		})
+	})
+}
+
+func TestWithCommandTimeout(t *testing.T) {
+	t.Run("Happy path", func(tt *testing.T) {
+		tt.Run("returns the response within the timeout", func(ttt *testing.T) {
+			clientConn, serverConn := net.Pipe()
+			defer serverConn.Close()
+			go serve(serverConn, Response{StdOut: "ok"}, 0)
+
+			connection := &AeroSpaceSocketConnection{Conn: clientConn}
+			WithCommandTimeout(time.Second)(connection)
+
+			response, err := connection.SendCommand("list-modes", []string{"--current"})
+			if err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+			if response.StdOut != "ok" {
+				ttt.Fatalf("expected stdout 'ok', got %q", response.StdOut)
+			}
+		})
+	})
+
+	t.Run("Error cases", func(tt *testing.T) {
+		tt.Run("fails with a TimeoutError when the server stalls", func(ttt *testing.T) {
+			clientConn, serverConn := net.Pipe()
+			defer serverConn.Close()
+			go serve(serverConn, Response{StdOut: "late"}, time.Second)
+
+			connection := &AeroSpaceSocketConnection{Conn: clientConn}
+			WithCommandTimeout(20 * time.Millisecond)(connection)
+
+			_, err := connection.SendCommand("list-modes", []string{"--current"})
+			var timeoutErr *TimeoutError
+			if !errors.As(err, &timeoutErr) {
+				ttt.Fatalf("expected a TimeoutError, got %v", err)
+			}
+			if timeoutErr.Command != "list-modes" || timeoutErr.Timeout != 20*time.Millisecond {
+				ttt.Fatalf("unexpected timeout error %+v", timeoutErr)
+			}
+			if !errors.Is(err, context.DeadlineExceeded) {
+				ttt.Fatalf("expected error to match context.DeadlineExceeded, got %v", err)
+			}
+			if !errors.Is(err, ErrTimeout) {
+				ttt.Fatalf("expected error to match ErrTimeout, got %v", err)
+			}
+			if connection.Conn != nil {
+				ttt.Fatalf("expected connection to be closed after a timeout")
+			}
+		})
+
+		tt.Run("an earlier context deadline wins", func(ttt *testing.T) {
+			clientConn, serverConn := net.Pipe()
+			defer serverConn.Close()
+			go serve(serverConn, Response{StdOut: "late"}, time.Second)
+
+			connection := &AeroSpaceSocketConnection{Conn: clientConn}
+			WithCommandTimeout(time.Minute)(connection)
+			ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+			defer cancel()
+
+			_, err := connection.SendCommandContext(ctx, "list-modes", []string{"--current"})
+			var timeoutErr *TimeoutError
+			if errors.As(err, &timeoutErr) {
+				ttt.Fatalf("expected the context deadline, got %v", err)
+			}
+			if !errors.Is(err, context.DeadlineExceeded) {
+				ttt.Fatalf("expected context.DeadlineExceeded, got %v", err)
+			}
+		})
+	})
+}
+
+// listen starts a unix socket server answering every request with response.
+// Accepted server-side connections are sent to the returned channel.
+func listen(t *testing.T, response Response) (string, <-chan net.Conn) {
+	accepted := make(chan net.Conn, 10)
+	socketPath := listenFunc(t, func(conn net.Conn) {
+		accepted <- conn
+		serve(conn, response, 0)
+	})
+	return socketPath, accepted
+}
+
+// listenFunc starts a unix socket server calling handle for every accepted connection.
+func listenFunc(t *testing.T, handle func(conn net.Conn)) string {
+	dir, err := os.MkdirTemp("", "aerospace")
+	if err != nil {
+		t.Fatalf("failed to create socket dir: %v", err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	socketPath := filepath.Join(dir, "aerospace.sock")
+	listener, err := net.Listen("unix", socketPath)
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go handle(conn)
+		}
+	}()
+
+	return socketPath
+}
+
+func TestWithReconnect(t *testing.T) {
+	t.Run("Happy path", func(tt *testing.T) {
+		tt.Run("resends the command when the server closed the connection", func(ttt *testing.T) {
+			socketPath, accepted := listen(ttt, Response{StdOut: "ok"})
+
+			connection, err := NewAeroSpaceSocketConnection(socketPath, WithReconnect(1))
+			if err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+			defer connection.CloseConnection()
+
+			// Simulates a server restart
+			(<-accepted).Close()
+
+			response, err := connection.SendCommand("list-modes", []string{"--current"})
+			if err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+			if response.StdOut != "ok" {
+				ttt.Fatalf("expected stdout 'ok', got %q", response.StdOut)
+			}
+		})
+
+		tt.Run("reconnects after an aborted request", func(ttt *testing.T) {
+			socketPath, _ := listen(ttt, Response{StdOut: "ok"})
+
+			connection, err := NewAeroSpaceSocketConnection(socketPath, WithReconnect(1))
+			if err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+			defer connection.CloseConnection()
+
+			connection.Conn.Close()
+			connection.Conn = nil
+
+			response, err := connection.SendCommand("list-modes", []string{"--current"})
+			if err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+			if response.StdOut != "ok" {
+				ttt.Fatalf("expected stdout 'ok', got %q", response.StdOut)
+			}
+		})
+	})
+
+	t.Run("Error cases", func(tt *testing.T) {
+		tt.Run("fails without reconnection", func(ttt *testing.T) {
+			socketPath, accepted := listen(ttt, Response{StdOut: "ok"})
+
+			connection, err := NewAeroSpaceSocketConnection(socketPath)
+			if err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+			defer connection.CloseConnection()
+
+			(<-accepted).Close()
+
+			_, err = connection.SendCommand("list-modes", []string{"--current"})
+			if err == nil {
+				ttt.Fatal("expected error, got nil")
+			}
+		})
+
+		tt.Run("fails when the server is gone", func(ttt *testing.T) {
+			connection := &AeroSpaceSocketConnection{
+				socketPath: filepath.Join(ttt.TempDir(), "missing.sock"),
+			}
+			WithReconnect(3)(connection)
+
+			_, err := connection.SendCommand("list-modes", []string{"--current"})
+			if err == nil || !strings.Contains(err.Error(), "failed to reconnect to socket") {
+				ttt.Fatalf("expected reconnect error, got %v", err)
+			}
+		})
+	})
+}
+
+func TestWithRetry(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 3, Backoff: time.Millisecond}
+
+	t.Run("Happy path", func(tt *testing.T) {
+		tt.Run("retries until the server answers", func(ttt *testing.T) {
+			var accepted atomic.Int32
+			socketPath := listenFunc(ttt, func(conn net.Conn) {
+				// The first connections are dropped, as while AeroSpace restarts
+				if accepted.Add(1) < 3 {
+					conn.Close()
+					return
+				}
+				serve(conn, Response{StdOut: "ok"}, 0)
+			})
+
+			connection, err := NewAeroSpaceSocketConnection(socketPath, WithRetry(policy))
+			if err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+			defer connection.CloseConnection()
+
+			response, err := connection.SendCommand("list-modes", []string{"--current"})
+			if err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+			if response.StdOut != "ok" {
+				ttt.Fatalf("expected stdout 'ok', got %q", response.StdOut)
+			}
+			if accepted.Load() != 3 {
+				ttt.Fatalf("expected 3 connections, got %d", accepted.Load())
+			}
+		})
+	})
+
+	t.Run("Error cases", func(tt *testing.T) {
+		tt.Run("gives up after MaxAttempts", func(ttt *testing.T) {
+			connection := &AeroSpaceSocketConnection{
+				socketPath: filepath.Join(ttt.TempDir(), "missing.sock"),
+			}
+			WithRetry(policy)(connection)
+
+			_, err := connection.SendCommand("list-modes", []string{"--current"})
+			if err == nil || !strings.Contains(err.Error(), "failed to reconnect to socket") {
+				ttt.Fatalf("expected reconnect error, got %v", err)
+			}
+		})
+
+		tt.Run("does not retry command failures", func(ttt *testing.T) {
+			var accepted atomic.Int32
+			socketPath := listenFunc(ttt, func(conn net.Conn) {
+				accepted.Add(1)
+				serve(conn, Response{ExitCode: 1, StdErr: "unknown command"}, 0)
+			})
+
+			connection, err := NewAeroSpaceSocketConnection(socketPath, WithRetry(policy))
+			if err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+			defer connection.CloseConnection()
+
+			_, err = connection.SendCommand("unknown", nil)
+			if err == nil {
+				ttt.Fatal("expected error, got nil")
+			}
+			if accepted.Load() != 1 {
+				ttt.Fatalf("expected a single connection, got %d", accepted.Load())
+			}
+		})
+
+		tt.Run("stops waiting when the context is done", func(ttt *testing.T) {
+			connection := &AeroSpaceSocketConnection{
+				socketPath: filepath.Join(ttt.TempDir(), "missing.sock"),
+			}
+			WithRetry(RetryPolicy{MaxAttempts: 3, Backoff: time.Minute})(connection)
+
+			ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+			defer cancel()
+
+			_, err := connection.SendCommandContext(ctx, "list-modes", []string{"--current"})
+			if !errors.Is(err, context.DeadlineExceeded) {
+				ttt.Fatalf("expected context.DeadlineExceeded, got %v", err)
+			}
+		})
+
+		tt.Run("releases the connection while backing off and stops once closed", func(ttt *testing.T) {
+			connection := &AeroSpaceSocketConnection{
+				socketPath: filepath.Join(ttt.TempDir(), "missing.sock"),
+			}
+			WithRetry(RetryPolicy{MaxAttempts: 3, Backoff: time.Minute})(connection)
+
+			result := connection.SendCommandAsync(context.Background(), "list-modes", []string{"--current"})
+			time.Sleep(20 * time.Millisecond)
+			if !connection.mu.TryLock() {
+				ttt.Fatal("expected the connection to be released while backing off")
+			}
+			connection.mu.Unlock()
+
+			start := time.Now()
+			if err := connection.CloseConnection(); err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+			r := <-result
+			if !errors.Is(r.Err, ErrClosing) && !errors.Is(r.Err, ErrClosed) {
+				ttt.Fatalf("expected ErrClosing, got %v", r.Err)
+			}
+			if elapsed := time.Since(start); elapsed > time.Second {
+				ttt.Fatalf("expected the backoff to stop once closed, took %s", elapsed)
+			}
+		})
+	})
+}
+
+func TestPing(t *testing.T) {
+	t.Run("returns the round-trip latency", func(tt *testing.T) {
+		clientConn, serverConn := net.Pipe()
+		defer serverConn.Close()
+		go serve(serverConn, Response{StdOut: "/Users/me/.aerospace.toml"}, 10*time.Millisecond)
+
+		connection := &AeroSpaceSocketConnection{Conn: clientConn}
+		latency, err := connection.Ping(context.Background())
+		if err != nil {
+			tt.Fatalf("unexpected error: %v", err)
+		}
+		if latency < 10*time.Millisecond {
+			tt.Fatalf("expected latency of at least 10ms, got %s", latency)
+		}
+	})
+
+	t.Run("fails when the server does not answer in time", func(tt *testing.T) {
+		clientConn, serverConn := net.Pipe()
+		defer serverConn.Close()
+		go serve(serverConn, Response{}, time.Second)
+
+		connection := &AeroSpaceSocketConnection{Conn: clientConn}
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		_, err := connection.Ping(ctx)
+		if !errors.Is(err, context.DeadlineExceeded) {
+			tt.Fatalf("expected context.DeadlineExceeded, got %v", err)
+		}
+	})
+}
+
+// fakeDialer opens in-memory connections served with response.
+type fakeDialer struct {
+	response Response
+	err      error
+	dials    []string
+	deadline bool
+}
+
+func (d *fakeDialer) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	d.dials = append(d.dials, network+":"+address)
+	_, d.deadline = ctx.Deadline()
+	if d.err != nil {
+		return nil, d.err
+	}
+
+	clientConn, serverConn := net.Pipe()
+	go serve(serverConn, d.response, 0)
+	return clientConn, nil
+}
+
+func TestWithDialer(t *testing.T) {
+	t.Run("Happy path", func(tt *testing.T) {
+		tt.Run("opens and reopens the connection with the dialer", func(ttt *testing.T) {
+			dialer := &fakeDialer{response: Response{StdOut: "ok"}}
+			connection, err := NewAeroSpaceSocketConnection(
+				"/tmp/aerospace.sock",
+				WithDialer(dialer),
+				WithReconnect(1),
+			)
+			if err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+
+			connection.Conn.Close()
+			connection.Conn = nil
+			if _, err := connection.SendCommand("list-modes", []string{"--current"}); err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+
+			expected := []string{"unix:/tmp/aerospace.sock", "unix:/tmp/aerospace.sock"}
+			if !reflect.DeepEqual(dialer.dials, expected) {
+				ttt.Fatalf("expected dials %v, got %v", expected, dialer.dials)
+			}
+			if dialer.deadline {
+				ttt.Fatalf("expected no dial deadline without WithDialTimeout")
+			}
+		})
+
+		tt.Run("bounds the dial with WithDialTimeout", func(ttt *testing.T) {
+			dialer := &fakeDialer{}
+			_, err := NewAeroSpaceSocketConnection(
+				"/tmp/aerospace.sock",
+				WithDialer(dialer),
+				WithDialTimeout(time.Second),
+			)
+			if err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+			if !dialer.deadline {
+				ttt.Fatalf("expected the dial to have a deadline")
+			}
+		})
+	})
+
+	t.Run("Error cases", func(tt *testing.T) {
+		tt.Run("fails when the dialer fails", func(ttt *testing.T) {
+			dialErr := errors.New("connection refused")
+			_, err := NewAeroSpaceSocketConnection("/tmp/aerospace.sock", WithDialer(&fakeDialer{err: dialErr}))
+			if !errors.Is(err, dialErr) {
+				ttt.Fatalf("expected dial error, got %v", err)
+			}
+		})
+	})
+}
+
+func TestWithNetwork(t *testing.T) {
+	t.Run("connects over tcp", func(tt *testing.T) {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			tt.Fatalf("failed to listen: %v", err)
+		}
+		defer listener.Close()
+		go func() {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			serve(conn, Response{StdOut: "ok"}, 0)
+		}()
+
+		connection, err := NewAeroSpaceSocketConnection("", WithNetwork("tcp", listener.Addr().String()))
+		if err != nil {
+			tt.Fatalf("unexpected error: %v", err)
+		}
+		defer connection.CloseConnection()
+
+		response, err := connection.SendCommand("list-modes", []string{"--current"})
+		if err != nil {
+			tt.Fatalf("unexpected error: %v", err)
+		}
+		if response.StdOut != "ok" {
+			tt.Fatalf("expected stdout 'ok', got %q", response.StdOut)
+		}
+
+		address, err := connection.GetSocketPath()
+		if err != nil || address != listener.Addr().String() {
+			tt.Fatalf("expected address %s, got %q (%v)", listener.Addr(), address, err)
+		}
+	})
+
+	t.Run("fails without an address", func(tt *testing.T) {
+		_, err := NewAeroSpaceSocketConnection("", WithNetwork("tcp", ""))
+		if err == nil {
+			tt.Fatal("expected error, got nil")
+		}
+	})
+}
+
+func TestErrServerNotRunning(t *testing.T) {
+	t.Run("when the socket is missing", func(tt *testing.T) {
+		_, err := NewAeroSpaceSocketConnection(filepath.Join(tt.TempDir(), "missing.sock"))
+		if !errors.Is(err, ErrServerNotRunning) {
+			tt.Fatalf("expected ErrServerNotRunning, got %v", err)
+		}
+	})
+
+	t.Run("when the socket refuses connections", func(tt *testing.T) {
+		socketPath := filepath.Join(tt.TempDir(), "stale.sock")
+		listener, err := net.ListenUnix("unix", &net.UnixAddr{Name: socketPath, Net: "unix"})
+		if err != nil {
+			tt.Fatalf("failed to listen: %v", err)
+		}
+		listener.SetUnlinkOnClose(false)
+		listener.Close()
+
+		_, err = NewAeroSpaceSocketConnection(socketPath)
+		if !errors.Is(err, ErrServerNotRunning) {
+			tt.Fatalf("expected ErrServerNotRunning, got %v", err)
+		}
+	})
+
+	t.Run("not for other dial failures", func(tt *testing.T) {
+		_, err := NewAeroSpaceSocketConnection("/tmp/aerospace.sock", WithDialer(&fakeDialer{err: errors.New("permission denied")}))
+		if errors.Is(err, ErrServerNotRunning) {
+			tt.Fatalf("expected a generic dial error, got %v", err)
+		}
+	})
+}
+
+func TestCloseConnection(t *testing.T) {
+	// serveSignaling answers requests after delay, signaling each received request.
+	serveSignaling := func(conn net.Conn, received chan<- struct{}, delay time.Duration) {
+		responseBytes, _ := json.Marshal(Response{StdOut: "ok"})
+		buf := make([]byte, 4096)
+		for {
+			if _, err := conn.Read(buf); err != nil {
+				return
+			}
+			received <- struct{}{}
+			time.Sleep(delay)
+			if _, err := conn.Write(responseBytes); err != nil {
+				return
+			}
+		}
+	}
+
+	t.Run("Happy path", func(tt *testing.T) {
+		tt.Run("waits for the command in flight", func(ttt *testing.T) {
+			clientConn, serverConn := net.Pipe()
+			defer serverConn.Close()
+			received := make(chan struct{}, 1)
+			go serveSignaling(serverConn, received, 50*time.Millisecond)
+
+			connection := &AeroSpaceSocketConnection{Conn: clientConn}
+			result := make(chan error, 1)
+			go func() {
+				_, err := connection.SendCommand("list-modes", []string{"--current"})
+				result <- err
+			}()
+			<-received
+
+			if err := connection.CloseConnection(); err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+			if err := <-result; err != nil {
+				ttt.Fatalf("expected the in-flight command to finish, got %v", err)
+			}
+		})
+	})
+
+	t.Run("Error cases", func(tt *testing.T) {
+		tt.Run("rejects commands after close", func(ttt *testing.T) {
+			clientConn, serverConn := net.Pipe()
+			defer serverConn.Close()
+
+			connection := &AeroSpaceSocketConnection{Conn: clientConn}
+			if err := connection.CloseConnection(); err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+
+			_, err := connection.SendCommand("list-modes", []string{"--current"})
+			if !errors.Is(err, ErrClosed) {
+				ttt.Fatalf("expected ErrClosed, got %v", err)
+			}
+			_, err = connection.GetServerVersion()
+			if !errors.Is(err, ErrClosed) {
+				ttt.Fatalf("expected ErrClosed, got %v", err)
+			}
+			if err := connection.CloseConnection(); !errors.Is(err, ErrClosed) {
+				ttt.Fatalf("expected ErrClosed on a second close, got %v", err)
+			}
+		})
+
+		tt.Run("rejects commands while closing", func(ttt *testing.T) {
+			clientConn, serverConn := net.Pipe()
+			defer serverConn.Close()
+			received := make(chan struct{}, 1)
+			go serveSignaling(serverConn, received, 50*time.Millisecond)
+
+			connection := &AeroSpaceSocketConnection{Conn: clientConn}
+			go connection.SendCommand("list-modes", []string{"--current"})
+			<-received
+
+			closed := make(chan error, 1)
+			go func() { closed <- connection.CloseConnection() }()
+			for !connection.closing.Load() {
+				time.Sleep(time.Millisecond)
+			}
+
+			_, err := connection.SendCommand("list-modes", []string{"--current"})
+			if !errors.Is(err, ErrClosing) {
+				ttt.Fatalf("expected ErrClosing, got %v", err)
+			}
+			if err := <-closed; err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+		})
+
+		tt.Run("interrupts a stalled command after the close timeout", func(ttt *testing.T) {
+			clientConn, serverConn := net.Pipe()
+			defer serverConn.Close()
+			received := make(chan struct{}, 1)
+			go serveSignaling(serverConn, received, time.Minute)
+
+			connection := &AeroSpaceSocketConnection{Conn: clientConn}
+			WithCloseTimeout(20 * time.Millisecond)(connection)
+			result := make(chan error, 1)
+			go func() {
+				_, err := connection.SendCommand("list-modes", []string{"--current"})
+				result <- err
+			}()
+			<-received
+
+			start := time.Now()
+			if err := connection.CloseConnection(); err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+			if elapsed := time.Since(start); elapsed > time.Second {
+				ttt.Fatalf("expected close to return after the timeout, took %s", elapsed)
+			}
+			if err := <-result; !errors.Is(err, ErrClosing) {
+				ttt.Fatalf("expected ErrClosing, got %v", err)
+			}
+		})
+	})
+}
+
+func TestStreamedResponses(t *testing.T) {
+	t.Run("decodes a large response", func(tt *testing.T) {
+		clientConn, serverConn := net.Pipe()
+		defer serverConn.Close()
+		stdout := strings.Repeat(`{"window-id": 1, "app-name": "Terminal"},`, 50000)
+		go serve(serverConn, Response{StdOut: stdout}, 0)
+
+		connection := &AeroSpaceSocketConnection{Conn: clientConn}
+		response, err := connection.SendCommand("list-windows", []string{"--all", "--json"})
+		if err != nil {
+			tt.Fatalf("unexpected error: %v", err)
+		}
+		if response.StdOut != stdout {
+			tt.Fatalf("expected %d bytes of stdout, got %d", len(stdout), len(response.StdOut))
+		}
+	})
+
+	t.Run("decodes a response split across small writes", func(tt *testing.T) {
+		clientConn, serverConn := net.Pipe()
+		defer serverConn.Close()
+		go func() {
+			responseBytes, _ := json.Marshal(Response{StdOut: "ok"})
+			buf := make([]byte, 4096)
+			if _, err := serverConn.Read(buf); err != nil {
+				return
+			}
+			for _, b := range responseBytes {
+				time.Sleep(time.Millisecond)
+				if _, err := serverConn.Write([]byte{b}); err != nil {
+					return
+				}
+			}
+		}()
+
+		connection := &AeroSpaceSocketConnection{Conn: clientConn}
+		response, err := connection.SendCommand("list-modes", []string{"--current"})
+		if err != nil {
+			tt.Fatalf("unexpected error: %v", err)
+		}
+		if response.StdOut != "ok" {
+			tt.Fatalf("expected stdout 'ok', got %q", response.StdOut)
+		}
+	})
+
+	t.Run("reuses the decoder until the connection is replaced", func(tt *testing.T) {
+		clientConn, serverConn := net.Pipe()
+		defer serverConn.Close()
+		go serve(serverConn, Response{StdOut: "ok"}, 0)
+
+		connection := &AeroSpaceSocketConnection{Conn: clientConn}
+		if _, err := connection.SendCommand("list-modes", []string{"--current"}); err != nil {
+			tt.Fatalf("unexpected error: %v", err)
+		}
+		decoder := connection.decoder
+
+		if _, err := connection.SendCommand("list-modes", []string{"--current"}); err != nil {
+			tt.Fatalf("unexpected error: %v", err)
+		}
+		if connection.decoder != decoder {
+			tt.Fatalf("expected the decoder to be reused across commands")
+		}
+
+		newClientConn, newServerConn := net.Pipe()
+		defer newServerConn.Close()
+		go serve(newServerConn, Response{StdOut: "replaced"}, 0)
+		connection.Conn = newClientConn
+
+		response, err := connection.SendCommand("list-modes", []string{"--current"})
+		if err != nil {
+			tt.Fatalf("unexpected error: %v", err)
+		}
+		if response.StdOut != "replaced" {
+			tt.Fatalf("expected stdout 'replaced', got %q", response.StdOut)
+		}
+		if connection.decoder == decoder {
+			tt.Fatalf("expected a new decoder for the replaced connection")
+		}
+	})
+
+	t.Run("fails on a malformed response", func(tt *testing.T) {
+		clientConn, serverConn := net.Pipe()
+		defer serverConn.Close()
+		go func() {
+			buf := make([]byte, 4096)
+			if _, err := serverConn.Read(buf); err != nil {
+				return
+			}
+			_, _ = serverConn.Write([]byte("not json"))
+		}()
+
+		connection := &AeroSpaceSocketConnection{Conn: clientConn}
+		_, err := connection.SendCommand("list-modes", []string{"--current"})
+		if err == nil || !strings.Contains(err.Error(), "failed to unmarshal socket response") {
+			tt.Fatalf("expected unmarshal error, got %v", err)
+		}
+		if connection.Conn != nil {
+			tt.Fatalf("expected the connection to be closed after a malformed response")
+		}
+	})
+}
+
+func TestSendCommandAsync(t *testing.T) {
+	t.Run("delivers the response without blocking the caller", func(tt *testing.T) {
+		clientConn, serverConn := net.Pipe()
+		defer serverConn.Close()
+		go serve(serverConn, Response{StdOut: "ok"}, 50*time.Millisecond)
+
+		connection := &AeroSpaceSocketConnection{Conn: clientConn}
+		start := time.Now()
+		result := connection.SendCommandAsync(context.Background(), "list-modes", []string{"--current"})
+		if elapsed := time.Since(start); elapsed > 25*time.Millisecond {
+			tt.Fatalf("expected SendCommandAsync to return immediately, took %s", elapsed)
+		}
+
+		r := <-result
+		if r.Err != nil {
+			tt.Fatalf("unexpected error: %v", r.Err)
+		}
+		if r.Response.StdOut != "ok" {
+			tt.Fatalf("expected stdout 'ok', got %q", r.Response.StdOut)
+		}
+		if _, open := <-result; open {
+			tt.Fatalf("expected the result channel to be closed")
+		}
+	})
+
+	t.Run("delivers errors", func(tt *testing.T) {
+		connection := &AeroSpaceSocketConnection{}
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		r := <-connection.SendCommandAsync(ctx, "list-modes", []string{"--current"})
+		if r.Err == nil || r.Response != nil {
+			tt.Fatalf("expected an error only, got %+v", r)
+		}
+	})
+}
+
+func TestCommandID(t *testing.T) {
+	t.Run("correlates errors with the debug logs", func(tt *testing.T) {
+		clientConn, serverConn := net.Pipe()
+		defer serverConn.Close()
+		go serve(serverConn, Response{ExitCode: 1, StdErr: "Window not found"}, 0)
+
+		var logs bytes.Buffer
+		connection := &AeroSpaceSocketConnection{
+			Conn:   clientConn,
+			Logger: slog.New(slog.NewTextHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug})),
+		}
+
+		_, firstErr := connection.SendCommand("focus", []string{"--window-id", "42"})
+		_, secondErr := connection.SendCommand("focus", []string{"--window-id", "43"})
+
+		firstID, ok := CommandID(firstErr)
+		if !ok {
+			tt.Fatalf("expected a command ID in %v", firstErr)
+		}
+		secondID, _ := CommandID(secondErr)
+		if firstID == secondID {
+			tt.Fatalf("expected distinct command IDs, got %d twice", firstID)
+		}
+
+		expected := fmt.Sprintf("command #%d (focus --window-id 42)\ncommand failed with exit code 1\nWindow not found", firstID)
+		if firstErr.Error() != expected {
+			tt.Fatalf("expected error %q, got %q", expected, firstErr.Error())
+		}
+		if !strings.Contains(logs.String(), fmt.Sprintf("id=%d command=focus", firstID)) {
+			tt.Fatalf("expected the command ID in the logs, got:\n%s", logs.String())
+		}
+	})
+
+	t.Run("preserves the wrapped errors", func(tt *testing.T) {
+		connection := &AeroSpaceSocketConnection{}
+		connection.closed.Store(true)
+
+		_, err := connection.SendCommand("list-modes", nil)
+		if !errors.Is(err, ErrClosed) {
+			tt.Fatalf("expected ErrClosed, got %v", err)
+		}
+		if _, ok := CommandID(errors.New("other")); ok {
+			tt.Fatal("expected no command ID for an unrelated error")
+		}
+	})
+}
+
+func TestErrTimeout(t *testing.T) {
+	t.Run("matches deadlines set on the transport", func(tt *testing.T) {
+		clientConn, serverConn := net.Pipe()
+		defer serverConn.Close()
+		go serve(serverConn, Response{StdOut: "late"}, time.Second)
+
+		_ = clientConn.SetDeadline(time.Now().Add(20 * time.Millisecond))
+		connection := &AeroSpaceSocketConnection{Conn: clientConn}
+
+		_, err := connection.SendCommand("list-modes", []string{"--current"})
+		if !errors.Is(err, ErrTimeout) {
+			tt.Fatalf("expected ErrTimeout, got %v", err)
+		}
+	})
+
+	t.Run("does not match protocol errors", func(tt *testing.T) {
+		clientConn, serverConn := net.Pipe()
+		defer serverConn.Close()
+		go serve(serverConn, Response{ExitCode: 1, StdErr: "Window not found"}, 0)
+
+		connection := &AeroSpaceSocketConnection{Conn: clientConn}
+		_, err := connection.SendCommand("focus", []string{"--window-id", "42"})
+		if err == nil || errors.Is(err, ErrTimeout) {
+			tt.Fatalf("expected an error not matching ErrTimeout, got %v", err)
+		}
+	})
+}
+
+func TestCommandError(t *testing.T) {
+	t.Run("reports the failed command", func(tt *testing.T) {
+		clientConn, serverConn := net.Pipe()
+		defer serverConn.Close()
+		go serve(serverConn, Response{ExitCode: 2, StdErr: "Window not found", StdOut: "partial"}, 0)
+
+		connection := &AeroSpaceSocketConnection{Conn: clientConn}
+
+		_, err := connection.SendCommand("focus", []string{"--window-id", "42"})
+		var cmdErr *CommandError
+		if !errors.As(err, &cmdErr) {
+			tt.Fatalf("expected a *CommandError, got %v", err)
+		}
+		expected := &CommandError{
+			Command:  "focus",
+			Args:     []string{"--window-id", "42"},
+			ExitCode: 2,
+			StdErr:   "Window not found",
+			StdOut:   "partial",
+		}
+		if !reflect.DeepEqual(cmdErr, expected) {
+			tt.Fatalf("expected %+v, got %+v", expected, cmdErr)
+		}
+		if cmdErr.Error() != "command failed with exit code 2\nWindow not found" {
+			tt.Fatalf("unexpected message: %q", cmdErr.Error())
+		}
+	})
+
+	t.Run("reports stderr under StderrError", func(tt *testing.T) {
+		_, err := checkResponse("list-modes", nil, &Response{StdErr: "deprecated"}, StderrError)
+		var cmdErr *CommandError
+		if !errors.As(err, &cmdErr) || cmdErr.ExitCode != 0 {
+			tt.Fatalf("expected a *CommandError with exit code 0, got %v", err)
+		}
+		if cmdErr.Error() != "command error\ndeprecated" {
+			tt.Fatalf("unexpected message: %q", cmdErr.Error())
+		}
+	})
+
+	t.Run("is returned by the CLI", func(tt *testing.T) {
+		conn := NewAeroSpaceCLIConnection(fakeCLI(tt))
+
+		_, err := conn.SendCommand("fail", nil)
+		var cmdErr *CommandError
+		if !errors.As(err, &cmdErr) || cmdErr.ExitCode != 2 || cmdErr.StdErr != "boom\n" {
+			tt.Fatalf("expected a *CommandError with exit code 2, got %v", err)
+		}
+	})
+}
+
+func TestSendCommandWithStdin(t *testing.T) {
+	t.Run("sends stdin with the command", func(tt *testing.T) {
+		clientConn, serverConn := net.Pipe()
+		defer serverConn.Close()
+		received := make(chan Command, 1)
+		go func() {
+			var cmd Command
+			if err := json.NewDecoder(serverConn).Decode(&cmd); err != nil {
+				return
+			}
+			received <- cmd
+			responseBytes, _ := json.Marshal(Response{StdOut: "ok"})
+			serverConn.Write(responseBytes)
+		}()
+
+		connection := &AeroSpaceSocketConnection{Conn: clientConn}
+
+		_, err := connection.SendCommandWithStdin(context.Background(), "move-node-to-workspace", []string{"next", "--stdin"}, "1\n3\n")
+		if err != nil {
+			tt.Fatalf("unexpected error: %v", err)
+		}
+		cmd := <-received
+		if cmd.Stdin != "1\n3\n" || !reflect.DeepEqual(cmd.Args, []string{"move-node-to-workspace", "next", "--stdin"}) {
+			tt.Fatalf("unexpected command: %+v", cmd)
+		}
+	})
+
+	t.Run("writes stdin to the CLI", func(tt *testing.T) {
+		conn := NewAeroSpaceCLIConnection(fakeCLI(tt))
+
+		response, err := conn.SendCommandWithStdin(context.Background(), "stdin", nil, "1\n3\n")
+		if err != nil {
+			tt.Fatalf("unexpected error: %v", err)
+		}
+		if response.StdOut != "1\n3\n" {
+			tt.Fatalf("expected stdin echoed, got %q", response.StdOut)
+		}
+	})
+
+	t.Run("is relayed by the queue", func(tt *testing.T) {
+		fake := NewFakeConnection(FakeState{})
+		queued := NewQueuedConnection(fake)
+		defer queued.CloseConnection()
+
+		if _, err := queued.SendCommandWithStdin(context.Background(), "list-modes", nil, "main\n"); err != nil {
+			tt.Fatalf("unexpected error: %v", err)
+		}
+		if sent := fake.Sent(); len(sent) != 1 || sent[0].Stdin != "main\n" {
+			tt.Fatalf("expected the stdin to be relayed, got %+v", sent)
+		}
+	})
+}
@@ -0,0 +1,84 @@
+package client
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// ConnectionStats is a snapshot of the counters of an AeroSpaceSocketConnection.
+type ConnectionStats struct {
+	// CommandsSent is the number of commands sent, whether they succeeded or not.
+	CommandsSent uint64
+
+	// Errors is the number of commands that failed, because of the connection
+	// or because AeroSpace reported an error.
+	Errors uint64
+
+	// BytesWritten and BytesRead are the bytes exchanged on the socket.
+	BytesWritten uint64
+	BytesRead    uint64
+
+	// Reconnects is the number of times the socket was dialed again, see WithReconnect.
+	Reconnects uint64
+
+	// TotalLatency is the time spent waiting for the commands sent, retries included.
+	TotalLatency time.Duration
+}
+
+// AverageLatency returns the average time a command took, or 0 if none was sent.
+func (s ConnectionStats) AverageLatency() time.Duration {
+	if s.CommandsSent == 0 {
+		return 0
+	}
+	return s.TotalLatency / time.Duration(s.CommandsSent)
+}
+
+// connectionStats holds the counters of a connection, updated concurrently.
+type connectionStats struct {
+	commands     atomic.Uint64
+	errors       atomic.Uint64
+	bytesWritten atomic.Uint64
+	bytesRead    atomic.Uint64
+	reconnects   atomic.Uint64
+	latency      atomic.Int64
+}
+
+// record counts a command that took latency and failed with err, if not nil.
+func (s *connectionStats) record(latency time.Duration, err error) {
+	s.commands.Add(1)
+	s.latency.Add(int64(latency))
+	if err != nil {
+		s.errors.Add(1)
+	}
+}
+
+// Stats returns the counters of the connection, so operators can monitor
+// the IPC usage of long-running tools.
+//
+// Commands rejected before being sent, e.g. after CloseConnection, are not counted.
+//
+// Usage:
+//
+//	if conn, ok := aerospaceClient.Connection().(*client.AeroSpaceSocketConnection); ok {
+//	    stats := conn.Stats()
+//	    log.Printf("%d commands, %d errors, %s average", stats.CommandsSent, stats.Errors, stats.AverageLatency())
+//	}
+func (c *AeroSpaceSocketConnection) Stats() ConnectionStats {
+	return ConnectionStats{
+		CommandsSent: c.stats.commands.Load(),
+		Errors:       c.stats.errors.Load(),
+		BytesWritten: c.stats.bytesWritten.Load(),
+		BytesRead:    c.stats.bytesRead.Load(),
+		Reconnects:   c.stats.reconnects.Load(),
+		TotalLatency: time.Duration(c.stats.latency.Load()),
+	}
+}
+
+// statsReader reads from the connection, counting the bytes read.
+type statsReader AeroSpaceSocketConnection
+
+func (r *statsReader) Read(p []byte) (int, error) {
+	n, err := r.Conn.Read(p)
+	r.stats.bytesRead.Add(uint64(n))
+	return n, err
+}
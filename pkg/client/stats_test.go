@@ -0,0 +1,67 @@
+package client
+
+import (
+	"encoding/json"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestStats(t *testing.T) {
+	t.Run("counts commands, bytes and reconnects", func(tt *testing.T) {
+		response := Response{StdOut: "ok"}
+		socketPath, accepted := listen(tt, response)
+
+		connection, err := NewAeroSpaceSocketConnection(socketPath, WithReconnect(1))
+		if err != nil {
+			tt.Fatalf("unexpected error: %v", err)
+		}
+		defer connection.CloseConnection()
+
+		if _, err := connection.SendCommand("list-modes", []string{"--current"}); err != nil {
+			tt.Fatalf("unexpected error: %v", err)
+		}
+		// Simulates a server restart
+		(<-accepted).Close()
+		if _, err := connection.SendCommand("list-modes", []string{"--current"}); err != nil {
+			tt.Fatalf("unexpected error: %v", err)
+		}
+
+		stats := connection.Stats()
+		if stats.CommandsSent != 2 || stats.Errors != 0 || stats.Reconnects != 1 {
+			tt.Fatalf("unexpected stats %+v", stats)
+		}
+		responseBytes, _ := json.Marshal(response)
+		if stats.BytesRead != 2*uint64(len(responseBytes)) {
+			tt.Fatalf("expected %d bytes read, got %d", 2*len(responseBytes), stats.BytesRead)
+		}
+		if stats.BytesWritten == 0 {
+			tt.Fatal("expected bytes written to be counted")
+		}
+		if stats.AverageLatency() <= 0 || stats.AverageLatency() != stats.TotalLatency/2 {
+			tt.Fatalf("unexpected average latency %s", stats.AverageLatency())
+		}
+	})
+
+	t.Run("counts failed commands as errors", func(tt *testing.T) {
+		clientConn, serverConn := net.Pipe()
+		defer serverConn.Close()
+		go serve(serverConn, Response{ExitCode: 1, StdErr: "unknown command"}, 0)
+
+		connection := &AeroSpaceSocketConnection{Conn: clientConn}
+		if _, err := connection.SendCommand("unknown", nil); err == nil {
+			tt.Fatal("expected an error")
+		}
+
+		stats := connection.Stats()
+		if stats.CommandsSent != 1 || stats.Errors != 1 {
+			tt.Fatalf("unexpected stats %+v", stats)
+		}
+	})
+
+	t.Run("has no average latency without commands", func(tt *testing.T) {
+		if latency := (ConnectionStats{TotalLatency: time.Second}).AverageLatency(); latency != 0 {
+			tt.Fatalf("expected no average latency, got %s", latency)
+		}
+	})
+}
@@ -0,0 +1,93 @@
+package client
+
+import "fmt"
+
+// StderrPolicy decides how a command exiting with code 0 but writing to
+// its standard error is handled, e.g. when AeroSpace prints a deprecation
+// warning. Commands with a non-zero exit code always fail.
+type StderrPolicy int
+
+const (
+	// StderrWarn returns the response and reports the standard error to the
+	// warning handler set by WithWarningHandler, if any. It is the default.
+	StderrWarn StderrPolicy = iota
+	// StderrError fails the command, as if it had a non-zero exit code.
+	StderrError
+	// StderrIgnore returns the response without reporting the standard error.
+	StderrIgnore
+)
+
+func (p StderrPolicy) String() string {
+	switch p {
+	case StderrWarn:
+		return "warn"
+	case StderrError:
+		return "error"
+	case StderrIgnore:
+		return "ignore"
+	}
+	return fmt.Sprintf("StderrPolicy(%d)", int(p))
+}
+
+// WarningHandler receives the standard error of a command that succeeded
+// under StderrWarn.
+type WarningHandler func(command string, args []string, warning string)
+
+// WithStderrPolicy sets how the standard error of successful commands is handled.
+// Defaults to StderrWarn.
+//
+// Usage:
+//
+//	conn, err := client.NewAeroSpaceSocketConnection(
+//	    socketPath,
+//	    client.WithStderrPolicy(client.StderrError),
+//	)
+func WithStderrPolicy(policy StderrPolicy) Option {
+	return func(c *AeroSpaceSocketConnection) {
+		c.stderrPolicy = policy
+	}
+}
+
+// WithWarningHandler calls handler with the standard error of the commands
+// that succeeded while printing a warning, under StderrWarn.
+//
+// The handler is called after the command completes, from the goroutine
+// that sent it, so it may send commands on the connection.
+//
+// Usage:
+//
+//	conn, err := client.NewAeroSpaceSocketConnection(
+//	    socketPath,
+//	    client.WithWarningHandler(func(command string, args []string, warning string) {
+//	        log.Printf("aerospace %s: %s", command, warning)
+//	    }),
+//	)
+func WithWarningHandler(handler WarningHandler) Option {
+	return func(c *AeroSpaceSocketConnection) {
+		c.onWarning = handler
+	}
+}
+
+// checkResponse returns a *CommandError if response reports a failed command,
+// through its exit code or, under StderrError, its standard error.
+func checkResponse(command string, args []string, response *Response, policy StderrPolicy) (*Response, error) {
+	if response.ExitCode != 0 || (response.StdErr != "" && policy == StderrError) {
+		return nil, &CommandError{
+			Command:  command,
+			Args:     args,
+			ExitCode: response.ExitCode,
+			StdErr:   response.StdErr,
+			StdOut:   response.StdOut,
+		}
+	}
+
+	return response, nil
+}
+
+// reportWarning passes the standard error of a successful response to handler under StderrWarn.
+func reportWarning(policy StderrPolicy, handler WarningHandler, command string, args []string, response *Response) {
+	if policy != StderrWarn || handler == nil || response == nil || response.StdErr == "" {
+		return
+	}
+	handler(command, args, response.StdErr)
+}
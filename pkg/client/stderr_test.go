@@ -0,0 +1,97 @@
+package client
+
+import (
+	"net"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestStderrPolicy(t *testing.T) {
+	warning := Response{StdOut: "ok", StdErr: "deprecated flag"}
+
+	t.Run("Happy path", func(tt *testing.T) {
+		tt.Run("reports warnings without failing by default", func(ttt *testing.T) {
+			clientConn, serverConn := net.Pipe()
+			defer serverConn.Close()
+			go serve(serverConn, warning, 0)
+
+			var warnings []string
+			connection := &AeroSpaceSocketConnection{Conn: clientConn}
+			WithWarningHandler(func(command string, args []string, warning string) {
+				warnings = append(warnings, command+" "+strings.Join(args, " ")+": "+warning)
+			})(connection)
+
+			response, err := connection.SendCommand("list-modes", []string{"--current"})
+			if err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+			if response.StdOut != "ok" {
+				ttt.Fatalf("expected stdout 'ok', got %q", response.StdOut)
+			}
+			if !slices.Equal(response.Warnings(), []string{"deprecated flag"}) {
+				ttt.Fatalf("unexpected response warnings: %q", response.Warnings())
+			}
+			if !slices.Equal(warnings, []string{"list-modes --current: deprecated flag"}) {
+				ttt.Fatalf("unexpected warnings: %v", warnings)
+			}
+		})
+
+		tt.Run("ignores warnings", func(ttt *testing.T) {
+			clientConn, serverConn := net.Pipe()
+			defer serverConn.Close()
+			go serve(serverConn, warning, 0)
+
+			called := false
+			connection := &AeroSpaceSocketConnection{Conn: clientConn}
+			WithStderrPolicy(StderrIgnore)(connection)
+			WithWarningHandler(func(string, []string, string) { called = true })(connection)
+
+			if _, err := connection.SendCommand("list-modes", nil); err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+			if called {
+				ttt.Fatal("expected the warning handler not to be called")
+			}
+		})
+
+		tt.Run("reports warnings of the CLI", func(ttt *testing.T) {
+			var warnings []string
+			conn := NewAeroSpaceCLIConnection(fakeCLI(ttt))
+			conn.OnWarning = func(_ string, _ []string, warning string) {
+				warnings = append(warnings, warning)
+			}
+
+			if _, err := conn.SendCommand("warn", nil); err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+			if !slices.Equal(warnings, []string{"deprecated\n"}) {
+				ttt.Fatalf("unexpected warnings: %q", warnings)
+			}
+		})
+	})
+
+	t.Run("Error cases", func(tt *testing.T) {
+		tt.Run("fails on warnings with StderrError", func(ttt *testing.T) {
+			clientConn, serverConn := net.Pipe()
+			defer serverConn.Close()
+			go serve(serverConn, warning, 0)
+
+			connection := &AeroSpaceSocketConnection{Conn: clientConn}
+			WithStderrPolicy(StderrError)(connection)
+
+			_, err := connection.SendCommand("list-modes", nil)
+			if err == nil || !strings.Contains(err.Error(), "command error\ndeprecated flag") {
+				ttt.Fatalf("expected a command error, got %v", err)
+			}
+		})
+
+		tt.Run("fails on non-zero exit codes with any policy", func(ttt *testing.T) {
+			for _, policy := range []StderrPolicy{StderrWarn, StderrError, StderrIgnore} {
+				if _, err := checkResponse("focus", nil, &Response{ExitCode: 1, StdErr: "boom"}, policy); err == nil {
+					ttt.Fatalf("expected an error with policy %s", policy)
+				}
+			}
+		})
+	})
+}
@@ -0,0 +1,77 @@
+package client
+
+import (
+	"io"
+	"time"
+
+	"github.com/cristianoliveira/aerospace-ipc/internal/constants"
+)
+
+// Transport is the byte stream an AeroSpaceSocketConnection exchanges commands over.
+//
+// net.Conn implements it and is used for socket connections. Tests and exotic
+// setups, e.g. in-memory pipes or SSH channels, can provide their own with
+// NewAeroSpaceTransportConnection instead of mocking net.Conn.
+//
+// If the transport also implements SetDeadline(time.Time) error, as net.Conn does,
+// context deadlines are applied to it. Otherwise pending I/O is interrupted by
+// closing the transport.
+type Transport interface {
+	io.ReadWriteCloser
+}
+
+// deadliner is implemented by transports supporting I/O deadlines, such as net.Conn.
+type deadliner interface {
+	SetDeadline(t time.Time) error
+}
+
+// NewAeroSpaceTransportConnection creates an AeroSpaceSocketConnection exchanging
+// commands over transport instead of dialing a socket.
+//
+// The connection cannot reconnect, so once the transport breaks, commands fail.
+// Options related to dialing, such as WithDialer, have no effect.
+//
+// Usage:
+//
+//	session, err := sshClient.NewSession()
+//	stdin, _ := session.StdinPipe()
+//	stdout, _ := session.StdoutPipe()
+//	_ = session.Start("nc -U /tmp/bobko.aerospace-me.sock")
+//
+//	conn := client.NewAeroSpaceTransportConnection(struct {
+//	    io.Reader
+//	    io.WriteCloser
+//	}{stdout, stdin})
+func NewAeroSpaceTransportConnection(transport Transport, opts ...Option) *AeroSpaceSocketConnection {
+	if transport == nil {
+		panic("ASSERTION: transport cannot be nil")
+	}
+
+	client := &AeroSpaceSocketConnection{
+		MinMajorVersion: constants.AeroSpaceSocketClientMajor,
+		MinMinorVersion: constants.AeroSpaceSocketClientMinor,
+		Conn:            transport,
+	}
+	for _, opt := range opts {
+		opt(client)
+	}
+
+	return client
+}
+
+// setDeadline sets the I/O deadline of transport, if it supports deadlines.
+func setDeadline(transport Transport, deadline time.Time) {
+	if d, ok := transport.(deadliner); ok {
+		_ = d.SetDeadline(deadline)
+	}
+}
+
+// interrupt unblocks the pending reads and writes on transport.
+func interrupt(transport Transport) {
+	if d, ok := transport.(deadliner); ok {
+		// A deadline in the past unblocks pending reads and writes immediately.
+		_ = d.SetDeadline(time.Unix(1, 0))
+		return
+	}
+	_ = transport.Close()
+}
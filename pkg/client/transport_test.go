@@ -0,0 +1,145 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+// pipeTransport is an in-memory Transport without deadline support.
+type pipeTransport struct {
+	io.Reader
+	io.Writer
+	closers []io.Closer
+}
+
+func (p *pipeTransport) Close() error {
+	for _, closer := range p.closers {
+		_ = closer.Close()
+	}
+	return nil
+}
+
+// shortWriter writes at most limit bytes per call, without reporting an error.
+type shortWriter struct {
+	io.Writer
+	limit int
+}
+
+func (w *shortWriter) Write(p []byte) (int, error) {
+	if w.limit == 0 {
+		return 0, nil
+	}
+	return w.Writer.Write(p[:min(len(p), w.limit)])
+}
+
+// newPipeTransports returns the two ends of an in-memory transport.
+func newPipeTransports() (*pipeTransport, *pipeTransport) {
+	clientReader, serverWriter := io.Pipe()
+	serverReader, clientWriter := io.Pipe()
+	closers := []io.Closer{clientReader, serverWriter, serverReader, clientWriter}
+	return &pipeTransport{Reader: clientReader, Writer: clientWriter, closers: closers},
+		&pipeTransport{Reader: serverReader, Writer: serverWriter, closers: closers}
+}
+
+func TestNewAeroSpaceTransportConnection(t *testing.T) {
+	t.Run("Happy path", func(tt *testing.T) {
+		tt.Run("sends commands over the transport", func(ttt *testing.T) {
+			clientEnd, serverEnd := newPipeTransports()
+			defer serverEnd.Close()
+			go func() {
+				var request struct {
+					Args []string `json:"args"`
+				}
+				decoder := json.NewDecoder(serverEnd)
+				encoder := json.NewEncoder(serverEnd)
+				for decoder.Decode(&request) == nil {
+					_ = encoder.Encode(Response{StdOut: request.Args[0]})
+				}
+			}()
+
+			connection := NewAeroSpaceTransportConnection(clientEnd)
+			for _, command := range []string{"list-modes", "list-windows"} {
+				response, err := connection.SendCommand(command, nil)
+				if err != nil {
+					ttt.Fatalf("unexpected error: %v", err)
+				}
+				if response.StdOut != command {
+					ttt.Fatalf("expected stdout %q, got %q", command, response.StdOut)
+				}
+			}
+
+			if err := connection.CloseConnection(); err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+		})
+
+		tt.Run("writes large commands in full over short writes", func(ttt *testing.T) {
+			clientEnd, serverEnd := newPipeTransports()
+			defer serverEnd.Close()
+			clientEnd.Writer = &shortWriter{Writer: clientEnd.Writer, limit: 7}
+			go func() {
+				var request Command
+				if json.NewDecoder(serverEnd).Decode(&request) == nil {
+					_ = json.NewEncoder(serverEnd).Encode(Response{StdOut: request.Stdin})
+				}
+			}()
+
+			stdin := strings.Repeat("workspace\n", 10000)
+			connection := NewAeroSpaceTransportConnection(clientEnd)
+			response, err := connection.SendCommandWithStdin(context.Background(), "workspace", []string{"next", "--stdin"}, stdin)
+			if err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+			if response.StdOut != stdin {
+				ttt.Fatalf("expected the whole stdin to be received, got %d bytes", len(response.StdOut))
+			}
+		})
+	})
+
+	t.Run("Error cases", func(tt *testing.T) {
+		tt.Run("closes a transport without deadlines when the context is done", func(ttt *testing.T) {
+			clientEnd, serverEnd := newPipeTransports()
+			defer serverEnd.Close()
+			// Read the request but never answer it.
+			go func() { _, _ = io.Copy(io.Discard, serverEnd) }()
+
+			connection := NewAeroSpaceTransportConnection(clientEnd)
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+			defer cancel()
+
+			_, err := connection.SendCommandContext(ctx, "list-modes", nil)
+			if !errors.Is(err, context.DeadlineExceeded) {
+				ttt.Fatalf("expected context.DeadlineExceeded, got %v", err)
+			}
+			if connection.Conn != nil {
+				ttt.Fatal("expected the transport to be dropped")
+			}
+		})
+
+		tt.Run("fails on writes making no progress", func(ttt *testing.T) {
+			clientEnd, serverEnd := newPipeTransports()
+			defer serverEnd.Close()
+			clientEnd.Writer = &shortWriter{Writer: clientEnd.Writer, limit: 0}
+
+			connection := NewAeroSpaceTransportConnection(clientEnd)
+			_, err := connection.SendCommand("list-modes", nil)
+			if !errors.Is(err, io.ErrShortWrite) {
+				ttt.Fatalf("expected io.ErrShortWrite, got %v", err)
+			}
+		})
+
+		tt.Run("panics on a nil transport", func(ttt *testing.T) {
+			defer func() {
+				if r := recover(); r == nil {
+					ttt.Fatal("expected a panic")
+				}
+			}()
+			NewAeroSpaceTransportConnection(nil)
+		})
+	})
+}
@@ -0,0 +1,155 @@
+package client
+
+import (
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+
+	"github.com/cristianoliveira/aerospace-ipc/internal/exceptions"
+)
+
+// VersionInfo describes how the AeroSpace server version was negotiated.
+//
+// It keeps the version string exactly as reported by the server together with
+// the parsed components and the constraint the client applied to it, so a
+// mismatch can be traced back to the original input.
+//
+// Example:
+//
+//	Raw:        "0.20.1-Beta abc123"
+//	Major:      0
+//	Minor:      20
+//	Patch:      1
+//	PreRelease: "Beta"
+//	Hash:       "abc123"
+//	Constraint: "0.20.x"
+type VersionInfo struct {
+	// Raw is the version string exactly as reported by the server.
+	Raw string
+
+	// Major, Minor and Patch are the numeric components parsed from Raw.
+	Major int
+	Minor int
+	Patch int
+
+	// PreRelease is the suffix after the dash, e.g. "Beta". Empty if absent.
+	PreRelease string
+
+	// Hash is the build hash after the space. Empty if absent.
+	Hash string
+
+	// Constraint is the version range accepted by the client, e.g. "0.20.x".
+	Constraint string
+
+	// Compatible reports whether the parsed version satisfies Constraint.
+	Compatible bool
+}
+
+// Version returns the parsed version as "<major>.<minor>.<patch>".
+func (v VersionInfo) Version() string {
+	return fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+}
+
+// String returns a human readable description of the negotiation.
+//
+// Example:
+//
+//	0.20.1 (reported as "0.20.1-Beta abc123", required 0.20.x)
+func (v VersionInfo) String() string {
+	return fmt.Sprintf("%s (reported as %q, required %s)", v.Version(), v.Raw, v.Constraint)
+}
+
+// ParseServerVersion parses the version string reported by the AeroSpace server.
+//
+// The expected format is "<major>.<minor>[.<patch>][-<pre-release>][ <hash>]",
+// e.g. "0.20.1-Beta abc123". Constraint and Compatible are left empty.
+//
+// A missing or non-numeric patch is parsed as 0.
+//
+// Returns an error if the string is empty or major/minor are not numbers.
+func ParseServerVersion(raw string) (VersionInfo, error) {
+	info := VersionInfo{Raw: raw}
+	if strings.TrimSpace(raw) == "" {
+		return info, fmt.Errorf("server version is empty")
+	}
+
+	version, hash, _ := strings.Cut(strings.TrimSpace(raw), " ")
+	info.Hash = strings.TrimSpace(hash)
+
+	version, preRelease, _ := strings.Cut(version, "-")
+	info.PreRelease = preRelease
+
+	versionParts := strings.Split(version, ".")
+	if len(versionParts) < 2 {
+		return info, fmt.Errorf("invalid server version format: %s", raw)
+	}
+
+	var err error
+	info.Major, err = strconv.Atoi(versionParts[0])
+	if err != nil {
+		return info, fmt.Errorf("failed to parse major version from %s\n%w", raw, err)
+	}
+
+	info.Minor, err = strconv.Atoi(versionParts[1])
+	if err != nil {
+		return info, fmt.Errorf("failed to parse minor version from %s\n%w", raw, err)
+	}
+
+	// The patch is informational only, builds like "0.20.x-Beta" report a non-numeric one.
+	if len(versionParts) > 2 {
+		if patch, err := strconv.Atoi(versionParts[2]); err == nil {
+			info.Patch = patch
+		}
+	}
+
+	return info, nil
+}
+
+// negotiateVersion parses serverVersion and checks it against the minimum major and minor versions,
+// logging the negotiation at debug level on logger.
+func negotiateVersion(logger *slog.Logger, serverVersion string, minMajor, minMinor int) (*VersionInfo, error) {
+	constraint := fmt.Sprintf("%d.%d.x", minMajor, minMinor)
+	info, err := ParseServerVersion(serverVersion)
+	if err != nil {
+		logger.Debug(
+			"failed to parse AeroSpace server version",
+			"raw", serverVersion,
+			"constraint", constraint,
+			"error", err,
+		)
+		return nil, err
+	}
+
+	// Since AeroSpace may have breaking changes even in minor versions,
+	// I'll enforce exact match on major and minor versions
+	// Last breaking change was on from 0.19.x to 0.20.0
+	info.Constraint = constraint
+	info.Compatible = info.Major == minMajor && info.Minor == minMinor
+
+	logger.Debug(
+		"AeroSpace server version negotiated",
+		"raw", info.Raw,
+		"parsed", info.Version(),
+		"pre_release", info.PreRelease,
+		"hash", info.Hash,
+		"constraint", info.Constraint,
+		"compatible", info.Compatible,
+	)
+
+	return &info, nil
+}
+
+// checkVersion returns an ErrVersionMismatch if info is not compatible.
+func checkVersion(info *VersionInfo, minMajor, minMinor int) error {
+	if !info.Compatible {
+		return exceptions.NewErrVersionMismatch(
+			minMajor,
+			minMinor,
+			info.Version(),
+			info.Raw,
+		)
+	}
+
+	return nil
+}
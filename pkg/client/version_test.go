@@ -0,0 +1,122 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/cristianoliveira/aerospace-ipc/internal/exceptions"
+	"github.com/cristianoliveira/aerospace-ipc/internal/mocks/net"
+	"go.uber.org/mock/gomock"
+)
+
+func TestParseServerVersion(t *testing.T) {
+	testCases := []struct {
+		raw      string
+		expected VersionInfo
+	}{
+		{
+			raw: "0.20.1-Beta abc123",
+			expected: VersionInfo{
+				Raw: "0.20.1-Beta abc123", Major: 0, Minor: 20, Patch: 1,
+				PreRelease: "Beta", Hash: "abc123",
+			},
+		},
+		{
+			raw:      "0.20",
+			expected: VersionInfo{Raw: "0.20", Major: 0, Minor: 20},
+		},
+		{
+			raw:      "1.2.3 abc",
+			expected: VersionInfo{Raw: "1.2.3 abc", Major: 1, Minor: 2, Patch: 3, Hash: "abc"},
+		},
+		{
+			raw:      "0.20.x-Beta abc",
+			expected: VersionInfo{Raw: "0.20.x-Beta abc", Major: 0, Minor: 20, PreRelease: "Beta", Hash: "abc"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.raw, func(tt *testing.T) {
+			info, err := ParseServerVersion(tc.raw)
+			if err != nil {
+				tt.Fatalf("unexpected error: %v", err)
+			}
+			if info != tc.expected {
+				tt.Fatalf("expected %+v, got %+v", tc.expected, info)
+			}
+		})
+	}
+
+	t.Run("invalid versions", func(tt *testing.T) {
+		for _, raw := range []string{"", "0", "a.20.0", "0.b.0"} {
+			if _, err := ParseServerVersion(raw); err == nil {
+				tt.Fatalf("expected error for %q, got nil", raw)
+			}
+		}
+	})
+}
+
+func TestGetVersionInfo(t *testing.T) {
+	newConnection := func(tt *testing.T, serverVersion string, logger *slog.Logger) *AeroSpaceSocketConnection {
+		responseBytes, err := json.Marshal(Response{ServerVersion: serverVersion})
+		if err != nil {
+			tt.Fatalf("failed to marshal mocked response: %v", err)
+		}
+
+		ctrl := gomock.NewController(tt)
+		mockConn := net_mock.NewMockConn(ctrl)
+		gomock.InOrder(
+			mockConn.EXPECT().
+				Write(gomock.Any()).
+				DoAndReturn(acceptWrite),
+			mockConn.EXPECT().
+				Read(gomock.Any()).
+				DoAndReturn(func(p []byte) (int, error) {
+					return copy(p, responseBytes), nil
+				}),
+		)
+
+		return &AeroSpaceSocketConnection{
+			MinMajorVersion: 0,
+			MinMinorVersion: 20,
+			Conn:            mockConn,
+			Logger:          logger,
+		}
+	}
+
+	t.Run("reports how the version was interpreted", func(tt *testing.T) {
+		var logs bytes.Buffer
+		logger := slog.New(slog.NewTextHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug}))
+		connection := newConnection(tt, "0.20.1-Beta abc123", logger)
+
+		info, err := connection.GetVersionInfo()
+		if err != nil {
+			tt.Fatalf("unexpected error: %v", err)
+		}
+
+		if !info.Compatible || info.Constraint != "0.20.x" || info.Version() != "0.20.1" {
+			tt.Fatalf("unexpected version info %+v", info)
+		}
+		if !strings.Contains(logs.String(), `raw="0.20.1-Beta abc123"`) ||
+			!strings.Contains(logs.String(), "parsed=0.20.1") ||
+			!strings.Contains(logs.String(), "constraint=0.20.x") {
+			tt.Fatalf("expected negotiation to be logged, got %q", logs.String())
+		}
+	})
+
+	t.Run("mismatch error mentions the reported version", func(tt *testing.T) {
+		connection := newConnection(tt, "0.19.2-Beta abc123", slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+		err := connection.CheckServerVersion()
+		if !errors.Is(err, exceptions.ErrVersion) {
+			tt.Fatalf("expected ErrVersion, got %v", err)
+		}
+		if !strings.Contains(err.Error(), `0.19.2 (reported as "0.19.2-Beta abc123")`) {
+			tt.Fatalf("expected reported version in error, got %q", err.Error())
+		}
+	})
+}
@@ -0,0 +1,25 @@
+package client
+
+import "strconv"
+
+// WindowID identifies an AeroSpace window.
+//
+// It is a distinct type so a PID or a DFS index cannot be passed by mistake
+// where a window ID is expected. The services re-export it, e.g. windows.WindowID.
+type WindowID int
+
+// String returns the ID as passed to --window-id.
+func (id WindowID) String() string {
+	return strconv.Itoa(int(id))
+}
+
+// Ptr returns a pointer to the ID, for the optional WindowID fields of the services.
+//
+// Usage:
+//
+//	err := layoutService.SetLayout([]string{"floating"}, layout.SetLayoutOpts{
+//	    WindowID: windows.WindowID(12345).Ptr(),
+//	})
+func (id WindowID) Ptr() *WindowID {
+	return &id
+}
@@ -0,0 +1,240 @@
+// Package aerospace is the v2 API of the AeroSpace IPC client.
+//
+// Compared to v1 (github.com/cristianoliveira/aerospace-ipc/pkg/aerospace):
+//
+//   - every service method takes a context.Context first
+//   - services are exposed as interfaces, so they can be faked in tests
+//   - client construction uses functional options
+//   - all service calls share a single Option type instead of one Opts struct per method
+//   - deprecated v1 methods (e.g. Windows().SetFocusByWindowID) are gone
+//
+// v1 stays frozen and keeps working. v2 is built on top of it and shares the
+// same transport and data types, so both can be used side by side while migrating.
+// See docs/MIGRATION-v1-to-v2.md.
+//
+// Usage:
+//
+//	client, err := aerospace.New(ctx)
+//	if err != nil {
+//	    log.Fatalf("failed to create AeroSpace client: %v", err)
+//	}
+//	defer client.Close()
+//
+//	windows, err := client.Windows().List(ctx, aerospace.WithWorkspace("1"))
+package aerospace
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	v1 "github.com/cristianoliveira/aerospace-ipc/pkg/aerospace"
+	"github.com/cristianoliveira/aerospace-ipc/pkg/aerospace/windows"
+	"github.com/cristianoliveira/aerospace-ipc/pkg/aerospace/workspaces"
+	"github.com/cristianoliveira/aerospace-ipc/pkg/client"
+)
+
+// Window is the same type as v1 windows.Window, so values can be shared between APIs.
+type Window = windows.Window
+
+// WindowID is the same type as v1 windows.WindowID.
+type WindowID = windows.WindowID
+
+// Workspace is the same type as v1 workspaces.Workspace, so values can be shared between APIs.
+type Workspace = workspaces.Workspace
+
+// ErrVersionMismatch indicates that the server version does not match the minimum required version.
+var ErrVersionMismatch = v1.ErrVersionMismatch
+
+// ErrWindowNotFound indicates that no window matches the one to focus, e.g. with Focus().App.
+var ErrWindowNotFound = v1.ErrWindowNotFound
+
+// Client defines the interface for interacting with AeroSpaceWM.
+type Client interface {
+	// Windows returns the service for querying windows.
+	Windows() WindowsService
+
+	// Workspaces returns the service for querying and moving workspaces.
+	Workspaces() WorkspacesService
+
+	// Focus returns the service for changing focus.
+	Focus() FocusService
+
+	// Layout returns the service for changing window layouts.
+	Layout() LayoutService
+
+	// Monitors returns the service for querying monitors.
+	Monitors() MonitorsService
+
+	// Modes returns the service for querying binding modes.
+	Modes() ModesService
+
+	// Connection returns the underlying connection.
+	//
+	// It can be passed to v1 services (e.g. windows.NewService) while migrating.
+	Connection() client.AeroSpaceConnection
+
+	// Close closes the connection and releases resources.
+	Close() error
+}
+
+// ClientOption configures a Client created with New.
+type ClientOption func(*clientOptions)
+
+type clientOptions struct {
+	socketPath string
+	conn       client.AeroSpaceConnection
+	v1Options  []v1.Option
+}
+
+// WithSocketPath connects to the socket at path instead of the default socket.
+//
+// The server version is always validated.
+func WithSocketPath(path string) ClientOption {
+	return func(o *clientOptions) {
+		o.socketPath = path
+	}
+}
+
+// WithConnection uses an existing connection instead of dialing a new one.
+//
+// Useful to share a v1 connection or to inject a mock in tests.
+func WithConnection(conn client.AeroSpaceConnection) ClientOption {
+	return func(o *clientOptions) {
+		o.conn = conn
+	}
+}
+
+// WithTimeout bounds every command sent on the connection by timeout.
+//
+// See v1 aerospace.WithTimeout.
+func WithTimeout(timeout time.Duration) ClientOption {
+	return func(o *clientOptions) {
+		o.v1Options = append(o.v1Options, v1.WithTimeout(timeout))
+	}
+}
+
+// WithRetry retries commands failing with transient errors following policy.
+//
+// See v1 aerospace.WithRetry.
+func WithRetry(policy client.RetryPolicy) ClientOption {
+	return func(o *clientOptions) {
+		o.v1Options = append(o.v1Options, v1.WithRetry(policy))
+	}
+}
+
+// WithLogger sets the logger receiving the connection diagnostics.
+func WithLogger(logger *slog.Logger) ClientOption {
+	return func(o *clientOptions) {
+		o.v1Options = append(o.v1Options, v1.WithLogger(logger))
+	}
+}
+
+// New creates a new Client.
+//
+// Without options it connects to the default socket, same as v1 aerospace.NewClient.
+//
+// Returns an error if the context is done or the connection fails.
+//
+// Usage:
+//
+//	client, err := aerospace.New(ctx, aerospace.WithSocketPath("/path/to/socket"))
+func New(ctx context.Context, opts ...ClientOption) (Client, error) {
+	var o clientOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if o.conn != nil {
+		if o.socketPath != "" || len(o.v1Options) > 0 {
+			return nil, fmt.Errorf("connection options cannot be used with an existing connection")
+		}
+		return newClient(o.conn), nil
+	}
+
+	v1Options := o.v1Options
+	if o.socketPath != "" {
+		v1Options = append(v1Options,
+			v1.WithSocketPath(o.socketPath),
+			v1.WithVersionPolicy(v1.VersionPolicyStrict),
+		)
+	}
+	wm, err := v1.NewClient(v1Options...)
+	if err != nil {
+		return nil, err
+	}
+
+	return newClient(wm.Connection()), nil
+}
+
+// FromV1 wraps an existing v1 client so it can be used through the v2 API.
+//
+// Both clients share the same connection; closing one closes the other.
+//
+// Usage:
+//
+//	legacy, _ := v1aerospace.NewClient()
+//	client := aerospace.FromV1(legacy)
+func FromV1(c v1.Client) Client {
+	return newClient(c.Connection())
+}
+
+type wmClient struct {
+	conn client.AeroSpaceConnection
+
+	windows    *windowsService
+	workspaces *workspacesService
+	focus      *focusService
+	layout     *layoutService
+	monitors   *monitorsService
+	modes      *modesService
+}
+
+func newClient(conn client.AeroSpaceConnection) *wmClient {
+	return &wmClient{
+		conn:       conn,
+		windows:    newWindowsService(conn),
+		workspaces: newWorkspacesService(conn),
+		focus:      newFocusService(conn),
+		layout:     newLayoutService(conn),
+		monitors:   newMonitorsService(conn),
+		modes:      newModesService(conn),
+	}
+}
+
+func (c *wmClient) Windows() WindowsService {
+	return c.windows
+}
+
+func (c *wmClient) Workspaces() WorkspacesService {
+	return c.workspaces
+}
+
+func (c *wmClient) Focus() FocusService {
+	return c.focus
+}
+
+func (c *wmClient) Layout() LayoutService {
+	return c.layout
+}
+
+func (c *wmClient) Monitors() MonitorsService {
+	return c.monitors
+}
+
+func (c *wmClient) Modes() ModesService {
+	return c.modes
+}
+
+func (c *wmClient) Connection() client.AeroSpaceConnection {
+	return c.conn
+}
+
+func (c *wmClient) Close() error {
+	return c.conn.CloseConnection()
+}
@@ -0,0 +1,160 @@
+package aerospace
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	aerospace_mock "github.com/cristianoliveira/aerospace-ipc/mocks/aerospace"
+	client_mock "github.com/cristianoliveira/aerospace-ipc/mocks/client"
+	"github.com/cristianoliveira/aerospace-ipc/pkg/client"
+	"go.uber.org/mock/gomock"
+)
+
+func TestClient(t *testing.T) {
+	t.Run("Happy path", func(tt *testing.T) {
+		tt.Run("wraps a v1 client sharing its connection", func(ttt *testing.T) {
+			ctrl := gomock.NewController(ttt)
+			defer ctrl.Finish()
+
+			conn := client_mock.NewMockAeroSpaceConnection(ctrl)
+			legacy := aerospace_mock.NewMockClient(ctrl)
+			legacy.EXPECT().Connection().Return(conn)
+			conn.EXPECT().CloseConnection().Return(nil)
+
+			c := FromV1(legacy)
+			if c.Connection() != conn {
+				ttt.Fatalf("expected the v1 connection to be shared")
+			}
+			if err := c.Close(); err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+		})
+	})
+
+	t.Run("Error cases", func(tt *testing.T) {
+		tt.Run("rejects a socket path together with a connection", func(ttt *testing.T) {
+			ctrl := gomock.NewController(ttt)
+			defer ctrl.Finish()
+
+			conn := client_mock.NewMockAeroSpaceConnection(ctrl)
+			_, err := New(context.Background(), WithConnection(conn), WithSocketPath("/tmp/sock"))
+			if err == nil {
+				ttt.Fatal("expected error, got nil")
+			}
+		})
+
+		tt.Run("rejects connection options together with a connection", func(ttt *testing.T) {
+			ctrl := gomock.NewController(ttt)
+			defer ctrl.Finish()
+
+			conn := client_mock.NewMockAeroSpaceConnection(ctrl)
+			_, err := New(context.Background(), WithConnection(conn), WithTimeout(time.Second))
+			if err == nil {
+				ttt.Fatal("expected error, got nil")
+			}
+		})
+
+		tt.Run("does not send when the context is done", func(ttt *testing.T) {
+			ctrl := gomock.NewController(ttt)
+			defer ctrl.Finish()
+
+			// No SendCommand expectation: any call fails the test.
+			conn := client_mock.NewMockAeroSpaceConnection(ctrl)
+			c, err := New(context.Background(), WithConnection(conn))
+			if err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+
+			err = c.Focus().Window(ctx, 42)
+			if !errors.Is(err, context.Canceled) {
+				ttt.Fatalf("expected context.Canceled, got %v", err)
+			}
+		})
+
+		tt.Run("does not list windows when the context is done", func(ttt *testing.T) {
+			ctrl := gomock.NewController(ttt)
+			defer ctrl.Finish()
+
+			conn := client_mock.NewMockAeroSpaceConnection(ctrl)
+			c, err := New(context.Background(), WithConnection(conn))
+			if err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+
+			for _, err := range c.Windows().All(ctx) {
+				if !errors.Is(err, context.Canceled) {
+					ttt.Fatalf("expected context.Canceled, got %v", err)
+				}
+			}
+		})
+
+		tt.Run("stops waiting once the context is done", func(ttt *testing.T) {
+			ctrl := gomock.NewController(ttt)
+			defer ctrl.Finish()
+
+			conn := client_mock.NewMockAeroSpaceConnection(ctrl)
+			conn.EXPECT().
+				SendCommandContext(gomock.Any(), "list-windows", gomock.Any()).
+				DoAndReturn(func(ctx context.Context, _ string, _ []string) (*client.Response, error) {
+					<-ctx.Done()
+					return nil, ctx.Err()
+				})
+			c, err := New(context.Background(), WithConnection(conn))
+			if err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+			defer cancel()
+
+			_, err = c.Windows().Focused(ctx)
+			if !errors.Is(err, context.DeadlineExceeded) {
+				ttt.Fatalf("expected context.DeadlineExceeded, got %v", err)
+			}
+		})
+
+		tt.Run("rejects options a method does not support", func(ttt *testing.T) {
+			ctrl := gomock.NewController(ttt)
+			defer ctrl.Finish()
+
+			conn := client_mock.NewMockAeroSpaceConnection(ctrl)
+			c, err := New(context.Background(), WithConnection(conn))
+			if err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+
+			err = c.Focus().Window(context.Background(), 42, WrapAround())
+			if err == nil {
+				ttt.Fatal("expected error, got nil")
+			}
+			if !strings.Contains(err.Error(), "option WrapAround is not supported by Focus().Window") {
+				ttt.Fatalf("unexpected error message: %v", err)
+			}
+		})
+
+		tt.Run("rejects a layout for both a window and a workspace", func(ttt *testing.T) {
+			ctrl := gomock.NewController(ttt)
+			defer ctrl.Finish()
+
+			conn := client_mock.NewMockAeroSpaceConnection(ctrl)
+			c, err := New(context.Background(), WithConnection(conn))
+			if err != nil {
+				ttt.Fatalf("unexpected error: %v", err)
+			}
+
+			err = c.Layout().Set(context.Background(), []string{"tiling"}, WithWindowID(42), WithWorkspace("1"))
+			if err == nil {
+				ttt.Fatal("expected error, got nil")
+			}
+		})
+	})
+}
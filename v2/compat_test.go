@@ -0,0 +1,441 @@
+package aerospace
+
+import (
+	"context"
+	"iter"
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+
+	client_mock "github.com/cristianoliveira/aerospace-ipc/mocks/client"
+	"github.com/cristianoliveira/aerospace-ipc/pkg/aerospace/focus"
+	"github.com/cristianoliveira/aerospace-ipc/pkg/aerospace/layout"
+	"github.com/cristianoliveira/aerospace-ipc/pkg/aerospace/modes"
+	"github.com/cristianoliveira/aerospace-ipc/pkg/aerospace/monitors"
+	"github.com/cristianoliveira/aerospace-ipc/pkg/aerospace/windows"
+	"github.com/cristianoliveira/aerospace-ipc/pkg/aerospace/workspaces"
+	"github.com/cristianoliveira/aerospace-ipc/pkg/client"
+	"go.uber.org/mock/gomock"
+)
+
+type sentCommand struct {
+	Command string
+	Args    []string
+}
+
+// recordCommands returns a mock connection that records every command sent
+// and answers with the stdout configured for the command in responses.
+func recordCommands(ctrl *gomock.Controller, responses map[string]string) (*client_mock.MockAeroSpaceConnection, *[]sentCommand) {
+	var sent []sentCommand
+	conn := client_mock.NewMockAeroSpaceConnection(ctrl)
+	record := func(command string, args []string) (*client.Response, error) {
+		sent = append(sent, sentCommand{Command: command, Args: args})
+		return &client.Response{StdOut: responses[command]}, nil
+	}
+	conn.EXPECT().
+		SendCommand(gomock.Any(), gomock.Any()).
+		DoAndReturn(record).
+		AnyTimes()
+	// v2 sends every command with the context of the call.
+	conn.EXPECT().
+		SendCommandContext(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, command string, args []string) (*client.Response, error) {
+			return record(command, args)
+		}).
+		AnyTimes()
+
+	return conn, &sent
+}
+
+// collect gathers the windows of an iterator, stopping at the first error.
+func collect(seq iter.Seq2[Window, error]) ([]Window, error) {
+	var all []Window
+	for window, err := range seq {
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, window)
+	}
+	return all, nil
+}
+
+// TestCompatibility asserts that every v2 call sends exactly the same
+// commands as the equivalent v1 call and returns the same result.
+func TestCompatibility(t *testing.T) {
+	responses := map[string]string{
+		"list-windows":    `[{"window-id":1,"app-name":"Terminal","window-title":"zsh","workspace":"1"}]`,
+		"list-workspaces": `[{"workspace":"1","monitor-id":1,"monitor-name":"Built-in"}]`,
+		"debug-windows":   "debug output",
+		"list-monitors":   `[{"monitor-id":1,"monitor-name":"Built-in","monitor-appkit-nsscreen-screens-id":1}]`,
+		"list-modes":      "main",
+	}
+	ctx := context.Background()
+	windowID := WindowID(42)
+	workspace := "2"
+	boundaries := focus.BoundariesAllMonitorsOuterFrame
+	action := focus.ActionWrapAllMonitors
+	isTerminal := func(window Window) bool { return window.AppName == "Terminal" }
+
+	tests := []struct {
+		name string
+		v1   func(conn client.AeroSpaceConnection) (any, error)
+		v2   func(c Client) (any, error)
+	}{
+		{
+			name: "list all windows",
+			v1: func(conn client.AeroSpaceConnection) (any, error) {
+				return windows.NewService(conn).GetAllWindows()
+			},
+			v2: func(c Client) (any, error) {
+				return c.Windows().List(ctx)
+			},
+		},
+		{
+			name: "list windows by workspace",
+			v1: func(conn client.AeroSpaceConnection) (any, error) {
+				return windows.NewService(conn).GetAllWindowsByWorkspace("1")
+			},
+			v2: func(c Client) (any, error) {
+				return c.Windows().List(ctx, WithWorkspace("1"))
+			},
+		},
+		{
+			name: "iterate windows",
+			v1: func(conn client.AeroSpaceConnection) (any, error) {
+				return collect(windows.NewService(conn).All())
+			},
+			v2: func(c Client) (any, error) {
+				return collect(c.Windows().All(ctx))
+			},
+		},
+		{
+			name: "focused window",
+			v1: func(conn client.AeroSpaceConnection) (any, error) {
+				return windows.NewService(conn).GetFocusedWindow()
+			},
+			v2: func(c Client) (any, error) {
+				return c.Windows().Focused(ctx)
+			},
+		},
+		{
+			name: "debug windows",
+			v1: func(conn client.AeroSpaceConnection) (any, error) {
+				return windows.NewService(conn).DebugWindows(windows.DebugWindowsOpts{WindowID: &windowID})
+			},
+			v2: func(c Client) (any, error) {
+				return c.Windows().Debug(ctx, WithWindowID(windowID))
+			},
+		},
+		{
+			name: "wait for window",
+			v1: func(conn client.AeroSpaceConnection) (any, error) {
+				return windows.NewService(conn).WaitFor(ctx, isTerminal, time.Millisecond)
+			},
+			v2: func(c Client) (any, error) {
+				return c.Windows().WaitFor(ctx, isTerminal, time.Millisecond)
+			},
+		},
+		{
+			name: "list workspaces",
+			v1: func(conn client.AeroSpaceConnection) (any, error) {
+				return workspaces.NewService(conn).GetAllWorkspaces(workspaces.ListWorkspacesOpts{
+					Monitors: []string{"focused"},
+				})
+			},
+			v2: func(c Client) (any, error) {
+				return c.Workspaces().List(ctx, WithMonitors("focused"))
+			},
+		},
+		{
+			name: "focused workspace",
+			v1: func(conn client.AeroSpaceConnection) (any, error) {
+				return workspaces.NewService(conn).GetFocusedWorkspace()
+			},
+			v2: func(c Client) (any, error) {
+				return c.Workspaces().Focused(ctx)
+			},
+		},
+		{
+			name: "move window to workspace",
+			v1: func(conn client.AeroSpaceConnection) (any, error) {
+				return nil, workspaces.NewService(conn).MoveWindowToWorkspaceWithOpts(
+					workspaces.MoveWindowToWorkspaceArgs{WorkspaceName: "next"},
+					workspaces.MoveWindowToWorkspaceOpts{
+						WindowID:           &windowID,
+						FocusFollowsWindow: true,
+						FailIfNoop:         true,
+						WrapAround:         true,
+					},
+				)
+			},
+			v2: func(c Client) (any, error) {
+				return nil, c.Workspaces().MoveWindow(ctx, "next",
+					WithWindowID(windowID), FocusFollowsWindow(), FailIfNoop(), WrapAround(),
+				)
+			},
+		},
+		{
+			name: "workspace back and forth",
+			v1: func(conn client.AeroSpaceConnection) (any, error) {
+				return nil, workspaces.NewService(conn).MoveBackAndForth()
+			},
+			v2: func(c Client) (any, error) {
+				return nil, c.Workspaces().BackAndForth(ctx)
+			},
+		},
+		{
+			name: "move workspace to monitor",
+			v1: func(conn client.AeroSpaceConnection) (any, error) {
+				return nil, workspaces.NewService(conn).MoveWorkspaceToMonitor(
+					workspaces.MoveWorkspaceToMonitorArgs{Order: "next"},
+					workspaces.MoveWorkspaceToMonitorOpts{Workspace: &workspace, WrapAround: true},
+				)
+			},
+			v2: func(c Client) (any, error) {
+				return nil, c.Workspaces().MoveToMonitor(ctx, MonitorByOrder("next"),
+					WithWorkspace(workspace), WrapAround(),
+				)
+			},
+		},
+		{
+			name: "ensure window on workspace",
+			v1: func(conn client.AeroSpaceConnection) (any, error) {
+				return workspaces.NewService(conn).EnsureWindowOnWorkspace(windowID, "2")
+			},
+			v2: func(c Client) (any, error) {
+				return c.Workspaces().EnsureWindow(ctx, windowID, "2")
+			},
+		},
+		{
+			name: "next free numeric workspace",
+			v1: func(conn client.AeroSpaceConnection) (any, error) {
+				return workspaces.NewService(conn).NextFreeNumeric()
+			},
+			v2: func(c Client) (any, error) {
+				return c.Workspaces().NextFreeNumeric(ctx)
+			},
+		},
+		{
+			name: "move window to new workspace",
+			v1: func(conn client.AeroSpaceConnection) (any, error) {
+				return workspaces.NewService(conn).MoveWindowToNewWorkspace(windowID)
+			},
+			v2: func(c Client) (any, error) {
+				return c.Workspaces().MoveWindowToNew(ctx, windowID)
+			},
+		},
+		{
+			name: "summon and focus workspace",
+			v1: func(conn client.AeroSpaceConnection) (any, error) {
+				return nil, workspaces.NewService(conn).SummonAndFocus("2")
+			},
+			v2: func(c Client) (any, error) {
+				return nil, c.Workspaces().SummonAndFocus(ctx, "2")
+			},
+		},
+		{
+			name: "wait for workspace focus",
+			v1: func(conn client.AeroSpaceConnection) (any, error) {
+				return nil, workspaces.NewService(conn).WaitForFocus(ctx, "1")
+			},
+			v2: func(c Client) (any, error) {
+				return nil, c.Workspaces().WaitForFocus(ctx, "1")
+			},
+		},
+		{
+			name: "focus window",
+			v1: func(conn client.AeroSpaceConnection) (any, error) {
+				return nil, focus.NewService(conn).SetFocusByWindowID(windowID, focus.SetFocusOpts{IgnoreFloating: true})
+			},
+			v2: func(c Client) (any, error) {
+				return nil, c.Focus().Window(ctx, windowID, IgnoreFloating())
+			},
+		},
+		{
+			name: "focus app",
+			v1: func(conn client.AeroSpaceConnection) (any, error) {
+				return nil, focus.NewService(conn).SetFocusByApp("terminal", focus.SetFocusOpts{IgnoreFloating: true})
+			},
+			v2: func(c Client) (any, error) {
+				return nil, c.Focus().App(ctx, "terminal", IgnoreFloating())
+			},
+		},
+		{
+			name: "focus title",
+			v1: func(conn client.AeroSpaceConnection) (any, error) {
+				return nil, focus.NewService(conn).SetFocusByTitle(regexp.MustCompile("zsh"))
+			},
+			v2: func(c Client) (any, error) {
+				return nil, c.Focus().Title(ctx, regexp.MustCompile("zsh"))
+			},
+		},
+		{
+			name: "focus direction",
+			v1: func(conn client.AeroSpaceConnection) (any, error) {
+				return nil, focus.NewService(conn).SetFocusByDirection("left", focus.SetFocusOpts{
+					Boundaries:       boundaries.Ptr(),
+					BoundariesAction: action.Ptr(),
+				})
+			},
+			v2: func(c Client) (any, error) {
+				return nil, c.Focus().Direction(ctx, "left",
+					WithBoundaries(boundaries), WithBoundariesAction(action),
+				)
+			},
+		},
+		{
+			name: "focus dfs",
+			v1: func(conn client.AeroSpaceConnection) (any, error) {
+				return nil, focus.NewService(conn).SetFocusByDFS("dfs-next", focus.SetFocusOpts{IgnoreFloating: true})
+			},
+			v2: func(c Client) (any, error) {
+				return nil, c.Focus().DFS(ctx, "dfs-next", IgnoreFloating())
+			},
+		},
+		{
+			name: "focus dfs index",
+			v1: func(conn client.AeroSpaceConnection) (any, error) {
+				return nil, focus.NewService(conn).SetFocusByDFSIndex(3)
+			},
+			v2: func(c Client) (any, error) {
+				return nil, c.Focus().DFSIndex(ctx, 3)
+			},
+		},
+		{
+			name: "focus back and forth",
+			v1: func(conn client.AeroSpaceConnection) (any, error) {
+				return nil, focus.NewService(conn).FocusBackAndForth()
+			},
+			v2: func(c Client) (any, error) {
+				return nil, c.Focus().BackAndForth(ctx)
+			},
+		},
+		{
+			name: "focus back and forth or workspace",
+			v1: func(conn client.AeroSpaceConnection) (any, error) {
+				return focus.NewService(conn).BackAndForthOrWorkspace()
+			},
+			v2: func(c Client) (any, error) {
+				return c.Focus().BackAndForthOrWorkspace(ctx)
+			},
+		},
+		{
+			name: "focus monitor",
+			v1: func(conn client.AeroSpaceConnection) (any, error) {
+				return nil, focus.NewService(conn).FocusMonitor(
+					focus.FocusMonitorArgs{Patterns: []string{"HDMI-1", "main"}},
+					focus.FocusMonitorOpts{},
+				)
+			},
+			v2: func(c Client) (any, error) {
+				return nil, c.Focus().Monitor(ctx, MonitorMatching("HDMI-1", "main"))
+			},
+		},
+		{
+			name: "move mouse",
+			v1: func(conn client.AeroSpaceConnection) (any, error) {
+				return nil, focus.NewService(conn).MoveMouse(focus.MouseWindowLazyCenter, focus.MoveMouseOpts{FailIfNoop: true})
+			},
+			v2: func(c Client) (any, error) {
+				return nil, c.Focus().MoveMouse(ctx, focus.MouseWindowLazyCenter, FailIfNoop())
+			},
+		},
+		{
+			name: "set layout",
+			v1: func(conn client.AeroSpaceConnection) (any, error) {
+				return nil, layout.NewService(conn).SetLayout([]string{"floating", "tiling"}, layout.SetLayoutOpts{WindowID: &windowID})
+			},
+			v2: func(c Client) (any, error) {
+				return nil, c.Layout().Set(ctx, []string{"floating", "tiling"}, WithWindowID(windowID))
+			},
+		},
+		{
+			name: "set layout for workspace",
+			v1: func(conn client.AeroSpaceConnection) (any, error) {
+				return nil, layout.NewService(conn).SetLayoutForWorkspace("1", []string{"tiling"})
+			},
+			v2: func(c Client) (any, error) {
+				return nil, c.Layout().Set(ctx, []string{"tiling"}, WithWorkspace("1"))
+			},
+		},
+		{
+			name: "toggle floating",
+			v1: func(conn client.AeroSpaceConnection) (any, error) {
+				return nil, layout.NewService(conn).ToggleFloating(layout.SetLayoutOpts{WindowID: &windowID})
+			},
+			v2: func(c Client) (any, error) {
+				return nil, c.Layout().ToggleFloating(ctx, WithWindowID(windowID))
+			},
+		},
+		{
+			name: "toggle orientation",
+			v1: func(conn client.AeroSpaceConnection) (any, error) {
+				return nil, layout.NewService(conn).ToggleOrientation()
+			},
+			v2: func(c Client) (any, error) {
+				return nil, c.Layout().ToggleOrientation(ctx)
+			},
+		},
+		{
+			name: "list monitors",
+			v1: func(conn client.AeroSpaceConnection) (any, error) {
+				return monitors.NewService(conn).GetAllMonitors()
+			},
+			v2: func(c Client) (any, error) {
+				return c.Monitors().List(ctx)
+			},
+		},
+		{
+			name: "focused monitor",
+			v1: func(conn client.AeroSpaceConnection) (any, error) {
+				return monitors.NewService(conn).GetFocusedMonitor()
+			},
+			v2: func(c Client) (any, error) {
+				return c.Monitors().Focused(ctx)
+			},
+		},
+		{
+			name: "current mode",
+			v1: func(conn client.AeroSpaceConnection) (any, error) {
+				return modes.NewService(conn).Current()
+			},
+			v2: func(c Client) (any, error) {
+				return c.Modes().Current(ctx)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			v1Conn, v1Sent := recordCommands(ctrl, responses)
+			v1Result, v1Err := tt.v1(v1Conn)
+			if v1Err != nil {
+				t.Fatalf("v1 call failed: %v", v1Err)
+			}
+
+			v2Conn, v2Sent := recordCommands(ctrl, responses)
+			c, err := New(ctx, WithConnection(v2Conn))
+			if err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+			v2Result, v2Err := tt.v2(c)
+			if v2Err != nil {
+				t.Fatalf("v2 call failed: %v", v2Err)
+			}
+
+			if len(*v1Sent) == 0 {
+				t.Fatalf("expected v1 to send at least one command")
+			}
+			if !reflect.DeepEqual(*v1Sent, *v2Sent) {
+				t.Errorf("commands differ\nv1: %+v\nv2: %+v", *v1Sent, *v2Sent)
+			}
+			if !reflect.DeepEqual(v1Result, v2Result) {
+				t.Errorf("results differ\nv1: %+v\nv2: %+v", v1Result, v2Result)
+			}
+		})
+	}
+}
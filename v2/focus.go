@@ -0,0 +1,262 @@
+package aerospace
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/cristianoliveira/aerospace-ipc/pkg/aerospace/focus"
+	"github.com/cristianoliveira/aerospace-ipc/pkg/client"
+)
+
+// BackAndForthResult describes what BackAndForthOrWorkspace switched to.
+// It is the same type as v1 focus.BackAndForthResult.
+type BackAndForthResult = focus.BackAndForthResult
+
+// Possible BackAndForthResult values.
+const (
+	BackAndForthNone      = focus.BackAndForthNone
+	BackAndForthWindow    = focus.BackAndForthWindow
+	BackAndForthWorkspace = focus.BackAndForthWorkspace
+)
+
+// FocusService defines the focus operations in AeroSpaceWM.
+type FocusService interface {
+	// Window focuses the window with the given ID.
+	//
+	// Supported options: IgnoreFloating.
+	Window(ctx context.Context, windowID WindowID, opts ...Option) error
+
+	// App focuses the first window of an application, given its name or bundle ID.
+	// Returns an error wrapping ErrWindowNotFound if the application has no window.
+	//
+	// Supported options: IgnoreFloating.
+	App(ctx context.Context, app string, opts ...Option) error
+
+	// Title focuses the first window whose title matches pattern.
+	// Returns an error wrapping ErrWindowNotFound if no title matches.
+	//
+	// Supported options: IgnoreFloating.
+	Title(ctx context.Context, pattern *regexp.Regexp, opts ...Option) error
+
+	// Direction focuses the nearest window in direction (left|down|up|right).
+	//
+	// Supported options: IgnoreFloating, WithBoundaries, WithBoundariesAction.
+	Direction(ctx context.Context, direction string, opts ...Option) error
+
+	// DFS focuses the window before or after the focused one in depth-first order (dfs-next|dfs-prev).
+	//
+	// Supported options: IgnoreFloating, WithBoundaries, WithBoundariesAction.
+	DFS(ctx context.Context, direction string, opts ...Option) error
+
+	// DFSIndex focuses the window at the given depth-first index.
+	DFSIndex(ctx context.Context, index int) error
+
+	// BackAndForth switches between the focused and the previously focused window.
+	BackAndForth(ctx context.Context) error
+
+	// BackAndForthOrWorkspace switches to the previously focused window or,
+	// when it was closed, to the previously focused workspace.
+	BackAndForthOrWorkspace(ctx context.Context) (BackAndForthResult, error)
+
+	// Monitor focuses the target monitor.
+	//
+	// Supported options: WrapAround.
+	Monitor(ctx context.Context, target MonitorTarget, opts ...Option) error
+
+	// MoveMouse moves the mouse to target, e.g. focus.MouseWindowLazyCenter.
+	//
+	// Supported options: FailIfNoop.
+	MoveMouse(ctx context.Context, target string, opts ...Option) error
+}
+
+type focusService struct {
+	v1 *focus.Service
+}
+
+func newFocusService(conn client.AeroSpaceConnection) *focusService {
+	return &focusService{v1: focus.NewService(conn)}
+}
+
+// Window focuses the window with the given ID.
+//
+// It is equivalent to running the command:
+//
+//	aerospace focus --window-id <window-id> [--ignore-floating]
+func (s *focusService) Window(ctx context.Context, windowID WindowID, opts ...Option) error {
+	o, err := resolveOptions("Focus().Window", opts, optIgnoreFloating)
+	if err != nil {
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return s.v1.WithContext(ctx).SetFocusByWindowID(windowID, focus.SetFocusOpts{IgnoreFloating: o.ignoreFloating})
+}
+
+// App focuses the first window of an application, given its name or bundle ID,
+// preferring the windows on the focused workspace.
+//
+// It is equivalent to running the commands:
+//
+//	aerospace list-windows --all --json --format <format>
+//	aerospace focus --window-id <window-id> [--ignore-floating]
+func (s *focusService) App(ctx context.Context, app string, opts ...Option) error {
+	o, err := resolveOptions("Focus().App", opts, optIgnoreFloating)
+	if err != nil {
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return s.v1.WithContext(ctx).SetFocusByApp(app, focus.SetFocusOpts{IgnoreFloating: o.ignoreFloating})
+}
+
+// Title focuses the first window whose title matches pattern,
+// preferring the windows on the focused workspace.
+//
+// It is equivalent to running the commands:
+//
+//	aerospace list-windows --all --json --format <format>
+//	aerospace focus --window-id <window-id> [--ignore-floating]
+func (s *focusService) Title(ctx context.Context, pattern *regexp.Regexp, opts ...Option) error {
+	o, err := resolveOptions("Focus().Title", opts, optIgnoreFloating)
+	if err != nil {
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return s.v1.WithContext(ctx).SetFocusByTitle(pattern, focus.SetFocusOpts{IgnoreFloating: o.ignoreFloating})
+}
+
+// Direction focuses the nearest window in direction.
+//
+// It is equivalent to running the command:
+//
+//	aerospace focus [--ignore-floating] [--boundaries <boundary>] [--boundaries-action <action>] <direction>
+func (s *focusService) Direction(ctx context.Context, direction string, opts ...Option) error {
+	o, err := resolveOptions(
+		"Focus().Direction", opts,
+		optIgnoreFloating, optBoundaries, optBoundariesAction,
+	)
+	if err != nil {
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return s.v1.WithContext(ctx).SetFocusByDirection(direction, o.setFocusOpts())
+}
+
+// DFS focuses the window before or after the focused one in depth-first order.
+//
+// It is equivalent to running the command:
+//
+//	aerospace focus [--ignore-floating] [--boundaries <boundary>] [--boundaries-action <action>] (dfs-next|dfs-prev)
+func (s *focusService) DFS(ctx context.Context, direction string, opts ...Option) error {
+	o, err := resolveOptions(
+		"Focus().DFS", opts,
+		optIgnoreFloating, optBoundaries, optBoundariesAction,
+	)
+	if err != nil {
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return s.v1.WithContext(ctx).SetFocusByDFS(direction, o.setFocusOpts())
+}
+
+// DFSIndex focuses the window at the given depth-first index.
+//
+// It is equivalent to running the command:
+//
+//	aerospace focus --dfs-index <index>
+func (s *focusService) DFSIndex(ctx context.Context, index int) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return s.v1.WithContext(ctx).SetFocusByDFSIndex(index)
+}
+
+// BackAndForth switches between the focused and the previously focused window.
+//
+// It is equivalent to running the command:
+//
+//	aerospace focus-back-and-forth
+func (s *focusService) BackAndForth(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return s.v1.WithContext(ctx).FocusBackAndForth()
+}
+
+// BackAndForthOrWorkspace switches to the previously focused window or,
+// when it was closed, to the previously focused workspace.
+//
+// It is equivalent to running the command:
+//
+//	aerospace focus-back-and-forth || aerospace workspace-back-and-forth
+func (s *focusService) BackAndForthOrWorkspace(ctx context.Context) (BackAndForthResult, error) {
+	if err := ctx.Err(); err != nil {
+		return BackAndForthNone, err
+	}
+
+	return s.v1.WithContext(ctx).BackAndForthOrWorkspace()
+}
+
+// Monitor focuses the target monitor.
+//
+// It is equivalent to running the command:
+//
+//	aerospace focus-monitor [--wrap-around] <target>
+func (s *focusService) Monitor(ctx context.Context, target MonitorTarget, opts ...Option) error {
+	o, err := resolveOptions("Focus().Monitor", opts, optWrapAround)
+	if err != nil {
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return s.v1.WithContext(ctx).FocusMonitor(
+		focus.FocusMonitorArgs{
+			Direction: target.direction,
+			Order:     target.order,
+			Patterns:  target.patterns,
+		},
+		focus.FocusMonitorOpts{WrapAround: o.wrapAround},
+	)
+}
+
+// MoveMouse moves the mouse to target.
+//
+// It is equivalent to running the command:
+//
+//	aerospace move-mouse [--fail-if-noop] <target>
+func (s *focusService) MoveMouse(ctx context.Context, target string, opts ...Option) error {
+	o, err := resolveOptions("Focus().MoveMouse", opts, optFailIfNoop)
+	if err != nil {
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return s.v1.WithContext(ctx).MoveMouse(target, focus.MoveMouseOpts{FailIfNoop: o.failIfNoop})
+}
+
+func (o callOptions) setFocusOpts() focus.SetFocusOpts {
+	return focus.SetFocusOpts{
+		IgnoreFloating:   o.ignoreFloating,
+		Boundaries:       o.boundaries,
+		BoundariesAction: o.boundariesAction,
+	}
+}
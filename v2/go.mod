@@ -0,0 +1,10 @@
+module github.com/cristianoliveira/aerospace-ipc/v2
+
+go 1.24.2
+
+replace github.com/cristianoliveira/aerospace-ipc => ../
+
+require (
+	github.com/cristianoliveira/aerospace-ipc v0.0.0-00010101000000-000000000000
+	go.uber.org/mock v0.5.2
+)
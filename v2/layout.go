@@ -0,0 +1,95 @@
+package aerospace
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cristianoliveira/aerospace-ipc/pkg/aerospace/layout"
+	"github.com/cristianoliveira/aerospace-ipc/pkg/client"
+)
+
+// LayoutService defines the layout operations in AeroSpaceWM.
+type LayoutService interface {
+	// Set applies the first of layouts that is not already active,
+	// to the focused window, a single window or every window of a workspace.
+	//
+	// Supported options: WithWindowID, WithWorkspace.
+	Set(ctx context.Context, layouts []string, opts ...Option) error
+
+	// ToggleFloating toggles the focused window between floating and tiling.
+	//
+	// Supported options: WithWindowID.
+	ToggleFloating(ctx context.Context, opts ...Option) error
+
+	// ToggleOrientation toggles the parent container between horizontal and vertical.
+	//
+	// Supported options: WithWindowID.
+	ToggleOrientation(ctx context.Context, opts ...Option) error
+}
+
+type layoutService struct {
+	v1 *layout.Service
+}
+
+func newLayoutService(conn client.AeroSpaceConnection) *layoutService {
+	return &layoutService{v1: layout.NewService(conn)}
+}
+
+// Set applies the first of layouts that is not already active.
+//
+// With WithWorkspace, the layouts are applied to every window of the workspace.
+//
+// It is equivalent to running the command:
+//
+//	aerospace layout <layout>... [--window-id <window-id>]
+func (s *layoutService) Set(ctx context.Context, layouts []string, opts ...Option) error {
+	o, err := resolveOptions("Layout().Set", opts, optWindowID, optWorkspace)
+	if err != nil {
+		return err
+	}
+	if o.windowID != nil && o.workspace != nil {
+		return fmt.Errorf("Layout().Set: WithWindowID and WithWorkspace cannot be combined")
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if o.workspace != nil {
+		return s.v1.WithContext(ctx).SetLayoutForWorkspace(*o.workspace, layouts)
+	}
+	return s.v1.WithContext(ctx).SetLayout(layouts, layout.SetLayoutOpts{WindowID: o.windowID})
+}
+
+// ToggleFloating toggles the focused window between floating and tiling.
+//
+// It is equivalent to running the command:
+//
+//	aerospace layout floating tiling [--window-id <window-id>]
+func (s *layoutService) ToggleFloating(ctx context.Context, opts ...Option) error {
+	o, err := resolveOptions("Layout().ToggleFloating", opts, optWindowID)
+	if err != nil {
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return s.v1.WithContext(ctx).ToggleFloating(layout.SetLayoutOpts{WindowID: o.windowID})
+}
+
+// ToggleOrientation toggles the parent container between horizontal and vertical.
+//
+// It is equivalent to running the command:
+//
+//	aerospace layout horizontal vertical [--window-id <window-id>]
+func (s *layoutService) ToggleOrientation(ctx context.Context, opts ...Option) error {
+	o, err := resolveOptions("Layout().ToggleOrientation", opts, optWindowID)
+	if err != nil {
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return s.v1.WithContext(ctx).ToggleOrientation(layout.SetLayoutOpts{WindowID: o.windowID})
+}
@@ -0,0 +1,35 @@
+package aerospace
+
+import (
+	"context"
+
+	"github.com/cristianoliveira/aerospace-ipc/pkg/aerospace/modes"
+	"github.com/cristianoliveira/aerospace-ipc/pkg/client"
+)
+
+// ModesService defines the binding mode queries in AeroSpaceWM.
+type ModesService interface {
+	// Current returns the name of the active binding mode, e.g. "main".
+	Current(ctx context.Context) (string, error)
+}
+
+type modesService struct {
+	v1 *modes.Service
+}
+
+func newModesService(conn client.AeroSpaceConnection) *modesService {
+	return &modesService{v1: modes.NewService(conn)}
+}
+
+// Current returns the name of the active binding mode, e.g. "main".
+//
+// It is equivalent to running the command:
+//
+//	aerospace list-modes --current
+func (s *modesService) Current(ctx context.Context) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	return s.v1.WithContext(ctx).Current()
+}
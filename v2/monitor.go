@@ -0,0 +1,26 @@
+package aerospace
+
+// MonitorTarget selects a monitor relative to the focused one or by pattern.
+//
+// Create it with MonitorInDirection, MonitorByOrder or MonitorMatching.
+type MonitorTarget struct {
+	direction string
+	order     string
+	patterns  []string
+}
+
+// MonitorInDirection selects the monitor in direction (left|down|up|right)
+// relative to the focused monitor.
+func MonitorInDirection(direction string) MonitorTarget {
+	return MonitorTarget{direction: direction}
+}
+
+// MonitorByOrder selects the next or prev monitor.
+func MonitorByOrder(order string) MonitorTarget {
+	return MonitorTarget{order: order}
+}
+
+// MonitorMatching selects the first monitor matching one of patterns.
+func MonitorMatching(patterns ...string) MonitorTarget {
+	return MonitorTarget{patterns: patterns}
+}
@@ -0,0 +1,58 @@
+package aerospace
+
+import (
+	"context"
+
+	"github.com/cristianoliveira/aerospace-ipc/pkg/aerospace/monitors"
+	"github.com/cristianoliveira/aerospace-ipc/pkg/client"
+)
+
+// Monitor is the same type as v1 monitors.Monitor, so values can be shared between APIs.
+type Monitor = monitors.Monitor
+
+// FocusedMonitor is the same type as v1 monitors.FocusedMonitor.
+type FocusedMonitor = monitors.FocusedMonitor
+
+// MonitorsService defines the monitor queries in AeroSpaceWM.
+type MonitorsService interface {
+	// List returns all monitors.
+	List(ctx context.Context) ([]Monitor, error)
+
+	// Focused returns the focused monitor together with its visible workspace.
+	Focused(ctx context.Context) (*FocusedMonitor, error)
+}
+
+type monitorsService struct {
+	v1 *monitors.Service
+}
+
+func newMonitorsService(conn client.AeroSpaceConnection) *monitorsService {
+	return &monitorsService{v1: monitors.NewService(conn)}
+}
+
+// List returns all monitors.
+//
+// It is equivalent to running the command:
+//
+//	aerospace list-monitors --json --format <format>
+func (s *monitorsService) List(ctx context.Context) ([]Monitor, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return s.v1.WithContext(ctx).GetAllMonitors()
+}
+
+// Focused returns the focused monitor together with its visible workspace.
+//
+// It is equivalent to running the commands:
+//
+//	aerospace list-monitors --focused --json --format <format>
+//	aerospace list-workspaces --monitor focused --visible --json --format <format>
+func (s *monitorsService) Focused(ctx context.Context) (*FocusedMonitor, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return s.v1.WithContext(ctx).GetFocusedMonitor()
+}
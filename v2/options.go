@@ -0,0 +1,144 @@
+package aerospace
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cristianoliveira/aerospace-ipc/pkg/aerospace/focus"
+)
+
+// Option configures a single service call.
+//
+// All services share this type. Each method documents the options it
+// supports and returns an error when given one it does not.
+//
+// Usage:
+//
+//	err := client.Focus().Direction(ctx, "left",
+//	    aerospace.IgnoreFloating(),
+//	    aerospace.WithBoundaries(aerospace.BoundariesAllMonitorsOuterFrame),
+//	)
+type Option struct {
+	name  string
+	apply func(*callOptions)
+}
+
+type callOptions struct {
+	windowID           *WindowID
+	workspace          *string
+	monitors           []string
+	ignoreFloating     bool
+	wrapAround         bool
+	focusFollowsWindow bool
+	failIfNoop         bool
+	boundaries         *string
+	boundariesAction   *string
+}
+
+const (
+	optWindowID           = "WithWindowID"
+	optWorkspace          = "WithWorkspace"
+	optMonitors           = "WithMonitors"
+	optIgnoreFloating     = "IgnoreFloating"
+	optWrapAround         = "WrapAround"
+	optFocusFollowsWindow = "FocusFollowsWindow"
+	optFailIfNoop         = "FailIfNoop"
+	optBoundaries         = "WithBoundaries"
+	optBoundariesAction   = "WithBoundariesAction"
+)
+
+// WithWindowID targets the window with the given ID instead of the focused window.
+func WithWindowID(id WindowID) Option {
+	return Option{optWindowID, func(o *callOptions) { o.windowID = &id }}
+}
+
+// WithWorkspace targets the workspace with the given name instead of the focused workspace.
+func WithWorkspace(name string) Option {
+	return Option{optWorkspace, func(o *callOptions) { o.workspace = &name }}
+}
+
+// WithMonitors restricts the call to the given monitors.
+// Possible values: monitor IDs (1-based), "focused", "mouse" or "all".
+func WithMonitors(monitors ...string) Option {
+	return Option{optMonitors, func(o *callOptions) { o.monitors = monitors }}
+}
+
+// IgnoreFloating doesn't perceive floating windows as part of the tree.
+func IgnoreFloating() Option {
+	return Option{optIgnoreFloating, func(o *callOptions) { o.ignoreFloating = true }}
+}
+
+// WrapAround allows jumping between the first and the last element.
+func WrapAround() Option {
+	return Option{optWrapAround, func(o *callOptions) { o.wrapAround = true }}
+}
+
+// FocusFollowsWindow makes the window receive focus after moving.
+func FocusFollowsWindow() Option {
+	return Option{optFocusFollowsWindow, func(o *callOptions) { o.focusFollowsWindow = true }}
+}
+
+// FailIfNoop makes the call fail if it would not change anything.
+func FailIfNoop() Option {
+	return Option{optFailIfNoop, func(o *callOptions) { o.failIfNoop = true }}
+}
+
+// Boundaries defines focus boundaries. It is the same type as v1 focus.Boundaries.
+type Boundaries = focus.Boundaries
+
+// BoundariesAction defines the behavior when requested to cross the boundary.
+// It is the same type as v1 focus.BoundariesAction.
+type BoundariesAction = focus.BoundariesAction
+
+// Possible Boundaries and BoundariesAction values.
+const (
+	BoundariesWorkspace             = focus.BoundariesWorkspace
+	BoundariesAllMonitorsOuterFrame = focus.BoundariesAllMonitorsOuterFrame
+
+	ActionStop            = focus.ActionStop
+	ActionFail            = focus.ActionFail
+	ActionWrapWorkspace   = focus.ActionWrapWorkspace
+	ActionWrapAllMonitors = focus.ActionWrapAllMonitors
+)
+
+// WithBoundaries defines focus boundaries, BoundariesWorkspace by default.
+func WithBoundaries(boundaries Boundaries) Option {
+	return Option{optBoundaries, func(o *callOptions) { o.boundaries = boundaries.Ptr() }}
+}
+
+// WithBoundariesAction defines the behavior when requested to cross the boundary,
+// ActionStop by default.
+func WithBoundariesAction(action BoundariesAction) Option {
+	return Option{optBoundariesAction, func(o *callOptions) { o.boundariesAction = action.Ptr() }}
+}
+
+// resolveOptions applies opts and fails if any of them is not in supported.
+func resolveOptions(method string, opts []Option, supported ...string) (callOptions, error) {
+	var o callOptions
+	for _, opt := range opts {
+		if opt.apply == nil {
+			continue
+		}
+
+		allowed := false
+		for _, name := range supported {
+			if opt.name == name {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			if len(supported) == 0 {
+				return o, fmt.Errorf("option %s is not supported by %s: it takes no options", opt.name, method)
+			}
+			return o, fmt.Errorf(
+				"option %s is not supported by %s\nsupported: %s",
+				opt.name, method, strings.Join(supported, ", "),
+			)
+		}
+
+		opt.apply(&o)
+	}
+
+	return o, nil
+}
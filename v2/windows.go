@@ -0,0 +1,131 @@
+package aerospace
+
+import (
+	"context"
+	"iter"
+	"time"
+
+	"github.com/cristianoliveira/aerospace-ipc/pkg/aerospace/windows"
+	"github.com/cristianoliveira/aerospace-ipc/pkg/client"
+)
+
+// WindowsService defines the window queries in AeroSpaceWM.
+type WindowsService interface {
+	// List returns all windows, or the windows of a single workspace.
+	//
+	// Supported options: WithWorkspace.
+	List(ctx context.Context, opts ...Option) ([]Window, error)
+
+	// All returns an iterator over all windows, decoded one at a time,
+	// so breaking early skips decoding the rest.
+	All(ctx context.Context) iter.Seq2[Window, error]
+
+	// Focused returns the currently focused window.
+	Focused(ctx context.Context) (*Window, error)
+
+	// Debug returns the diagnostic output of `aerospace debug-windows`.
+	//
+	// Supported options: WithWindowID.
+	Debug(ctx context.Context, opts ...Option) (string, error)
+
+	// WaitFor blocks until a window matching predicate appears, or ctx is done,
+	// listing the windows every pollInterval.
+	WaitFor(ctx context.Context, predicate func(Window) bool, pollInterval time.Duration) (*Window, error)
+}
+
+type windowsService struct {
+	v1 *windows.Service
+}
+
+func newWindowsService(conn client.AeroSpaceConnection) *windowsService {
+	return &windowsService{v1: windows.NewService(conn)}
+}
+
+// List returns all windows, or the windows of a single workspace.
+//
+// It is equivalent to running the command:
+//
+//	aerospace list-windows --all|--workspace <workspace> --json
+func (s *windowsService) List(ctx context.Context, opts ...Option) ([]Window, error) {
+	o, err := resolveOptions("Windows().List", opts, optWorkspace)
+	if err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if o.workspace != nil {
+		return s.v1.WithContext(ctx).GetAllWindowsByWorkspace(*o.workspace)
+	}
+	return s.v1.WithContext(ctx).GetAllWindows()
+}
+
+// All returns an iterator over all windows, decoded one at a time.
+//
+// On failure, including ctx being done, it yields a single zero Window
+// with the error and stops.
+//
+// It is equivalent to running the command:
+//
+//	aerospace list-windows --all --json
+func (s *windowsService) All(ctx context.Context) iter.Seq2[Window, error] {
+	return func(yield func(Window, error) bool) {
+		if err := ctx.Err(); err != nil {
+			yield(Window{}, err)
+			return
+		}
+
+		for window, err := range s.v1.WithContext(ctx).All() {
+			if !yield(window, err) {
+				return
+			}
+		}
+	}
+}
+
+// Focused returns the currently focused window.
+//
+// It is equivalent to running the command:
+//
+//	aerospace list-windows --focused --json
+func (s *windowsService) Focused(ctx context.Context) (*Window, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return s.v1.WithContext(ctx).GetFocusedWindow()
+}
+
+// Debug returns the diagnostic output of `aerospace debug-windows`.
+//
+// It is equivalent to running the command:
+//
+//	aerospace debug-windows [--window-id <window-id>]
+func (s *windowsService) Debug(ctx context.Context, opts ...Option) (string, error) {
+	o, err := resolveOptions("Windows().Debug", opts, optWindowID)
+	if err != nil {
+		return "", err
+	}
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	return s.v1.WithContext(ctx).DebugWindows(windows.DebugWindowsOpts{WindowID: o.windowID})
+}
+
+// WaitFor blocks until a window matching predicate appears, or ctx is done,
+// and returns the first matching window.
+//
+// A zero or negative pollInterval defaults to windows.DefaultWaitPollInterval.
+//
+// It is equivalent to running, until a window matches, the command:
+//
+//	aerospace list-windows --all --json
+func (s *windowsService) WaitFor(ctx context.Context, predicate func(Window) bool, pollInterval time.Duration) (*Window, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return s.v1.WaitFor(ctx, predicate, pollInterval)
+}
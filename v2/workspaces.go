@@ -0,0 +1,224 @@
+package aerospace
+
+import (
+	"context"
+
+	"github.com/cristianoliveira/aerospace-ipc/pkg/aerospace/workspaces"
+	"github.com/cristianoliveira/aerospace-ipc/pkg/client"
+)
+
+// WorkspacesService defines the workspace operations in AeroSpaceWM.
+type WorkspacesService interface {
+	// List returns the workspaces of all monitors, or of the given monitors.
+	//
+	// Supported options: WithMonitors.
+	List(ctx context.Context, opts ...Option) ([]Workspace, error)
+
+	// Focused returns the currently focused workspace.
+	Focused(ctx context.Context) (*Workspace, error)
+
+	// MoveWindow moves the focused window to workspace.
+	//
+	// Supported options: WithWindowID, FocusFollowsWindow, FailIfNoop, WrapAround.
+	MoveWindow(ctx context.Context, workspace string, opts ...Option) error
+
+	// EnsureWindow moves the window to workspace only if it is not already there.
+	// It reports whether the window was moved.
+	EnsureWindow(ctx context.Context, windowID WindowID, workspace string) (bool, error)
+
+	// NextFreeNumeric returns the smallest numeric workspace name without windows.
+	NextFreeNumeric(ctx context.Context) (string, error)
+
+	// MoveWindowToNew moves the window to the workspace returned by NextFreeNumeric
+	// and returns its name.
+	MoveWindowToNew(ctx context.Context, windowID WindowID) (string, error)
+
+	// SummonAndFocus moves the workspace to the focused monitor and focuses it.
+	SummonAndFocus(ctx context.Context, workspace string) error
+
+	// WaitForFocus blocks until the workspace is focused, or ctx is done.
+	WaitForFocus(ctx context.Context, workspace string) error
+
+	// BackAndForth switches between the focused and the previously focused workspace.
+	BackAndForth(ctx context.Context) error
+
+	// MoveToMonitor moves the focused workspace to the target monitor.
+	//
+	// Supported options: WithWorkspace, WrapAround.
+	MoveToMonitor(ctx context.Context, target MonitorTarget, opts ...Option) error
+}
+
+type workspacesService struct {
+	v1 *workspaces.Service
+}
+
+func newWorkspacesService(conn client.AeroSpaceConnection) *workspacesService {
+	return &workspacesService{v1: workspaces.NewService(conn)}
+}
+
+// List returns the workspaces of all monitors, or of the given monitors.
+//
+// It is equivalent to running the command:
+//
+//	aerospace list-workspaces --all|--monitor <monitor>... --json --format <format>
+func (s *workspacesService) List(ctx context.Context, opts ...Option) ([]Workspace, error) {
+	o, err := resolveOptions("Workspaces().List", opts, optMonitors)
+	if err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return s.v1.WithContext(ctx).GetAllWorkspaces(workspaces.ListWorkspacesOpts{Monitors: o.monitors})
+}
+
+// Focused returns the currently focused workspace.
+//
+// It is equivalent to running the command:
+//
+//	aerospace list-workspaces --focused --json
+func (s *workspacesService) Focused(ctx context.Context) (*Workspace, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return s.v1.WithContext(ctx).GetFocusedWorkspace()
+}
+
+// MoveWindow moves the focused window to workspace.
+//
+// It is equivalent to running the command:
+//
+//	aerospace move-node-to-workspace [--window-id <id>] [--focus-follows-window] [--fail-if-noop] [--wrap-around] <workspace>
+func (s *workspacesService) MoveWindow(ctx context.Context, workspace string, opts ...Option) error {
+	o, err := resolveOptions(
+		"Workspaces().MoveWindow", opts,
+		optWindowID, optFocusFollowsWindow, optFailIfNoop, optWrapAround,
+	)
+	if err != nil {
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return s.v1.WithContext(ctx).MoveWindowToWorkspaceWithOpts(
+		workspaces.MoveWindowToWorkspaceArgs{WorkspaceName: workspace},
+		workspaces.MoveWindowToWorkspaceOpts{
+			WindowID:           o.windowID,
+			FocusFollowsWindow: o.focusFollowsWindow,
+			FailIfNoop:         o.failIfNoop,
+			WrapAround:         o.wrapAround,
+		},
+	)
+}
+
+// EnsureWindow moves the window to workspace only if it is not already there.
+//
+// It is equivalent to running the commands:
+//
+//	aerospace list-windows --workspace <workspace> --json --format %{window-id}
+//	aerospace move-node-to-workspace --window-id <window-id> --fail-if-noop <workspace>
+func (s *workspacesService) EnsureWindow(ctx context.Context, windowID WindowID, workspace string) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+
+	return s.v1.WithContext(ctx).EnsureWindowOnWorkspace(windowID, workspace)
+}
+
+// NextFreeNumeric returns the smallest numeric workspace name without windows.
+//
+// It is equivalent to running the command:
+//
+//	aerospace list-workspaces --all --json --format <format>
+func (s *workspacesService) NextFreeNumeric(ctx context.Context) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	return s.v1.WithContext(ctx).NextFreeNumeric()
+}
+
+// MoveWindowToNew moves the window to the workspace returned by NextFreeNumeric.
+//
+// It is equivalent to running the commands:
+//
+//	aerospace list-workspaces --all --json --format <format>
+//	aerospace move-node-to-workspace <workspace> --window-id <window-id>
+func (s *workspacesService) MoveWindowToNew(ctx context.Context, windowID WindowID) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	return s.v1.WithContext(ctx).MoveWindowToNewWorkspace(windowID)
+}
+
+// SummonAndFocus moves the workspace to the focused monitor and focuses it.
+//
+// It is equivalent to running the commands:
+//
+//	aerospace summon-workspace <workspace>
+//	aerospace workspace <workspace>
+func (s *workspacesService) SummonAndFocus(ctx context.Context, workspace string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return s.v1.WithContext(ctx).SummonAndFocus(workspace)
+}
+
+// WaitForFocus blocks until the workspace is focused, or ctx is done,
+// querying the focused workspace every workspaces.DefaultWaitPollInterval.
+//
+// It is equivalent to running, until the workspace is focused, the command:
+//
+//	aerospace list-workspaces --focused --json --format <format>
+func (s *workspacesService) WaitForFocus(ctx context.Context, workspace string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return s.v1.WaitForFocus(ctx, workspace)
+}
+
+// BackAndForth switches between the focused and the previously focused workspace.
+//
+// It is equivalent to running the command:
+//
+//	aerospace workspace-back-and-forth
+func (s *workspacesService) BackAndForth(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return s.v1.WithContext(ctx).MoveBackAndForth()
+}
+
+// MoveToMonitor moves the focused workspace to the target monitor.
+//
+// It is equivalent to running the command:
+//
+//	aerospace move-workspace-to-monitor [--workspace <workspace>] [--wrap-around] <target>
+func (s *workspacesService) MoveToMonitor(ctx context.Context, target MonitorTarget, opts ...Option) error {
+	o, err := resolveOptions("Workspaces().MoveToMonitor", opts, optWorkspace, optWrapAround)
+	if err != nil {
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return s.v1.WithContext(ctx).MoveWorkspaceToMonitor(
+		workspaces.MoveWorkspaceToMonitorArgs{
+			Direction: target.direction,
+			Order:     target.order,
+			Patterns:  target.patterns,
+		},
+		workspaces.MoveWorkspaceToMonitorOpts{
+			Workspace:  o.workspace,
+			WrapAround: o.wrapAround,
+		},
+	)
+}